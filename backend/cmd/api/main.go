@@ -6,16 +6,19 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
 	gorillaHandlers "github.com/gorilla/handlers"
+	"github.com/shopspring/decimal"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 	"github.com/wonny/aegis/v14/internal/api/handlers"
 	audithandlers "github.com/wonny/aegis/v14/internal/api/handlers/audit"
 	fetcherhandlers "github.com/wonny/aegis/v14/internal/api/handlers/fetcher"
+	portfoliohandlers "github.com/wonny/aegis/v14/internal/api/handlers/portfolio"
+	riskhandlers "github.com/wonny/aegis/v14/internal/api/handlers/risk"
+	backtesthandlers "github.com/wonny/aegis/v14/internal/api/handlers/backtest"
 	signalshandlers "github.com/wonny/aegis/v14/internal/api/handlers/signals"
 	universehandlers "github.com/wonny/aegis/v14/internal/api/handlers/universe"
 	"github.com/wonny/aegis/v14/internal/api/routes"
@@ -23,23 +26,34 @@ import (
 	"github.com/wonny/aegis/v14/internal/infra/external/openai"
 	ai_analysis_repo "github.com/wonny/aegis/v14/internal/infrastructure/postgres/ai_analysis"
 	ai_analysis_service "github.com/wonny/aegis/v14/internal/service/ai_analysis"
+	executiondomain "github.com/wonny/aegis/v14/internal/domain/execution"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	"github.com/wonny/aegis/v14/internal/infra/database/postgres"
 	fetcherrepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/fetcher"
 	signalsrepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/signals"
+	portfoliorepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/portfolio"
+	rankingrepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/ranking"
+	riskrepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/risk"
 	exitrepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/exit"
 	"github.com/wonny/aegis/v14/internal/infra/external/dart"
 	"github.com/wonny/aegis/v14/internal/infra/external/naver"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres/kisauth"
 	"github.com/wonny/aegis/v14/internal/infra/kis"
 	universerepo "github.com/wonny/aegis/v14/internal/infrastructure/postgres/universe"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 	"github.com/wonny/aegis/v14/internal/pkg/config"
 	"github.com/wonny/aegis/v14/internal/pkg/logger"
 	auditservice "github.com/wonny/aegis/v14/internal/service/audit"
+	backtestservice "github.com/wonny/aegis/v14/internal/service/backtest"
 	exitservice "github.com/wonny/aegis/v14/internal/service/exit"
 	fetcherservice "github.com/wonny/aegis/v14/internal/service/fetcher"
 	"github.com/wonny/aegis/v14/internal/service/pricesync"
+	"github.com/wonny/aegis/v14/internal/service/pricing"
+	riskservice "github.com/wonny/aegis/v14/internal/service/risk"
 	universeservice "github.com/wonny/aegis/v14/internal/service/universe"
 	signalsservice "github.com/wonny/aegis/v14/internal/strategy/signals"
+	portfolioservice "github.com/wonny/aegis/v14/internal/strategy/portfolio"
+	rankingservice "github.com/wonny/aegis/v14/internal/strategy/ranking"
 )
 
 const (
@@ -79,6 +93,20 @@ func main() {
 		Str("version", serviceVersion).
 		Msg("🚀 Starting Aegis v14 API Server...")
 
+	// Load KRX trading calendar (built-in 휴장일 + 추가 파일)
+	if cfg.Market.CalendarFile != "" {
+		if err := calendar.LoadFile(cfg.Market.CalendarFile); err != nil {
+			log.Warn().Err(err).Str("file", cfg.Market.CalendarFile).Msg("⚠️ Failed to load KRX calendar file, using built-in holidays")
+		} else {
+			log.Info().Str("file", cfg.Market.CalendarFile).Msg("✅ KRX calendar loaded")
+		}
+	}
+
+	// Trading cost model (수수료율 설정 + KRX 시장/일자별 거래세)
+	if cfg.Cost.CommissionRate > 0 {
+		executiondomain.SetDefaultCostModel(executiondomain.NewCostModel(decimal.NewFromFloat(cfg.Cost.CommissionRate)))
+	}
+
 	// Context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -112,24 +140,20 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create KIS client")
 	}
-	kisAdapter := kis.NewExecutionAdapter(kisClient)
-
-	// Get account ID from environment
-	accountID := os.Getenv("KIS_ACCOUNT_ID")
-	if accountID == "" {
-		accountID = os.Getenv("KIS_ACCOUNT_NO")
-	}
-	if accountID == "" {
-		log.Fatal().Msg("KIS_ACCOUNT_ID or KIS_ACCOUNT_NO environment variable is required")
+	// 토큰 공유 (KIS_TOKEN_STORE=postgres)
+	if kisClient.UsesPostgresTokenStore() {
+		kisClient.SetTokenStore(kisauth.NewTokenRepository(dbPool.Pool))
 	}
+	kisAdapter := kis.NewExecutionAdapter(kisClient)
 
-	// Parse account number and product code (format: "12345678-01")
-	accountNo := accountID
-	accountProductCode := "01" // Default
-	if idx := strings.Index(accountID, "-"); idx > 0 {
-		accountNo = accountID[:idx]
-		accountProductCode = accountID[idx+1:]
+	// 기본 계좌 (KIS_ACCOUNTS 첫 번째 또는 KIS_ACCOUNT_ID)
+	if len(cfg.Accounts) == 0 {
+		log.Fatal().Msg("KIS_ACCOUNTS or KIS_ACCOUNT_ID environment variable is required")
 	}
+	primaryAccount := cfg.Accounts[0]
+	accountID := primaryAccount.ID
+	accountNo := primaryAccount.AccountNo()
+	accountProductCode := primaryAccount.ProductCode()
 
 	log.Info().Str("account_id", accountID).Msg("✅ KIS client initialized")
 
@@ -165,6 +189,20 @@ func main() {
 	fillsHandler := handlers.NewFillsHandler(fillRepo)
 	kisOrdersHandler := handlers.NewKISOrdersHandler(kisAdapter, accountID)
 
+	// Pre-trade Risk gate (직접 주문도 Execution과 동일한 게이트 통과)
+	riskRepo := riskrepo.NewRepository(dbPool.Pool)
+	exitEventRepo := postgres.NewExitEventRepository(dbPool.Pool)
+	riskSvc := riskservice.NewService(riskRepo, riskRepo, holdingRepo, priceService, exitEventRepo, accountID)
+	cashReader := executiondomain.AccountCashReader{Broker: kisAdapter, AccountID: accountID}
+	riskSvc.SetCashReader(cashReader)
+	kisOrdersHandler.SetRiskGate(riskSvc)
+	kisOrdersHandler.SetScheduler(kisClient.REST.Scheduler())
+
+	// 호가단위/가격제한폭/VI 검증 (Execution과 동일)
+	priceGuard := pricing.NewGuard(kisClient.REST, priceService)
+	priceGuard.SetSymbolInfoReader(riskRepo)
+	kisOrdersHandler.SetPriceGuard(priceGuard)
+
 	// Create gorilla/mux router
 	httpRouter := mux.NewRouter()
 
@@ -204,6 +242,7 @@ func main() {
 	apiRouter.HandleFunc("/kis/orders", kisOrdersHandler.PlaceOrder).Methods("POST")
 	apiRouter.HandleFunc("/kis/orders/{order_no}", kisOrdersHandler.CancelOrder).Methods("DELETE")
 	apiRouter.HandleFunc("/kis/trade-profit-loss", kisOrdersHandler.GetTradeProfitLoss).Methods("GET")
+	apiRouter.HandleFunc("/kis/rate-limit", kisOrdersHandler.GetRateLimitStats).Methods("GET")
 
 	// Register Exit routes
 	routes.RegisterExitRoutes(httpRouter, exitSvc)
@@ -261,8 +300,12 @@ func main() {
 	fetcherMarketCapRepo := fetcherrepo.NewMarketCapRepository(dbPool)
 	fetcherDisclosureRepo := fetcherrepo.NewDisclosureRepository(dbPool)
 	fetcherLogRepo := fetcherrepo.NewFetchLogRepository(dbPool.Pool)
+	fetcherIndexRepo := fetcherrepo.NewIndexPriceRepository(dbPool)
 	rankingRepo := postgres.NewRankingRepository(dbPool.Pool)
 
+	// Custom rule preview 일봉 변수 (ma*, avg_volume)
+	exitSvc.SetDailyPriceReader(fetcherPriceRepo)
+
 	// 3. Fetcher Service Configuration
 	fetcherConfig := &fetcherservice.Config{
 		PriceInterval:       1 * time.Hour,
@@ -293,6 +336,9 @@ func main() {
 		rankingRepo,
 	)
 
+	// 지수 일봉 수집 (KIS 업종기간별시세 → data.index_prices)
+	fetcherSvc.SetIndexCollector(kisClient.REST, fetcherIndexRepo)
+
 	// 5. Start Fetcher Service in background
 	if err := fetcherSvc.Start(); err != nil {
 		log.Error().Err(err).Msg("Failed to start Fetcher service")
@@ -351,10 +397,15 @@ func main() {
 	// Initialize Audit Service
 	auditRepo := postgres.NewAuditRepository(dbPool.Pool)
 	auditSvc := auditservice.NewService(auditRepo)
+	auditSvc.SetIndexPriceReader(fetcherIndexRepo) // 벤치마크 (KOSPI) 자동 동기화
 	auditHandler := audithandlers.NewHandler(auditSvc)
 
 	// Initialize KIS Audit Builder
 	kisAuditBuilder := auditservice.NewKISAuditBuilder(auditSvc, kisClient, accountNo, accountProductCode)
+	if err := kisAuditBuilder.SetCostBasis(cfg.Cost.Method, nil); err != nil {
+		log.Warn().Err(err).Msg("⚠️ Invalid COST_BASIS_METHOD, using FIFO")
+	}
+	kisAuditBuilder.SetSymbolInfoReader(riskRepo)
 	auditHandler.SetKISBuilder(kisAuditBuilder)
 
 	routes.RegisterAuditRoutes(httpRouter, auditHandler)
@@ -383,7 +434,67 @@ func main() {
 	signalsHandler := signalshandlers.NewHandler(signalsSvc, signalsFactorRepo)
 	routes.RegisterSignalsRoutes(httpRouter, signalsHandler)
 
-	log.Info().Msg("✅ All routes registered (Exit, Holdings, Intents, Orders, Fills, KIS, Watchlist, Stocks, Charts, Fetcher, Universe, Audit, Signals)")
+	// Initialize Ranking Service (Signals BUY → ranking.snapshots, 새 Signal 스냅샷마다 생성)
+	rankingSnapshotRepo := rankingrepo.NewSnapshotRepository(dbPool.Pool)
+	rankingSvc := rankingservice.NewService(
+		ctx,
+		rankingSnapshotRepo,
+		rankingrepo.NewRiskDataRepository(dbPool.Pool),
+		signalsSignalRepo,
+	)
+	if err := rankingSvc.Start(); err != nil {
+		log.Error().Err(err).Msg("Failed to start Ranking service")
+	} else {
+		rankingSvc.StartJob(ctx, time.Minute)
+		log.Info().Msg("✅ Ranking service started (job: every 1m)")
+	}
+
+	// Initialize Portfolio Service (Ranking snapshot → target weights)
+	portfolioRepo := portfoliorepo.NewRepository(dbPool.Pool)
+
+	portfolioSvc := portfolioservice.NewService(
+		ctx,
+		portfolioRepo,
+		rankingSnapshotRepo,
+		holdingRepo,
+		priceService,
+		accountID,
+	)
+	portfolioSvc.SetCashReader(cashReader)
+
+	if err := portfolioSvc.Start(); err != nil {
+		log.Error().Err(err).Msg("Failed to start Portfolio service")
+	} else {
+		log.Info().Msg("✅ Portfolio service started")
+	}
+
+	// Rebalancer (target vs holdings → PENDING_APPROVAL intents)
+	rebalanceRepo := portfoliorepo.NewRebalanceRepository(dbPool.Pool)
+	rebalancer := portfolioservice.NewRebalancer(
+		rebalanceRepo,
+		portfolioSvc,
+		holdingRepo,
+		positionRepo,
+		accountID,
+	)
+	rebalancer.SetCashReader(cashReader)
+
+	portfolioHandler := portfoliohandlers.NewHandler(portfolioSvc)
+	rebalanceHandler := portfoliohandlers.NewRebalanceHandler(rebalancer)
+	routes.RegisterPortfolioRoutes(httpRouter, portfolioHandler, rebalanceHandler)
+
+	// Register Risk routes (limits, emergency stop, blocks)
+	riskHandler := riskhandlers.NewHandler(riskSvc)
+	routes.RegisterRiskRoutes(httpRouter, riskHandler)
+
+	// Register Backtest routes (exit profile replay)
+	backtestSvc := backtestservice.NewService(fetcherPriceRepo, priceRepo, profileRepo)
+	backtestSvc.SetSymbolInfoReader(riskRepo)
+	backtestSvc.SetPositionResolver(exitSvc)
+	backtestHandler := backtesthandlers.NewHandler(backtestSvc)
+	routes.RegisterBacktestRoutes(httpRouter, backtestHandler)
+
+	log.Info().Msg("✅ All routes registered (Exit, Holdings, Intents, Orders, Fills, KIS, Watchlist, Stocks, Charts, Fetcher, Universe, Audit, Signals, Portfolio, Risk, Backtest)")
 
 	// Wrap with CORS
 	handler := gorillaHandlers.CORS(allowedOrigins, allowedMethods, allowedHeaders, allowCredentials)(httpRouter)
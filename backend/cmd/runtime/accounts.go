@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres/kisauth"
+	"github.com/wonny/aegis/v14/internal/infra/kis"
+	"github.com/wonny/aegis/v14/internal/infra/paper"
+	"github.com/wonny/aegis/v14/internal/pkg/config"
+	executionservice "github.com/wonny/aegis/v14/internal/service/execution"
+)
+
+// accountRuntime 계좌별 주문 실행 구성 (KIS client / broker / execution service)
+type accountRuntime struct {
+	account   config.AccountConfig
+	kisClient *kis.Client          // nil → KIS 미접속 (paper)
+	broker    execution.KISAdapter // paper 모드는 모든 계좌가 하나의 paper broker 공유
+	execution *executionservice.Service
+}
+
+// newAccountRuntimes creates KIS clients and brokers for all configured accounts
+// 계좌별 인증키가 없거나 공통 키와 같으면 기본 client를 공유
+func newAccountRuntimes(
+	ctx context.Context,
+	cfg *config.Config,
+	kisClient *kis.Client,
+	prices paper.PriceSource,
+	pool *pgxpool.Pool,
+) ([]*accountRuntime, error) {
+	paperMode := cfg.Broker.Mode == config.BrokerModePaper
+
+	clients := make(map[string]*kis.Client) // app key → client
+	if kisClient != nil {
+		clients[kisClient.Config.AppKey] = kisClient
+	}
+	brokers := make(map[*kis.Client]execution.KISAdapter)
+
+	runtimes := make([]*accountRuntime, 0, len(cfg.Accounts))
+	for _, account := range cfg.Accounts {
+		client := kisClient
+		if account.AppKey != "" && !paperMode {
+			if existing, ok := clients[account.AppKey]; ok {
+				client = existing
+			} else {
+				if kisClient == nil {
+					return nil, fmt.Errorf("account %s: KIS client unavailable", account.Name)
+				}
+				client = kis.NewClient(kisClient.Config.WithCredentials(account.AppKey, account.AppSecret))
+				if client.UsesPostgresTokenStore() {
+					client.SetTokenStore(kisauth.NewTokenRepository(pool))
+				}
+				clients[account.AppKey] = client
+				log.Info().Str("account", account.Name).Msg("✅ KIS client initialized (account credentials)")
+			}
+		}
+
+		// paper: 단일 broker (계좌별 원장은 paper broker 내부에서 분리)
+		brokerKey := client
+		if paperMode {
+			brokerKey = nil
+		}
+		broker, ok := brokers[brokerKey]
+		if !ok {
+			broker = newBroker(ctx, cfg.Broker, client, prices, pool)
+			brokers[brokerKey] = broker
+		}
+
+		runtimes = append(runtimes, &accountRuntime{
+			account:   account,
+			kisClient: client,
+			broker:    broker,
+		})
+	}
+
+	return runtimes, nil
+}
+
+// subscribeExecutionNotices routes KIS execution notifications to account services
+// 같은 client를 공유하는 계좌는 통보의 계좌번호로 구분
+func subscribeExecutionNotices(runtimes []*accountRuntime, onNotice func(rt *accountRuntime, exec kis.ExecutionNotification)) {
+	byClient := make(map[*kis.Client][]*accountRuntime)
+	var order []*kis.Client
+	for _, rt := range runtimes {
+		if rt.kisClient == nil {
+			continue
+		}
+		if _, ok := byClient[rt.kisClient]; !ok {
+			order = append(order, rt.kisClient)
+		}
+		byClient[rt.kisClient] = append(byClient[rt.kisClient], rt)
+	}
+
+	for _, client := range order {
+		accounts := byClient[client]
+		client.WS.SetExecutionHandler(func(exec kis.ExecutionNotification) {
+			rt := accounts[0]
+			for _, candidate := range accounts {
+				if exec.AccountNo != "" && strings.HasPrefix(exec.AccountNo, candidate.account.AccountNo()) {
+					rt = candidate
+					break
+				}
+			}
+			onNotice(rt, exec)
+		})
+
+		// WS 체결통보 구독은 client당 1계좌 (나머지 계좌는 fills polling)
+		for i, rt := range accounts {
+			if i > 0 {
+				log.Warn().Str("account", rt.account.Name).Msg("Execution notifications share a KIS client, using fills polling for this account")
+				continue
+			}
+			if err := client.WS.SubscribeExecution(rt.account.ID); err != nil {
+				log.Warn().Err(err).Str("account", rt.account.Name).Msg("Failed to subscribe to execution notifications - will use polling instead")
+			} else {
+				log.Info().Str("account_id", rt.account.ID).Msg("✅ Subscribed to KIS execution notifications")
+			}
+		}
+	}
+}
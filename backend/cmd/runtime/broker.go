@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	paperpg "github.com/wonny/aegis/v14/internal/infra/database/postgres/paper"
+	riskpg "github.com/wonny/aegis/v14/internal/infra/database/postgres/risk"
+	"github.com/wonny/aegis/v14/internal/infra/kis"
+	"github.com/wonny/aegis/v14/internal/infra/paper"
+	"github.com/wonny/aegis/v14/internal/pkg/config"
+	"github.com/wonny/aegis/v14/internal/service/pricing"
+)
+
+// newBroker creates the order execution adapter by BROKER_MODE
+// - kis: KIS REST (실계좌/모의계좌)
+// - paper: in-process 모의 체결 (pricesync 가격 매칭, paper schema 원장)
+func newBroker(ctx context.Context, cfg config.BrokerConfig, kisClient *kis.Client, prices paper.PriceSource, pool *pgxpool.Pool) execution.KISAdapter {
+	if cfg.Mode != config.BrokerModePaper {
+		return kis.NewExecutionAdapter(kisClient)
+	}
+
+	paperCfg := paper.Config{
+		InitialCash:       decimal.NewFromInt(cfg.Paper.InitialCash),
+		Latency:           time.Duration(cfg.Paper.LatencyMs) * time.Millisecond,
+		PartialFillPct:    cfg.Paper.PartialFillPct,
+		RejectRate:        cfg.Paper.RejectRate,
+		MatchInterval:     time.Duration(cfg.Paper.MatchIntervalMs) * time.Millisecond,
+		MaxPriceAge:       paper.DefaultConfig().MaxPriceAge,
+		MatchOutsideHours: cfg.Paper.MatchOutsideHours,
+		Seed:              cfg.Paper.Seed,
+	}
+
+	broker := paper.NewBroker(paperCfg, prices, paperpg.NewLedgerRepository(pool))
+	broker.SetSymbolInfoReader(riskpg.NewRepository(pool))
+	broker.Start(ctx)
+
+	log.Warn().
+		Str("initial_cash", paperCfg.InitialCash.String()).
+		Msg("🧪 PAPER broker enabled - orders are simulated, nothing is sent to KIS")
+
+	return broker
+}
+
+// applyCostModel sets the process-wide trading cost model from BROKER_COMMISSION_RATE
+func applyCostModel(cfg config.CostConfig) {
+	rate := execution.DefaultCommissionRate
+	if cfg.CommissionRate > 0 {
+		rate = decimal.NewFromFloat(cfg.CommissionRate)
+	}
+	execution.SetDefaultCostModel(execution.NewCostModel(rate))
+	log.Info().Str("commission_rate", rate.String()).Msg("✅ Trading cost model configured")
+}
+
+// newRepricePolicy converts REPRICE_* config to execution.RepricePolicy
+func newRepricePolicy(cfg config.RepriceConfig) execution.RepricePolicy {
+	policy := execution.DefaultRepricePolicy()
+	policy.Enabled = cfg.Enabled
+	if cfg.TimeoutSec > 0 {
+		policy.Timeout = time.Duration(cfg.TimeoutSec) * time.Second
+	}
+	if cfg.ChaseTicks >= 0 {
+		policy.ChaseTicks = cfg.ChaseTicks
+	}
+	if cfg.MaxAttempts >= 0 {
+		policy.MaxAttempts = cfg.MaxAttempts
+	}
+	policy.ConvertToMarket = cfg.ConvertToMarket
+	return policy
+}
+
+// newPriceGuard builds the limit price guard (KIS 현재가 시세 기준 상/하한가·VI, 실패 시 현재가 기반 추정)
+func newPriceGuard(kisClient *kis.Client, prices pricing.BestPriceReader, pool *pgxpool.Pool) *pricing.Guard {
+	var bands price.BandReader
+	if kisClient != nil {
+		bands = kisClient.REST
+	}
+	guard := pricing.NewGuard(bands, prices)
+	guard.SetSymbolInfoReader(riskpg.NewRepository(pool))
+	return guard
+}
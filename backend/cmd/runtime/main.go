@@ -8,17 +8,26 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	executiondomain "github.com/wonny/aegis/v14/internal/domain/execution"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	"github.com/wonny/aegis/v14/internal/infra/database/postgres"
 	exitpg "github.com/wonny/aegis/v14/internal/infra/database/postgres/exit"
+	fetcherpg "github.com/wonny/aegis/v14/internal/infra/database/postgres/fetcher"
+	riskpg "github.com/wonny/aegis/v14/internal/infra/database/postgres/risk"
 	signalsrepo "github.com/wonny/aegis/v14/internal/infra/database/postgres/signals"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres/kisauth"
 	"github.com/wonny/aegis/v14/internal/infra/kis"
+	executionpg "github.com/wonny/aegis/v14/internal/infrastructure/postgres/execution"
+	reentrypg "github.com/wonny/aegis/v14/internal/infrastructure/postgres/reentry"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 	"github.com/wonny/aegis/v14/internal/pkg/config"
 	"github.com/wonny/aegis/v14/internal/pkg/logger"
 	auditservice "github.com/wonny/aegis/v14/internal/service/audit"
 	"github.com/wonny/aegis/v14/internal/service/execution"
 	exitservice "github.com/wonny/aegis/v14/internal/service/exit"
 	"github.com/wonny/aegis/v14/internal/service/pricesync"
+	reentryservice "github.com/wonny/aegis/v14/internal/service/reentry"
+	riskservice "github.com/wonny/aegis/v14/internal/service/risk"
 )
 
 const (
@@ -58,6 +67,18 @@ func main() {
 		Str("version", serviceVersion).
 		Msg("🚀 Starting Aegis v14 Runtime (Core Trading Engine)...")
 
+	// Load KRX trading calendar (built-in 휴장일 + 추가 파일)
+	if cfg.Market.CalendarFile != "" {
+		if err := calendar.LoadFile(cfg.Market.CalendarFile); err != nil {
+			log.Warn().Err(err).Str("file", cfg.Market.CalendarFile).Msg("⚠️ Failed to load KRX calendar file, using built-in holidays")
+		} else {
+			log.Info().Str("file", cfg.Market.CalendarFile).Msg("✅ KRX calendar loaded")
+		}
+	}
+
+	// Trading cost model (수수료율 설정 + KRX 시장/일자별 거래세)
+	applyCostModel(cfg.Cost)
+
 	// Context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -71,24 +92,35 @@ func main() {
 
 	log.Info().Msg("✅ Database connected")
 
-	// Initialize KIS client
+	// Initialize KIS client (paper 모드에서는 시세용 선택 사항, 없으면 Naver 시세만 사용)
+	paperMode := cfg.Broker.Mode == config.BrokerModePaper
 	kisClient, err := kis.NewClientFromEnv()
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to initialize KIS client (required for trading)")
-	}
-
-	log.Info().Msg("✅ KIS client initialized")
+		if !paperMode {
+			log.Fatal().Err(err).Msg("Failed to initialize KIS client (required for trading)")
+		}
+		log.Warn().Err(err).Msg("⚠️ KIS client unavailable, paper mode will use Naver prices only")
+		kisClient = nil
+	} else {
+		log.Info().Msg("✅ KIS client initialized")
 
-	// Create KIS Execution Adapter
-	kisAdapter := kis.NewExecutionAdapter(kisClient)
+		// 토큰 공유 (KIS_TOKEN_STORE=postgres)
+		if kisClient.UsesPostgresTokenStore() {
+			kisClient.SetTokenStore(kisauth.NewTokenRepository(dbPool.Pool))
+		}
+	}
 
-	// Get account ID from environment
-	accountID := os.Getenv("KIS_ACCOUNT_ID")
-	if accountID == "" {
-		accountID = os.Getenv("KIS_ACCOUNT_NO")
+	// Trading accounts (KIS_ACCOUNTS 또는 KIS_ACCOUNT_ID, 첫 번째 = 기본 계좌)
+	if len(cfg.Accounts) == 0 {
+		log.Fatal().Msg("KIS_ACCOUNTS or KIS_ACCOUNT_ID environment variable is required")
 	}
-	if accountID == "" {
-		log.Fatal().Msg("KIS_ACCOUNT_ID or KIS_ACCOUNT_NO environment variable is required")
+	primaryAccountID := cfg.Accounts[0].ID
+	for _, account := range cfg.Accounts {
+		log.Info().
+			Str("account", account.Name).
+			Str("account_id", account.ID).
+			Str("risk_profile", account.RiskProfile).
+			Msg("Trading account configured")
 	}
 
 	// ========================================
@@ -112,101 +144,163 @@ func main() {
 
 	log.Info().Msg("✅ PriceSync Manager started (V2 with DB protection)")
 
-	// ========================================
-	// 1.1. Subscribe to KIS Execution Notifications
-	// ========================================
-	// When execution notification is received, trigger immediate price-sync
-	kisClient.WS.SetExecutionHandler(func(exec kis.ExecutionNotification) {
-		log.Info().
-			Str("symbol", exec.Symbol).
-			Str("order_no", exec.OrderNo).
-			Str("side", exec.Side).
-			Int64("filled_qty", exec.FilledQty).
-			Int64("filled_price", exec.FilledPrice).
-			Msg("📣 Execution notification received - triggering price sync")
-
-		// Trigger immediate price sync for this symbol
-		priceSyncManager.TriggerRefresh(exec.Symbol)
-	})
-
-	// Subscribe to execution notifications for the account
-	if err := kisClient.WS.SubscribeExecution(accountID); err != nil {
-		log.Warn().Err(err).Msg("Failed to subscribe to execution notifications - will use polling instead")
-	} else {
-		log.Info().Str("account_id", accountID).Msg("✅ Subscribed to KIS execution notifications")
+	// Create order execution adapters per account (BROKER_MODE: kis | paper)
+	accountRuntimes, err := newAccountRuntimes(ctx, cfg, kisClient, priceService, dbPool.Pool)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize trading accounts")
 	}
 
 	// ========================================
-	// 1.5. Initialize Holdings Sync Service
+	// 1.5. Initialize Holdings Sync Service (계좌별)
 	// ========================================
 	// ✅ 2026-01-18: 간격 120초로 증가 (rate limit 방지)
 	// Execution Service의 holdings sync와 역할 분담:
 	// - 이 서비스: DB에 holdings 저장 (API용)
 	// - Execution Service: ExitEvent 감지용
-	holdingsSync := NewHoldingsSyncService(
-		kisAdapter,
-		postgres.NewHoldingRepository(dbPool.Pool),
-		accountID,
-		120*time.Second, // Sync every 2 minutes (rate limit 완화)
-	)
-
-	// 3초 대기 후 시작 (다른 서비스와 시차 두기)
-	go func() {
-		time.Sleep(3 * time.Second)
-		holdingsSync.Start(ctx)
-	}()
-	log.Info().Msg("✅ Holdings Sync Service started (interval: 120s, delayed start)")
+	for _, rt := range accountRuntimes {
+		holdingsSync := NewHoldingsSyncService(
+			rt.broker,
+			postgres.NewHoldingRepository(dbPool.Pool),
+			rt.account.ID,
+			120*time.Second, // Sync every 2 minutes (rate limit 완화)
+		)
+
+		// 3초 대기 후 시작 (다른 서비스와 시차 두기)
+		go func() {
+			time.Sleep(3 * time.Second)
+			holdingsSync.Start(ctx)
+		}()
+	}
+	log.Info().Int("accounts", len(accountRuntimes)).Msg("✅ Holdings Sync Service started (interval: 120s, delayed start)")
 
 	// ========================================
-	// 2. Initialize Execution Service
+	// 2. Initialize Execution Service (계좌별 loop)
 	// ========================================
 	orderRepo := postgres.NewOrderRepository(dbPool.Pool)
 	fillRepo := postgres.NewFillRepository(dbPool.Pool)
 	holdingRepo := postgres.NewHoldingRepository(dbPool.Pool)
 	exitEventRepo := postgres.NewExitEventRepository(dbPool.Pool)
 	orderIntentRepo := exitpg.NewOrderIntentRepository(dbPool.Pool)
+	rebalanceSellChecker := executionpg.NewIntentRepository(dbPool.Pool)
 	positionRepo := exitpg.NewPositionRepository(dbPool.Pool)
 
-	executionService := execution.NewService(
-		ctx,
-		orderRepo,
-		fillRepo,
-		holdingRepo,
-		exitEventRepo,
-		orderIntentRepo,
-		positionRepo,
-		positionRepo, // exitPositionRepo - for auto-creating positions from holdings
-		kisAdapter,
-		accountID,
-	)
-
-	// ========================================
-	// 2.1. Connect Audit Trade Writer (for performance page)
-	// ========================================
+	// Shared hooks
 	auditRepo := postgres.NewAuditRepository(dbPool.Pool)
 	auditTradeWriter := auditservice.NewTradeWriter(auditRepo)
-	executionService.SetAuditTradeWriter(auditTradeWriter)
+	riskRepo := riskpg.NewRepository(dbPool.Pool)
+	repricePolicy := newRepricePolicy(cfg.Reprice)
+	priceGuard := newPriceGuard(kisClient, priceService, dbPool.Pool)
+
+	for i, rt := range accountRuntimes {
+		executionService := execution.NewService(
+			ctx,
+			orderRepo,
+			fillRepo,
+			holdingRepo,
+			exitEventRepo,
+			orderIntentRepo,
+			positionRepo,
+			positionRepo, // exitPositionRepo - for auto-creating positions from holdings
+			rt.broker,
+			rt.account.ID,
+		)
+		// 계좌 미지정 intent (ENTRY/리밸런싱)는 기본 계좌에서 처리
+		executionService.SetPrimaryAccount(i == 0)
+		rt.execution = executionService
+
+		// 2.1. Audit Trade Writer (for performance page)
+		executionService.SetAuditTradeWriter(auditTradeWriter)
+
+		// 2.2. Pre-trade Risk Gate (Fail-Closed, 계좌별 한도 프로필)
+		riskService := riskservice.NewService(riskRepo, riskRepo, holdingRepo, priceService, exitEventRepo, rt.account.ID)
+		riskService.SetLimitsProfile(rt.account.RiskProfile)
+		riskService.SetCashReader(executiondomain.AccountCashReader{Broker: rt.broker, AccountID: rt.account.ID})
+		executionService.SetRiskGate(riskService)
+
+		// 2.3. Order Repricer (미체결 LMT 청산 → 매수호가 추격 → 시장가)
+		executionService.SetRepricer(postgres.NewOrderEventRepository(dbPool.Pool), priceService, repricePolicy)
+
+		// 2.4. Price Guard (호가단위 보정 + 가격제한폭/VI 검증)
+		executionService.SetPriceGuard(priceGuard)
+
+		// 2.4.1. Rebalance Sell Checker (REBALANCE_BUY는 같은 계획 매도 체결 후 제출)
+		executionService.SetRebalanceSellChecker(rebalanceSellChecker)
+
+		// 2.5. Position Ledger (체결 기반 포지션 원장, holdings sync는 대사만)
+		if cfg.Ledger.Enabled {
+			executionService.SetLedger(postgres.NewLedgerRepository(dbPool.Pool), riskRepo)
+			if err := executionService.SetCostBasis(cfg.Cost.Method); err != nil {
+				log.Warn().Err(err).Msg("⚠️ Invalid COST_BASIS_METHOD, using FIFO")
+			}
+		}
+
+		log.Info().
+			Str("account", rt.account.Name).
+			Str("account_id", rt.account.ID).
+			Bool("primary", i == 0).
+			Msg("✅ Execution Service configured")
+	}
+
 	log.Info().Msg("✅ Audit Trade Writer connected (trades will be saved to audit.trade_history)")
+	log.Info().Msg("✅ Risk Gate connected (every intent checked before KIS submission)")
+	log.Info().
+		Bool("enabled", repricePolicy.Enabled).
+		Dur("timeout", repricePolicy.Timeout).
+		Int("chase_ticks", repricePolicy.ChaseTicks).
+		Int("max_attempts", repricePolicy.MaxAttempts).
+		Msg("✅ Order Repricer connected")
+	log.Info().Msg("✅ Price Guard connected (limit prices aligned to KRX tick/band)")
+	if cfg.Ledger.Enabled {
+		log.Info().Msg("✅ Position Ledger connected (positions driven by fills, holdings reconciled)")
+	} else {
+		log.Info().Msg("Position Ledger disabled (positions synced from holdings polling)")
+	}
+
+	// ========================================
+	// 2.6. Subscribe to KIS Execution Notifications
+	// ========================================
+	// When execution notification is received, apply it to the account's position ledger and trigger immediate price-sync
+	if !paperMode {
+		subscribeExecutionNotices(accountRuntimes, func(rt *accountRuntime, exec kis.ExecutionNotification) {
+			log.Info().
+				Str("account_id", rt.account.ID).
+				Str("symbol", exec.Symbol).
+				Str("order_no", exec.OrderNo).
+				Str("side", exec.Side).
+				Int64("filled_qty", exec.FilledQty).
+				Int64("filled_price", exec.FilledPrice).
+				Msg("📣 Execution notification received - triggering price sync")
+
+			// Apply to position ledger ahead of fills sync (no-op when ledger disabled)
+			rt.execution.OnExecutionNotice(ctx, kis.ToExecutionNotice(exec))
+
+			// Trigger immediate price sync for this symbol
+			priceSyncManager.TriggerRefresh(exec.Symbol)
+		})
+	}
 
 	// Bootstrap execution service (sync holdings, orders, fills from KIS)
 	// ✅ 2026-01-18: 5초 대기 후 bootstrap (rate limit 방지)
 	log.Info().Msg("Waiting 5s before Execution Service bootstrap (rate limit prevention)...")
 	time.Sleep(5 * time.Second)
 
-	if err := executionService.Bootstrap(ctx); err != nil {
-		log.Error().Err(err).Msg("Execution Service bootstrap failed, continuing anyway")
-	} else {
-		log.Info().Msg("✅ Execution Service bootstrapped")
-	}
-
-	// Start execution service loops
-	go func() {
-		if err := executionService.Start(); err != nil {
-			log.Error().Err(err).Msg("Execution Service failed")
+	for _, rt := range accountRuntimes {
+		executionService := rt.execution
+		if err := executionService.Bootstrap(ctx); err != nil {
+			log.Error().Err(err).Str("account_id", rt.account.ID).Msg("Execution Service bootstrap failed, continuing anyway")
+		} else {
+			log.Info().Str("account_id", rt.account.ID).Msg("✅ Execution Service bootstrapped")
 		}
-	}()
 
-	log.Info().Msg("✅ Execution Service started")
+		// Start execution service loops
+		go func() {
+			if err := executionService.Start(); err != nil {
+				log.Error().Err(err).Str("account_id", executionService.AccountID()).Msg("Execution Service failed")
+			}
+		}()
+	}
+
+	log.Info().Int("accounts", len(accountRuntimes)).Msg("✅ Execution Service started")
 
 	// ========================================
 	// 3. Initialize Exit Engine
@@ -274,6 +368,15 @@ func main() {
 				Enabled: true,
 				Pct:     -0.10,
 			},
+			// 승인 정책: 손절은 즉시 실행, TP1은 60초 승인 대기 후 자동 승인, 나머지는 수동 승인
+			Approval: &exit.ApprovalPolicy{
+				Default: &exit.ApprovalRule{Mode: exit.ApprovalModeManual},
+				Reasons: map[string]exit.ApprovalRule{
+					exit.ReasonHardStop: {Mode: exit.ApprovalModeAuto},
+					exit.ReasonSL2:      {Mode: exit.ApprovalModeAuto},
+					exit.ReasonTP1:      {Mode: exit.ApprovalModeTimeoutApprove, TimeoutSeconds: 60},
+				},
+			},
 		},
 		IsActive:  true,
 		CreatedBy: "system",
@@ -301,12 +404,57 @@ func main() {
 
 	log.Info().Msg("✅ Exit Engine started")
 
+	// 일봉 소스: custom rule 변수(ma*, avg_volume) + ATR job (ATR 비활성이어도 필요)
+	exitService.SetDailyPriceReader(fetcherpg.NewPriceRepository(dbPool))
+
+	// Daily ATR job (장전 data.daily_prices → position_state.atr, SL/TP 변동성 스케일링)
+	if cfg.ATR.Enabled {
+		if err := exitService.StartATRJob(ctx, exitservice.ATRJobConfig{
+			Period: cfg.ATR.Period,
+			RunAt:  cfg.ATR.RunAt,
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to start daily ATR job")
+		} else {
+			log.Info().Int("period", cfg.ATR.Period).Str("run_at", cfg.ATR.RunAt).Msg("✅ Daily ATR job started")
+		}
+	}
+
+	// ========================================
+	// 3.1. Initialize Reentry Engine
+	// ========================================
+	reentryService := reentryservice.NewService(
+		ctx,
+		reentrypg.NewCandidateRepository(dbPool.Pool),
+		reentrypg.NewControlRepository(dbPool.Pool),
+		reentrypg.NewProfileRepository(dbPool.Pool),
+		exitEventRepo,
+		priceService,
+		reentrypg.NewIntentWriter(dbPool.Pool),
+	)
+	// ENTRY intent는 계좌 미지정 → 기본 계좌 기준 비중 산정 (보유 평가금액 + 예수금)
+	reentryService.SetPortfolioValueReader(NewPortfolioValueAdapter(holdingRepo, accountRuntimes[0].broker, primaryAccountID))
+
+	// ENTRY 체결 → Candidate ENTERED
+	for _, rt := range accountRuntimes {
+		rt.execution.SetEntryFillHandler(reentryService)
+	}
+
+	if err := reentryService.Start(); err != nil {
+		log.Error().Err(err).Msg("Reentry Engine failed to start")
+	} else {
+		log.Info().Msg("✅ Reentry Engine started")
+	}
+
 	// ========================================
 	// 4. Initialize PriorityManager and Subscriptions
 	// ========================================
 	// Now that all repositories are ready, create PriorityManager
-	positionAdapter := NewPositionRepoAdapter(positionRepo, holdingRepo, accountID)
-	orderAdapter := NewOrderRepoAdapter(kisAdapter, accountID)
+	positionAdapter := NewPositionRepoAdapter(positionRepo, holdingRepo)
+	accountBrokers := make(map[string]UnfilledOrderReader, len(accountRuntimes))
+	for _, rt := range accountRuntimes {
+		accountBrokers[rt.account.ID] = rt.broker
+	}
+	orderAdapter := NewOrderRepoAdapter(accountBrokers)
 	watchlistAdapter := NewWatchlistRepoAdapter(dbPool.Pool)
 	systemAdapter := NewSystemRepoAdapter()
 	rankingAdapter := NewRankingRepoAdapter(dbPool.Pool)
@@ -342,6 +490,7 @@ func main() {
 	log.Info().Msg("  - PriceSync: Syncing prices from KIS/Naver")
 	log.Info().Msg("  - Exit Engine: Evaluating exit rules on holdings")
 	log.Info().Msg("  - Execution Service: Processing order intents → KIS orders")
+	log.Info().Msg("  - Reentry Engine: Watching exited symbols → ENTRY intents")
 
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
@@ -15,7 +15,6 @@ type PositionRepoAdapter struct {
 	holdingRepo interface {
 		GetAllHoldings(ctx context.Context) ([]*execution.Holding, error)
 	}
-	accountID string
 }
 
 func NewPositionRepoAdapter(
@@ -23,12 +22,10 @@ func NewPositionRepoAdapter(
 	holdingRepo interface {
 		GetAllHoldings(ctx context.Context) ([]*execution.Holding, error)
 	},
-	accountID string,
 ) *PositionRepoAdapter {
 	return &PositionRepoAdapter{
 		exitRepo:    exitRepo,
 		holdingRepo: holdingRepo,
-		accountID:   accountID,
 	}
 }
 
@@ -53,7 +50,8 @@ func (a *PositionRepoAdapter) GetOpenPositions(ctx context.Context) ([]pricesync
 }
 
 func (a *PositionRepoAdapter) GetClosingPositions(ctx context.Context) ([]pricesync.PositionSummary, error) {
-	positions, err := a.exitRepo.GetOpenPositions(ctx, a.accountID)
+	// 모든 계좌
+	positions, err := a.exitRepo.GetAllOpenPositions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -71,35 +69,35 @@ func (a *PositionRepoAdapter) GetClosingPositions(ctx context.Context) ([]prices
 	return summaries, nil
 }
 
-// OrderRepoAdapter adapts order repository to pricesync.OrderRepository
+// UnfilledOrderReader reads unfilled orders of an account (broker)
+type UnfilledOrderReader interface {
+	GetUnfilledOrders(ctx context.Context, accountID string) ([]*execution.KISUnfilledOrder, error)
+}
+
+// OrderRepoAdapter adapts order repository to pricesync.OrderRepository (모든 운용 계좌)
 type OrderRepoAdapter struct {
-	kisAdapter interface {
-		GetUnfilledOrders(ctx context.Context, accountID string) ([]*execution.KISUnfilledOrder, error)
-	}
-	accountID string
+	accounts map[string]UnfilledOrderReader // account_id → broker
 }
 
-func NewOrderRepoAdapter(kisAdapter interface {
-	GetUnfilledOrders(ctx context.Context, accountID string) ([]*execution.KISUnfilledOrder, error)
-}, accountID string) *OrderRepoAdapter {
+func NewOrderRepoAdapter(accounts map[string]UnfilledOrderReader) *OrderRepoAdapter {
 	return &OrderRepoAdapter{
-		kisAdapter: kisAdapter,
-		accountID:  accountID,
+		accounts: accounts,
 	}
 }
 
 func (a *OrderRepoAdapter) GetActiveOrderSymbols(ctx context.Context) ([]string, error) {
-	// Get unfilled orders from KIS
-	orders, err := a.kisAdapter.GetUnfilledOrders(ctx, a.accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extract unique symbols
+	// Extract unique symbols of unfilled orders (from KIS, per account)
 	symbolSet := make(map[string]bool)
-	for _, o := range orders {
-		if o.Symbol != "" && o.OpenQty > 0 {
-			symbolSet[o.Symbol] = true
+	for accountID, broker := range a.accounts {
+		orders, err := broker.GetUnfilledOrders(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range orders {
+			if o.Symbol != "" && o.OpenQty > 0 {
+				symbolSet[o.Symbol] = true
+			}
 		}
 	}
 
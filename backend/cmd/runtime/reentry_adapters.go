@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+)
+
+// PortfolioValueAdapter adapts HoldingRepository + broker cash to reentry.PortfolioValueReader
+// 포트폴리오 가치 = 보유 종목 평가금액 합계 + 예수금 (현금 조회 실패 시 error → 비중 산정 중단)
+type PortfolioValueAdapter struct {
+	holdingRepo execution.HoldingRepository
+	cashReader  execution.AccountCashReader
+	accountID   string
+}
+
+func NewPortfolioValueAdapter(holdingRepo execution.HoldingRepository, broker execution.KISAdapter, accountID string) *PortfolioValueAdapter {
+	return &PortfolioValueAdapter{
+		holdingRepo: holdingRepo,
+		cashReader:  execution.AccountCashReader{Broker: broker, AccountID: accountID},
+		accountID:   accountID,
+	}
+}
+
+func (a *PortfolioValueAdapter) GetPortfolioValue(ctx context.Context) (decimal.Decimal, error) {
+	holdings, err := a.holdingRepo.LoadHoldings(ctx, a.accountID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	cash, err := a.cashReader.GetCash(ctx)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("get cash: %w", err)
+	}
+
+	total := cash
+	for _, h := range holdings {
+		if h.Qty <= 0 {
+			continue
+		}
+		total = total.Add(h.CurrentPrice.Mul(decimal.NewFromInt(h.Qty)))
+	}
+
+	return total, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/wonny/aegis/v14/internal/domain/execution"
 	"github.com/wonny/aegis/v14/internal/infra/database/postgres"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres/kisauth"
 	"github.com/wonny/aegis/v14/internal/infra/kis"
 	"github.com/wonny/aegis/v14/internal/pkg/config"
 )
@@ -46,6 +47,11 @@ func main() {
 
 	log.Info().Msg("✅ KIS client initialized")
 
+	// 토큰 공유 (KIS_TOKEN_STORE=postgres)
+	if kisClient.UsesPostgresTokenStore() {
+		kisClient.SetTokenStore(kisauth.NewTokenRepository(dbPool.Pool))
+	}
+
 	// Create KIS Execution Adapter
 	kisAdapter := kis.NewExecutionAdapter(kisClient)
 
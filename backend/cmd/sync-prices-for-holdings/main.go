@@ -16,6 +16,7 @@ import (
 	"github.com/wonny/aegis/v14/internal/domain/price"
 	"github.com/wonny/aegis/v14/internal/infra/database/postgres"
 	exitpg "github.com/wonny/aegis/v14/internal/infra/database/postgres/exit"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres/kisauth"
 	"github.com/wonny/aegis/v14/internal/infra/kis"
 	"github.com/wonny/aegis/v14/internal/pkg/config"
 	exitservice "github.com/wonny/aegis/v14/internal/service/exit"
@@ -55,6 +56,11 @@ func main() {
 	}
 	log.Info().Msg("✅ KIS client initialized")
 
+	// 토큰 공유 (KIS_TOKEN_STORE=postgres)
+	if kisClient.UsesPostgresTokenStore() {
+		kisClient.SetTokenStore(kisauth.NewTokenRepository(dbPool.Pool))
+	}
+
 	// 3. Initialize PriceSync Service and Manager
 	log.Info().Msg("Initializing PriceSync...")
 	priceRepo := postgres.NewPriceRepository(dbPool.Pool)
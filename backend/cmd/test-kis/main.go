@@ -8,7 +8,10 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres/kisauth"
 	"github.com/wonny/aegis/v14/internal/infra/kis"
+	"github.com/wonny/aegis/v14/internal/pkg/config"
 )
 
 func main() {
@@ -26,6 +29,20 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// 토큰 공유 (KIS_TOKEN_STORE=postgres): 다른 프로세스와 같은 토큰 사용
+	if client.UsesPostgresTokenStore() {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		dbPool, err := postgres.NewPool(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer dbPool.Close()
+		client.SetTokenStore(kisauth.NewTokenRepository(dbPool.Pool))
+	}
+
 	// Test 1: Get access token
 	fmt.Println("========================================")
 	fmt.Println("Test 1: Get Access Token")
@@ -58,6 +58,13 @@ type AttributionResponse struct {
 	Error   string                     `json:"error,omitempty"`
 }
 
+// AccountReportResponse 계좌별 성과 응답
+type AccountReportResponse struct {
+	Success bool                 `json:"success"`
+	Data    *audit.AccountReport `json:"data,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
 // RiskMetricsResponse 리스크 지표 응답
 type RiskMetricsResponse struct {
 	Success bool               `json:"success"`
@@ -129,6 +136,33 @@ func (h *Handler) GeneratePerformance(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetAccountPerformance handles GET /api/v1/audit/accounts
+// 계좌별 + 통합 실현 성과 (period: 1M, 3M, 6M, 1Y, YTD)
+func (h *Handler) GetAccountPerformance(w http.ResponseWriter, r *http.Request) {
+	periodStr := r.URL.Query().Get("period")
+	if periodStr == "" {
+		periodStr = "1M"
+	}
+
+	period := audit.Period(periodStr)
+	if !period.IsValid() {
+		h.writeError(w, "Invalid period", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GenerateAccountReport(r.Context(), period)
+	if err != nil {
+		log.Error().Err(err).Str("period", periodStr).Msg("Failed to generate account report")
+		h.writeError(w, "Failed to generate account report", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, AccountReportResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
 // =============================================================================
 // Daily PnL Handlers
 // =============================================================================
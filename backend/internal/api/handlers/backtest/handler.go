@@ -0,0 +1,107 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/backtest"
+)
+
+// BacktestService 백테스트 서비스 인터페이스
+type BacktestService interface {
+	Run(ctx context.Context, req *backtest.Request) (*backtest.Result, error)
+	SimulatePosition(ctx context.Context, req *backtest.SimulationRequest) (*backtest.Simulation, error)
+}
+
+// Handler Backtest API 핸들러
+type Handler struct {
+	service BacktestService
+}
+
+// NewHandler 핸들러 생성
+func NewHandler(service BacktestService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RunExitBacktest handles POST /api/v1/backtest/exit
+// Exit 프로필을 과거 진입에 대해 리플레이 (일봉 또는 틱)
+func (h *Handler) RunExitBacktest(w http.ResponseWriter, r *http.Request) {
+	var req backtest.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Run(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, backtest.ErrNoEntries),
+			errors.Is(err, backtest.ErrInvalidEntry),
+			errors.Is(err, backtest.ErrInvalidSource),
+			errors.Is(err, backtest.ErrTooManyEntries):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, backtest.ErrProfileNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Error().Err(err).Msg("Failed to run exit backtest")
+			http.Error(w, "Failed to run backtest", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, result)
+}
+
+// SimulatePosition handles POST /api/v1/exit/positions/{positionId}/simulate
+// 후보 ExitProfileConfig를 진입 이후 가격 경로에 적용 → 현재 프로필과 비교
+func (h *Handler) SimulatePosition(w http.ResponseWriter, r *http.Request) {
+	positionIDStr := mux.Vars(r)["positionId"]
+	positionID, err := uuid.Parse(positionIDStr)
+	if err != nil {
+		http.Error(w, "Invalid position ID", http.StatusBadRequest)
+		return
+	}
+
+	var req backtest.SimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.PositionID = positionID
+
+	sim, err := h.service.SimulatePosition(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, backtest.ErrProfileRequired),
+			errors.Is(err, backtest.ErrInvalidSource):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, backtest.ErrPositionNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			log.Error().Err(err).Str("position_id", positionIDStr).Msg("Failed to simulate exit profile")
+			http.Error(w, "Failed to simulate exit profile", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, sim)
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func (h *Handler) writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Error().Err(err).Msg("Failed to encode response")
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
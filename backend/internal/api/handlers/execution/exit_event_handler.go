@@ -57,7 +57,7 @@ func (h *ExitEventHandler) GetExitEvent(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(exitEvent)
 }
 
-// ListExitEvents handles GET /api/v1/execution/exit-events
+// ListExitEvents handles GET /api/v1/execution/exit-events (?account_id= 계좌 필터)
 func (h *ExitEventHandler) ListExitEvents(w http.ResponseWriter, r *http.Request) {
 	// Get since parameter (default: last 24 hours)
 	sinceStr := r.URL.Query().Get("since")
@@ -78,6 +78,17 @@ func (h *ExitEventHandler) ListExitEvents(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Optional account filter
+	if accountID := r.URL.Query().Get("account_id"); accountID != "" {
+		filtered := make([]*execution.ExitEvent, 0, len(exitEvents))
+		for _, event := range exitEvents {
+			if event.AccountID == accountID {
+				filtered = append(filtered, event)
+			}
+		}
+		exitEvents = filtered
+	}
+
 	// Return exit events
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
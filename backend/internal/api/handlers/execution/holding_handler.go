@@ -22,14 +22,17 @@ func NewHoldingHandler(holdingRepo execution.HoldingRepository) *HoldingHandler
 
 // ListHoldings handles GET /api/v1/execution/holdings
 func (h *HoldingHandler) ListHoldings(w http.ResponseWriter, r *http.Request) {
-	// Get account_id from query parameter (or use default)
+	// Get account_id from query parameter ("" → 전체 계좌)
 	accountID := r.URL.Query().Get("account_id")
-	if accountID == "" {
-		accountID = "default" // TODO: get from config or auth
-	}
 
 	// Load holdings
-	holdings, err := h.holdingRepo.LoadHoldings(r.Context(), accountID)
+	var holdings []*execution.Holding
+	var err error
+	if accountID == "" {
+		holdings, err = h.holdingRepo.GetAllHoldings(r.Context())
+	} else {
+		holdings, err = h.holdingRepo.LoadHoldings(r.Context(), accountID)
+	}
 	if err != nil {
 		log.Error().Err(err).Str("account_id", accountID).Msg("Failed to load holdings")
 		http.Error(w, "internal error", http.StatusInternalServerError)
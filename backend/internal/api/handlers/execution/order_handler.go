@@ -48,7 +48,7 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(order)
 }
 
-// ListOpenOrders handles GET /api/v1/execution/orders/open
+// ListOpenOrders handles GET /api/v1/execution/orders/open (?account_id= 계좌 필터)
 func (h *OrderHandler) ListOpenOrders(w http.ResponseWriter, r *http.Request) {
 	// Load open orders
 	orders, err := h.orderRepo.LoadOpenOrders(r.Context())
@@ -58,6 +58,17 @@ func (h *OrderHandler) ListOpenOrders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Optional account filter
+	if accountID := r.URL.Query().Get("account_id"); accountID != "" {
+		filtered := make([]*execution.Order, 0, len(orders))
+		for _, order := range orders {
+			if order.AccountID == accountID {
+				filtered = append(filtered, order)
+			}
+		}
+		orders = filtered
+	}
+
 	// Return orders
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
 	exitService "github.com/wonny/aegis/v14/internal/service/exit"
 )
 
@@ -22,20 +23,23 @@ func NewControlHandler(exitSvc *exitService.Service) *ControlHandler {
 
 // GetControlRequest represents GET /api/v1/exit/control request
 type GetControlResponse struct {
-	Mode      string  `json:"mode"`
-	Reason    *string `json:"reason"`
-	UpdatedBy string  `json:"updated_by"`
-	UpdatedTS string  `json:"updated_ts"`
+	AccountID     string  `json:"account_id,omitempty"`
+	Mode          string  `json:"mode"`
+	Reason        *string `json:"reason"`
+	UpdatedBy     string  `json:"updated_by"`
+	UpdatedTS     string  `json:"updated_ts"`
+	EffectiveMode string  `json:"effective_mode,omitempty"` // 계좌 조회 시: 전역/계좌 중 더 엄격한 모드
 }
 
 // UpdateControlRequest represents POST /api/v1/exit/control request
 type UpdateControlRequest struct {
+	AccountID string  `json:"account_id,omitempty"` // "" → 전역 제어
 	Mode      string  `json:"mode"`
 	Reason    *string `json:"reason"`
 	UpdatedBy string  `json:"updated_by"`
 }
 
-// GetControl handles GET /api/v1/exit/control
+// GetControl handles GET /api/v1/exit/control (?account_id= 계좌별 제어)
 func (h *ControlHandler) GetControl(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -56,6 +60,25 @@ func (h *ControlHandler) GetControl(w http.ResponseWriter, r *http.Request) {
 		UpdatedTS: control.UpdatedTS.Format("2006-01-02T15:04:05-07:00"),
 	}
 
+	if accountID := r.URL.Query().Get("account_id"); accountID != "" {
+		accountControl, err := h.exitSvc.GetAccountControl(ctx, accountID)
+		if err != nil {
+			log.Error().Err(err).Str("account_id", accountID).Msg("Failed to get account exit control")
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		resp = GetControlResponse{
+			AccountID:     accountID,
+			Mode:          accountControl.Mode,
+			Reason:        accountControl.Reason,
+			UpdatedBy:     accountControl.UpdatedBy,
+			EffectiveMode: exit.EffectiveControlMode(control.Mode, accountControl.Mode),
+		}
+		if !accountControl.UpdatedTS.IsZero() {
+			resp.UpdatedTS = accountControl.UpdatedTS.Format("2006-01-02T15:04:05-07:00")
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -94,8 +117,12 @@ func (h *ControlHandler) UpdateControl(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update control
-	// TODO: Service에 UpdateControl 메서드 추가 필요
-	err := h.exitSvc.UpdateControl(ctx, req.Mode, req.Reason, req.UpdatedBy)
+	var err error
+	if req.AccountID != "" {
+		err = h.exitSvc.UpdateAccountControl(ctx, req.AccountID, req.Mode, req.Reason, req.UpdatedBy)
+	} else {
+		err = h.exitSvc.UpdateControl(ctx, req.Mode, req.Reason, req.UpdatedBy)
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to update exit control")
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -103,9 +130,27 @@ func (h *ControlHandler) UpdateControl(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Info().
+		Str("account_id", req.AccountID).
 		Str("mode", req.Mode).
 		Str("updated_by", req.UpdatedBy).
 		Msg("Exit control updated")
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// GetFlattenProgress handles GET /api/v1/exit/flatten/progress (?account_id= 계좌별)
+// EMERGENCY_FLATTEN 잔여 포지션 / 최근 청산 intent 상태
+func (h *ControlHandler) GetFlattenProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	progress, err := h.exitSvc.GetFlattenProgress(ctx, r.URL.Query().Get("account_id"))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get flatten progress")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(progress)
+}
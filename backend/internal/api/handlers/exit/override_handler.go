@@ -2,6 +2,7 @@ package exit
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -54,7 +55,7 @@ func (h *OverrideHandler) GetOverride(w http.ResponseWriter, r *http.Request) {
 	// Get override
 	override, err := h.exitSvc.GetSymbolOverride(ctx, symbol)
 	if err != nil {
-		if err.Error() == "override not found for symbol: "+symbol {
+		if errors.Is(err, exit.ErrOverrideNotFound) {
 			http.Error(w, "Override not found", http.StatusNotFound)
 			return
 		}
@@ -155,7 +156,7 @@ func (h *OverrideHandler) DeleteOverride(w http.ResponseWriter, r *http.Request)
 	// Delete override
 	err := h.exitSvc.DeleteSymbolOverride(ctx, symbol)
 	if err != nil {
-		if err.Error() == "override not found for symbol: "+symbol {
+		if errors.Is(err, exit.ErrOverrideNotFound) {
 			http.Error(w, "Override not found", http.StatusNotFound)
 			return
 		}
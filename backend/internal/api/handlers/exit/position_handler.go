@@ -2,11 +2,15 @@ package exit
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
 	exitService "github.com/wonny/aegis/v14/internal/service/exit"
 )
 
@@ -30,14 +34,17 @@ type ManualExitRequest struct {
 
 // PositionStateResponse represents GET /api/v1/exit/positions/{positionId}/state response
 type PositionStateResponse struct {
-	PositionID     string  `json:"position_id"`
-	Phase          string  `json:"phase"`
-	HWMPrice       *string `json:"hwm_price"`        // High-Water Mark (decimal string)
-	StopFloorPrice *string `json:"stop_floor_price"` // Stop Floor (decimal string)
-	ATR            *string `json:"atr"`              // ATR (decimal string)
-	CooldownUntil  *string `json:"cooldown_until"`   // ISO8601 timestamp
-	LastEvalTS     *string `json:"last_eval_ts"`     // ISO8601 timestamp
-	UpdatedTS      string  `json:"updated_ts"`       // ISO8601 timestamp
+	PositionID        string  `json:"position_id"`
+	Phase             string  `json:"phase"`
+	HWMPrice          *string `json:"hwm_price"`           // High-Water Mark (decimal string)
+	StopFloorPrice    *string `json:"stop_floor_price"`    // Stop Floor (decimal string)
+	TrailingStopPrice *string `json:"trailing_stop_price"` // Trailing stop (decimal string, trailing phase)
+	ATR               *string `json:"atr"`                 // ATR% (decimal string)
+	ATRUpdatedTS      *string `json:"atr_updated_ts"`      // ISO8601 timestamp
+	ATRStatus         string  `json:"atr_status"`          // OK | MISSING | STALE (MISSING/STALE → factor 1.0 또는 이전 값)
+	CooldownUntil     *string `json:"cooldown_until"`      // ISO8601 timestamp
+	LastEvalTS        *string `json:"last_eval_ts"`        // ISO8601 timestamp
+	UpdatedTS         string  `json:"updated_ts"`          // ISO8601 timestamp
 }
 
 // CreateManualExit handles POST /api/v1/exit/positions/{positionId}/manual
@@ -70,13 +77,9 @@ func (h *PositionHandler) CreateManualExit(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Validate order type
-	validOrderTypes := map[string]bool{
-		"MKT": true,
-		"LMT": true,
-	}
-	if !validOrderTypes[req.OrderType] {
+	if !execution.IsValidOrderType(req.OrderType) {
 		log.Warn().Str("order_type", req.OrderType).Msg("Invalid order type")
-		http.Error(w, "Invalid order type (must be MKT or LMT)", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid order type (must be one of %s)", strings.Join(execution.OrderTypes(), ", ")), http.StatusBadRequest)
 		return
 	}
 
@@ -132,7 +135,7 @@ func (h *PositionHandler) GetPositionState(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Convert optional decimal fields to strings
-	var hwmPriceStr, stopFloorPriceStr, atrStr *string
+	var hwmPriceStr, stopFloorPriceStr, trailingStopPriceStr, atrStr *string
 	if state.HWMPrice != nil {
 		s := state.HWMPrice.String()
 		hwmPriceStr = &s
@@ -141,13 +144,17 @@ func (h *PositionHandler) GetPositionState(w http.ResponseWriter, r *http.Reques
 		s := state.StopFloorPrice.String()
 		stopFloorPriceStr = &s
 	}
+	if state.TrailingStopPrice != nil {
+		s := state.TrailingStopPrice.String()
+		trailingStopPriceStr = &s
+	}
 	if state.ATR != nil {
 		s := state.ATR.String()
 		atrStr = &s
 	}
 
 	// Convert optional time fields to ISO8601 strings
-	var cooldownUntilStr, lastEvalTSStr *string
+	var cooldownUntilStr, lastEvalTSStr, atrUpdatedTSStr *string
 	if state.CooldownUntil != nil {
 		s := state.CooldownUntil.Format("2006-01-02T15:04:05-07:00")
 		cooldownUntilStr = &s
@@ -156,16 +163,23 @@ func (h *PositionHandler) GetPositionState(w http.ResponseWriter, r *http.Reques
 		s := state.LastEvalTS.Format("2006-01-02T15:04:05-07:00")
 		lastEvalTSStr = &s
 	}
+	if state.ATRUpdatedTS != nil {
+		s := state.ATRUpdatedTS.Format("2006-01-02T15:04:05-07:00")
+		atrUpdatedTSStr = &s
+	}
 
 	resp := PositionStateResponse{
-		PositionID:     state.PositionID.String(),
-		Phase:          state.Phase,
-		HWMPrice:       hwmPriceStr,
-		StopFloorPrice: stopFloorPriceStr,
-		ATR:            atrStr,
-		CooldownUntil:  cooldownUntilStr,
-		LastEvalTS:     lastEvalTSStr,
-		UpdatedTS:      state.UpdatedTS.Format("2006-01-02T15:04:05-07:00"),
+		PositionID:        state.PositionID.String(),
+		Phase:             state.Phase,
+		HWMPrice:          hwmPriceStr,
+		StopFloorPrice:    stopFloorPriceStr,
+		TrailingStopPrice: trailingStopPriceStr,
+		ATR:               atrStr,
+		ATRUpdatedTS:      atrUpdatedTSStr,
+		ATRStatus:         exitService.ATRStatus(state, time.Now()),
+		CooldownUntil:     cooldownUntilStr,
+		LastEvalTS:        lastEvalTSStr,
+		UpdatedTS:         state.UpdatedTS.Format("2006-01-02T15:04:05-07:00"),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -2,9 +2,12 @@ package exit
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	exitService "github.com/wonny/aegis/v14/internal/service/exit"
 )
@@ -128,6 +131,11 @@ func (h *ProfileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err := h.exitSvc.CreateOrUpdateProfile(ctx, profile)
+	if errors.Is(err, execution.ErrInvalidOrderType) || errors.Is(err, exit.ErrInvalidProfile) {
+		log.Warn().Err(err).Str("profile_id", req.ProfileID).Msg("Invalid exit profile configuration")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("profile_id", req.ProfileID).Msg("Failed to create profile")
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -142,3 +150,48 @@ func (h *ProfileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusCreated)
 }
+
+// PreviewRuleRequest represents POST /api/v1/exit/rules/preview request
+type PreviewRuleRequest struct {
+	Expression string     `json:"expression"`
+	PositionID *uuid.UUID `json:"position_id,omitempty"` // 지정 시 현재 값으로 평가
+}
+
+// PreviewRule handles POST /api/v1/exit/rules/preview
+// 문법/변수 검증 + (position_id 지정 시) 현재 변수값과 발동 여부
+func (h *ProfileHandler) PreviewRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PreviewRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warn().Err(err).Msg("Invalid request body")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Expression == "" {
+		http.Error(w, "expression is required", http.StatusBadRequest)
+		return
+	}
+
+	preview, err := h.exitSvc.PreviewCustomRule(ctx, req.Expression, req.PositionID)
+	if errors.Is(err, exit.ErrPositionNotFound) {
+		http.Error(w, "Position not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("expression", req.Expression).Msg("Failed to preview custom rule")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(preview)
+}
+
+// GetRuleVariables handles GET /api/v1/exit/rules/variables
+func (h *ProfileHandler) GetRuleVariables(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"variables": exit.RuleVariables})
+}
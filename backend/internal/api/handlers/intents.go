@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -24,6 +28,33 @@ func NewIntentsHandler(intentRepo IntentReader, intentRepoWriter exit.OrderInten
 	}
 }
 
+// maxDecidedByLen 호출자 주장 이름 최대 길이
+const maxDecidedByLen = 64
+
+// IntentDecisionRequest represents approve/reject request body (optional)
+type IntentDecisionRequest struct {
+	// 승인/거부 주체 (audit, 미지정 시 "user")
+	// API 인증이 없으므로 호출자가 주장한 이름 (caller-asserted) → "user:<이름>"으로 기록
+	DecidedBy string `json:"decided_by"`
+}
+
+// decodeDecidedBy reads caller-asserted decided_by from optional request body
+// 시스템 주체(policy, EMERGENCY_FLATTEN 등)를 사칭하지 않도록 항상 "user" 네임스페이스로 기록
+func decodeDecidedBy(r *http.Request) (string, error) {
+	var req IntentDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	name := strings.TrimSpace(req.DecidedBy)
+	if name == "" {
+		return exit.DecidedByDefaultUser, nil
+	}
+	if len(name) > maxDecidedByLen {
+		return "", fmt.Errorf("decided_by exceeds %d bytes", maxDecidedByLen)
+	}
+	return exit.DecidedByUserPrefix + name, nil
+}
+
 // GetIntents retrieves recent order intents
 // GET /api/intents
 func (h *IntentsHandler) GetIntents(w http.ResponseWriter, r *http.Request) {
@@ -47,7 +78,7 @@ func (h *IntentsHandler) GetIntents(w http.ResponseWriter, r *http.Request) {
 }
 
 // ApproveIntent approves an intent (PENDING_APPROVAL → NEW)
-// POST /api/intents/{intent_id}/approve
+// POST /api/intents/{intent_id}/approve (body: {"decided_by": "..."}, optional)
 func (h *IntentsHandler) ApproveIntent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	intentIDStr := mux.Vars(r)["intent_id"]
@@ -59,14 +90,20 @@ func (h *IntentsHandler) ApproveIntent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	decidedBy, err := decodeDecidedBy(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
 	// Approve intent
-	if err := h.intentRepoWriter.ApproveIntent(ctx, intentID); err != nil {
+	if err := h.intentRepoWriter.ApproveIntent(ctx, intentID, decidedBy); err != nil {
 		log.Error().Err(err).Str("intent_id", intentIDStr).Msg("Failed to approve intent")
 		http.Error(w, "Failed to approve intent", http.StatusInternalServerError)
 		return
 	}
 
-	log.Info().Str("intent_id", intentIDStr).Msg("Intent approved")
+	log.Info().Str("intent_id", intentIDStr).Str("decided_by", decidedBy).Msg("Intent approved")
 
 	// Return success
 	w.Header().Set("Content-Type", "application/json")
@@ -74,7 +111,7 @@ func (h *IntentsHandler) ApproveIntent(w http.ResponseWriter, r *http.Request) {
 }
 
 // RejectIntent rejects an intent (PENDING_APPROVAL → CANCELLED)
-// POST /api/intents/{intent_id}/reject
+// POST /api/intents/{intent_id}/reject (body: {"decided_by": "..."}, optional)
 func (h *IntentsHandler) RejectIntent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	intentIDStr := mux.Vars(r)["intent_id"]
@@ -86,14 +123,20 @@ func (h *IntentsHandler) RejectIntent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	decidedBy, err := decodeDecidedBy(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
 	// Reject intent
-	if err := h.intentRepoWriter.RejectIntent(ctx, intentID); err != nil {
+	if err := h.intentRepoWriter.RejectIntent(ctx, intentID, decidedBy); err != nil {
 		log.Error().Err(err).Str("intent_id", intentIDStr).Msg("Failed to reject intent")
 		http.Error(w, "Failed to reject intent", http.StatusInternalServerError)
 		return
 	}
 
-	log.Info().Str("intent_id", intentIDStr).Msg("Intent rejected")
+	log.Info().Str("intent_id", intentIDStr).Str("decided_by", decidedBy).Msg("Intent rejected")
 
 	// Return success
 	w.Header().Set("Content-Type", "application/json")
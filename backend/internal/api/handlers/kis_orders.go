@@ -13,6 +13,9 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+	"github.com/wonny/aegis/v14/internal/infra/kis"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
 // Cache entry for KIS API responses
@@ -23,12 +26,15 @@ type cacheEntry struct {
 
 // KISOrdersHandler handles KIS orders API requests
 type KISOrdersHandler struct {
-	kisAdapter       execution.KISAdapter
-	accountID        string
-	unfilledCache    *cacheEntry
-	filledCache      *cacheEntry
-	cacheMu          sync.RWMutex
-	cacheDuration    time.Duration
+	kisAdapter    execution.KISAdapter
+	accountID     string
+	unfilledCache *cacheEntry
+	filledCache   *cacheEntry
+	cacheMu       sync.RWMutex
+	cacheDuration time.Duration
+	riskGate      execution.RiskGate   // Pre-trade risk check (nil → no gate)
+	priceGuard    execution.PriceGuard // 호가단위/가격제한폭/VI 검증 (nil → 원가격 전송)
+	scheduler     *kis.Scheduler       // KIS REST rate limiter (nil → stats 미제공)
 }
 
 // NewKISOrdersHandler creates a new KISOrdersHandler
@@ -40,6 +46,33 @@ func NewKISOrdersHandler(kisAdapter execution.KISAdapter, accountID string) *KIS
 	}
 }
 
+// SetRiskGate sets the optional pre-trade risk gate (same gate as Execution intents)
+func (h *KISOrdersHandler) SetRiskGate(gate execution.RiskGate) {
+	h.riskGate = gate
+}
+
+// SetPriceGuard sets the optional limit price guard (same guard as Execution intents)
+func (h *KISOrdersHandler) SetPriceGuard(guard execution.PriceGuard) {
+	h.priceGuard = guard
+}
+
+// SetScheduler sets the KIS REST rate limit scheduler for stats (optional)
+func (h *KISOrdersHandler) SetScheduler(scheduler *kis.Scheduler) {
+	h.scheduler = scheduler
+}
+
+// GetRateLimitStats returns KIS REST scheduler statistics (우선순위별 대기열/대기 시간)
+// GET /api/kis/rate-limit
+func (h *KISOrdersHandler) GetRateLimitStats(w http.ResponseWriter, r *http.Request) {
+	if h.scheduler == nil {
+		http.Error(w, "KIS rate limit scheduler not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.scheduler.Stats())
+}
+
 // GetUnfilledOrders retrieves unfilled orders from KIS
 // GET /api/kis/unfilled-orders
 func (h *KISOrdersHandler) GetUnfilledOrders(w http.ResponseWriter, r *http.Request) {
@@ -184,16 +217,17 @@ func (h *KISOrdersHandler) GetFilledOrders(w http.ResponseWriter, r *http.Reques
 type PlaceOrderRequest struct {
 	Symbol    string `json:"symbol"`     // 종목코드 (6자리)
 	Side      string `json:"side"`       // buy 또는 sell
-	OrderType string `json:"order_type"` // limit 또는 market
+	OrderType string `json:"order_type"` // limit, market 또는 주문유형 코드 (COND_LMT, IOC_LMT, CLOSE_AUCTION, ...)
 	Qty       int    `json:"qty"`        // 주문수량
 	Price     int    `json:"price"`      // 주문가격 (시장가일 경우 0)
 }
 
 // PlaceOrderResponse is the response for placing an order
 type PlaceOrderResponse struct {
-	Success bool   `json:"success"`
-	OrderID string `json:"order_id,omitempty"`
-	Error   string `json:"error,omitempty"`
+	Success   bool            `json:"success"`
+	OrderID   string          `json:"order_id,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	RiskCheck *risk.RiskCheck `json:"risk_check,omitempty"` // 리스크 게이트 차단 사유
 }
 
 // PlaceOrder places an order to KIS
@@ -224,23 +258,69 @@ func (h *KISOrdersHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.OrderType == "limit" && req.Price <= 0 {
+	orderType, ok := normalizeOrderType(req.OrderType)
+	if !ok {
+		log.Warn().Interface("req", req).Msg("Invalid order type")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PlaceOrderResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Invalid order type: %s (must be limit, market or one of %s)", req.OrderType, strings.Join(execution.OrderTypes(), ", ")),
+		})
+		return
+	}
+	req.OrderType = orderType
+
+	// 주문유형별 접수 가능 세션 확인 (장마감 동시호가, 시간외 등)
+	phase := calendar.CurrentPhase(time.Now())
+	if availability, _ := execution.CheckSession(orderType, phase); availability != execution.SessionAvailable {
+		log.Warn().
+			Str("order_type", orderType).
+			Str("phase", string(phase)).
+			Msg("Order type not available in current session")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PlaceOrderResponse{
+			Success: false,
+			Error:   fmt.Sprintf("%s order is not available in current session (%s)", orderType, phase),
+		})
+		return
+	}
+
+	needsPrice := execution.OrderTypeNeedsPrice(orderType)
+	if needsPrice && req.Price <= 0 {
 		log.Warn().Interface("req", req).Msg("Invalid limit order: price must be > 0")
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(PlaceOrderResponse{
 			Success: false,
-			Error:   "Limit order requires price > 0",
+			Error:   fmt.Sprintf("%s order requires price > 0", orderType),
 		})
 		return
 	}
 
 	// Submit order to KIS
 	var limitPrice *decimal.Decimal
-	if req.OrderType == "limit" && req.Price > 0 {
+	if needsPrice {
 		price := decimal.NewFromInt(int64(req.Price))
 		limitPrice = &price
 	}
 
+	// 호가단위 보정 + 가격제한폭/VI 검증
+	if h.priceGuard != nil {
+		normalized, err := h.priceGuard.NormalizeLimitPrice(ctx, req.Symbol, normalizeSide(req.Side), orderType, limitPrice)
+		if err != nil {
+			log.Warn().Err(err).Interface("req", req).Msg("Order rejected by price guard")
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(PlaceOrderResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		limitPrice = normalized
+		if limitPrice != nil {
+			req.Price = int(limitPrice.IntPart())
+		}
+	}
+
 	kisReq := execution.KISOrderRequest{
 		AccountID:  h.accountID,
 		Symbol:     req.Symbol,
@@ -250,6 +330,13 @@ func (h *KISOrdersHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 		LimitPrice: limitPrice,
 	}
 
+	// Pre-trade risk gate (Fail-Closed)
+	if h.riskGate != nil {
+		if blocked := h.checkRisk(ctx, w, req, limitPrice); blocked {
+			return
+		}
+	}
+
 	resp, err := h.kisAdapter.SubmitOrder(ctx, kisReq)
 	if err != nil {
 		log.Error().Err(err).Interface("req", req).Msg("Failed to submit order to KIS")
@@ -278,6 +365,41 @@ func (h *KISOrdersHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 		Msg("Order placed successfully")
 }
 
+// checkRisk runs the risk gate for a direct order and writes the rejection response
+// 차단 또는 검증 불가 시 true
+func (h *KISOrdersHandler) checkRisk(ctx context.Context, w http.ResponseWriter, req PlaceOrderRequest, limitPrice *decimal.Decimal) bool {
+	result, err := h.riskGate.CheckOrder(ctx, risk.RiskCheckRequest{
+		Source:     risk.SourceManual,
+		AccountID:  h.accountID,
+		Symbol:     req.Symbol,
+		Side:       strings.ToUpper(req.Side),
+		OrderType:  req.OrderType,
+		Qty:        int64(req.Qty),
+		LimitPrice: limitPrice,
+	})
+	if err != nil {
+		log.Error().Err(err).Interface("req", req).Msg("Risk check failed, order not submitted")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PlaceOrderResponse{
+			Success: false,
+			Error:   "Risk check unavailable, order not submitted",
+		})
+		return true
+	}
+
+	if result.Approved {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PlaceOrderResponse{
+		Success:   false,
+		Error:     result.Message,
+		RiskCheck: result.FailedCheck,
+	})
+	return true
+}
+
 // CancelOrderRequest is the request body for cancelling an order
 type CancelOrderRequest struct {
 	OrderNo string `json:"order_no"` // 주문번호
@@ -342,6 +464,28 @@ type KISAdapterProvider interface {
 	GetKISAdapter(ctx context.Context) (execution.KISAdapter, error)
 }
 
+// normalizeSide converts request side (buy/sell) to execution side
+func normalizeSide(side string) string {
+	if strings.EqualFold(side, "sell") {
+		return execution.SideSell
+	}
+	return execution.SideBuy
+}
+
+// normalizeOrderType converts request order type to execution order type
+// limit/market은 기존 클라이언트 호환용 별칭
+func normalizeOrderType(orderType string) (string, bool) {
+	switch strings.ToLower(orderType) {
+	case "limit":
+		return execution.OrderTypeLimit, true
+	case "market":
+		return execution.OrderTypeMarket, true
+	}
+
+	code := strings.ToUpper(orderType)
+	return code, execution.IsValidOrderType(code)
+}
+
 // isRateLimitError checks if the error is a rate limit error
 func isRateLimitError(errMsg string) bool {
 	return strings.Contains(errMsg, "token refresh on hold") ||
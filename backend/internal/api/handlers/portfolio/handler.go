@@ -0,0 +1,191 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+)
+
+// PortfolioService 포트폴리오 서비스 인터페이스
+type PortfolioService interface {
+	// 포트폴리오 생성
+	GeneratePortfolio(ctx context.Context) (*portfolio.PortfolioSnapshot, error)
+
+	// 스냅샷 조회
+	GetLatestSnapshot(ctx context.Context) (*portfolio.PortfolioSnapshot, error)
+	GetSnapshotByID(ctx context.Context, snapshotID string) (*portfolio.PortfolioSnapshot, error)
+	ListSnapshots(ctx context.Context, from, to time.Time) ([]*portfolio.PortfolioSnapshot, error)
+}
+
+// Handler Portfolio API 핸들러
+type Handler struct {
+	service PortfolioService
+}
+
+// NewHandler 핸들러 생성
+func NewHandler(service PortfolioService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// =============================================================================
+// Response Types
+// =============================================================================
+
+// SnapshotSummary 스냅샷 요약 (목록용)
+type SnapshotSummary struct {
+	SnapshotID        string                   `json:"snapshot_id"`
+	RankingSnapshotID string                   `json:"ranking_snapshot_id"`
+	GeneratedAt       time.Time                `json:"generated_at"`
+	TotalValue        string                   `json:"total_value"`
+	TotalWeight       float64                  `json:"total_weight"`
+	Stats             portfolio.PortfolioStats `json:"stats"`
+}
+
+// SnapshotListResponse 스냅샷 목록 응답
+type SnapshotListResponse struct {
+	Snapshots []SnapshotSummary `json:"snapshots"`
+	Count     int               `json:"count"`
+}
+
+// GenerateResponse 포트폴리오 생성 응답
+type GenerateResponse struct {
+	Success           bool   `json:"success"`
+	SnapshotID        string `json:"snapshot_id"`
+	RankingSnapshotID string `json:"ranking_snapshot_id"`
+	TotalHoldings     int    `json:"total_holdings"`
+	BuyCount          int    `json:"buy_count"`
+	SellCount         int    `json:"sell_count"`
+}
+
+// =============================================================================
+// Handlers
+// =============================================================================
+
+// GetLatestSnapshot handles GET /api/v1/portfolio
+func (h *Handler) GetLatestSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.service.GetLatestSnapshot(r.Context())
+	if err != nil {
+		if errors.Is(err, portfolio.ErrSnapshotNotFound) {
+			http.Error(w, "No portfolio found", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get latest portfolio")
+		http.Error(w, "Failed to get portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, snapshot)
+}
+
+// GetSnapshotByID handles GET /api/v1/portfolio/snapshots/{id}
+func (h *Handler) GetSnapshotByID(w http.ResponseWriter, r *http.Request) {
+	snapshotID := mux.Vars(r)["id"]
+
+	snapshot, err := h.service.GetSnapshotByID(r.Context(), snapshotID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrSnapshotNotFound) {
+			http.Error(w, "Portfolio not found", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Str("snapshot_id", snapshotID).Msg("Failed to get portfolio")
+		http.Error(w, "Failed to get portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, snapshot)
+}
+
+// ListSnapshots handles GET /api/v1/portfolio/snapshots?from=&to= (RFC3339, 기본 최근 30일)
+func (h *Handler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "Invalid from (RFC3339)", http.StatusBadRequest)
+			return
+		}
+		from = t
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "Invalid to (RFC3339)", http.StatusBadRequest)
+			return
+		}
+		to = t
+	}
+
+	snapshots, err := h.service.ListSnapshots(r.Context(), from, to)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list portfolios")
+		http.Error(w, "Failed to list portfolios", http.StatusInternalServerError)
+		return
+	}
+
+	summaries := make([]SnapshotSummary, 0, len(snapshots))
+	for _, s := range snapshots {
+		summaries = append(summaries, SnapshotSummary{
+			SnapshotID:        s.SnapshotID,
+			RankingSnapshotID: s.RankingSnapshotID,
+			GeneratedAt:       s.GeneratedAt,
+			TotalValue:        s.TotalValue.String(),
+			TotalWeight:       s.TotalWeight,
+			Stats:             s.Stats,
+		})
+	}
+
+	h.writeJSON(w, SnapshotListResponse{
+		Snapshots: summaries,
+		Count:     len(summaries),
+	})
+}
+
+// GeneratePortfolio handles POST /api/v1/portfolio/generate
+func (h *Handler) GeneratePortfolio(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.service.GeneratePortfolio(r.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, portfolio.ErrRankingNotReady):
+			http.Error(w, "Ranking not ready", http.StatusServiceUnavailable)
+		case errors.Is(err, portfolio.ErrNoCandidates):
+			http.Error(w, "No portfolio candidates", http.StatusUnprocessableEntity)
+		case errors.Is(err, portfolio.ErrNoCapital):
+			http.Error(w, "No capital to allocate", http.StatusUnprocessableEntity)
+		default:
+			log.Error().Err(err).Msg("Failed to generate portfolio")
+			http.Error(w, "Failed to generate portfolio", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, GenerateResponse{
+		Success:           true,
+		SnapshotID:        snapshot.SnapshotID,
+		RankingSnapshotID: snapshot.RankingSnapshotID,
+		TotalHoldings:     snapshot.Stats.TotalHoldings,
+		BuyCount:          snapshot.Stats.BuyCount,
+		SellCount:         snapshot.Stats.SellCount,
+	})
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func (h *Handler) writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Error().Err(err).Msg("Failed to encode response")
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,140 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+)
+
+// RebalanceService 리밸런싱 서비스 인터페이스
+type RebalanceService interface {
+	PlanRebalance(ctx context.Context, dryRun bool) (*portfolio.RebalancePlan, error)
+	GetPlan(ctx context.Context, planID uuid.UUID) (*portfolio.RebalancePlan, error)
+	GetLatestPlan(ctx context.Context) (*portfolio.RebalancePlan, error)
+	ApprovePlan(ctx context.Context, planID uuid.UUID) error
+	RejectPlan(ctx context.Context, planID uuid.UUID) error
+}
+
+// RebalanceHandler Rebalance API 핸들러
+type RebalanceHandler struct {
+	service RebalanceService
+}
+
+// NewRebalanceHandler 핸들러 생성
+func NewRebalanceHandler(service RebalanceService) *RebalanceHandler {
+	return &RebalanceHandler{
+		service: service,
+	}
+}
+
+// CreatePlan handles POST /api/v1/portfolio/rebalance?dry_run=true
+// dry_run=true → 미리보기 (intent 미생성)
+func (h *RebalanceHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	plan, err := h.service.PlanRebalance(r.Context(), dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, portfolio.ErrNothingToRebalance):
+			// 주문 없음 → 스킵 내역만 반환
+			h.writeJSON(w, plan)
+		case errors.Is(err, portfolio.ErrSnapshotNotFound):
+			http.Error(w, "No portfolio found", http.StatusNotFound)
+		default:
+			log.Error().Err(err).Msg("Failed to plan rebalance")
+			http.Error(w, "Failed to plan rebalance", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, plan)
+}
+
+// GetLatestPlan handles GET /api/v1/portfolio/rebalance/latest
+func (h *RebalanceHandler) GetLatestPlan(w http.ResponseWriter, r *http.Request) {
+	plan, err := h.service.GetLatestPlan(r.Context())
+	if err != nil {
+		if errors.Is(err, portfolio.ErrPlanNotFound) {
+			http.Error(w, "No rebalance plan found", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get latest rebalance plan")
+		http.Error(w, "Failed to get rebalance plan", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, plan)
+}
+
+// GetPlan handles GET /api/v1/portfolio/rebalance/{planId}
+func (h *RebalanceHandler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	planID, err := uuid.Parse(mux.Vars(r)["planId"])
+	if err != nil {
+		http.Error(w, "Invalid plan ID", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.service.GetPlan(r.Context(), planID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrPlanNotFound) {
+			http.Error(w, "Rebalance plan not found", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Str("plan_id", planID.String()).Msg("Failed to get rebalance plan")
+		http.Error(w, "Failed to get rebalance plan", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, plan)
+}
+
+// ApprovePlan handles POST /api/v1/portfolio/rebalance/{planId}/approve
+func (h *RebalanceHandler) ApprovePlan(w http.ResponseWriter, r *http.Request) {
+	h.resolvePlan(w, r, h.service.ApprovePlan, "approved")
+}
+
+// RejectPlan handles POST /api/v1/portfolio/rebalance/{planId}/reject
+func (h *RebalanceHandler) RejectPlan(w http.ResponseWriter, r *http.Request) {
+	h.resolvePlan(w, r, h.service.RejectPlan, "rejected")
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func (h *RebalanceHandler) resolvePlan(w http.ResponseWriter, r *http.Request, resolve func(context.Context, uuid.UUID) error, status string) {
+	planID, err := uuid.Parse(mux.Vars(r)["planId"])
+	if err != nil {
+		http.Error(w, "Invalid plan ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := resolve(r.Context(), planID); err != nil {
+		switch {
+		case errors.Is(err, portfolio.ErrPlanNotFound):
+			http.Error(w, "Rebalance plan not found", http.StatusNotFound)
+		case errors.Is(err, portfolio.ErrPlanNotPending):
+			http.Error(w, "Rebalance plan is not pending approval", http.StatusConflict)
+		default:
+			log.Error().Err(err).Str("plan_id", planID.String()).Str("action", status).Msg("Failed to resolve rebalance plan")
+			http.Error(w, "Failed to update rebalance plan", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, map[string]string{"plan_id": planID.String(), "status": status})
+}
+
+func (h *RebalanceHandler) writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Error().Err(err).Msg("Failed to encode response")
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -19,6 +19,7 @@ import (
 type PriceStreamHandler struct {
 	broker *pricesync.Broker
 	cache  *pricesync.PriceCache
+	books  *pricesync.OrderBookCache // optional (호가 스트림 초기 스냅샷)
 }
 
 // NewPriceStreamHandler creates a new price stream handler
@@ -29,6 +30,11 @@ func NewPriceStreamHandler(broker *pricesync.Broker, cache *pricesync.PriceCache
 	}
 }
 
+// SetOrderBookCache sets the order book cache used for initial book snapshots (optional)
+func (h *PriceStreamHandler) SetOrderBookCache(books *pricesync.OrderBookCache) {
+	h.books = books
+}
+
 // ==============================================================================
 // SSE Endpoints
 // ==============================================================================
@@ -176,6 +182,83 @@ func (h *PriceStreamHandler) StreamAllPrices(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// StreamOrderBooks streams 10-level order book updates via SSE
+// GET /api/v1/prices/book/stream?symbols=005930,000660
+// 호가는 WS 호가 구독 종목(PriorityManager 호가 슬롯)에 대해서만 발행됨
+func (h *PriceStreamHandler) StreamOrderBooks(w http.ResponseWriter, r *http.Request) {
+	symbolsParam := r.URL.Query().Get("symbols")
+	if symbolsParam == "" {
+		http.Error(w, "symbols parameter required", http.StatusBadRequest)
+		return
+	}
+
+	symbols := parseSymbols(symbolsParam)
+	if len(symbols) == 0 {
+		http.Error(w, "at least one symbol required", http.StatusBadRequest)
+		return
+	}
+
+	// 호가 구독 슬롯 이상은 의미 없음
+	const maxSymbols = 40
+	if len(symbols) > maxSymbols {
+		http.Error(w, fmt.Sprintf("max %d symbols allowed", maxSymbols), http.StatusBadRequest)
+		return
+	}
+
+	// Setup SSE headers
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Send initial books from cache
+	if h.books != nil {
+		for _, book := range h.books.GetMultiple(symbols) {
+			h.sendEvent(w, "orderbook", book)
+		}
+		flusher.Flush()
+	}
+
+	sub := h.broker.SubscribeBooks(symbols)
+	defer h.broker.UnsubscribeBooks(sub)
+
+	log.Info().
+		Strs("symbols", symbols).
+		Str("remote", r.RemoteAddr).
+		Msg("SSE: order book client connected")
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Info().
+				Str("remote", r.RemoteAddr).
+				Msg("SSE: order book client disconnected")
+			return
+
+		case book, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			h.sendEvent(w, "orderbook", book)
+			flusher.Flush()
+
+		case <-keepAlive.C:
+			fmt.Fprintf(w, ": keepalive %d\n\n", time.Now().Unix())
+			flusher.Flush()
+		}
+	}
+}
+
 // ==============================================================================
 // REST Endpoints (for compatibility)
 // ==============================================================================
@@ -35,6 +35,6 @@ type IntentReader interface {
 
 // IntentWriter is a write interface for order intents (approval/rejection)
 type IntentWriter interface {
-	ApproveIntent(ctx context.Context, intentID string) error
-	RejectIntent(ctx context.Context, intentID string) error
+	ApproveIntent(ctx context.Context, intentID string, decidedBy string) error
+	RejectIntent(ctx context.Context, intentID string, decidedBy string) error
 }
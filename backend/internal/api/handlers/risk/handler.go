@@ -0,0 +1,189 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+// RiskService 리스크 서비스 인터페이스
+type RiskService interface {
+	GetLimits(ctx context.Context) (*risk.RiskLimits, error)
+	UpdateLimits(ctx context.Context, limits *risk.RiskLimits) error
+	GetEmergencyStop(ctx context.Context) (*risk.EmergencyStop, error)
+	SetEmergencyStop(ctx context.Context, enabled bool, reason *string, updatedBy string) error
+	ListBlocks(ctx context.Context, since time.Time, limit int) ([]*risk.RiskBlock, error)
+}
+
+// Handler Risk API 핸들러
+type Handler struct {
+	service RiskService
+}
+
+// NewHandler 핸들러 생성
+func NewHandler(service RiskService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// UpdateLimitsRequest represents PUT /api/v1/risk/limits request
+type UpdateLimitsRequest struct {
+	ProfileName          string          `json:"profile_name"`
+	MaxTotalPositions    int             `json:"max_total_positions"`
+	MaxSingleWeight      float64         `json:"max_single_weight"`
+	MaxSectorWeight      float64         `json:"max_sector_weight"`
+	MaxDailyLossPct      float64         `json:"max_daily_loss_pct"`
+	MaxOrderValue        decimal.Decimal `json:"max_order_value"`
+	MaxPriceDeviationPct float64         `json:"max_price_deviation_pct"`
+	UpdatedBy            string          `json:"updated_by"`
+}
+
+// EmergencyStopRequest represents PUT /api/v1/risk/emergency-stop request
+type EmergencyStopRequest struct {
+	Enabled   bool    `json:"enabled"`
+	Reason    *string `json:"reason"`
+	UpdatedBy string  `json:"updated_by"`
+}
+
+// GetLimits handles GET /api/v1/risk/limits
+func (h *Handler) GetLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.service.GetLimits(r.Context())
+	if err != nil {
+		if errors.Is(err, risk.ErrLimitsNotFound) {
+			http.Error(w, "No active risk limits (all orders blocked)", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Msg("Failed to get risk limits")
+		http.Error(w, "Failed to get risk limits", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, limits)
+}
+
+// UpdateLimits handles PUT /api/v1/risk/limits
+func (h *Handler) UpdateLimits(w http.ResponseWriter, r *http.Request) {
+	var req UpdateLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UpdatedBy == "" {
+		http.Error(w, "updated_by is required", http.StatusBadRequest)
+		return
+	}
+
+	limits := &risk.RiskLimits{
+		ProfileName:          req.ProfileName,
+		MaxTotalPositions:    req.MaxTotalPositions,
+		MaxSingleWeight:      req.MaxSingleWeight,
+		MaxSectorWeight:      req.MaxSectorWeight,
+		MaxDailyLossPct:      req.MaxDailyLossPct,
+		MaxOrderValue:        req.MaxOrderValue,
+		MaxPriceDeviationPct: req.MaxPriceDeviationPct,
+		UpdatedBy:            req.UpdatedBy,
+	}
+
+	if err := h.service.UpdateLimits(r.Context(), limits); err != nil {
+		if errors.Is(err, risk.ErrInvalidLimits) {
+			http.Error(w, "Invalid risk limits", http.StatusBadRequest)
+			return
+		}
+		log.Error().Err(err).Msg("Failed to update risk limits")
+		http.Error(w, "Failed to update risk limits", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, limits)
+}
+
+// GetEmergencyStop handles GET /api/v1/risk/emergency-stop
+func (h *Handler) GetEmergencyStop(w http.ResponseWriter, r *http.Request) {
+	stop, err := h.service.GetEmergencyStop(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get emergency stop")
+		http.Error(w, "Failed to get emergency stop", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, stop)
+}
+
+// SetEmergencyStop handles PUT /api/v1/risk/emergency-stop
+func (h *Handler) SetEmergencyStop(w http.ResponseWriter, r *http.Request) {
+	var req EmergencyStopRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UpdatedBy == "" {
+		http.Error(w, "updated_by is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetEmergencyStop(r.Context(), req.Enabled, req.Reason, req.UpdatedBy); err != nil {
+		log.Error().Err(err).Msg("Failed to set emergency stop")
+		http.Error(w, "Failed to set emergency stop", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, map[string]interface{}{"enabled": req.Enabled})
+}
+
+// ListBlocks handles GET /api/v1/risk/blocks?since=2026-10-16T00:00:00Z&limit=100
+// since 기본값: 최근 24시간
+func (h *Handler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	since := time.Now().Add(-24 * time.Hour)
+	if s := r.URL.Query().Get("since"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(w, "Invalid since (RFC3339)", http.StatusBadRequest)
+			return
+		}
+		since = t
+	}
+
+	limit := 100
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > 1000 {
+			http.Error(w, "Invalid limit (1-1000)", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	blocks, err := h.service.ListBlocks(r.Context(), since, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list risk blocks")
+		http.Error(w, "Failed to list risk blocks", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, map[string]interface{}{
+		"blocks": blocks,
+		"count":  len(blocks),
+	})
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func (h *Handler) writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Error().Err(err).Msg("Failed to encode response")
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
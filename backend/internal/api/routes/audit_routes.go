@@ -10,6 +10,7 @@ func RegisterAuditRoutes(router *mux.Router, auditHandler *auditHandlers.Handler
 	// Performance endpoints
 	router.HandleFunc("/api/v1/audit/performance", auditHandler.GetPerformance).Methods("GET")
 	router.HandleFunc("/api/v1/audit/performance/generate", auditHandler.GeneratePerformance).Methods("POST")
+	router.HandleFunc("/api/v1/audit/accounts", auditHandler.GetAccountPerformance).Methods("GET")
 
 	// Daily PnL endpoints
 	router.HandleFunc("/api/v1/audit/daily-pnl", auditHandler.GetDailyPnL).Methods("GET")
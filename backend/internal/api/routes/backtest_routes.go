@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	backtestHandlers "github.com/wonny/aegis/v14/internal/api/handlers/backtest"
+)
+
+// RegisterBacktestRoutes Backtest API 라우트 등록
+func RegisterBacktestRoutes(router *mux.Router, handler *backtestHandlers.Handler) {
+	// Exit profile replay (일봉/틱)
+	router.HandleFunc("/api/v1/backtest/exit", handler.RunExitBacktest).Methods("POST")
+
+	// Live position what-if (후보 프로필 vs 현재 프로필)
+	router.HandleFunc("/api/v1/exit/positions/{positionId}/simulate", handler.SimulatePosition).Methods("POST")
+}
@@ -17,6 +17,7 @@ func RegisterExitRoutes(router *mux.Router, exitSvc *exitService.Service) {
 	// Control endpoints
 	router.HandleFunc("/api/v1/exit/control", controlHandler.GetControl).Methods("GET")
 	router.HandleFunc("/api/v1/exit/control", controlHandler.UpdateControl).Methods("POST")
+	router.HandleFunc("/api/v1/exit/flatten/progress", controlHandler.GetFlattenProgress).Methods("GET")
 
 	// Position endpoints
 	router.HandleFunc("/api/v1/exit/positions/{positionId}/manual", positionHandler.CreateManualExit).Methods("POST")
@@ -26,6 +27,10 @@ func RegisterExitRoutes(router *mux.Router, exitSvc *exitService.Service) {
 	router.HandleFunc("/api/v1/exit/profiles", profileHandler.GetProfiles).Methods("GET")
 	router.HandleFunc("/api/v1/exit/profiles", profileHandler.CreateProfile).Methods("POST")
 
+	// Custom rule expression endpoints
+	router.HandleFunc("/api/v1/exit/rules/variables", profileHandler.GetRuleVariables).Methods("GET")
+	router.HandleFunc("/api/v1/exit/rules/preview", profileHandler.PreviewRule).Methods("POST")
+
 	// Symbol override endpoints
 	router.HandleFunc("/api/v1/exit/overrides/{symbol}", overrideHandler.GetOverride).Methods("GET")
 	router.HandleFunc("/api/v1/exit/overrides/{symbol}", overrideHandler.SetOverride).Methods("POST")
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	portfolioHandlers "github.com/wonny/aegis/v14/internal/api/handlers/portfolio"
+)
+
+// RegisterPortfolioRoutes Portfolio API 라우트 등록
+func RegisterPortfolioRoutes(
+	router *mux.Router,
+	portfolioHandler *portfolioHandlers.Handler,
+	rebalanceHandler *portfolioHandlers.RebalanceHandler,
+) {
+	// Snapshot endpoints
+	router.HandleFunc("/api/v1/portfolio", portfolioHandler.GetLatestSnapshot).Methods("GET")
+	router.HandleFunc("/api/v1/portfolio/snapshots", portfolioHandler.ListSnapshots).Methods("GET")
+	router.HandleFunc("/api/v1/portfolio/snapshots/{id}", portfolioHandler.GetSnapshotByID).Methods("GET")
+
+	// Generation endpoints
+	router.HandleFunc("/api/v1/portfolio/generate", portfolioHandler.GeneratePortfolio).Methods("POST")
+
+	// Rebalance endpoints (plan → review → approve/reject)
+	router.HandleFunc("/api/v1/portfolio/rebalance", rebalanceHandler.CreatePlan).Methods("POST")
+	router.HandleFunc("/api/v1/portfolio/rebalance/latest", rebalanceHandler.GetLatestPlan).Methods("GET")
+	router.HandleFunc("/api/v1/portfolio/rebalance/{planId}", rebalanceHandler.GetPlan).Methods("GET")
+	router.HandleFunc("/api/v1/portfolio/rebalance/{planId}/approve", rebalanceHandler.ApprovePlan).Methods("POST")
+	router.HandleFunc("/api/v1/portfolio/rebalance/{planId}/reject", rebalanceHandler.RejectPlan).Methods("POST")
+}
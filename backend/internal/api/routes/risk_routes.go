@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	riskHandlers "github.com/wonny/aegis/v14/internal/api/handlers/risk"
+)
+
+// RegisterRiskRoutes Risk API 라우트 등록
+func RegisterRiskRoutes(router *mux.Router, handler *riskHandlers.Handler) {
+	// Limits
+	router.HandleFunc("/api/v1/risk/limits", handler.GetLimits).Methods("GET")
+	router.HandleFunc("/api/v1/risk/limits", handler.UpdateLimits).Methods("PUT")
+
+	// Emergency stop (신규 매수 차단)
+	router.HandleFunc("/api/v1/risk/emergency-stop", handler.GetEmergencyStop).Methods("GET")
+	router.HandleFunc("/api/v1/risk/emergency-stop", handler.SetEmergencyStop).Methods("PUT")
+
+	// Blocks (차단 기록)
+	router.HandleFunc("/api/v1/risk/blocks", handler.ListBlocks).Methods("GET")
+}
@@ -29,6 +29,9 @@ const (
 	Period6M  Period = "6M"
 	Period1Y  Period = "1Y"
 	PeriodYTD Period = "YTD"
+
+	// PeriodBacktest 백테스트 리포트 (StartDate/EndDate로 기간 표현, 조회용 기간 아님)
+	PeriodBacktest Period = "BACKTEST"
 )
 
 // ValidPeriods 유효한 기간 목록
@@ -151,12 +154,39 @@ type Trade struct {
 	Side       string    `json:"side"` // BUY or SELL
 	Quantity   int       `json:"quantity"`
 	Price      int64     `json:"price"`
-	PnL        float64   `json:"pnl"`
+	EntryPrice int64     `json:"entry_price,omitempty"` // 매수 체결가 (0 → Price)
+	PnL        float64   `json:"pnl"`                   // 실현손익 (수수료/세금 차감)
 	PnLPercent float64   `json:"pnl_percent"`
+	Fee        float64   `json:"fee,omitempty"` // 매도 수수료
+	Tax        float64   `json:"tax,omitempty"` // 거래세 + 농특세
 	EntryDate  time.Time `json:"entry_date"`
 	ExitDate   time.Time `json:"exit_date"`
 	HoldDays   int       `json:"hold_days"`
 	ExitReason string    `json:"exit_reason,omitempty"` // SL1, SL2, TP1, TP2, TP3, TRAIL, TIME, MANUAL
+	AccountID  string    `json:"account_id,omitempty"`  // 거래 계좌 ("" → 계좌 미상)
+}
+
+// AccountPerformance 계좌별 실현 성과 (trade_history 기준)
+type AccountPerformance struct {
+	AccountID    string  `json:"account_id"` // "" → 계좌 미상, 통합은 "ALL"
+	TotalTrades  int     `json:"total_trades"`
+	RealizedPnL  float64 `json:"realized_pnl"` // 수수료/세금 차감 후
+	Fees         float64 `json:"fees"`
+	Taxes        float64 `json:"taxes"`
+	WinRate      float64 `json:"win_rate"`
+	AvgWin       float64 `json:"avg_win"`
+	AvgLoss      float64 `json:"avg_loss"`
+	ProfitFactor float64 `json:"profit_factor"`
+	AvgHoldDays  float64 `json:"avg_hold_days"`
+}
+
+// AccountReport 계좌별 + 통합 성과 리포트
+type AccountReport struct {
+	Period       Period               `json:"period"`
+	StartDate    time.Time            `json:"start_date"`
+	EndDate      time.Time            `json:"end_date"`
+	Consolidated AccountPerformance   `json:"consolidated"`
+	Accounts     []AccountPerformance `json:"accounts"` // account_id 오름차순
 }
 
 // =============================================================================
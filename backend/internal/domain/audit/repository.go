@@ -50,8 +50,8 @@ type TradeRepository interface {
 	// 거래 내역 저장
 	SaveTradeHistory(ctx context.Context, trade *Trade) error
 
-	// 마지막 거래 날짜 조회 (증분 동기화용)
-	GetLastTradeDate(ctx context.Context) (time.Time, error)
+	// 계좌별 마지막 거래 날짜 조회 (증분 동기화용)
+	GetLastTradeDate(ctx context.Context, accountID string) (time.Time, error)
 
 	// 거래 개수 조회
 	GetTradeCount(ctx context.Context) (int, error)
@@ -63,6 +63,9 @@ type BenchmarkRepository interface {
 	SaveBenchmark(ctx context.Context, data *BenchmarkData) error
 	GetBenchmark(ctx context.Context, code string, startDate, endDate time.Time) ([]BenchmarkData, error)
 	GetBenchmarkReturns(ctx context.Context, code string, startDate, endDate time.Time) ([]float64, error)
+
+	// 포트폴리오/벤치마크 일별 수익률 (양쪽 모두 있는 날짜만, 베타 계산용)
+	GetAlignedReturns(ctx context.Context, code string, startDate, endDate time.Time) (portfolio, benchmark []float64, err error)
 }
 
 // =============================================================================
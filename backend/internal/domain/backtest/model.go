@@ -0,0 +1,214 @@
+package backtest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/audit"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// =============================================================================
+// Errors
+// =============================================================================
+
+var (
+	ErrNoEntries       = errors.New("no entries to replay")
+	ErrInvalidEntry    = errors.New("invalid entry (symbol, entry_date, qty required)")
+	ErrInvalidSource   = errors.New("invalid source (DAILY or TICK)")
+	ErrProfileNotFound = errors.New("exit profile not found")
+	ErrTooManyEntries  = errors.New("too many entries")
+)
+
+// MaxEntries 요청당 최대 진입 수
+const MaxEntries = 500
+
+// DefaultSlippageBps 기본 슬리피지 (bp, 체결가 불리 방향)
+const DefaultSlippageBps = 5.0
+
+// =============================================================================
+// Request
+// =============================================================================
+
+// Source 리플레이 가격 소스
+type Source string
+
+const (
+	SourceDaily Source = "DAILY" // data.daily_prices (OHLC 경로 근사)
+	SourceTick  Source = "TICK"  // market.prices_ticks
+)
+
+// Entry 과거 진입 (리플레이 대상)
+type Entry struct {
+	Symbol     string           `json:"symbol"`
+	EntryDate  time.Time        `json:"entry_date"`
+	EntryPrice *decimal.Decimal `json:"entry_price,omitempty"` // nil = 진입일 종가
+	Qty        int64            `json:"qty"`
+	Market     string           `json:"market,omitempty"` // KOSPI/KOSDAQ (비용 계산, 없으면 조회)
+}
+
+// Request 백테스트 요청
+// Profile(inline) > ProfileID > DB default 순으로 Exit 프로필 결정
+type Request struct {
+	ProfileID   string                  `json:"profile_id,omitempty"`
+	Profile     *exit.ExitProfileConfig `json:"profile,omitempty"`
+	Source      Source                  `json:"source"`
+	Entries     []Entry                 `json:"entries"`
+	EndDate     *time.Time              `json:"end_date,omitempty"` // nil = 현재까지
+	SlippageBps *float64                `json:"slippage_bps,omitempty"`
+}
+
+// Validate validates request and fills defaults
+func (r *Request) Validate() error {
+	if r.Source == "" {
+		r.Source = SourceDaily
+	}
+	if r.Source != SourceDaily && r.Source != SourceTick {
+		return ErrInvalidSource
+	}
+	if len(r.Entries) == 0 {
+		return ErrNoEntries
+	}
+	if len(r.Entries) > MaxEntries {
+		return ErrTooManyEntries
+	}
+	for _, e := range r.Entries {
+		if e.Symbol == "" || e.EntryDate.IsZero() || e.Qty <= 0 {
+			return ErrInvalidEntry
+		}
+		if e.EntryPrice != nil && !e.EntryPrice.IsPositive() {
+			return ErrInvalidEntry
+		}
+	}
+	if r.SlippageBps == nil {
+		slippage := DefaultSlippageBps
+		r.SlippageBps = &slippage
+	}
+	return nil
+}
+
+// =============================================================================
+// Result
+// =============================================================================
+
+// Fill 시뮬레이션 체결 (Exit 트리거 1건)
+type Fill struct {
+	TS           time.Time       `json:"ts"`
+	ReasonCode   string          `json:"reason_code"`
+	Phase        string          `json:"phase"`      // 트리거 시점 FSM phase
+	NextPhase    string          `json:"next_phase"` // 체결 후 FSM phase
+	Qty          int64           `json:"qty"`
+	TriggerPrice decimal.Decimal `json:"trigger_price"`
+	FillPrice    decimal.Decimal `json:"fill_price"` // 슬리피지 반영
+	Fee          decimal.Decimal `json:"fee"`
+	Tax          decimal.Decimal `json:"tax"`
+}
+
+// Trade status
+const (
+	TradeStatusClosed  = "CLOSED"  // 전량 청산
+	TradeStatusOpen    = "OPEN"    // 기간 종료 시 잔량 보유 (종가 평가)
+	TradeStatusSkipped = "SKIPPED" // 가격 데이터 없음
+)
+
+// TradeResult 진입별 리플레이 결과
+type TradeResult struct {
+	Symbol       string          `json:"symbol"`
+	Market       string          `json:"market"`
+	EntryDate    time.Time       `json:"entry_date"`
+	EntryPrice   decimal.Decimal `json:"entry_price"`
+	Qty          int64           `json:"qty"`
+	EntryFee     decimal.Decimal `json:"entry_fee"`
+	ATR          *float64        `json:"atr,omitempty"` // 진입 시점 ATR 비율
+	Fills        []Fill          `json:"fills"`
+	Status       string          `json:"status"`
+	RemainingQty int64           `json:"remaining_qty"`
+	LastPrice    decimal.Decimal `json:"last_price"`
+	ExitDate     *time.Time      `json:"exit_date,omitempty"`
+	ExitReason   string          `json:"exit_reason,omitempty"` // 마지막 청산 사유
+	HoldDays     int             `json:"hold_days"`             // 거래일 기준
+	NetPnL       decimal.Decimal `json:"net_pnl"`               // 수수료/세금 차감 (잔량은 평가손익)
+	PnLPct       float64         `json:"pnl_pct"`               // NetPnL / 진입금액 (%)
+	Message      string          `json:"message,omitempty"`
+}
+
+// EquityPoint 일별 평가 자산 (전체 진입금액 기준)
+type EquityPoint struct {
+	Date   time.Time       `json:"date"`
+	Equity decimal.Decimal `json:"equity"`
+}
+
+// Result 백테스트 결과
+type Result struct {
+	ProfileID   string                  `json:"profile_id"`
+	Profile     exit.ExitProfileConfig  `json:"profile"`
+	Source      Source                  `json:"source"`
+	SlippageBps float64                 `json:"slippage_bps"`
+	StartDate   time.Time               `json:"start_date"`
+	EndDate     time.Time               `json:"end_date"`
+	Trades      []TradeResult           `json:"trades"`
+	Equity      []EquityPoint           `json:"equity"`
+	Report      audit.PerformanceReport `json:"report"`
+	RanAt       time.Time               `json:"ran_at"`
+}
+
+// =============================================================================
+// Position what-if simulation
+// =============================================================================
+
+var (
+	ErrPositionNotFound = errors.New("position not found")
+	ErrProfileRequired  = errors.New("candidate profile required")
+)
+
+// SimulationRequest 라이브 포지션 what-if 요청 (후보 프로필 vs 현재 프로필)
+type SimulationRequest struct {
+	PositionID  uuid.UUID               `json:"-"`
+	Profile     *exit.ExitProfileConfig `json:"profile"`                // 후보 ExitProfileConfig (CustomRules 포함)
+	Source      Source                  `json:"source,omitempty"`       // 빈 값 = TICK 우선, 없으면 DAILY
+	SlippageBps *float64                `json:"slippage_bps,omitempty"` // nil = DefaultSlippageBps
+}
+
+// Validate validates request and fills defaults
+func (r *SimulationRequest) Validate() error {
+	if r.Profile == nil {
+		return ErrProfileRequired
+	}
+	if r.Source != "" && r.Source != SourceDaily && r.Source != SourceTick {
+		return ErrInvalidSource
+	}
+	if r.SlippageBps == nil {
+		slippage := DefaultSlippageBps
+		r.SlippageBps = &slippage
+	}
+	return nil
+}
+
+// Scenario 프로필 1개에 대한 시뮬레이션 결과
+type Scenario struct {
+	ProfileID     string                 `json:"profile_id"`
+	Profile       exit.ExitProfileConfig `json:"profile"`
+	Fills         []Fill                 `json:"fills"` // 발동 트리거 + phase 전이
+	FinalPhase    string                 `json:"final_phase"`
+	RemainingQty  int64                  `json:"remaining_qty"`
+	RealizedPnL   decimal.Decimal        `json:"realized_pnl"`   // (체결가 - 평단) × 수량 - 수수료 - 세금
+	UnrealizedPnL decimal.Decimal        `json:"unrealized_pnl"` // (마지막 가격 - 평단) × 잔량
+}
+
+// Simulation 라이브 포지션 what-if 결과
+type Simulation struct {
+	PositionID      uuid.UUID       `json:"position_id"`
+	Symbol          string          `json:"symbol"`
+	EntryTS         time.Time       `json:"entry_ts"`
+	Qty             int64           `json:"qty"` // 원본 수량 기준
+	AvgPrice        decimal.Decimal `json:"avg_price"`
+	Source          Source          `json:"source"`
+	PricePoints     int             `json:"price_points"`
+	LastPrice       decimal.Decimal `json:"last_price"`
+	Current         Scenario        `json:"current"`
+	Candidate       Scenario        `json:"candidate"`
+	RealizedPnLDiff decimal.Decimal `json:"realized_pnl_diff"` // candidate - current
+	SimulatedAt     time.Time       `json:"simulated_at"`
+}
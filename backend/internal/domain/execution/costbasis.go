@@ -0,0 +1,237 @@
+package execution
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Cost Basis Methods
+const (
+	CostMethodFIFO    = "FIFO"    // 선입선출 (lot별 취득단가)
+	CostMethodAverage = "AVERAGE" // 이동평균 (KIS 잔고 평균단가 방식)
+)
+
+// ErrInvalidCostMethod invalid cost basis method
+var ErrInvalidCostMethod = errors.New("invalid cost basis method")
+
+// NormalizeCostMethod validates a cost basis method ("" → FIFO)
+func NormalizeCostMethod(method string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(method)) {
+	case "", CostMethodFIFO:
+		return CostMethodFIFO, nil
+	case CostMethodAverage, "AVG", "MOVING_AVERAGE":
+		return CostMethodAverage, nil
+	}
+	return "", ErrInvalidCostMethod
+}
+
+// LotFill a buy/sell execution fed into a LotBook
+type LotFill struct {
+	Side   string
+	Qty    int64
+	Price  decimal.Decimal
+	TS     time.Time
+	Market string     // 세율 결정 (KOSPI, KOSDAQ, ETF, ...)
+	Cost   *TradeCost // 실제 수수료/세금 (nil → CostModel로 계산, 원 미만 절사)
+}
+
+// Lot an open tax lot
+type Lot struct {
+	OpenTS    time.Time
+	Qty       int64           // 잔여 수량
+	Price     decimal.Decimal // 매수 체결가
+	UnitCost  decimal.Decimal // 주당 취득원가 (매수 수수료 포함, AVERAGE는 전체 평균)
+	OpenedQty int64           // 최초 수량
+}
+
+// RealizedLot realized P&L of a (partial) lot closed by a sell
+type RealizedLot struct {
+	OpenTS     time.Time
+	CloseTS    time.Time
+	Qty        int64
+	EntryPrice decimal.Decimal // 매수 체결가
+	ExitPrice  decimal.Decimal // 매도 체결가
+	CostBasis  decimal.Decimal // 취득원가 (매수 수수료 포함)
+	Proceeds   decimal.Decimal // 순매도금액 (매도 수수료/세금 차감)
+	Fee        decimal.Decimal // 배분된 매도 수수료
+	Tax        decimal.Decimal // 배분된 거래세 + 농특세
+	PnL        decimal.Decimal
+	PnLPct     float64 // 취득원가 대비 %
+	HoldDays   int
+}
+
+// LotBook tracks open lots of a symbol and realizes P&L per lot
+type LotBook struct {
+	method string
+	model  *CostModel
+	lots   []*Lot
+}
+
+// NewLotBook creates a lot book (method: FIFO | AVERAGE)
+func NewLotBook(method string, model *CostModel) *LotBook {
+	if model == nil {
+		model = DefaultCostModel()
+	}
+	if m, err := NormalizeCostMethod(method); err == nil {
+		method = m
+	} else {
+		method = CostMethodFIFO
+	}
+	return &LotBook{method: method, model: model}
+}
+
+// Apply applies a fill and returns lots realized by a sell
+// 보유 수량 초과 매도는 보유분까지만 실현하고 ErrLedgerOversell 반환
+func (b *LotBook) Apply(f LotFill) ([]RealizedLot, error) {
+	if f.Qty <= 0 {
+		return nil, nil
+	}
+
+	amount := f.Price.Mul(decimal.NewFromInt(f.Qty))
+	cost := b.model.Cost(f.Side, f.Market, f.TS, amount).Truncated()
+	if f.Cost != nil {
+		cost = *f.Cost
+	}
+
+	if f.Side == SideBuy {
+		b.lots = append(b.lots, &Lot{
+			OpenTS:    f.TS,
+			Qty:       f.Qty,
+			Price:     f.Price,
+			UnitCost:  amount.Add(cost.Commission).Div(decimal.NewFromInt(f.Qty)),
+			OpenedQty: f.Qty,
+		})
+		if b.method == CostMethodAverage {
+			b.averageLots()
+		}
+		return nil, nil
+	}
+
+	var err error
+	sellQty := f.Qty
+	if held := b.Qty(); sellQty > held {
+		sellQty = held
+		err = ErrLedgerOversell
+	}
+
+	// 매도 비용은 수량 비례 배분 (마지막 lot에 잔여분 → 합계 보존)
+	feeLeft := cost.Commission.Mul(decimal.NewFromInt(sellQty)).Div(decimal.NewFromInt(f.Qty))
+	taxLeft := cost.Tax().Mul(decimal.NewFromInt(sellQty)).Div(decimal.NewFromInt(f.Qty))
+	remaining := sellQty
+
+	var realized []RealizedLot
+	for remaining > 0 && len(b.lots) > 0 {
+		lot := b.lots[0]
+		qty := lot.Qty
+		if remaining < qty {
+			qty = remaining
+		}
+
+		fee, tax := feeLeft, taxLeft
+		if qty < remaining {
+			ratio := decimal.NewFromInt(qty).Div(decimal.NewFromInt(remaining))
+			fee = feeLeft.Mul(ratio).Round(4)
+			tax = taxLeft.Mul(ratio).Round(4)
+		}
+		feeLeft = feeLeft.Sub(fee)
+		taxLeft = taxLeft.Sub(tax)
+
+		q := decimal.NewFromInt(qty)
+		costBasis := lot.UnitCost.Mul(q)
+		proceeds := f.Price.Mul(q).Sub(fee).Sub(tax)
+		pnl := proceeds.Sub(costBasis)
+		pnlPct := 0.0
+		if costBasis.IsPositive() {
+			pnlPct, _ = pnl.Div(costBasis).Mul(decimal.NewFromInt(100)).Float64()
+		}
+		holdDays := int(f.TS.Sub(lot.OpenTS).Hours() / 24)
+		if holdDays < 0 {
+			holdDays = 0
+		}
+
+		realized = append(realized, RealizedLot{
+			OpenTS:     lot.OpenTS,
+			CloseTS:    f.TS,
+			Qty:        qty,
+			EntryPrice: lot.Price,
+			ExitPrice:  f.Price,
+			CostBasis:  costBasis,
+			Proceeds:   proceeds,
+			Fee:        fee,
+			Tax:        tax,
+			PnL:        pnl,
+			PnLPct:     pnlPct,
+			HoldDays:   holdDays,
+		})
+
+		lot.Qty -= qty
+		remaining -= qty
+		if lot.Qty == 0 {
+			b.lots = b.lots[1:]
+		}
+	}
+
+	return realized, err
+}
+
+// averageLots sets every open lot to the moving average unit cost
+func (b *LotBook) averageLots() {
+	totalQty := int64(0)
+	totalCost := decimal.Zero
+	for _, lot := range b.lots {
+		totalQty += lot.Qty
+		totalCost = totalCost.Add(lot.UnitCost.Mul(decimal.NewFromInt(lot.Qty)))
+	}
+	if totalQty == 0 {
+		return
+	}
+	avg := totalCost.Div(decimal.NewFromInt(totalQty))
+	for _, lot := range b.lots {
+		lot.UnitCost = avg
+	}
+}
+
+// Qty returns open qty
+func (b *LotBook) Qty() int64 {
+	total := int64(0)
+	for _, lot := range b.lots {
+		total += lot.Qty
+	}
+	return total
+}
+
+// AvgCost returns average unit cost of open lots (수수료 포함)
+func (b *LotBook) AvgCost() decimal.Decimal {
+	qty := b.Qty()
+	if qty == 0 {
+		return decimal.Zero
+	}
+	total := decimal.Zero
+	for _, lot := range b.lots {
+		total = total.Add(lot.UnitCost.Mul(decimal.NewFromInt(lot.Qty)))
+	}
+	return total.Div(decimal.NewFromInt(qty))
+}
+
+// Lots returns open lots (oldest first)
+func (b *LotBook) Lots() []Lot {
+	result := make([]Lot, 0, len(b.lots))
+	for _, lot := range b.lots {
+		result = append(result, *lot)
+	}
+	return result
+}
+
+// SortLotFills orders fills by time (동시각은 매수 우선 → 당일 매수분 매도 가능)
+func SortLotFills(fills []LotFill) {
+	sort.SliceStable(fills, func(i, j int) bool {
+		if !fills[i].TS.Equal(fills[j].TS) {
+			return fills[i].TS.Before(fills[j].TS)
+		}
+		return fills[i].Side == SideBuy && fills[j].Side != SideBuy
+	})
+}
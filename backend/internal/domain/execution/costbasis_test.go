@@ -0,0 +1,113 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestLotBook tests FIFO/moving-average realization with KRX fees and taxes
+func TestLotBook(t *testing.T) {
+	model := NewCostModel(DefaultCommissionRate)
+	day := func(n int) time.Time { return kstDate(2026, 3, n) }
+
+	// 매수 10 @10,000 (수수료 15), 매수 10 @12,000 (수수료 18)
+	// 매도 15 @13,000 KOSPI: 수수료 29 + 거래세 97 + 농특세 292 = 418 (원 미만 절사)
+	fills := []LotFill{
+		{Side: SideSell, Qty: 15, Price: d(13_000), TS: day(5), Market: "KOSPI"},
+		{Side: SideBuy, Qty: 10, Price: d(12_000), TS: day(2), Market: "KOSPI"},
+		{Side: SideBuy, Qty: 10, Price: d(10_000), TS: day(1), Market: "KOSPI"},
+	}
+	SortLotFills(fills)
+
+	run := func(method string) ([]RealizedLot, *LotBook) {
+		book := NewLotBook(method, model)
+		var realized []RealizedLot
+		for _, f := range fills {
+			lots, err := book.Apply(f)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+			realized = append(realized, lots...)
+		}
+		return realized, book
+	}
+
+	sum := func(lots []RealizedLot) decimal.Decimal {
+		total := decimal.Zero
+		for _, l := range lots {
+			total = total.Add(l.PnL)
+		}
+		return total
+	}
+
+	t.Run("FIFO", func(t *testing.T) {
+		realized, book := run(CostMethodFIFO)
+
+		if len(realized) != 2 || realized[0].Qty != 10 || realized[1].Qty != 5 {
+			t.Fatalf("Expected lots 10 + 5, got %+v", realized)
+		}
+		if !realized[0].EntryPrice.Equal(d(10_000)) || realized[0].HoldDays != 4 {
+			t.Errorf("Expected first lot @10000 held 4 days, got %s / %d", realized[0].EntryPrice, realized[0].HoldDays)
+		}
+		// 195,000 - 418 - (100,015 + 60,009)
+		if got := sum(realized); !got.Equal(d(34_558)) {
+			t.Errorf("Expected realized 34558, got %s", got)
+		}
+		if book.Qty() != 5 || !book.AvgCost().Equal(decimal.RequireFromString("12001.8")) {
+			t.Errorf("Expected 5 @12001.8 left, got %d @%s", book.Qty(), book.AvgCost())
+		}
+	})
+
+	t.Run("Moving average", func(t *testing.T) {
+		realized, book := run(CostMethodAverage)
+
+		// 평균단가 (100,015 + 120,018) / 20 = 11,001.65
+		if got := sum(realized); !got.Equal(decimal.RequireFromString("29557.25")) {
+			t.Errorf("Expected realized 29557.25, got %s", got)
+		}
+		if book.Qty() != 5 || !book.AvgCost().Equal(decimal.RequireFromString("11001.65")) {
+			t.Errorf("Expected 5 @11001.65 left, got %d @%s", book.Qty(), book.AvgCost())
+		}
+	})
+
+	t.Run("Oversell", func(t *testing.T) {
+		book := NewLotBook(CostMethodFIFO, model)
+		book.Apply(LotFill{Side: SideBuy, Qty: 10, Price: d(10_000), TS: day(1)})
+
+		realized, err := book.Apply(LotFill{Side: SideSell, Qty: 15, Price: d(10_000), TS: day(2)})
+		if !errors.Is(err, ErrLedgerOversell) {
+			t.Errorf("Expected ErrLedgerOversell, got %v", err)
+		}
+		if len(realized) != 1 || realized[0].Qty != 10 || book.Qty() != 0 {
+			t.Errorf("Expected 10 realized and flat book, got %+v (qty %d)", realized, book.Qty())
+		}
+	})
+}
+
+// TestSellTaxRate tests dated KRX tax schedule by market
+func TestSellTaxRate(t *testing.T) {
+	model := NewCostModel(DefaultCommissionRate)
+
+	tests := []struct {
+		market string
+		date   time.Time
+		want   string
+	}{
+		{"KOSPI", kstDate(2024, 6, 1), "0.0018"},
+		{"KOSPI", kstDate(2025, 6, 1), "0.0015"},
+		{"KOSDAQ", kstDate(2025, 6, 1), "0.0015"},
+		{"KOSPI", kstDate(2026, 3, 1), "0.002"},
+		{"KOSDAQ", kstDate(2026, 3, 1), "0.002"},
+		{"", kstDate(2022, 3, 1), "0.0023"},
+		{"ETF", kstDate(2026, 3, 1), "0"},
+	}
+
+	for _, tt := range tests {
+		if got := model.SellTaxRate(tt.market, tt.date); !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("SellTaxRate(%q, %s) = %s, want %s", tt.market, tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
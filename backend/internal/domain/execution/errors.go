@@ -8,6 +8,13 @@ var (
 	ErrOrderNotFound      = errors.New("order not found")
 	ErrOrderExists        = errors.New("order already exists")
 	ErrDuplicateOrder     = errors.New("duplicate order (intent already submitted)")
+	ErrInvalidOrderType   = errors.New("invalid order type")
+	ErrSessionUnavailable = errors.New("order type not available in current session")
+
+	// Pricing errors (호가단위/가격제한폭/VI)
+	ErrPriceOutOfBand     = errors.New("limit price outside today's price band")
+	ErrVIActive           = errors.New("volatility interruption in progress")
+	ErrTradingHalted      = errors.New("trading halted")
 
 	// Fill errors
 	ErrFillNotFound       = errors.New("fill not found")
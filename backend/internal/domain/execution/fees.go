@@ -0,0 +1,191 @@
+package execution
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 거래 비용 (KIS 거래내역 기준)
+// - 위탁수수료: 설정값 (기본 0.015%, 매수/매도)
+// - KOSPI 매도: 증권거래세 + 농어촌특별세 0.15%
+// - KOSDAQ 매도: 증권거래세 (농특세 없음)
+// - ETF/ETN 매도: 거래세 면제
+// 거래세율은 연도별로 바뀌므로 시행일 기준 테이블로 관리 (KRXTaxSchedule)
+
+// DefaultCommissionRate 기본 위탁수수료율 (0.015%)
+var DefaultCommissionRate = decimal.NewFromFloat(0.00015)
+
+// TaxRate KRX 매도 세율 (Effective 시행일부터 적용)
+type TaxRate struct {
+	Effective    time.Time
+	KOSPITax     decimal.Decimal // KOSPI 증권거래세
+	KOSPIAgriTax decimal.Decimal // KOSPI 농어촌특별세
+	KOSDAQTax    decimal.Decimal // KOSDAQ 증권거래세
+}
+
+// KRXTaxSchedule KRX 매도 세율 이력 (시행일 오름차순)
+var KRXTaxSchedule = []TaxRate{
+	{Effective: kstDate(2019, 5, 30), KOSPITax: mustRate("0.001"), KOSPIAgriTax: mustRate("0.0015"), KOSDAQTax: mustRate("0.0025")},
+	{Effective: kstDate(2021, 1, 1), KOSPITax: mustRate("0.0008"), KOSPIAgriTax: mustRate("0.0015"), KOSDAQTax: mustRate("0.0023")},
+	{Effective: kstDate(2023, 1, 1), KOSPITax: mustRate("0.0005"), KOSPIAgriTax: mustRate("0.0015"), KOSDAQTax: mustRate("0.002")},
+	{Effective: kstDate(2024, 1, 1), KOSPITax: mustRate("0.0003"), KOSPIAgriTax: mustRate("0.0015"), KOSDAQTax: mustRate("0.0018")},
+	{Effective: kstDate(2025, 1, 1), KOSPITax: mustRate("0"), KOSPIAgriTax: mustRate("0.0015"), KOSDAQTax: mustRate("0.0015")},
+	{Effective: kstDate(2026, 1, 1), KOSPITax: mustRate("0.0005"), KOSPIAgriTax: mustRate("0.0015"), KOSDAQTax: mustRate("0.002")},
+}
+
+// TradeCost fee/tax breakdown of a trade
+type TradeCost struct {
+	Commission     decimal.Decimal // 위탁수수료
+	TransactionTax decimal.Decimal // 증권거래세
+	AgriTax        decimal.Decimal // 농어촌특별세 (KOSPI)
+}
+
+// Tax returns total tax (증권거래세 + 농특세)
+func (c TradeCost) Tax() decimal.Decimal {
+	return c.TransactionTax.Add(c.AgriTax)
+}
+
+// Total returns commission + tax
+func (c TradeCost) Total() decimal.Decimal {
+	return c.Commission.Add(c.Tax())
+}
+
+// Truncated returns cost truncated to won (KIS 거래내역: 원 미만 절사)
+func (c TradeCost) Truncated() TradeCost {
+	return TradeCost{
+		Commission:     c.Commission.Truncate(0),
+		TransactionTax: c.TransactionTax.Truncate(0),
+		AgriTax:        c.AgriTax.Truncate(0),
+	}
+}
+
+// CostModel broker commission + KRX tax schedule
+type CostModel struct {
+	CommissionRate decimal.Decimal
+	TaxSchedule    []TaxRate // 시행일 오름차순
+}
+
+// NewCostModel creates a cost model with the KRX tax schedule
+func NewCostModel(commissionRate decimal.Decimal) *CostModel {
+	return &CostModel{
+		CommissionRate: commissionRate,
+		TaxSchedule:    KRXTaxSchedule,
+	}
+}
+
+// TaxRateAt returns the tax rates effective on date (시행 이전 → 최초 세율)
+func (m *CostModel) TaxRateAt(date time.Time) TaxRate {
+	if len(m.TaxSchedule) == 0 {
+		return TaxRate{KOSPITax: decimal.Zero, KOSPIAgriTax: decimal.Zero, KOSDAQTax: decimal.Zero}
+	}
+	current := m.TaxSchedule[0]
+	for _, r := range m.TaxSchedule[1:] {
+		if date.Before(r.Effective) {
+			break
+		}
+		current = r
+	}
+	return current
+}
+
+// SellTaxRates returns transaction tax and agricultural tax rates by market and date
+// (unknown market → KOSDAQ rate)
+func (m *CostModel) SellTaxRates(market string, date time.Time) (transactionTax, agriTax decimal.Decimal) {
+	r := m.TaxRateAt(date)
+	switch market {
+	case "ETF", "ETN":
+		return decimal.Zero, decimal.Zero
+	case "KOSPI":
+		return r.KOSPITax, r.KOSPIAgriTax
+	default:
+		return r.KOSDAQTax, decimal.Zero
+	}
+}
+
+// SellTaxRate returns total sell-side tax rate by market and date
+func (m *CostModel) SellTaxRate(market string, date time.Time) decimal.Decimal {
+	tx, agri := m.SellTaxRates(market, date)
+	return tx.Add(agri)
+}
+
+// Cost returns fee/tax of a trade (BUY: 수수료만, SELL: 수수료 + 거래세 + 농특세)
+func (m *CostModel) Cost(side, market string, date time.Time, amount decimal.Decimal) TradeCost {
+	cost := TradeCost{
+		Commission:     amount.Mul(m.CommissionRate),
+		TransactionTax: decimal.Zero,
+		AgriTax:        decimal.Zero,
+	}
+	if side == SideSell {
+		tx, agri := m.SellTaxRates(market, date)
+		cost.TransactionTax = amount.Mul(tx)
+		cost.AgriTax = amount.Mul(agri)
+	}
+	return cost
+}
+
+// UnrealizedPnL returns HTS-style unrealized P&L (매도 시 수수료/세금 차감)
+// avgCost: 평균 취득단가, pct: 취득원가 대비 %
+func (m *CostModel) UnrealizedPnL(market string, date time.Time, qty int64, avgCost, price decimal.Decimal) (decimal.Decimal, float64) {
+	q := decimal.NewFromInt(qty)
+	costBasis := avgCost.Mul(q)
+	sellAmount := price.Mul(q)
+	pnl := sellAmount.Sub(m.Cost(SideSell, market, date, sellAmount).Total()).Sub(costBasis)
+
+	pct := 0.0
+	if costBasis.IsPositive() {
+		pct, _ = pnl.Div(costBasis).Mul(decimal.NewFromInt(100)).Float64()
+	}
+	return pnl, pct
+}
+
+var (
+	defaultCostModelMu sync.RWMutex
+	defaultCostModel   = NewCostModel(DefaultCommissionRate)
+)
+
+// SetDefaultCostModel replaces the process-wide cost model (startup 설정 반영)
+func SetDefaultCostModel(m *CostModel) {
+	defaultCostModelMu.Lock()
+	defer defaultCostModelMu.Unlock()
+	defaultCostModel = m
+}
+
+// DefaultCostModel returns the process-wide cost model
+func DefaultCostModel() *CostModel {
+	defaultCostModelMu.RLock()
+	defer defaultCostModelMu.RUnlock()
+	return defaultCostModel
+}
+
+// SellTaxRate returns the current sell-side tax rate by market (unknown → KOSDAQ rate)
+func SellTaxRate(market string) decimal.Decimal {
+	return DefaultCostModel().SellTaxRate(market, time.Now())
+}
+
+// BuyCostRate returns total buy cost rate (commission only)
+func BuyCostRate() decimal.Decimal {
+	return DefaultCostModel().CommissionRate
+}
+
+// SellCostRate returns total sell cost rate (commission + tax)
+// 2026: KOSPI 0.215%, KOSDAQ 0.215%
+func SellCostRate(market string) decimal.Decimal {
+	return BuyCostRate().Add(SellTaxRate(market))
+}
+
+// EstimateTradeCost estimates fee and tax for an order amount at current rates
+// BUY: 수수료만, SELL: 수수료 + 거래세
+func EstimateTradeCost(side, market string, amount decimal.Decimal) (fee, tax decimal.Decimal) {
+	cost := DefaultCostModel().Cost(side, market, time.Now(), amount)
+	return cost.Commission, cost.Tax()
+}
+
+func mustRate(v string) decimal.Decimal {
+	return decimal.RequireFromString(v)
+}
+
+func kstDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.FixedZone("KST", 9*3600))
+}
@@ -15,6 +15,9 @@ type KISAdapter interface {
 	// CancelOrder cancels an order in KIS
 	CancelOrder(ctx context.Context, accountID string, orderNo string) (*KISCancelResponse, error)
 
+	// ModifyOrder amends price/type of an open order (정정, 잔량 전부 → 새 주문번호)
+	ModifyOrder(ctx context.Context, req KISModifyRequest) (*KISModifyResponse, error)
+
 	// GetUnfilledOrders retrieves unfilled orders from KIS
 	GetUnfilledOrders(ctx context.Context, accountID string) ([]*KISUnfilledOrder, error)
 
@@ -26,6 +29,20 @@ type KISAdapter interface {
 
 	// GetHoldings retrieves holdings from KIS
 	GetHoldings(ctx context.Context, accountID string) ([]*KISHolding, error)
+
+	// GetCash retrieves settled cash of an account (D+2 예수금, 미결제 대금 반영)
+	GetCash(ctx context.Context, accountID string) (decimal.Decimal, error)
+}
+
+// AccountCashReader binds a broker adapter to one account (Risk/Portfolio CashReader)
+type AccountCashReader struct {
+	Broker    KISAdapter
+	AccountID string
+}
+
+// GetCash returns settled cash of the bound account
+func (r AccountCashReader) GetCash(ctx context.Context) (decimal.Decimal, error) {
+	return r.Broker.GetCash(ctx, r.AccountID)
 }
 
 // KISOrderRequest represents KIS order submission request
@@ -53,6 +70,24 @@ type KISCancelResponse struct {
 	Raw       map[string]any // 원본 응답
 }
 
+// KISModifyRequest represents KIS order amendment (정정) request
+type KISModifyRequest struct {
+	AccountID  string           // 계좌번호
+	OrderNo    string           // 원주문번호
+	OrderType  string           // 정정 후 주문유형 (MKT, LMT)
+	Qty        int64            // 정정 수량 (원주문 잔량)
+	LimitPrice *decimal.Decimal // 정정 가격 (LMT only)
+}
+
+// KISModifyResponse represents KIS order amendment response
+// KIS는 정정 시 잔량을 새 주문번호로 이관 (원주문은 정정 처리)
+type KISModifyResponse struct {
+	OrderNo    string         // 원주문번호
+	NewOrderNo string         // 정정주문번호
+	Timestamp  time.Time      // 정정 시각
+	Raw        map[string]any // 원본 응답
+}
+
 // KISUnfilledOrder represents unfilled order from KIS
 type KISUnfilledOrder struct {
 	OrderID   string         // 주문번호
@@ -0,0 +1,126 @@
+package execution
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Ledger errors
+var (
+	ErrLedgerOversell = errors.New("sell qty exceeds ledger position")
+)
+
+// Ledger Entry Types
+const (
+	LedgerEntryBuy     = "BUY"     // 매수 체결 (수량 증가, 평균단가 재계산)
+	LedgerEntrySell    = "SELL"    // 매도 체결 (수량 감소, 실현손익)
+	LedgerEntryOpening = "OPENING" // 원장 밖 보유분 편입 (holdings 기준 기초잔고)
+)
+
+// Ledger Sources
+const (
+	LedgerSourceFill     = "FILL"     // trade.fills (REST 체결 조회 / paper)
+	LedgerSourceNotice   = "NOTICE"   // H0STCNI0 실시간 체결통보
+	LedgerSourceHoldings = "HOLDINGS" // 잔고 대사 (기초잔고 편입)
+)
+
+// Discrepancy Kinds (원장 vs KIS 잔고 대사)
+const (
+	DiscrepancyQtyMismatch      = "QTY_MISMATCH"      // 수량 불일치
+	DiscrepancyUntrackedHolding = "UNTRACKED_HOLDING" // 원장에 없는 보유 종목 (기초잔고로 편입)
+	DiscrepancyMissingHolding   = "MISSING_HOLDING"   // 원장 보유, KIS 잔고 없음
+	DiscrepancyUntrackedSell    = "UNTRACKED_SELL"    // 원장 포지션 없이 매도 체결 / 보유 초과 매도
+)
+
+// LedgerEntry represents a position ledger entry (trade.position_ledger)
+// 체결 1건(주문별 누적 체결 증분)마다 1행, 적용 후 포지션 상태를 함께 기록
+type LedgerEntry struct {
+	EntryID      uuid.UUID       `json:"entry_id"`
+	PositionID   uuid.UUID       `json:"position_id"`
+	AccountID    string          `json:"account_id"`
+	Symbol       string          `json:"symbol"`
+	OrderID      string          `json:"order_id"`  // 체결 주문 ("" = 기초잔고)
+	IntentID     *uuid.UUID      `json:"intent_id"` // 주문 intent (수동/HTS 주문은 nil)
+	EntryType    string          `json:"entry_type"`
+	Source       string          `json:"source"`
+	Qty          int64           `json:"qty"`
+	Price        decimal.Decimal `json:"price"` // 체결 단가 (증분 가중평균)
+	Fee          decimal.Decimal `json:"fee"`
+	Tax          decimal.Decimal `json:"tax"`
+	QtyAfter     int64           `json:"qty_after"`
+	AvgCostAfter decimal.Decimal `json:"avg_cost_after"` // 수수료 포함 평균 취득단가
+	RealizedPnl  decimal.Decimal `json:"realized_pnl"`   // 이 체결의 실현손익 (매도, 수수료/세금 차감)
+	TS           time.Time       `json:"ts"`             // 체결 시각
+	CreatedTS    time.Time       `json:"created_ts"`
+}
+
+// FillTotals cumulative fill totals of an order (체결 누적 / 원장 반영 누적)
+type FillTotals struct {
+	Qty    int64
+	Amount decimal.Decimal // Σ 체결가 × 수량
+	Fee    decimal.Decimal
+	Tax    decimal.Decimal
+}
+
+// Sub returns increment t - applied
+func (t FillTotals) Sub(applied FillTotals) FillTotals {
+	return FillTotals{
+		Qty:    t.Qty - applied.Qty,
+		Amount: t.Amount.Sub(applied.Amount),
+		Fee:    t.Fee.Sub(applied.Fee),
+		Tax:    t.Tax.Sub(applied.Tax),
+	}
+}
+
+// SumFills sums fills of an order
+func SumFills(fills []*Fill) FillTotals {
+	totals := FillTotals{Amount: decimal.Zero, Fee: decimal.Zero, Tax: decimal.Zero}
+	for _, f := range fills {
+		totals.Qty += f.Qty
+		totals.Amount = totals.Amount.Add(f.Price.Mul(decimal.NewFromInt(f.Qty)))
+		totals.Fee = totals.Fee.Add(f.Fee)
+		totals.Tax = totals.Tax.Add(f.Tax)
+	}
+	return totals
+}
+
+// ExecutionNotice real-time execution notice (H0STCNI0 체결통보)
+type ExecutionNotice struct {
+	OrderID        string
+	Symbol         string
+	Side           string // BUY, SELL
+	FilledQty      int64  // 이번 체결 수량
+	FilledPrice    decimal.Decimal
+	TotalFilledQty int64 // 주문 누적 체결 수량
+	Timestamp      time.Time
+}
+
+// LedgerDiscrepancy represents a ledger vs broker holdings mismatch (trade.ledger_discrepancies)
+type LedgerDiscrepancy struct {
+	DiscrepancyID uuid.UUID       `json:"discrepancy_id"`
+	AccountID     string          `json:"account_id"`
+	Symbol        string          `json:"symbol"`
+	Kind          string          `json:"kind"`
+	LedgerQty     int64           `json:"ledger_qty"`
+	BrokerQty     int64           `json:"broker_qty"`
+	LedgerAvg     decimal.Decimal `json:"ledger_avg"`
+	BrokerAvg     decimal.Decimal `json:"broker_avg"`
+	Detail        string          `json:"detail"`
+	DetectedTS    time.Time       `json:"detected_ts"`
+	ResolvedTS    *time.Time      `json:"resolved_ts,omitempty"`
+}
+
+// SideFromName parses KIS 매도매수구분명 ("현금매도", "매수", ...) into side ("" = 알 수 없음)
+func SideFromName(name string) string {
+	switch {
+	case strings.Contains(name, "매도"), strings.EqualFold(name, SideSell):
+		return SideSell
+	case strings.Contains(name, "매수"), strings.EqualFold(name, SideBuy):
+		return SideBuy
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func d(v int64) decimal.Decimal { return decimal.NewFromInt(v) }
+
+// TestFillTotalsSub tests per-order fill increments (누적 체결 - 원장 반영분)
+func TestFillTotalsSub(t *testing.T) {
+	fills := []*Fill{
+		{Qty: 3, Price: d(10_000), Fee: d(5), Tax: decimal.Zero},
+		{Qty: 7, Price: d(10_100), Fee: d(10), Tax: decimal.Zero},
+	}
+	total := SumFills(fills)
+	applied := SumFills(fills[:1])
+
+	delta := total.Sub(applied)
+	if delta.Qty != 7 || !delta.Amount.Equal(d(70_700)) || !delta.Fee.Equal(d(10)) {
+		t.Errorf("Expected 7 / 70700 / 10, got %+v", delta)
+	}
+}
@@ -17,6 +17,7 @@ const (
 	OrderStatusCancelledPartial = "CANCELLED_PARTIAL" // 부분 체결 후 취소
 	OrderStatusError           = "ERROR"            // 에러
 	OrderStatusUnknown         = "UNKNOWN"          // 알 수 없음
+	OrderStatusReplaced        = "REPLACED"         // 정정됨 (잔량 → 정정주문)
 )
 
 // Order Type
@@ -36,9 +37,36 @@ type Order struct {
 	OpenQty      int64            `json:"open_qty"`       // 미체결 수량
 	FilledQty    int64            `json:"filled_qty"`     // 체결 수량
 	Raw          map[string]any   `json:"raw"`            // KIS API 응답 원본
+	AccountID    string           `json:"account_id"`     // 주문 계좌
 	UpdatedTS    time.Time        `json:"updated_ts"`     // 마지막 갱신
 }
 
+// Order Event Types (주문 하위 이벤트)
+const (
+	OrderEventModify     = "MODIFY"      // 수동 정정
+	OrderEventReprice    = "REPRICE"     // 자동 재호가 (최우선 매수호가 추격)
+	OrderEventConvertMkt = "CONVERT_MKT" // 시장가 전환
+)
+
+// OrderEvent represents a child event of an order (정정 이력)
+// 정정 시 잔량은 NewOrderID로 이관되고 원주문은 REPLACED
+type OrderEvent struct {
+	EventID      uuid.UUID        `json:"event_id"`       // 이벤트 ID (PK)
+	OrderID      string           `json:"order_id"`       // 원주문번호 (FK)
+	NewOrderID   string           `json:"new_order_id"`   // 정정주문번호
+	IntentID     uuid.UUID        `json:"intent_id"`      // 원본 의도 ID
+	EventType    string           `json:"event_type"`     // MODIFY, REPRICE, CONVERT_MKT
+	Attempt      int              `json:"attempt"`        // 재호가 차수 (intent 기준, 1부터)
+	OldOrderType string           `json:"old_order_type"` // 정정 전 주문유형
+	NewOrderType string           `json:"new_order_type"` // 정정 후 주문유형
+	OldPrice     *decimal.Decimal `json:"old_price"`      // 정정 전 가격 (MKT = nil)
+	NewPrice     *decimal.Decimal `json:"new_price"`      // 정정 후 가격 (MKT = nil)
+	Qty          int64            `json:"qty"`            // 정정 수량
+	Reason       string           `json:"reason"`         // 사유
+	Raw          map[string]any   `json:"raw"`            // KIS API 응답 원본
+	CreatedTS    time.Time        `json:"created_ts"`     // 생성 시각
+}
+
 // Fill represents an execution/fill event
 type Fill struct {
 	FillID    string          `json:"fill_id"`     // 체결 고유 ID (PK)
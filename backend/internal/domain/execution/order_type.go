@@ -0,0 +1,149 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// Advanced Order Types (MKT/LMT는 model.go)
+const (
+	OrderTypeConditionalLimit = "COND_LMT"      // 조건부지정가 (미체결 잔량 장마감 동시호가 시장가 전환)
+	OrderTypeBestLimit        = "BEST_LMT"      // 최유리지정가 (상대 최우선호가)
+	OrderTypePriorityLimit    = "PRIO_LMT"      // 최우선지정가 (자기 최우선호가)
+	OrderTypeIOCLimit         = "IOC_LMT"       // IOC 지정가 (즉시 체결, 잔량 취소)
+	OrderTypeFOKLimit         = "FOK_LMT"       // FOK 지정가 (전량 체결 불가 시 전량 취소)
+	OrderTypeIOCMarket        = "IOC_MKT"       // IOC 시장가
+	OrderTypeFOKMarket        = "FOK_MKT"       // FOK 시장가
+	OrderTypeIOCBest          = "IOC_BEST"      // IOC 최유리
+	OrderTypeFOKBest          = "FOK_BEST"      // FOK 최유리
+	OrderTypeClosingAuction   = "CLOSE_AUCTION" // 장마감 동시호가 시장가 (15:20~15:30)
+	OrderTypePreMarketClose   = "PRE_OT"        // 장전 시간외 종가 (전일 종가)
+	OrderTypeAfterHoursClose  = "POST_OT"       // 장후 시간외 종가 (당일 종가)
+	OrderTypeAfterHoursSingle = "AH_SINGLE"     // 시간외 단일가 (지정가, 10분 단위 체결)
+)
+
+// Time in force (IOC/FOK 변형)
+const (
+	TimeInForceDay = ""    // 당일 유효
+	TimeInForceIOC = "IOC" // Immediate or Cancel
+	TimeInForceFOK = "FOK" // Fill or Kill
+)
+
+// OrderTypeSpec 주문유형별 속성
+type OrderTypeSpec struct {
+	Code        string           // 주문유형 코드 (MKT, LMT, ...)
+	Name        string           // 한글명
+	NeedsPrice  bool             // 지정가 필수 여부 (false = 가격 0 전송)
+	Marketable  bool             // 가격 무관 즉시 체결 성격 (시장가/최유리 계열)
+	TimeInForce string           // IOC, FOK ("" = 당일)
+	Phases      []calendar.Phase // 접수 가능 세션 단계
+}
+
+// 정규장 접수 세션 (장마감 동시호가 포함, 장전 동시호가는 기존 동작대로 제외)
+var regularPhases = []calendar.Phase{calendar.PhaseRegular, calendar.PhaseClosingAuction}
+
+// 접속매매 전용 (최유리/최우선/IOC/FOK는 동시호가 시간 접수 불가)
+var continuousPhases = []calendar.Phase{calendar.PhaseRegular}
+
+var orderTypeSpecs = map[string]OrderTypeSpec{
+	OrderTypeMarket:           {Code: OrderTypeMarket, Name: "시장가", Marketable: true, Phases: regularPhases},
+	OrderTypeLimit:            {Code: OrderTypeLimit, Name: "지정가", NeedsPrice: true, Phases: regularPhases},
+	OrderTypeConditionalLimit: {Code: OrderTypeConditionalLimit, Name: "조건부지정가", NeedsPrice: true, Phases: continuousPhases},
+	OrderTypeBestLimit:        {Code: OrderTypeBestLimit, Name: "최유리지정가", Marketable: true, Phases: continuousPhases},
+	OrderTypePriorityLimit:    {Code: OrderTypePriorityLimit, Name: "최우선지정가", Phases: continuousPhases},
+	OrderTypeIOCLimit:         {Code: OrderTypeIOCLimit, Name: "IOC지정가", NeedsPrice: true, TimeInForce: TimeInForceIOC, Phases: continuousPhases},
+	OrderTypeFOKLimit:         {Code: OrderTypeFOKLimit, Name: "FOK지정가", NeedsPrice: true, TimeInForce: TimeInForceFOK, Phases: continuousPhases},
+	OrderTypeIOCMarket:        {Code: OrderTypeIOCMarket, Name: "IOC시장가", Marketable: true, TimeInForce: TimeInForceIOC, Phases: continuousPhases},
+	OrderTypeFOKMarket:        {Code: OrderTypeFOKMarket, Name: "FOK시장가", Marketable: true, TimeInForce: TimeInForceFOK, Phases: continuousPhases},
+	OrderTypeIOCBest:          {Code: OrderTypeIOCBest, Name: "IOC최유리", Marketable: true, TimeInForce: TimeInForceIOC, Phases: continuousPhases},
+	OrderTypeFOKBest:          {Code: OrderTypeFOKBest, Name: "FOK최유리", Marketable: true, TimeInForce: TimeInForceFOK, Phases: continuousPhases},
+	OrderTypeClosingAuction:   {Code: OrderTypeClosingAuction, Name: "장마감동시호가", Marketable: true, Phases: []calendar.Phase{calendar.PhaseClosingAuction}},
+	OrderTypePreMarketClose:   {Code: OrderTypePreMarketClose, Name: "장전시간외", Marketable: true, Phases: []calendar.Phase{calendar.PhasePreOpenAuction}},
+	OrderTypeAfterHoursClose:  {Code: OrderTypeAfterHoursClose, Name: "장후시간외", Marketable: true, Phases: []calendar.Phase{calendar.PhaseAfterHoursClose}},
+	OrderTypeAfterHoursSingle: {Code: OrderTypeAfterHoursSingle, Name: "시간외단일가", NeedsPrice: true, Phases: []calendar.Phase{calendar.PhaseAfterHoursSingle}},
+}
+
+// phaseOrder 세션 진행 순서 (당일 접수 가능 여부 판단용)
+var phaseOrder = map[calendar.Phase]int{
+	calendar.PhasePreOpenAuction:   1,
+	calendar.PhaseRegular:          2,
+	calendar.PhaseClosingAuction:   3,
+	calendar.PhaseAfterHoursClose:  4,
+	calendar.PhaseAfterHoursSingle: 5,
+}
+
+// LookupOrderType returns spec of an order type
+func LookupOrderType(orderType string) (OrderTypeSpec, bool) {
+	spec, ok := orderTypeSpecs[orderType]
+	return spec, ok
+}
+
+// IsValidOrderType checks if order type is supported
+func IsValidOrderType(orderType string) bool {
+	_, ok := orderTypeSpecs[orderType]
+	return ok
+}
+
+// OrderTypeNeedsPrice returns true if order type requires a limit price
+func OrderTypeNeedsPrice(orderType string) bool {
+	return orderTypeSpecs[orderType].NeedsPrice
+}
+
+// OrderTypes returns all supported order type codes
+func OrderTypes() []string {
+	return []string{
+		OrderTypeMarket, OrderTypeLimit, OrderTypeConditionalLimit,
+		OrderTypeBestLimit, OrderTypePriorityLimit,
+		OrderTypeIOCLimit, OrderTypeFOKLimit, OrderTypeIOCMarket, OrderTypeFOKMarket, OrderTypeIOCBest, OrderTypeFOKBest,
+		OrderTypeClosingAuction, OrderTypePreMarketClose, OrderTypeAfterHoursClose, OrderTypeAfterHoursSingle,
+	}
+}
+
+// FlattenOrderType returns the marketable order type for emergency flatten at session phase
+// 정규장/장마감 동시호가 → 시장가, 장후 시간외 → 시간외 종가, 시간외 단일가 → 단일가 지정가
+// 장전/휴장은 시장가로 생성해 정규장 개시 대기 (장전 시간외는 전일 종가 체결이라 제외)
+func FlattenOrderType(phase calendar.Phase) string {
+	switch phase {
+	case calendar.PhaseAfterHoursClose:
+		return OrderTypeAfterHoursClose
+	case calendar.PhaseAfterHoursSingle:
+		return OrderTypeAfterHoursSingle
+	default:
+		return OrderTypeMarket
+	}
+}
+
+// SessionAvailability 주문유형의 현재 세션 접수 가능 여부
+type SessionAvailability string
+
+const (
+	SessionAvailable SessionAvailability = "AVAILABLE" // 지금 접수 가능
+	SessionLater     SessionAvailability = "LATER"     // 당일 이후 세션에서 접수 가능 (대기)
+	SessionMissed    SessionAvailability = "MISSED"    // 당일 접수 세션 종료 (익일 대기)
+)
+
+// CheckSession checks order type availability at session phase
+// PhaseClosed는 장 시작 전/종료 후 구분이 없으므로 LATER (다음 세션 대기)
+func CheckSession(orderType string, phase calendar.Phase) (SessionAvailability, error) {
+	spec, ok := orderTypeSpecs[orderType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidOrderType, orderType)
+	}
+
+	last := 0
+	for _, p := range spec.Phases {
+		if p == phase {
+			return SessionAvailable, nil
+		}
+		if phaseOrder[p] > last {
+			last = phaseOrder[p]
+		}
+	}
+
+	current, ok := phaseOrder[phase]
+	if !ok || current < last {
+		return SessionLater, nil
+	}
+	return SessionMissed, nil
+}
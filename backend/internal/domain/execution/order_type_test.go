@@ -0,0 +1,60 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// TestCheckSession tests order type availability per session phase
+func TestCheckSession(t *testing.T) {
+	tests := []struct {
+		orderType string
+		phase     calendar.Phase
+		want      SessionAvailability
+	}{
+		{OrderTypeLimit, calendar.PhaseRegular, SessionAvailable},
+		{OrderTypeMarket, calendar.PhaseClosingAuction, SessionAvailable},
+		{OrderTypeMarket, calendar.PhasePreOpenAuction, SessionLater},
+		{OrderTypeIOCLimit, calendar.PhaseClosingAuction, SessionMissed},
+		{OrderTypeClosingAuction, calendar.PhaseRegular, SessionLater},
+		{OrderTypeClosingAuction, calendar.PhaseAfterHoursClose, SessionMissed},
+		{OrderTypeAfterHoursSingle, calendar.PhaseAfterHoursSingle, SessionAvailable},
+		{OrderTypePreMarketClose, calendar.PhaseClosed, SessionLater},
+	}
+
+	for _, tt := range tests {
+		got, err := CheckSession(tt.orderType, tt.phase)
+		if err != nil {
+			t.Fatalf("CheckSession(%s, %s) failed: %v", tt.orderType, tt.phase, err)
+		}
+		if got != tt.want {
+			t.Errorf("CheckSession(%s, %s) = %s, want %s", tt.orderType, tt.phase, got, tt.want)
+		}
+	}
+
+	if _, err := CheckSession("STOP", calendar.PhaseRegular); !errors.Is(err, ErrInvalidOrderType) {
+		t.Errorf("Expected ErrInvalidOrderType, got %v", err)
+	}
+}
+
+// TestFlattenOrderType tests flatten order types are accepted in their session
+func TestFlattenOrderType(t *testing.T) {
+	phases := []calendar.Phase{
+		calendar.PhaseRegular,
+		calendar.PhaseClosingAuction,
+		calendar.PhaseAfterHoursClose,
+		calendar.PhaseAfterHoursSingle,
+	}
+	for _, phase := range phases {
+		orderType := FlattenOrderType(phase)
+		if got, err := CheckSession(orderType, phase); err != nil || got != SessionAvailable {
+			t.Errorf("FlattenOrderType(%s) = %s not available (%s, %v)", phase, orderType, got, err)
+		}
+	}
+
+	if got := FlattenOrderType(calendar.PhasePreOpenAuction); got != OrderTypeMarket {
+		t.Errorf("Expected MKT before open, got %s", got)
+	}
+}
@@ -0,0 +1,77 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// NormalizeLimitPrice rounds a limit price onto the KRX tick grid and validates it against today's band
+//
+// - 매수는 내림, 매도는 올림 (요청 가격보다 불리하게 체결되지 않음)
+// - 체결 방향으로 가격제한폭을 벗어나면 상/하한가로 보정 (매수 > 상한가 → 상한가)
+// - 반대 방향으로 벗어나면 당일 체결 불가 → ErrPriceOutOfBand (매도 > 상한가, 매수 < 하한가)
+// - VI 발동 중(단일가)에는 IOC/FOK/최유리/최우선 접수 불가 → ErrVIActive
+// - 거래정지 → ErrTradingHalted
+//
+// band가 nil이면 호가단위 보정만 수행. 시장가 계열은 nil 반환.
+func NormalizeLimitPrice(side, orderType string, limit *decimal.Decimal, band *price.Band) (*decimal.Decimal, error) {
+	spec, ok := LookupOrderType(orderType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOrderType, orderType)
+	}
+
+	if band != nil {
+		if band.Halted {
+			return nil, fmt.Errorf("%w: %s", ErrTradingHalted, band.Symbol)
+		}
+		if band.VIActive && requiresContinuousMatching(spec) {
+			return nil, fmt.Errorf("%w: %s (%s 주문 불가)", ErrVIActive, band.Symbol, orderType)
+		}
+	}
+
+	if !spec.NeedsPrice {
+		return nil, nil
+	}
+	if limit == nil || !limit.IsPositive() {
+		return nil, fmt.Errorf("%s order requires limit price", orderType)
+	}
+
+	market := ""
+	if band != nil {
+		market = band.Market
+	}
+
+	buy := side == SideBuy
+	var p int64
+	if buy {
+		p = price.RoundToTick(market, limit.Floor().IntPart(), false)
+	} else {
+		p = price.RoundToTick(market, limit.Ceil().IntPart(), true)
+	}
+
+	if band != nil && band.Upper > 0 {
+		switch {
+		case buy && p < band.Lower:
+			return nil, fmt.Errorf("%w: 매수 %d < 하한가 %d (당일 체결 불가)", ErrPriceOutOfBand, p, band.Lower)
+		case !buy && p > band.Upper:
+			return nil, fmt.Errorf("%w: 매도 %d > 상한가 %d (당일 체결 불가)", ErrPriceOutOfBand, p, band.Upper)
+		case p > band.Upper:
+			p = band.Upper
+		case p < band.Lower:
+			p = band.Lower
+		}
+	}
+
+	normalized := decimal.NewFromInt(p)
+	return &normalized, nil
+}
+
+// requiresContinuousMatching returns true for order types rejected during single-price auction
+// (IOC/FOK, 최유리/최우선지정가)
+func requiresContinuousMatching(spec OrderTypeSpec) bool {
+	return spec.TimeInForce != TimeInForceDay ||
+		spec.Code == OrderTypeBestLimit ||
+		spec.Code == OrderTypePriorityLimit
+}
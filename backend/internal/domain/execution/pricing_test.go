@@ -0,0 +1,55 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// TestNormalizeLimitPrice tests tick rounding and price band validation
+func TestNormalizeLimitPrice(t *testing.T) {
+	band := price.NewBand("005930", "KOSPI", 10_000) // 상한 13,000 / 하한 7,000
+
+	tests := []struct {
+		name    string
+		side    string
+		limit   int64
+		want    int64
+		wantErr error
+	}{
+		{"sell rounds up", SideSell, 10_003, 10_010, nil},
+		{"buy rounds down", SideBuy, 10_003, 10_000, nil},
+		{"buy above upper clamps", SideBuy, 14_000, 13_000, nil},
+		{"sell below lower clamps", SideSell, 6_000, 7_000, nil},
+		{"sell above upper rejected", SideSell, 13_100, 0, ErrPriceOutOfBand},
+		{"buy below lower rejected", SideBuy, 6_900, 0, ErrPriceOutOfBand},
+	}
+
+	for _, tt := range tests {
+		limit := decimal.NewFromInt(tt.limit)
+		got, err := NormalizeLimitPrice(tt.side, OrderTypeLimit, &limit, band)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !got.Equal(decimal.NewFromInt(tt.want)) {
+			t.Errorf("%s: got %s, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	band.VIActive = true
+	limit := decimal.NewFromInt(10_000)
+	if _, err := NormalizeLimitPrice(SideSell, OrderTypeIOCLimit, &limit, band); !errors.Is(err, ErrVIActive) {
+		t.Errorf("Expected ErrVIActive for IOC during VI, got %v", err)
+	}
+	if _, err := NormalizeLimitPrice(SideSell, OrderTypeLimit, &limit, band); err != nil {
+		t.Errorf("Expected LMT accepted during VI, got %v", err)
+	}
+}
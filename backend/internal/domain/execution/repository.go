@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
 )
 
 // OrderRepository manages order persistence
@@ -38,6 +40,18 @@ type OrderRepository interface {
 	GetRecentOrders(ctx context.Context, limit int) ([]*Order, error)
 }
 
+// OrderEventRepository manages order child events (정정 이력)
+type OrderEventRepository interface {
+	// CreateOrderEvent creates a new order event
+	CreateOrderEvent(ctx context.Context, event *OrderEvent) error
+
+	// LoadOrderEvents loads events of an order (order_id 또는 new_order_id, 시간순)
+	LoadOrderEvents(ctx context.Context, orderID string) ([]*OrderEvent, error)
+
+	// LoadOrderEventsByIntent loads all events of an intent (시간순)
+	LoadOrderEventsByIntent(ctx context.Context, intentID uuid.UUID) ([]*OrderEvent, error)
+}
+
 // FillRepository manages fill persistence
 type FillRepository interface {
 	// UpsertFill creates or updates a fill (idempotent by fill_id)
@@ -52,8 +66,8 @@ type FillRepository interface {
 	// LoadFillsSinceCursor loads fills since cursor (for sync)
 	LoadFillsSinceCursor(ctx context.Context, cursor FillCursor) ([]*Fill, error)
 
-	// GetLastCursor retrieves the last sync cursor
-	GetLastCursor(ctx context.Context) (*FillCursor, error)
+	// GetLastCursor retrieves the last sync cursor of an account
+	GetLastCursor(ctx context.Context, accountID string) (*FillCursor, error)
 
 	// SaveCursor saves the sync cursor
 	SaveCursor(ctx context.Context, cursor FillCursor) error
@@ -62,6 +76,30 @@ type FillRepository interface {
 	GetRecentFills(ctx context.Context, limit int) ([]*Fill, error)
 }
 
+// LedgerRepository manages the fill-driven position ledger
+type LedgerRepository interface {
+	// AppendEntry inserts a ledger entry and applies qty_after/avg_cost_after to trade.positions (single tx)
+	AppendEntry(ctx context.Context, entry *LedgerEntry) error
+
+	// LoadAppliedTotals loads fill totals already applied for an order (주문별 원장 반영 누적, untracked fill 포함)
+	LoadAppliedTotals(ctx context.Context, orderID string) (FillTotals, error)
+
+	// RecordUntrackedFill records a fill increment handled without a ledger position (원장 포지션 없는 매도)
+	RecordUntrackedFill(ctx context.Context, accountID, symbol, orderID string, delta FillTotals, ts time.Time) error
+
+	// LoadEntries loads ledger entries for a position since ts (ordered by ts)
+	LoadEntries(ctx context.Context, positionID uuid.UUID, since time.Time) ([]*LedgerEntry, error)
+
+	// RaiseDiscrepancy records a discrepancy (idempotent per account/symbol/kind while unresolved)
+	RaiseDiscrepancy(ctx context.Context, d *LedgerDiscrepancy) error
+
+	// ResolveDiscrepancies marks unresolved discrepancies of a symbol as resolved
+	ResolveDiscrepancies(ctx context.Context, accountID, symbol string) error
+
+	// LoadOpenDiscrepancies loads unresolved discrepancies
+	LoadOpenDiscrepancies(ctx context.Context, accountID string) ([]*LedgerDiscrepancy, error)
+}
+
 // HoldingRepository manages holding persistence
 type HoldingRepository interface {
 	// UpsertHolding creates or updates a holding (idempotent by account_id, symbol)
@@ -100,6 +138,10 @@ type ExitEventRepository interface {
 	// ExitEventExists checks if an exit event exists for a position
 	ExitEventExists(ctx context.Context, positionID uuid.UUID) (bool, error)
 
+	// ExitEventExistsSince checks if an exit event exists for a position lifecycle (exit_ts >= entry_ts)
+	// position_id는 (account_id, symbol) 재오픈 시 재사용 → 멱등성은 lifecycle(entry_ts) 기준
+	ExitEventExistsSince(ctx context.Context, positionID uuid.UUID, entryTS time.Time) (bool, error)
+
 	// LoadExitEventsSince loads exit events since timestamp
 	LoadExitEventsSince(ctx context.Context, since time.Time) ([]*ExitEvent, error)
 }
@@ -119,6 +161,13 @@ type IntentReader interface {
 	UpdateIntentStatus(ctx context.Context, intentID uuid.UUID, status string) error
 }
 
+// RebalanceSellChecker checks rebalance plan sell progress (REBALANCE_BUY 제출 게이트)
+type RebalanceSellChecker interface {
+	// HasOpenRebalanceSells checks if a rebalance plan still has SELL intents not yet filled
+	// (승인 대기/NEW/ACK, 또는 제출 후 미체결 주문) - 거부/취소/실패는 제외
+	HasOpenRebalanceSells(ctx context.Context, planID string) (bool, error)
+}
+
 // Intent Status (Execution-specific additions)
 const (
 	IntentStatusSubmitted = "SUBMITTED" // 제출됨
@@ -126,9 +175,11 @@ const (
 	IntentStatusDuplicate = "DUPLICATE" // 중복 (이미 제출됨)
 )
 
-// Intent Type for ENTRY (Reentry Engine)
+// Intent Types (Execution-side, EXIT_* are owned by Exit Engine)
 const (
-	IntentTypeEntry = "ENTRY" // 진입 (재진입)
+	IntentTypeEntry         = "ENTRY"          // 진입 (재진입)
+	IntentTypeRebalanceBuy  = "REBALANCE_BUY"  // 리밸런싱 매수
+	IntentTypeRebalanceSell = "REBALANCE_SELL" // 리밸런싱 매도
 )
 
 // PositionReader is a read-only interface for positions (owned by Strategy)
@@ -145,3 +196,24 @@ type AuditTradeWriter interface {
 	// SaveExitTrade saves a trade to audit.trade_history when an exit event is created
 	SaveExitTrade(ctx context.Context, event *ExitEvent, entryDate time.Time) error
 }
+
+// EntryFillHandler is an optional hook called when an ENTRY order is fully filled
+// (after the new position is opened)
+type EntryFillHandler interface {
+	// OnEntryFilled handles a filled ENTRY intent (e.g. Reentry candidate → ENTERED)
+	OnEntryFilled(ctx context.Context, intent *exit.OrderIntent, position *exit.Position) error
+}
+
+// RiskGate is an optional pre-trade check called before every order submission
+// error = 검증 불가 (제출 보류), Approved=false = 한도 위반 (주문 거부)
+type RiskGate interface {
+	// CheckOrder validates an order against risk limits
+	CheckOrder(ctx context.Context, req risk.RiskCheckRequest) (*risk.RiskCheckResult, error)
+}
+
+// PriceGuard is an optional limit price check called before every order submission
+// 호가단위 보정 + 가격제한폭/VI 검증 (ErrPriceOutOfBand/ErrTradingHalted/ErrVIActive)
+type PriceGuard interface {
+	// NormalizeLimitPrice returns tick-aligned limit price (시장가 계열은 nil)
+	NormalizeLimitPrice(ctx context.Context, symbol, side, orderType string, limit *decimal.Decimal) (*decimal.Decimal, error)
+}
@@ -0,0 +1,80 @@
+package execution
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// RepricePolicy 미체결 LMT 청산 주문 자동 재호가 정책
+// Timeout 동안 미체결 → 최우선 매수호가 - ChaseTicks 틱으로 정정 (최대 MaxAttempts회)
+// → 이후에도 미체결이면 시장가 전환 (ConvertToMarket)
+// 호가 공백 등으로 정정하지 못한 대기 구간도 시도로 계산 → 최대 Timeout × (MaxAttempts+1) 후 시장가
+type RepricePolicy struct {
+	Enabled         bool          // 자동 재호가 사용 여부
+	Timeout         time.Duration // 제출(또는 직전 정정) 후 대기 시간
+	ChaseTicks      int           // 매수호가 대비 추격 틱 수 (0 = 매수호가)
+	MaxAttempts     int           // 지정가 재호가 최대 횟수
+	ConvertToMarket bool          // 재호가 소진 후 시장가 전환
+}
+
+// DefaultRepricePolicy returns default reprice policy (opt-in: 시장가 전환은 명시적으로 활성화)
+func DefaultRepricePolicy() RepricePolicy {
+	return RepricePolicy{
+		Enabled:         false,
+		Timeout:         30 * time.Second,
+		ChaseTicks:      0,
+		MaxAttempts:     2,
+		ConvertToMarket: true,
+	}
+}
+
+// RepriceAction 재호가 판단 결과
+type RepriceAction string
+
+const (
+	RepriceNone   RepriceAction = ""            // 유지
+	RepriceLimit  RepriceAction = "REPRICE"     // 지정가 정정
+	RepriceMarket RepriceAction = "CONVERT_MKT" // 시장가 전환
+)
+
+// RepriceDecision 재호가 판단 (Action=RepriceLimit이면 Price 설정)
+type RepriceDecision struct {
+	Action RepriceAction
+	Price  decimal.Decimal
+}
+
+// Decide decides next action for an unfilled sell LMT order
+// attempts: 지금까지 지정가 재호가 횟수, elapsed: 현재 주문(원주문 또는 직전 정정) 제출 후 경과 시간,
+// current: 현재 지정가, bestBid: 최우선 매수호가 (0 = 없음)
+func (p RepricePolicy) Decide(attempts int, elapsed time.Duration, current decimal.Decimal, bestBid int64) RepriceDecision {
+	if !p.Enabled {
+		return RepriceDecision{Action: RepriceNone}
+	}
+
+	// 첫 Timeout 이후 정정 없이 지난 Timeout마다 시도 1회 소진 (새 가격 없음/이미 매수호가 이하로 대기한 구간)
+	if p.Timeout > 0 && elapsed > p.Timeout {
+		attempts += int(elapsed/p.Timeout) - 1
+	}
+
+	if attempts >= p.MaxAttempts {
+		if p.ConvertToMarket {
+			return RepriceDecision{Action: RepriceMarket}
+		}
+		return RepriceDecision{Action: RepriceNone}
+	}
+
+	// 호가 없음: 추격 기준이 없으므로 대기 (대기 시간은 elapsed로 시도에 반영)
+	if bestBid <= 0 {
+		return RepriceDecision{Action: RepriceNone}
+	}
+
+	target := decimal.NewFromInt(price.AddTicks(bestBid, -p.ChaseTicks))
+	if !target.LessThan(current) {
+		// 이미 매수호가 이하로 걸려 있음 (체결 대기 중) → 유지
+		return RepriceDecision{Action: RepriceNone}
+	}
+
+	return RepriceDecision{Action: RepriceLimit, Price: target}
+}
@@ -0,0 +1,61 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestRepricePolicyDecide tests bid chase → market conversion sequence
+func TestRepricePolicyDecide(t *testing.T) {
+	policy := RepricePolicy{Enabled: true, Timeout: 30 * time.Second, ChaseTicks: 1, MaxAttempts: 2, ConvertToMarket: true}
+	stale := 30 * time.Second
+	limit := decimal.NewFromInt(10300)
+
+	d := policy.Decide(0, stale, limit, 10100)
+	if d.Action != RepriceLimit || !d.Price.Equal(decimal.NewFromInt(10090)) {
+		t.Errorf("Expected REPRICE @ 10090 (bid - 1 tick), got %s @ %s", d.Action, d.Price)
+	}
+
+	// 경계: 5000 - 1 tick = 4995 (아래 구간 호가단위)
+	d = policy.Decide(1, stale, limit, 5000)
+	if d.Action != RepriceLimit || !d.Price.Equal(decimal.NewFromInt(4995)) {
+		t.Errorf("Expected REPRICE @ 4995, got %s @ %s", d.Action, d.Price)
+	}
+
+	// 이미 매수호가 이하 → 유지
+	if d := policy.Decide(1, stale, decimal.NewFromInt(10000), 10100); d.Action != RepriceNone {
+		t.Errorf("Expected no action when limit already below target, got %s", d.Action)
+	}
+
+	// 호가 없음 → 유지
+	if d := policy.Decide(0, stale, limit, 0); d.Action != RepriceNone {
+		t.Errorf("Expected no action without bid, got %s", d.Action)
+	}
+
+	// 호가 없음 / 이미 매수호가 이하로 Timeout이 반복 경과 → 대기 구간도 시도로 계산, 결국 시장가
+	if d := policy.Decide(0, 2*stale, limit, 0); d.Action != RepriceNone {
+		t.Errorf("Expected no action after 2 timeouts (1 attempt consumed), got %s", d.Action)
+	}
+	if d := policy.Decide(0, 3*stale, limit, 0); d.Action != RepriceMarket {
+		t.Errorf("Expected CONVERT_MKT after 3 timeouts without bid, got %s", d.Action)
+	}
+	if d := policy.Decide(1, 2*stale, decimal.NewFromInt(10000), 10100); d.Action != RepriceMarket {
+		t.Errorf("Expected CONVERT_MKT when resting below bid past budget, got %s", d.Action)
+	}
+
+	// 재호가 소진 → 시장가
+	if d := policy.Decide(2, stale, limit, 10100); d.Action != RepriceMarket {
+		t.Errorf("Expected CONVERT_MKT after max attempts, got %s", d.Action)
+	}
+
+	policy.ConvertToMarket = false
+	if d := policy.Decide(2, stale, limit, 10100); d.Action != RepriceNone {
+		t.Errorf("Expected no action when market conversion disabled, got %s", d.Action)
+	}
+
+	if d := (RepricePolicy{}).Decide(0, stale, limit, 10100); d.Action != RepriceNone {
+		t.Errorf("Expected disabled policy to do nothing, got %s", d.Action)
+	}
+}
@@ -0,0 +1,135 @@
+package exit
+
+import (
+	"fmt"
+	"time"
+)
+
+// Approval Modes (자동 청산 intent 승인 정책)
+const (
+	ApprovalModeAuto           = "AUTO"            // 즉시 NEW (승인 생략)
+	ApprovalModeManual         = "MANUAL"          // 승인 대기 (무기한)
+	ApprovalModeTimeoutApprove = "TIMEOUT_APPROVE" // 승인 대기, timeout 경과 시 자동 승인
+	ApprovalModeTimeoutCancel  = "TIMEOUT_CANCEL"  // 승인 대기, timeout 경과 시 자동 취소
+)
+
+// Decided By (intent 승인/거부 주체)
+// API 사용자 이름은 인증 없이 호출자가 주장한 값 → "user:" 접두어로 시스템 주체와 구분해 기록
+const (
+	DecidedByPolicy      = "policy"            // 생성 시 AUTO 정책
+	DecidedByTimeout     = "policy:timeout"    // 승인 대기 timeout
+	DecidedByKillSwitch  = "EMERGENCY_FLATTEN" // Kill switch 전량 청산
+	DecidedByDefaultUser = "user"              // API 호출자 미지정
+	DecidedByUserPrefix  = "user:"             // API 호출자 주장 이름 (caller-asserted, 미인증)
+)
+
+// ApprovalRule decides how an intent is approved
+type ApprovalRule struct {
+	Mode           string `json:"mode"`                      // AUTO | MANUAL | TIMEOUT_APPROVE | TIMEOUT_CANCEL
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // TIMEOUT_* 전용
+}
+
+// ApprovalPolicy represents approval rules of a level (default profile / profile / symbol override)
+// 레벨 내 우선순위: control_modes > reasons > default
+type ApprovalPolicy struct {
+	Default      *ApprovalRule           `json:"default,omitempty"`       // reason 규칙 없을 때
+	Reasons      map[string]ApprovalRule `json:"reasons,omitempty"`       // reason code → rule (SL2, TP1, CUSTOM ...)
+	ControlModes map[string]ApprovalRule `json:"control_modes,omitempty"` // control mode → rule (예: PAUSE_PROFIT 중 전부 MANUAL)
+}
+
+// ApprovalLevel is a named policy level for resolution (구체적인 레벨부터)
+type ApprovalLevel struct {
+	Name   string // symbol:005930 | profile:aggressive | profile:default
+	Policy *ApprovalPolicy
+}
+
+// ApprovalDecision represents resolved approval of an intent
+type ApprovalDecision struct {
+	Mode    string
+	Timeout time.Duration
+	Source  string // 결정한 정책 (예: profile:default/reasons.SL2)
+}
+
+// InitialStatus returns intent status at creation (AUTO → NEW, 나머지 → PENDING_APPROVAL)
+func (d ApprovalDecision) InitialStatus() string {
+	if d.Mode == ApprovalModeAuto {
+		return IntentStatusNew
+	}
+	return IntentStatusPendingApproval
+}
+
+// Deadline returns approval deadline for timeout modes (nil = 무기한/즉시)
+func (d ApprovalDecision) Deadline(now time.Time) *time.Time {
+	if d.Mode != ApprovalModeTimeoutApprove && d.Mode != ApprovalModeTimeoutCancel {
+		return nil
+	}
+	deadline := now.Add(d.Timeout)
+	return &deadline
+}
+
+// ResolveApproval resolves approval of a reason code under a control mode
+// 구체적인 레벨(symbol > profile > default profile)의 첫 매칭 규칙 적용, 매칭 없으면 MANUAL (기존 동작)
+func ResolveApproval(levels []ApprovalLevel, reasonCode, controlMode string) ApprovalDecision {
+	for _, level := range levels {
+		if level.Policy == nil {
+			continue
+		}
+		if rule, ok := level.Policy.ControlModes[controlMode]; ok {
+			return rule.decision(level.Name + "/control_modes." + controlMode)
+		}
+		if rule, ok := level.Policy.Reasons[reasonCode]; ok {
+			return rule.decision(level.Name + "/reasons." + reasonCode)
+		}
+		if level.Policy.Default != nil {
+			return level.Policy.Default.decision(level.Name + "/default")
+		}
+	}
+	return ApprovalDecision{Mode: ApprovalModeManual, Source: "fallback"}
+}
+
+func (r ApprovalRule) decision(source string) ApprovalDecision {
+	return ApprovalDecision{
+		Mode:    r.Mode,
+		Timeout: time.Duration(r.TimeoutSeconds) * time.Second,
+		Source:  source,
+	}
+}
+
+// Validate validates approval policy modes and timeouts
+func (p *ApprovalPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Default != nil {
+		if err := p.Default.validate(); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+	for reason, rule := range p.Reasons {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("reasons.%s: %w", reason, err)
+		}
+	}
+	for mode, rule := range p.ControlModes {
+		if _, ok := controlModeRank[mode]; !ok {
+			return fmt.Errorf("control_modes: unknown control mode %q", mode)
+		}
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("control_modes.%s: %w", mode, err)
+		}
+	}
+	return nil
+}
+
+func (r ApprovalRule) validate() error {
+	switch r.Mode {
+	case ApprovalModeAuto, ApprovalModeManual:
+		return nil
+	case ApprovalModeTimeoutApprove, ApprovalModeTimeoutCancel:
+		if r.TimeoutSeconds <= 0 {
+			return fmt.Errorf("%s requires timeout_seconds > 0", r.Mode)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown approval mode %q", r.Mode)
+}
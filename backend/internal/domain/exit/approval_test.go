@@ -0,0 +1,85 @@
+package exit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestResolveApproval tests approval resolution across levels, reasons and control modes
+func TestResolveApproval(t *testing.T) {
+	profile := &ApprovalPolicy{
+		Default: &ApprovalRule{Mode: ApprovalModeManual},
+		Reasons: map[string]ApprovalRule{
+			ReasonSL2: {Mode: ApprovalModeAuto},
+			ReasonTP1: {Mode: ApprovalModeTimeoutApprove, TimeoutSeconds: 60},
+		},
+		ControlModes: map[string]ApprovalRule{
+			ControlModePauseProfit: {Mode: ApprovalModeManual},
+		},
+	}
+	symbol := &ApprovalPolicy{
+		Reasons: map[string]ApprovalRule{
+			ReasonTP1: {Mode: ApprovalModeTimeoutCancel, TimeoutSeconds: 30},
+		},
+	}
+	levels := []ApprovalLevel{
+		{Name: "symbol:005930", Policy: symbol},
+		{Name: "profile:default", Policy: profile},
+	}
+
+	tests := []struct {
+		name        string
+		reason      string
+		controlMode string
+		wantMode    string
+		wantTimeout time.Duration
+		wantSource  string
+	}{
+		{"symbol override wins", ReasonTP1, ControlModeRunning, ApprovalModeTimeoutCancel, 30 * time.Second, "symbol:005930/reasons.TP1"},
+		{"profile reason", ReasonSL2, ControlModeRunning, ApprovalModeAuto, 0, "profile:default/reasons.SL2"},
+		{"profile default", ReasonTP2, ControlModeRunning, ApprovalModeManual, 0, "profile:default/default"},
+		{"control mode over reason", ReasonSL2, ControlModePauseProfit, ApprovalModeManual, 0, "profile:default/control_modes.PAUSE_PROFIT"},
+	}
+
+	for _, tt := range tests {
+		got := ResolveApproval(levels, tt.reason, tt.controlMode)
+		if got.Mode != tt.wantMode || got.Timeout != tt.wantTimeout || got.Source != tt.wantSource {
+			t.Errorf("%s: got %+v, want mode=%s timeout=%s source=%s", tt.name, got, tt.wantMode, tt.wantTimeout, tt.wantSource)
+		}
+	}
+
+	// 정책 없음 → 기존 동작 (수동 승인)
+	fallback := ResolveApproval([]ApprovalLevel{{Name: "profile:default"}}, ReasonSL2, ControlModeRunning)
+	if fallback.Mode != ApprovalModeManual || fallback.InitialStatus() != IntentStatusPendingApproval {
+		t.Errorf("Expected MANUAL fallback, got %+v", fallback)
+	}
+
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	timeout := ApprovalDecision{Mode: ApprovalModeTimeoutApprove, Timeout: time.Minute}
+	if deadline := timeout.Deadline(now); deadline == nil || !deadline.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected deadline %s, got %v", now.Add(time.Minute), deadline)
+	}
+	auto := ApprovalDecision{Mode: ApprovalModeAuto}
+	if auto.InitialStatus() != IntentStatusNew || auto.Deadline(now) != nil {
+		t.Errorf("Expected AUTO → NEW without deadline")
+	}
+}
+
+// TestApprovalPolicyValidate tests approval policy validation
+func TestApprovalPolicyValidate(t *testing.T) {
+	var nilPolicy *ApprovalPolicy
+	if err := nilPolicy.Validate(); err != nil {
+		t.Errorf("Expected nil policy valid, got %v", err)
+	}
+
+	invalid := []*ApprovalPolicy{
+		{Default: &ApprovalRule{Mode: "SOMETIMES"}},
+		{Reasons: map[string]ApprovalRule{ReasonTP1: {Mode: ApprovalModeTimeoutApprove}}},
+		{ControlModes: map[string]ApprovalRule{"HALT": {Mode: ApprovalModeManual}}},
+	}
+	for i, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected invalid policy #%d to fail validation", i)
+		}
+	}
+}
@@ -21,8 +21,9 @@ var (
 	ErrPriceNotAvailable = errors.New("price not available")
 
 	// Profile errors
-	ErrProfileNotFound = errors.New("exit profile not found")
-	ErrInvalidProfile  = errors.New("invalid exit profile configuration")
+	ErrProfileNotFound  = errors.New("exit profile not found")
+	ErrInvalidProfile   = errors.New("invalid exit profile configuration")
+	ErrOverrideNotFound = errors.New("override not found")
 
 	// Intent errors
 	ErrIntentExists    = errors.New("intent already exists (idempotent)")
@@ -53,7 +53,10 @@ type PositionState struct {
 	Phase          string           `json:"phase"` // FSM phase
 	HWMPrice       *decimal.Decimal `json:"hwm_price"`        // High-Water Mark
 	StopFloorPrice *decimal.Decimal `json:"stop_floor_price"` // Stop Floor (breakeven protect)
-	ATR            *decimal.Decimal `json:"atr"`              // ATR (cached, daily)
+	TrailingStopPrice *decimal.Decimal `json:"trailing_stop_price"` // 현재 trailing stop (trailing phase, UI stop line)
+	ATR            *decimal.Decimal `json:"atr"`              // ATR% = ATR(N) / 전일 종가 (cached, daily)
+	ATRWon         *decimal.Decimal `json:"atr_won"`          // ATR(N) 원 단위 (chandelier trailing stop)
+	ATRUpdatedTS   *time.Time       `json:"atr_updated_ts"`   // ATR 계산 시각 (daily ATR job)
 	CooldownUntil  *time.Time       `json:"cooldown_until"`   // Re-entry cooldown
 	LastEvalTS            *time.Time       `json:"last_eval_ts"`
 	LastAvgPrice          *decimal.Decimal `json:"last_avg_price"` // 마지막 평단가 (추가매수 감지용)
@@ -77,8 +80,10 @@ const (
 // ====================
 
 // ExitControl represents global exit control (kill switch)
+// AccountID != "" → 계좌별 control (trade.account_exit_control)
 type ExitControl struct {
 	ID        int       `json:"id"` // Always 1 (singleton)
+	AccountID string    `json:"account_id,omitempty"`
 	Mode      string    `json:"mode"`
 	Reason    *string   `json:"reason"`
 	UpdatedBy string    `json:"updated_by"`
@@ -93,6 +98,49 @@ const (
 	ControlModeEmergencyFlatten = "EMERGENCY_FLATTEN" // Force close all (optional)
 )
 
+// controlModeRank 제한 강도 (높을수록 우선)
+var controlModeRank = map[string]int{
+	ControlModeRunning:          0,
+	ControlModePauseProfit:      1,
+	ControlModePauseAll:         2,
+	ControlModeEmergencyFlatten: 3,
+}
+
+// EffectiveControlMode combines global and account control modes (더 강한 제한 우선)
+// accountMode "" → global mode
+func EffectiveControlMode(globalMode, accountMode string) string {
+	if controlModeRank[accountMode] > controlModeRank[globalMode] {
+		return accountMode
+	}
+	return globalMode
+}
+
+// FlattenProgress represents EMERGENCY_FLATTEN progress (전량 청산 진행 현황)
+type FlattenProgress struct {
+	Active             bool                      `json:"active"`                // 대상 계좌 중 EMERGENCY_FLATTEN 유효 모드 존재
+	AccountID          string                    `json:"account_id,omitempty"`  // "" → 전체 계좌
+	StartedTS          *time.Time                `json:"started_ts,omitempty"`  // 가장 이른 flatten 전환 시각
+	Phase              string                    `json:"phase"`                 // 현재 KRX 세션 단계
+	OrderType          string                    `json:"order_type"`            // 현재 세션 청산 주문유형
+	RemainingPositions int                       `json:"remaining_positions"`
+	RemainingQty       int64                     `json:"remaining_qty"`
+	Complete           bool                      `json:"complete"`              // Active && 잔여 포지션 0
+	Positions          []FlattenPositionProgress `json:"positions"`
+}
+
+// FlattenPositionProgress represents flatten progress of a remaining position
+type FlattenPositionProgress struct {
+	PositionID   uuid.UUID  `json:"position_id"`
+	AccountID    string     `json:"account_id"`
+	Symbol       string     `json:"symbol"`
+	Qty          int64      `json:"qty"`      // 잔여 수량
+	Attempts     int        `json:"attempts"` // 생성된 flatten intent 수
+	IntentID     *uuid.UUID `json:"intent_id,omitempty"`
+	IntentStatus string     `json:"intent_status,omitempty"` // 최근 flatten intent 상태
+	OrderType    string     `json:"order_type,omitempty"`
+	Stuck        bool       `json:"stuck"` // 재시도 한도 초과 (수동 개입 필요)
+}
+
 // ====================
 // ExitProfile (trade.exit_profiles)
 // ====================
@@ -131,8 +179,18 @@ type ExitProfileConfig struct {
 
 	// Custom Rules
 	CustomRules []CustomExitRule `json:"custom_rules,omitempty"`
+
+	// Intent 승인 정책 (nil → 상위 레벨 / MANUAL)
+	Approval *ApprovalPolicy `json:"approval,omitempty"`
 }
 
+// ATR Status (position state API)
+const (
+	ATRStatusOK      = "OK"
+	ATRStatusMissing = "MISSING" // ATR 미계산 → factor 1.0
+	ATRStatusStale   = "STALE"   // 직전 거래일 이후 미갱신
+)
+
 type ATRConfig struct {
 	Ref       float64 `json:"ref"`        // Reference ATR% (e.g., 0.02 = 2%)
 	FactorMin float64 `json:"factor_min"` // Min factor (e.g., 0.7)
@@ -146,33 +204,96 @@ type TriggerConfig struct {
 	QtyPct           float64  `json:"qty_pct"`            // Qty % to exit (e.g., 0.25 = 25%)
 	StopFloorProfit  *float64 `json:"stop_floor_profit"`  // Stop floor profit % (TP1 only)
 	StartTrailing    bool     `json:"start_trailing"`     // Start trailing after hit (TP3 only)
+	OrderType        string   `json:"order_type,omitempty"` // 주문유형 override (빈 값 = 규칙 기본값, 예: COND_LMT, IOC_LMT)
 }
 
 type TrailingConfig struct {
 	PctTrail float64 `json:"pct_trail"` // % trail (e.g., 0.04 = 4%)
 	ATRK     float64 `json:"atr_k"`     // ATR multiplier (e.g., 2.0)
+	Mode     string  `json:"mode,omitempty"` // PCT | ATR | TIGHTER | LOOSER (빈 값 = PCT)
+	OrderType string `json:"order_type,omitempty"` // 주문유형 override (빈 값 = MKT)
 }
 
+// Trailing Modes
+// ATR = position_state.atr_won (일봉 ATR14, 원 단위) (chandelier: HWM - ATRK × ATR)
+const (
+	TrailModePct     = "PCT"     // HWM × (1 - PctTrail)
+	TrailModeATR     = "ATR"     // HWM - ATRK × ATR (ATR 미계산 → PCT)
+	TrailModeTighter = "TIGHTER" // PCT/ATR 중 높은 stop (청산 빠름)
+	TrailModeLooser  = "LOOSER"  // PCT/ATR 중 낮은 stop (여유 폭)
+)
+
 type TimeStopConfig struct {
-	MaxHoldDays       int     `json:"max_hold_days"`        // Max hold days (e.g., 10)
-	NoMomentumDays    int     `json:"no_momentum_days"`     // No momentum days (e.g., 3)
+	MaxHoldDays       int     `json:"max_hold_days"`        // Max hold trading days (e.g., 10, 주말/휴장일 제외)
+	NoMomentumDays    int     `json:"no_momentum_days"`     // No momentum trading days (e.g., 3)
 	NoMomentumProfit  float64 `json:"no_momentum_profit"`   // No momentum profit % (e.g., 0.02)
+	OrderType         string  `json:"order_type,omitempty"` // 주문유형 override (예: CLOSE_AUCTION = 장마감 동시호가 청산)
 }
 
 type HardStopConfig struct {
 	Enabled bool    `json:"enabled"` // Always on (even in PAUSE_ALL)
 	Pct     float64 `json:"pct"`     // HardStop % (e.g., -0.10 = -10%)
+	OrderType string `json:"order_type,omitempty"` // 주문유형 override (빈 값 = MKT)
 }
 
+// Custom Rule Conditions
+const (
+	CustomConditionProfitAbove = "profit_above"
+	CustomConditionProfitBelow = "profit_below"
+	CustomConditionExpression  = "expression" // Expression 평가 (rule_expr.go)
+)
+
 // CustomExitRule represents a user-defined exit condition
 type CustomExitRule struct {
 	ID          string  `json:"id"`           // UUID for frontend tracking
 	Enabled     bool    `json:"enabled"`      // On/Off toggle
-	Condition   string  `json:"condition"`    // "profit_above" | "profit_below"
+	Condition   string  `json:"condition"`    // "profit_above" | "profit_below" | "expression"
 	Threshold   float64 `json:"threshold"`    // % threshold (e.g., 7.0 for +7%)
+	Expression  string  `json:"expression,omitempty"` // condition=expression (예: "time >= 14:50 AND pnl_pct < 1 AND days_held >= 3")
 	ExitPercent float64 `json:"exit_percent"` // % of position to exit (e.g., 20.0)
 	Priority    int     `json:"priority"`     // Evaluation order (0-indexed)
 	Description string  `json:"description"`  // Optional user note
+	OrderType   string  `json:"order_type,omitempty"` // 주문유형 override (빈 값 = MKT)
+}
+
+// RuleOrderType returns configured order type override for a trigger reason ("" = 규칙 기본값)
+// CUSTOM은 규칙별 설정이므로 evaluateCustomRules에서 직접 적용
+func (c ExitProfileConfig) RuleOrderType(reasonCode string) string {
+	switch reasonCode {
+	case ReasonSL1:
+		return c.SL1.OrderType
+	case ReasonSL2:
+		return c.SL2.OrderType
+	case ReasonTP1:
+		return c.TP1.OrderType
+	case ReasonTP2:
+		return c.TP2.OrderType
+	case ReasonTP3:
+		return c.TP3.OrderType
+	case ReasonTrail, ReasonTrailPartial:
+		return c.Trailing.OrderType
+	case ReasonTime:
+		return c.TimeStop.OrderType
+	case ReasonHardStop:
+		return c.HardStop.OrderType
+	}
+	return ""
+}
+
+// OrderTypeOverrides returns all configured order type overrides (rule → order type, 검증용)
+func (c ExitProfileConfig) OrderTypeOverrides() map[string]string {
+	overrides := make(map[string]string)
+	for _, reason := range []string{ReasonSL1, ReasonSL2, ReasonTP1, ReasonTP2, ReasonTP3, ReasonTrail, ReasonTime, ReasonHardStop} {
+		if ot := c.RuleOrderType(reason); ot != "" {
+			overrides[reason] = ot
+		}
+	}
+	for _, rule := range c.CustomRules {
+		if rule.OrderType != "" {
+			overrides[ReasonCustom+":"+rule.ID] = rule.OrderType
+		}
+	}
+	return overrides
 }
 
 // ====================
@@ -185,15 +306,23 @@ type OrderIntent struct {
 	PositionID   uuid.UUID        `json:"position_id"`
 	Symbol       string           `json:"symbol"`
 	SymbolName   string           `json:"symbol_name"`   // 종목명
-	IntentType   string           `json:"intent_type"`   // EXIT_PARTIAL | EXIT_FULL
+	IntentType   string           `json:"intent_type"`   // EXIT_PARTIAL | EXIT_FULL | ENTRY | REBALANCE_BUY | REBALANCE_SELL
 	Qty          int64            `json:"qty"`
-	OrderType    string           `json:"order_type"`    // MKT | LMT
+	OrderType    string           `json:"order_type"`    // MKT | LMT | COND_LMT | IOC_LMT | CLOSE_AUCTION ... (execution 주문유형)
 	LimitPrice   *decimal.Decimal `json:"limit_price"`
 	ReasonCode   string           `json:"reason_code"`   // SL1 | SL2 | TP1 | TP2 | TP3 | TRAIL | TIME | MANUAL | CUSTOM
 	ReasonDetail string           `json:"reason_detail"` // 상세 사유 (예: "+4%/10% 익절")
 	ActionKey    string           `json:"action_key"`    // {position_id}:SL1 (unique)
 	Status       string           `json:"status"`        // NEW | ACK | REJECTED | FILLED
+	AccountID    string           `json:"account_id"`    // 주문 계좌 ("" → 기본 계좌)
 	CreatedTS    time.Time        `json:"created_ts"`
+
+	// 승인 정책 결정 (AUTO | MANUAL | TIMEOUT_APPROVE | TIMEOUT_CANCEL)
+	ApprovalMode     string     `json:"approval_mode,omitempty"`
+	ApprovalSource   string     `json:"approval_source,omitempty"`   // 결정한 정책 (예: profile:default/reasons.SL2)
+	ApprovalDeadline *time.Time `json:"approval_deadline,omitempty"` // TIMEOUT_* 만료 시각
+	DecidedBy        string     `json:"decided_by,omitempty"`        // 승인/거부 주체 (policy, policy:timeout, EMERGENCY_FLATTEN, user[:이름])
+	DecidedTS        *time.Time `json:"decided_ts,omitempty"`
 }
 
 // Intent Types
@@ -222,6 +351,7 @@ const (
 	ReasonHardStop      = "HARDSTOP"
 	ReasonStopFloor     = "STOP_FLOOR"
 	ReasonCustom        = "CUSTOM"         // Custom exit rules
+	ReasonEmergencyFlatten = "EMERGENCY_FLATTEN" // Kill switch 전량 청산 (승인 생략)
 )
 
 // Intent Status
@@ -276,6 +406,7 @@ type SymbolExitOverride struct {
 	Enabled       bool       `json:"enabled"`
 	EffectiveFrom *time.Time `json:"effective_from"`
 	Reason        string     `json:"reason"`
+	Approval      *ApprovalPolicy `json:"approval,omitempty"` // 종목별 승인 정책 (프로필 정책보다 우선)
 	CreatedBy     string     `json:"created_by"`
 	CreatedTS     time.Time  `json:"created_ts"`
 }
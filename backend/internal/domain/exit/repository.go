@@ -2,6 +2,7 @@ package exit
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -34,6 +35,12 @@ type PositionRepository interface {
 
 	// GetAvailableQty calculates available qty (position qty - locked qty from pending orders)
 	GetAvailableQty(ctx context.Context, positionID uuid.UUID) (int64, error)
+
+	// OpenPosition opens a position from an ENTRY fill
+	// CLOSED/flat row for (account_id, symbol) is reopened (Exit FSM state reset to OPEN);
+	// a held row is merged (qty summed, weighted avg price, phase/state kept).
+	// position is updated with the position_id actually used and the resulting qty/avg price
+	OpenPosition(ctx context.Context, position *Position) error
 }
 
 // PositionStateRepository manages Exit FSM state
@@ -53,8 +60,11 @@ type PositionStateRepository interface {
 	// UpdateStopFloor updates stop floor price
 	UpdateStopFloor(ctx context.Context, positionID uuid.UUID, stopFloorPrice decimal.Decimal) error
 
-	// UpdateATR updates cached ATR
-	UpdateATR(ctx context.Context, positionID uuid.UUID, atr decimal.Decimal) error
+	// UpdateATR updates cached ATR (ATR% + ATR 원)
+	UpdateATR(ctx context.Context, positionID uuid.UUID, atrPct, atrWon decimal.Decimal) error
+
+	// UpdateTrailingStop updates computed trailing stop price
+	UpdateTrailingStop(ctx context.Context, positionID uuid.UUID, stopPrice decimal.Decimal) error
 
 	// IncrementStopFloorBreachTicks increments stop floor breach tick counter
 	IncrementStopFloorBreachTicks(ctx context.Context, positionID uuid.UUID) error
@@ -83,6 +93,12 @@ type ExitControlRepository interface {
 
 	// UpdateControl updates control mode
 	UpdateControl(ctx context.Context, mode string, reason *string, updatedBy string) error
+
+	// GetAccountControls retrieves per-account control modes (account_id → control)
+	GetAccountControls(ctx context.Context) (map[string]*ExitControl, error)
+
+	// UpdateAccountControl upserts control mode of an account
+	UpdateAccountControl(ctx context.Context, accountID, mode string, reason *string, updatedBy string) error
 }
 
 // ExitProfileRepository manages exit rule profiles
@@ -132,17 +148,23 @@ type OrderIntentRepository interface {
 	// GetActiveIntentsByPosition retrieves active intents for a position (NEW, PENDING_APPROVAL, ACK)
 	GetActiveIntentsByPosition(ctx context.Context, positionID uuid.UUID) ([]*OrderIntent, error)
 
+	// LoadIntentsForPosition loads intents of a position created since ts (nil filter = all, created_ts 오름차순)
+	LoadIntentsForPosition(ctx context.Context, positionID uuid.UUID, intentTypes []string, statuses []string, since time.Time) ([]*OrderIntent, error)
+
 	// UpdateIntentStatus updates intent status
 	UpdateIntentStatus(ctx context.Context, intentID uuid.UUID, status string) error
 
 	// GetRecentIntents retrieves recent intents (for monitoring)
 	GetRecentIntents(ctx context.Context, limit int) ([]*OrderIntent, error)
 
-	// ApproveIntent approves an intent (PENDING_APPROVAL → NEW)
-	ApproveIntent(ctx context.Context, intentID uuid.UUID) error
+	// ApproveIntent approves an intent (PENDING_APPROVAL → NEW, decided_by 기록)
+	ApproveIntent(ctx context.Context, intentID uuid.UUID, decidedBy string) error
+
+	// RejectIntent rejects an intent (PENDING_APPROVAL → CANCELLED, decided_by 기록)
+	RejectIntent(ctx context.Context, intentID uuid.UUID, decidedBy string) error
 
-	// RejectIntent rejects an intent (PENDING_APPROVAL → CANCELLED)
-	RejectIntent(ctx context.Context, intentID uuid.UUID) error
+	// LoadExpiredApprovals loads PENDING_APPROVAL intents whose approval deadline passed
+	LoadExpiredApprovals(ctx context.Context, now time.Time) ([]*OrderIntent, error)
 }
 
 // ExitSignalRepository manages exit trigger evaluation records (debugging/backtest)
@@ -0,0 +1,552 @@
+package exit
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ====================
+// Custom Rule Expression (CustomExitRule.Expression)
+// ====================
+//
+// 예: time >= 14:50 AND pnl_pct < 1 AND days_held >= 3
+//     price < ma20 * 0.98 OR (drawdown_pct >= 5 AND phase == "TP1_DONE")
+//
+// - 논리: AND, OR, NOT, 괄호
+// - 비교: < <= > >= == !=  (문자열은 == != 만)
+// - 산술: + - * /
+// - 리터럴: 숫자, 시각 HH:MM (자정 기준 분), 문자열 "..." / '...'
+
+// ErrRuleVarUnavailable is returned when a referenced variable has no value (MA 데이터 없음 등)
+var ErrRuleVarUnavailable = errors.New("rule variable unavailable")
+
+// Rule variable names
+const (
+	RuleVarPnLPct      = "pnl_pct"   // 평가손익률 % (현재가 vs 평단)
+	RuleVarPrice       = "price"     // 현재가 (매수호가 우선)
+	RuleVarAvgPrice    = "avg_price" // 평단가
+	RuleVarMA5         = "ma5"       // 일봉 종가 이동평균 (전일까지)
+	RuleVarMA20        = "ma20"
+	RuleVarMA60        = "ma60"
+	RuleVarMA120       = "ma120"
+	RuleVarDaysHeld    = "days_held"    // 보유 거래일 수
+	RuleVarDrawdownPct = "drawdown_pct" // HWM 대비 하락률 % (HWM 없으면 0)
+	RuleVarVolume      = "volume"       // 당일 누적 거래량
+	RuleVarAvgVolume   = "avg_volume"   // 20일 평균 거래량 (전일까지)
+	RuleVarVolumeRatio = "volume_ratio" // volume / avg_volume
+	RuleVarTime        = "time"         // 장중 시각 KST (자정 기준 분, 리터럴 HH:MM과 비교)
+	RuleVarPhase       = "phase"        // FSM phase (문자열)
+)
+
+// RuleVariable describes a variable available in rule expressions
+type RuleVariable struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"` // number | string
+	Description string   `json:"description"`
+	Values      []string `json:"values,omitempty"` // 문자열 변수 허용값
+}
+
+// RuleVariables lists variables available in rule expressions
+var RuleVariables = []RuleVariable{
+	{Name: RuleVarPnLPct, Type: "number", Description: "평가손익률 % (현재가 vs 평단)"},
+	{Name: RuleVarPrice, Type: "number", Description: "현재가 (매수호가 우선)"},
+	{Name: RuleVarAvgPrice, Type: "number", Description: "평단가"},
+	{Name: RuleVarMA5, Type: "number", Description: "5일 이동평균 (전일 종가까지)"},
+	{Name: RuleVarMA20, Type: "number", Description: "20일 이동평균 (전일 종가까지)"},
+	{Name: RuleVarMA60, Type: "number", Description: "60일 이동평균 (전일 종가까지)"},
+	{Name: RuleVarMA120, Type: "number", Description: "120일 이동평균 (전일 종가까지)"},
+	{Name: RuleVarDaysHeld, Type: "number", Description: "보유 거래일 수"},
+	{Name: RuleVarDrawdownPct, Type: "number", Description: "HWM 대비 하락률 %"},
+	{Name: RuleVarVolume, Type: "number", Description: "당일 누적 거래량"},
+	{Name: RuleVarAvgVolume, Type: "number", Description: "20일 평균 거래량 (전일까지)"},
+	{Name: RuleVarVolumeRatio, Type: "number", Description: "당일 거래량 / 20일 평균 거래량"},
+	{Name: RuleVarTime, Type: "number", Description: "장중 시각 KST (HH:MM 리터럴과 비교)"},
+	{Name: RuleVarPhase, Type: "string", Description: "FSM phase",
+		Values: []string{PhaseOpen, PhaseTP1Done, PhaseTP2Done, PhaseTP3Done, PhaseTrailingActive}},
+}
+
+// RuleEnv holds variable values for rule evaluation (없는 변수 → ErrRuleVarUnavailable)
+type RuleEnv struct {
+	Numbers map[string]float64
+	Strings map[string]string
+}
+
+// RulePreview represents expression preview result (API)
+type RulePreview struct {
+	Expression string                 `json:"expression"`
+	Valid      bool                   `json:"valid"`
+	Error      string                 `json:"error,omitempty"`     // 문법/타입 오류
+	Variables  []string               `json:"variables,omitempty"` // 참조 변수
+	PositionID *uuid.UUID             `json:"position_id,omitempty"`
+	Symbol     string                 `json:"symbol,omitempty"`
+	Values     map[string]interface{} `json:"values,omitempty"` // 포지션 현재 변수값
+	Result     *bool                  `json:"result,omitempty"` // 현재 발동 여부
+	EvalError  string                 `json:"eval_error,omitempty"`
+}
+
+// RuleExpr is a parsed, type-checked rule expression
+type RuleExpr struct {
+	src  string
+	root *ruleNode
+	vars []string
+}
+
+// ParseRuleExpr parses and type-checks a rule expression (결과는 boolean이어야 함)
+func ParseRuleExpr(src string) (*RuleExpr, error) {
+	tokens, err := lexRule(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ruleParser{tokens: tokens, vars: make(map[string]bool)}
+	root, err := p.parse(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+	if root.typ != ruleBool {
+		return nil, errors.New("expression must be a condition (comparison or AND/OR)")
+	}
+
+	vars := make([]string, 0, len(p.vars))
+	for name := range p.vars {
+		vars = append(vars, name)
+	}
+	sort.Strings(vars)
+
+	return &RuleExpr{src: src, root: root, vars: vars}, nil
+}
+
+// String returns source expression
+func (e *RuleExpr) String() string {
+	return e.src
+}
+
+// Variables returns referenced variable names (sorted)
+func (e *RuleExpr) Variables() []string {
+	return e.vars
+}
+
+// Eval evaluates the expression
+func (e *RuleExpr) Eval(env RuleEnv) (bool, error) {
+	v, err := e.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+	return v.b, nil
+}
+
+// ====================
+// Lexer
+// ====================
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokStr
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type ruleToken struct {
+	kind tokKind
+	text string
+	num  float64
+	pos  int
+}
+
+func lexRule(src string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			// 시각 리터럴 HH:MM
+			if i < len(src) && src[i] == ':' {
+				hh, err := strconv.Atoi(src[start:i])
+				if err != nil || i+3 > len(src) {
+					return nil, fmt.Errorf("invalid time literal at %d", start)
+				}
+				mm, err := strconv.Atoi(src[i+1 : i+3])
+				if err != nil || hh > 23 || mm > 59 {
+					return nil, fmt.Errorf("invalid time literal %q at %d", src[start:i+3], start)
+				}
+				i += 3
+				tokens = append(tokens, ruleToken{kind: tokNum, text: src[start:i], num: float64(hh*60 + mm), pos: start})
+				continue
+			}
+			n, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", src[start:i], start)
+			}
+			tokens = append(tokens, ruleToken{kind: tokNum, text: src[start:i], num: n, pos: start})
+
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(src[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, ruleToken{kind: tokStr, text: src[i+1 : i+1+end], pos: i})
+			i += end + 2
+
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_':
+			start := i
+			for i < len(src) && (src[i] >= 'a' && src[i] <= 'z' || src[i] >= 'A' && src[i] <= 'Z' || src[i] >= '0' && src[i] <= '9' || src[i] == '_') {
+				i++
+			}
+			word := src[start:i]
+			switch upper := strings.ToUpper(word); upper {
+			case "AND", "OR", "NOT":
+				tokens = append(tokens, ruleToken{kind: tokOp, text: upper, pos: start})
+			default:
+				tokens = append(tokens, ruleToken{kind: tokIdent, text: strings.ToLower(word), pos: start})
+			}
+
+		case c == '(':
+			tokens = append(tokens, ruleToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, ruleToken{kind: tokRParen, text: ")", pos: i})
+			i++
+
+		default:
+			op := ""
+			if i+1 < len(src) {
+				switch two := src[i : i+2]; two {
+				case "<=", ">=", "==", "!=":
+					op = two
+				}
+			}
+			if op == "" {
+				switch c {
+				case '<', '>', '+', '-', '*', '/':
+					op = string(c)
+				default:
+					return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+				}
+			}
+			tokens = append(tokens, ruleToken{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, ruleToken{kind: tokEOF, text: "end of expression", pos: len(src)}), nil
+}
+
+// ====================
+// Parser (precedence climbing + type check)
+// ====================
+
+type ruleType int
+
+const (
+	ruleNum ruleType = iota
+	ruleStr
+	ruleBool
+)
+
+// binary operator precedence (OR < AND < NOT < 비교 < +- < */)
+var ruleBinaryPrec = map[string]int{
+	"OR": 1, "AND": 2,
+	"<": 4, "<=": 4, ">": 4, ">=": 4, "==": 4, "!=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6,
+}
+
+const rulePrecNot = 3
+
+type ruleNode struct {
+	op          string // num | str | var | NOT | neg | 이항 연산자
+	typ         ruleType
+	num         float64
+	str         string // 문자열 리터럴 / 변수명
+	left, right *ruleNode
+}
+
+type ruleParser struct {
+	tokens []ruleToken
+	i      int
+	vars   map[string]bool
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.tokens[p.i]
+}
+
+func (p *ruleParser) next() ruleToken {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *ruleParser) parse(minPrec int) (*ruleNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		prec, ok := ruleBinaryPrec[tok.text]
+		if tok.kind != tokOp || !ok || prec < minPrec {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parse(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		if left, err = binaryNode(tok, left, right); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *ruleParser) parseUnary() (*ruleNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNum:
+		return &ruleNode{op: "num", typ: ruleNum, num: tok.num}, nil
+
+	case tokStr:
+		return &ruleNode{op: "str", typ: ruleStr, str: tok.text}, nil
+
+	case tokIdent:
+		v, ok := lookupRuleVariable(tok.text)
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q at %d", tok.text, tok.pos)
+		}
+		p.vars[v.Name] = true
+		typ := ruleNum
+		if v.Type == "string" {
+			typ = ruleStr
+		}
+		return &ruleNode{op: "var", typ: typ, str: v.Name}, nil
+
+	case tokLParen:
+		inner, err := p.parse(0)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected ) at %d, got %q", closing.pos, closing.text)
+		}
+		return inner, nil
+
+	case tokOp:
+		switch tok.text {
+		case "NOT":
+			operand, err := p.parse(rulePrecNot + 1)
+			if err != nil {
+				return nil, err
+			}
+			if operand.typ != ruleBool {
+				return nil, fmt.Errorf("NOT requires a condition at %d", tok.pos)
+			}
+			return &ruleNode{op: "NOT", typ: ruleBool, left: operand}, nil
+		case "-":
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			if operand.typ != ruleNum {
+				return nil, fmt.Errorf("unary - requires a number at %d", tok.pos)
+			}
+			return &ruleNode{op: "neg", typ: ruleNum, left: operand}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+}
+
+// binaryNode builds a binary node with type check
+func binaryNode(tok ruleToken, left, right *ruleNode) (*ruleNode, error) {
+	switch tok.text {
+	case "AND", "OR":
+		if left.typ != ruleBool || right.typ != ruleBool {
+			return nil, fmt.Errorf("%s requires conditions on both sides at %d", tok.text, tok.pos)
+		}
+		return &ruleNode{op: tok.text, typ: ruleBool, left: left, right: right}, nil
+
+	case "+", "-", "*", "/":
+		if left.typ != ruleNum || right.typ != ruleNum {
+			return nil, fmt.Errorf("%s requires numbers at %d", tok.text, tok.pos)
+		}
+		return &ruleNode{op: tok.text, typ: ruleNum, left: left, right: right}, nil
+	}
+
+	// 비교
+	switch {
+	case left.typ == ruleNum && right.typ == ruleNum:
+	case left.typ == ruleStr && right.typ == ruleStr:
+		if tok.text != "==" && tok.text != "!=" {
+			return nil, fmt.Errorf("strings support only == and != at %d", tok.pos)
+		}
+		if err := checkStringValue(left, right); err != nil {
+			return nil, err
+		}
+		if err := checkStringValue(right, left); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s compares incompatible values at %d", tok.text, tok.pos)
+	}
+	return &ruleNode{op: tok.text, typ: ruleBool, left: left, right: right}, nil
+}
+
+// checkStringValue validates a string literal compared with an enumerated variable (phase 오타 방지)
+func checkStringValue(variable, literal *ruleNode) error {
+	if variable.op != "var" || literal.op != "str" {
+		return nil
+	}
+	v, _ := lookupRuleVariable(variable.str)
+	if len(v.Values) == 0 {
+		return nil
+	}
+	for _, allowed := range v.Values {
+		if literal.str == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s value %q (allowed: %s)", v.Name, literal.str, strings.Join(v.Values, ", "))
+}
+
+func lookupRuleVariable(name string) (RuleVariable, bool) {
+	for _, v := range RuleVariables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return RuleVariable{}, false
+}
+
+// ====================
+// Evaluation
+// ====================
+
+type ruleValue struct {
+	num float64
+	str string
+	b   bool
+}
+
+func (n *ruleNode) eval(env RuleEnv) (ruleValue, error) {
+	switch n.op {
+	case "num":
+		return ruleValue{num: n.num}, nil
+	case "str":
+		return ruleValue{str: n.str}, nil
+	case "var":
+		if n.typ == ruleStr {
+			s, ok := env.Strings[n.str]
+			if !ok {
+				return ruleValue{}, fmt.Errorf("%w: %s", ErrRuleVarUnavailable, n.str)
+			}
+			return ruleValue{str: s}, nil
+		}
+		v, ok := env.Numbers[n.str]
+		if !ok {
+			return ruleValue{}, fmt.Errorf("%w: %s", ErrRuleVarUnavailable, n.str)
+		}
+		return ruleValue{num: v}, nil
+	case "NOT":
+		v, err := n.left.eval(env)
+		return ruleValue{b: !v.b}, err
+	case "neg":
+		v, err := n.left.eval(env)
+		return ruleValue{num: -v.num}, err
+	}
+
+	left, err := n.left.eval(env)
+	if err != nil {
+		return ruleValue{}, err
+	}
+
+	// 단락 평가 (AND/OR): 불필요한 변수 조회 생략
+	switch {
+	case n.op == "AND" && !left.b:
+		return ruleValue{b: false}, nil
+	case n.op == "OR" && left.b:
+		return ruleValue{b: true}, nil
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return ruleValue{}, err
+	}
+
+	switch n.op {
+	case "AND", "OR":
+		return ruleValue{b: right.b}, nil
+	case "+":
+		return ruleValue{num: left.num + right.num}, nil
+	case "-":
+		return ruleValue{num: left.num - right.num}, nil
+	case "*":
+		return ruleValue{num: left.num * right.num}, nil
+	case "/":
+		if right.num == 0 {
+			return ruleValue{}, errors.New("division by zero")
+		}
+		return ruleValue{num: left.num / right.num}, nil
+	case "==":
+		if n.left.typ == ruleStr {
+			return ruleValue{b: left.str == right.str}, nil
+		}
+		return ruleValue{b: left.num == right.num}, nil
+	case "!=":
+		if n.left.typ == ruleStr {
+			return ruleValue{b: left.str != right.str}, nil
+		}
+		return ruleValue{b: left.num != right.num}, nil
+	case "<":
+		return ruleValue{b: left.num < right.num}, nil
+	case "<=":
+		return ruleValue{b: left.num <= right.num}, nil
+	case ">":
+		return ruleValue{b: left.num > right.num}, nil
+	case ">=":
+		return ruleValue{b: left.num >= right.num}, nil
+	}
+	return ruleValue{}, fmt.Errorf("unknown operator %s", n.op)
+}
+
+// Validate validates a custom rule (condition, expression, exit percent)
+func (r CustomExitRule) Validate() error {
+	switch r.Condition {
+	case CustomConditionProfitAbove, CustomConditionProfitBelow:
+	case CustomConditionExpression:
+		if strings.TrimSpace(r.Expression) == "" {
+			return errors.New("expression is required")
+		}
+		if _, err := ParseRuleExpr(r.Expression); err != nil {
+			return fmt.Errorf("expression: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown condition %q", r.Condition)
+	}
+	if r.ExitPercent <= 0 || r.ExitPercent > 100 {
+		return fmt.Errorf("exit_percent must be in (0, 100], got %v", r.ExitPercent)
+	}
+	return nil
+}
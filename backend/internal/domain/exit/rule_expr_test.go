@@ -0,0 +1,104 @@
+package exit
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRuleExprEval tests rule expression parsing and evaluation
+func TestRuleExprEval(t *testing.T) {
+	env := RuleEnv{
+		Numbers: map[string]float64{
+			RuleVarPnLPct:      0.5,
+			RuleVarPrice:       9700,
+			RuleVarMA20:        10000,
+			RuleVarDaysHeld:    3,
+			RuleVarDrawdownPct: 6,
+			RuleVarTime:        14*60 + 55,
+		},
+		Strings: map[string]string{RuleVarPhase: PhaseTP1Done},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"time >= 14:50 AND pnl_pct < 1 AND days_held >= 3", true},
+		{"time >= 14:50 AND pnl_pct < 1 AND days_held >= 4", false},
+		{"price < ma20 * 0.98", true},
+		{"price < ma20 * 0.97 OR (drawdown_pct >= 5 AND phase == \"TP1_DONE\")", true},
+		{"NOT pnl_pct > 0 OR phase != 'TP1_DONE'", false},
+		{"pnl_pct > -1 and time < 15:20", true},
+		{"1 + 2 * 3 == 7", true},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseRuleExpr(tt.expr)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.expr, err)
+		}
+		got, err := expr.Eval(env)
+		if err != nil {
+			t.Fatalf("%q: unexpected eval error: %v", tt.expr, err)
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	// 값 없는 변수 → ErrRuleVarUnavailable (단락 평가 시 조회하지 않음)
+	expr, _ := ParseRuleExpr("price < ma60")
+	if _, err := expr.Eval(env); !errors.Is(err, ErrRuleVarUnavailable) {
+		t.Errorf("Expected ErrRuleVarUnavailable, got %v", err)
+	}
+	expr, _ = ParseRuleExpr("days_held > 10 AND price < ma60")
+	if got, err := expr.Eval(env); err != nil || got {
+		t.Errorf("Expected short-circuit false, got %v (err=%v)", got, err)
+	}
+	if vars := expr.Variables(); len(vars) != 3 || vars[0] != RuleVarDaysHeld {
+		t.Errorf("Unexpected variables %v", vars)
+	}
+}
+
+// TestRuleExprInvalid tests parse/type errors
+func TestRuleExprInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"pnl_pct",                   // not a condition
+		"profit < 1",                // unknown variable
+		"pnl_pct < 1 AND",           // incomplete
+		"(pnl_pct < 1",              // unbalanced
+		"pnl_pct < 1 < 2",           // chained comparison
+		"phase > \"OPEN\"",          // string ordering
+		"phase == \"TP1DONE\"",      // unknown phase
+		"phase == 1",                // type mismatch
+		"pnl_pct AND days_held > 1", // non-boolean operand
+		"time >= 25:00",             // invalid time
+		"pnl_pct < 1 $",             // unexpected character
+	}
+	for _, src := range invalid {
+		if _, err := ParseRuleExpr(src); err == nil {
+			t.Errorf("%q: expected parse error", src)
+		}
+	}
+}
+
+// TestCustomExitRuleValidate tests custom rule validation
+func TestCustomExitRuleValidate(t *testing.T) {
+	valid := CustomExitRule{ID: "r1", Condition: CustomConditionExpression, Expression: "pnl_pct < 1", ExitPercent: 50}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid rule, got %v", err)
+	}
+
+	invalid := []CustomExitRule{
+		{ID: "r2", Condition: CustomConditionExpression, ExitPercent: 50},
+		{ID: "r3", Condition: CustomConditionExpression, Expression: "pnl_pct <", ExitPercent: 50},
+		{ID: "r4", Condition: CustomConditionProfitAbove, Threshold: 7, ExitPercent: 0},
+		{ID: "r5", Condition: "loss_below", ExitPercent: 50},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", rule.ID)
+		}
+	}
+}
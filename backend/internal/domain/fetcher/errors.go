@@ -14,6 +14,9 @@ var (
 	ErrInvalidDateRange   = errors.New("invalid date range")
 	ErrNoDataAvailable    = errors.New("no data available for the given period")
 
+	// Index price errors
+	ErrIndexPriceNotFound = errors.New("index price not found")
+
 	// Flow errors
 	ErrFlowNotFound       = errors.New("investor flow not found")
 
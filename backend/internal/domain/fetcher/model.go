@@ -36,6 +36,19 @@ type DailyPrice struct {
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
+// IndexPrice 지수 일봉 데이터 (data.index_prices)
+type IndexPrice struct {
+	IndexCode    string    `json:"index_code" db:"index_code"` // KOSPI, KOSDAQ, KOSPI200
+	TradeDate    time.Time `json:"trade_date" db:"trade_date"`
+	OpenPrice    float64   `json:"open_price" db:"open_price"`
+	HighPrice    float64   `json:"high_price" db:"high_price"`
+	LowPrice     float64   `json:"low_price" db:"low_price"`
+	ClosePrice   float64   `json:"close_price" db:"close_price"`
+	Volume       int64     `json:"volume" db:"volume"`
+	TradingValue int64     `json:"trading_value" db:"trading_value"` // 백만원
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
 // InvestorFlow 투자자별 수급 (data.investor_flow)
 type InvestorFlow struct {
 	StockCode       string    `json:"stock_code" db:"stock_code"`
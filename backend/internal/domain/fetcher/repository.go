@@ -42,6 +42,20 @@ type PriceRepository interface {
 	GetLatestN(ctx context.Context, stockCode string, n int) ([]*DailyPrice, error)
 }
 
+// =============================================================================
+// Index Price Repository
+// =============================================================================
+
+// IndexPriceRepository 지수 일봉 저장소 (data.index_prices)
+type IndexPriceRepository interface {
+	// Upsert 지수 일봉 저장
+	UpsertBatch(ctx context.Context, prices []*IndexPrice) (int, error)
+
+	// Query 지수 일봉 조회 (GetRange는 trade_date 오름차순)
+	GetRange(ctx context.Context, indexCode string, from, to time.Time) ([]*IndexPrice, error)
+	GetLatest(ctx context.Context, indexCode string) (*IndexPrice, error)
+}
+
 // =============================================================================
 // Flow Repository
 // =============================================================================
@@ -165,6 +179,11 @@ type NaverClient interface {
 	FetchMarketCapRanking(ctx context.Context, market string, limit int) ([]*Stock, error)
 }
 
+// IndexSource 지수 일봉 수집 소스 (KIS 업종기간별시세)
+type IndexSource interface {
+	FetchDailyIndexPrices(ctx context.Context, indexCode string, from, to time.Time) ([]*IndexPrice, error)
+}
+
 // DartClient DART 공시 클라이언트
 type DartClient interface {
 	// 특정 종목 공시 수집
@@ -0,0 +1,9 @@
+package paper
+
+import "errors"
+
+var (
+	ErrAccountNotFound     = errors.New("paper account not found")
+	ErrOrderNotFound       = errors.New("paper order not found")
+	ErrOrderNotCancellable = errors.New("paper order not cancellable")
+)
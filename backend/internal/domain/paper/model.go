@@ -0,0 +1,84 @@
+package paper
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Order status
+const (
+	OrderStatusPending   = "PENDING"   // 접수 (체결 대기)
+	OrderStatusPartial   = "PARTIAL"   // 부분 체결
+	OrderStatusFilled    = "FILLED"    // 전량 체결
+	OrderStatusCancelled = "CANCELLED" // 취소
+	OrderStatusRejected  = "REJECTED"  // 거부 (잔고 부족, 시뮬레이션 거부 등)
+)
+
+// Account paper.accounts
+type Account struct {
+	AccountID   string          `json:"account_id"`
+	Cash        decimal.Decimal `json:"cash"`
+	InitialCash decimal.Decimal `json:"initial_cash"`
+	UpdatedTS   time.Time       `json:"updated_ts"`
+}
+
+// Order paper.orders
+type Order struct {
+	OrderID      string           `json:"order_id"`
+	AccountID    string           `json:"account_id"`
+	Symbol       string           `json:"symbol"`
+	Side         string           `json:"side"`       // BUY, SELL
+	OrderType    string           `json:"order_type"` // MKT, LMT
+	Qty          int64            `json:"qty"`
+	LimitPrice   *decimal.Decimal `json:"limit_price,omitempty"`
+	FilledQty    int64            `json:"filled_qty"`
+	Status       string           `json:"status"`
+	RejectReason string           `json:"reject_reason,omitempty"`
+	SubmittedTS  time.Time        `json:"submitted_ts"`
+	EligibleTS   time.Time        `json:"eligible_ts"` // 체결 가능 시각 (지연 시뮬레이션)
+	UpdatedTS    time.Time        `json:"updated_ts"`
+	TimeInForce  string           `json:"-"` // IOC, FOK (메모리 전용, DB 미저장)
+}
+
+// OpenQty returns unfilled qty
+func (o *Order) OpenQty() int64 {
+	return o.Qty - o.FilledQty
+}
+
+// IsOpen returns true if order can still be filled
+func (o *Order) IsOpen() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusPartial
+}
+
+// Fill paper.fills
+type Fill struct {
+	ExecID    string          `json:"exec_id"`
+	OrderID   string          `json:"order_id"`
+	AccountID string          `json:"account_id"`
+	Symbol    string          `json:"symbol"`
+	Side      string          `json:"side"`
+	Qty       int64           `json:"qty"`
+	Price     decimal.Decimal `json:"price"`
+	Fee       decimal.Decimal `json:"fee"`
+	Tax       decimal.Decimal `json:"tax"`
+	Seq       int             `json:"seq"`
+	TS        time.Time       `json:"ts"`
+}
+
+// Holding paper.holdings (평균단가 = 매수금액 / 수량, 수수료 제외)
+type Holding struct {
+	AccountID string          `json:"account_id"`
+	Symbol    string          `json:"symbol"`
+	Qty       int64           `json:"qty"`
+	AvgPrice  decimal.Decimal `json:"avg_price"`
+	UpdatedTS time.Time       `json:"updated_ts"`
+}
+
+// Ledger 계좌 원장 스냅샷 (broker 기동 시 로드)
+type Ledger struct {
+	Account  *Account
+	Orders   []*Order // 미체결 + since 이후 주문
+	Fills    []*Fill  // since 이후 체결
+	Holdings []*Holding
+}
@@ -0,0 +1,21 @@
+package paper
+
+import (
+	"context"
+	"time"
+)
+
+// LedgerRepository paper broker 원장 저장소 (paper schema)
+type LedgerRepository interface {
+	// 계좌 원장 로드 (계좌 없으면 ErrAccountNotFound)
+	LoadLedger(ctx context.Context, accountID string, since time.Time) (*Ledger, error)
+
+	// 계좌 생성
+	CreateAccount(ctx context.Context, account *Account) error
+
+	// 주문 저장 (접수/취소/거부, order_id upsert)
+	SaveOrder(ctx context.Context, order *Order) error
+
+	// 체결 기록 (주문 + 체결 + 보유 + 현금 단일 트랜잭션)
+	RecordFill(ctx context.Context, order *Order, fill *Fill, holding *Holding, account *Account) error
+}
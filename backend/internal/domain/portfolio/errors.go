@@ -0,0 +1,26 @@
+package portfolio
+
+import "errors"
+
+var (
+	// ErrSnapshotNotFound 스냅샷을 찾을 수 없음
+	ErrSnapshotNotFound = errors.New("portfolio snapshot not found")
+
+	// ErrRankingNotReady Ranking이 준비되지 않음
+	ErrRankingNotReady = errors.New("ranking not ready")
+
+	// ErrNoCandidates 편입 후보 없음
+	ErrNoCandidates = errors.New("no portfolio candidates")
+
+	// ErrNoCapital 평가 자산 없음 (보유 + 현금 = 0)
+	ErrNoCapital = errors.New("no capital to allocate")
+
+	// ErrPlanNotFound 리밸런싱 계획을 찾을 수 없음
+	ErrPlanNotFound = errors.New("rebalance plan not found")
+
+	// ErrPlanNotPending 승인 대기 상태가 아님
+	ErrPlanNotPending = errors.New("rebalance plan is not pending approval")
+
+	// ErrNothingToRebalance 목표와 보유가 일치 (주문 없음)
+	ErrNothingToRebalance = errors.New("nothing to rebalance")
+)
@@ -0,0 +1,199 @@
+package portfolio
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PortfolioSnapshot 목표 포트폴리오 스냅샷 (Ranking → 목표 비중/수량)
+type PortfolioSnapshot struct {
+	SnapshotID        string    `json:"snapshot_id"`         // YYYYMMDD-xxxxxxxx
+	RankingSnapshotID string    `json:"ranking_snapshot_id"` // 기반 Ranking Snapshot
+	AccountID         string    `json:"account_id"`          // 계좌번호
+	GeneratedAt       time.Time `json:"generated_at"`
+
+	// 평가 기준 자산
+	TotalValue    decimal.Decimal `json:"total_value"`    // 보유 평가액 + 현금
+	HoldingsValue decimal.Decimal `json:"holdings_value"` // 보유 종목 평가액
+	Cash          decimal.Decimal `json:"cash"`           // 현금
+	Investable    decimal.Decimal `json:"investable"`     // 투자 가능 금액 (현금 버퍼 제외)
+
+	// 목표 구성
+	Targets     []TargetPosition `json:"targets"`      // 목표 종목 (미보유 편입 + 보유 편출 포함)
+	TotalWeight float64          `json:"total_weight"` // 총 목표 비중 (%)
+
+	// 통계
+	Stats PortfolioStats `json:"stats"`
+}
+
+// TargetPosition 종목별 목표
+type TargetPosition struct {
+	// 종목 정보
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+	Market string `json:"market"`
+	Sector string `json:"sector"`
+
+	// Ranking 정보 (편출 종목은 0)
+	Rank       int     `json:"rank"`
+	TotalScore float64 `json:"total_score"`
+	AlphaScore float64 `json:"alpha_score"`
+	RiskScore  float64 `json:"risk_score"`
+
+	// 목표
+	TargetWeight float64         `json:"target_weight"` // 목표 비중 (%)
+	TargetValue  decimal.Decimal `json:"target_value"`  // 목표 금액 (원)
+	RefPrice     decimal.Decimal `json:"ref_price"`     // 기준가 (호가단위 정규화)
+	TargetQty    int64           `json:"target_qty"`    // 목표 수량 (주, 매매단위 1주)
+
+	// 현재
+	CurrentQty    int64   `json:"current_qty"`    // 현재 보유 수량
+	CurrentWeight float64 `json:"current_weight"` // 현재 비중 (%)
+	DeltaQty      int64   `json:"delta_qty"`      // TargetQty - CurrentQty (+매수/-매도)
+
+	// 제약 적용 여부
+	Capped       bool   `json:"capped"`
+	CappedReason string `json:"capped_reason,omitempty"`
+}
+
+// PortfolioStats 포트폴리오 통계
+type PortfolioStats struct {
+	TotalHoldings int     `json:"total_holdings"` // 목표 비중 > 0 종목 수
+	AvgWeight     float64 `json:"avg_weight"`
+	MaxWeight     float64 `json:"max_weight"`
+	MinWeight     float64 `json:"min_weight"`
+
+	// 분산도
+	SectorWeights map[string]float64 `json:"sector_weights"` // 섹터별 목표 비중 (%)
+	MarketCount   map[string]int     `json:"market_count"`   // 시장별 종목 수
+
+	// 점수 분포
+	AvgTotalScore float64 `json:"avg_total_score"`
+	AvgRiskScore  float64 `json:"avg_risk_score"`
+
+	// 매매 필요
+	BuyCount  int `json:"buy_count"`  // DeltaQty > 0
+	SellCount int `json:"sell_count"` // DeltaQty < 0
+}
+
+// AllocationMethod 할당 방식
+type AllocationMethod string
+
+const (
+	AllocationMethodEqualWeight   AllocationMethod = "EQUAL_WEIGHT"   // 균등 배분
+	AllocationMethodScoreWeighted AllocationMethod = "SCORE_WEIGHTED" // 점수 가중
+)
+
+// PortfolioCriteria 포트폴리오 구성 기준
+type PortfolioCriteria struct {
+	// 종목 수
+	MinHoldings int `json:"min_holdings"` // 최소 보유 종목 (10, 미달 시 경고만)
+	MaxHoldings int `json:"max_holdings"` // 최대 보유 종목 (15)
+
+	// 비중 제약
+	MaxSingleWeight float64 `json:"max_single_weight"` // 단일 종목 최대 비중 (15%)
+	MaxSectorWeight float64 `json:"max_sector_weight"` // 섹터 최대 비중 (40%)
+
+	// 선택 기준
+	MinScore float64 `json:"min_score"` // 최소 점수 (60)
+
+	// 할당 방식
+	AllocationMethod AllocationMethod `json:"allocation_method"`
+
+	// 현금 버퍼
+	CashBuffer float64 `json:"cash_buffer"` // 투자 제외 비율 (2%, 수수료/호가 여유)
+}
+
+// DefaultPortfolioCriteria 기본 기준
+func DefaultPortfolioCriteria() *PortfolioCriteria {
+	return &PortfolioCriteria{
+		MinHoldings:      10,
+		MaxHoldings:      15,
+		MaxSingleWeight:  15.0,
+		MaxSectorWeight:  40.0,
+		MinScore:         60.0,
+		AllocationMethod: AllocationMethodEqualWeight,
+		CashBuffer:       2.0,
+	}
+}
+
+// RebalancePlan 리밸런싱 계획 (목표 vs 보유 → 승인 대기 주문 의도)
+type RebalancePlan struct {
+	PlanID              uuid.UUID `json:"plan_id"`
+	PortfolioSnapshotID string    `json:"portfolio_snapshot_id"` // 기반 Portfolio Snapshot
+	AccountID           string    `json:"account_id"`
+	CreatedAt           time.Time `json:"created_at"`
+	Status              string    `json:"status"`  // PENDING_APPROVAL | APPROVED | REJECTED
+	DryRun              bool      `json:"dry_run"` // true → intent 미생성 (미리보기)
+
+	// 실행 순서 (매도 → 매수)
+	Orders  []RebalanceOrder `json:"orders"`
+	Skipped []RebalanceOrder `json:"skipped"` // 임계값 미만/현금 부족 등 (Reason 참고)
+
+	Summary RebalanceSummary `json:"summary"`
+}
+
+// RebalanceOrder 리밸런싱 주문 (1 order = 1 intent)
+type RebalanceOrder struct {
+	Seq        int       `json:"seq"`         // 실행 순서 (1-based, 매도 먼저)
+	IntentID   uuid.UUID `json:"intent_id"`   // 생성된 intent (dry run이면 uuid.Nil)
+	PositionID uuid.UUID `json:"position_id"` // 보유 포지션 (없으면 신규)
+	Symbol     string    `json:"symbol"`
+	Name       string    `json:"name"`
+	Market     string    `json:"market"`
+	Side       string    `json:"side"` // BUY | SELL
+	Qty        int64     `json:"qty"`
+
+	// 근거
+	TargetQty  int64   `json:"target_qty"`
+	CurrentQty int64   `json:"current_qty"` // 보유 수량
+	PendingQty int64   `json:"pending_qty"` // 미체결/대기 주문 (+매수/-매도)
+	DriftPct   float64 `json:"drift_pct"`   // |목표-현재| 금액 / 총자산 (%)
+
+	// 예상 비용 (calculateRealPnL과 동일 요율)
+	EstPrice  decimal.Decimal `json:"est_price"`
+	EstAmount decimal.Decimal `json:"est_amount"`
+	EstFee    decimal.Decimal `json:"est_fee"`
+	EstTax    decimal.Decimal `json:"est_tax"`
+
+	Reason string `json:"reason,omitempty"`
+}
+
+// RebalanceSummary 리밸런싱 요약
+type RebalanceSummary struct {
+	SellCount    int             `json:"sell_count"`
+	BuyCount     int             `json:"buy_count"`
+	SkippedCount int             `json:"skipped_count"`
+	SellAmount   decimal.Decimal `json:"sell_amount"`
+	BuyAmount    decimal.Decimal `json:"buy_amount"`
+	TotalFee     decimal.Decimal `json:"total_fee"`
+	TotalTax     decimal.Decimal `json:"total_tax"`
+	CashBefore   decimal.Decimal `json:"cash_before"`
+	CashAfter    decimal.Decimal `json:"cash_after"` // 예상 (매도 순수입 - 매수 총액)
+}
+
+// Rebalance Plan Status
+const (
+	PlanStatusPendingApproval = "PENDING_APPROVAL" // 검토 대기
+	PlanStatusApproved        = "APPROVED"         // 일괄 승인 (intent → NEW)
+	PlanStatusRejected        = "REJECTED"         // 일괄 거부 (intent → CANCELLED)
+)
+
+// ReasonCodeRebalance order_intents.reason_code for rebalance intents
+const ReasonCodeRebalance = "REBALANCE"
+
+// RebalanceCriteria 리밸런싱 기준
+type RebalanceCriteria struct {
+	DriftThreshold float64         `json:"drift_threshold"` // 최소 괴리 (총자산 대비 %, 1.0) - 전량 편출은 예외
+	MinOrderValue  decimal.Decimal `json:"min_order_value"` // 최소 주문 금액 (원)
+}
+
+// DefaultRebalanceCriteria 기본 기준
+func DefaultRebalanceCriteria() *RebalanceCriteria {
+	return &RebalanceCriteria{
+		DriftThreshold: 1.0,
+		MinOrderValue:  decimal.NewFromInt(100_000), // 10만원
+	}
+}
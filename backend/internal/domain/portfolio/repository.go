@@ -0,0 +1,44 @@
+package portfolio
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PortfolioRepository 포트폴리오 스냅샷 저장소
+type PortfolioRepository interface {
+	// 스냅샷 저장
+	SaveSnapshot(ctx context.Context, snapshot *PortfolioSnapshot) error
+
+	// 최신 스냅샷 조회
+	GetLatestSnapshot(ctx context.Context) (*PortfolioSnapshot, error)
+
+	// 특정 스냅샷 조회
+	GetSnapshotByID(ctx context.Context, snapshotID string) (*PortfolioSnapshot, error)
+
+	// 스냅샷 목록 (시간 범위)
+	ListSnapshots(ctx context.Context, from, to time.Time) ([]*PortfolioSnapshot, error)
+}
+
+// RebalancePlanRepository 리밸런싱 계획 저장소
+type RebalancePlanRepository interface {
+	// 계획 저장 + 주문 의도(PENDING_APPROVAL) 생성 (단일 트랜잭션)
+	CreatePlan(ctx context.Context, plan *RebalancePlan) error
+
+	// 계획 조회
+	GetPlan(ctx context.Context, planID uuid.UUID) (*RebalancePlan, error)
+
+	// 최신 계획 조회
+	GetLatestPlan(ctx context.Context) (*RebalancePlan, error)
+
+	// 일괄 승인 (PENDING_APPROVAL intent → NEW, 매도 먼저)
+	ApprovePlan(ctx context.Context, planID uuid.UUID) error
+
+	// 일괄 거부 (PENDING_APPROVAL intent → CANCELLED)
+	RejectPlan(ctx context.Context, planID uuid.UUID) error
+
+	// 종목별 미체결/대기 수량 (+매수/-매도)
+	LoadPendingQty(ctx context.Context) (map[string]int64, error)
+}
@@ -0,0 +1,60 @@
+package price
+
+import (
+	"math"
+)
+
+// 시장 지수 심볼 (종목코드와 같은 price 파이프라인에서 사용)
+const (
+	IndexKOSPI    = "KOSPI"
+	IndexKOSDAQ   = "KOSDAQ"
+	IndexKOSPI200 = "KOSPI200"
+)
+
+// IndexPriceScale 지수 값 → Tick.LastPrice 변환 배율
+// 지수는 소수점 2자리 (예: 2601.23 → 260123), Tick 가격 필드는 정수이므로 ×100 저장
+const IndexPriceScale = 100
+
+// indexCodes 지수 심볼 → KIS 업종코드 (FID_INPUT_ISCD / tr_key)
+var indexCodes = map[string]string{
+	IndexKOSPI:    "0001",
+	IndexKOSDAQ:   "1001",
+	IndexKOSPI200: "2001",
+}
+
+// IndexSymbols returns all supported market index symbols
+func IndexSymbols() []string {
+	return []string{IndexKOSPI, IndexKOSDAQ, IndexKOSPI200}
+}
+
+// IsIndexSymbol returns true if symbol is a market index (not a stock code)
+func IsIndexSymbol(symbol string) bool {
+	_, ok := indexCodes[symbol]
+	return ok
+}
+
+// IndexCode returns KIS 업종코드 for an index symbol
+func IndexCode(symbol string) (string, bool) {
+	code, ok := indexCodes[symbol]
+	return code, ok
+}
+
+// IndexSymbolForCode returns index symbol for a KIS 업종코드
+func IndexSymbolForCode(code string) (string, bool) {
+	for symbol, c := range indexCodes {
+		if c == code {
+			return symbol, true
+		}
+	}
+	return "", false
+}
+
+// ScaleIndexValue converts index value to scaled integer price (×IndexPriceScale)
+func ScaleIndexValue(value float64) int64 {
+	return int64(math.Round(value * IndexPriceScale))
+}
+
+// IndexValue converts scaled integer price back to index value
+func IndexValue(scaled int64) float64 {
+	return float64(scaled) / IndexPriceScale
+}
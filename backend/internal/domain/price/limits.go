@@ -0,0 +1,78 @@
+package price
+
+import "time"
+
+// PriceLimitRate KRX 가격제한폭 (기준가 대비 ±30%)
+const PriceLimitRate = 0.30
+
+// Band represents today's KRX price band of a symbol
+// 기준가(전일 종가) 기반 상/하한가 + VI 발동 상태
+type Band struct {
+	Symbol    string    `json:"symbol"`
+	Market    string    `json:"market"`     // KOSPI, KOSDAQ, ETF, ... ("" = 주식 공통)
+	BasePrice int64     `json:"base_price"` // 기준가
+	Upper     int64     `json:"upper"`      // 상한가
+	Lower     int64     `json:"lower"`      // 하한가
+	VIActive  bool      `json:"vi_active"`  // 변동성완화장치 발동 중 (단일가 매매)
+	Halted    bool      `json:"halted"`     // 거래정지/임시정지
+	Derived   bool      `json:"derived"`    // 현재가/전일대비로 추정한 값 (KIS 조회 실패 시)
+	UpdatedTS time.Time `json:"updated_ts"`
+}
+
+// NewBand builds a band from base price (상/하한가 계산, VI 상태 미상)
+func NewBand(symbol, market string, base int64) *Band {
+	upper, lower := PriceLimits(market, base)
+	return &Band{
+		Symbol:    symbol,
+		Market:    market,
+		BasePrice: base,
+		Upper:     upper,
+		Lower:     lower,
+		UpdatedTS: time.Now(),
+	}
+}
+
+// Contains checks if price is within [Lower, Upper]
+func (b *Band) Contains(p int64) bool {
+	return p >= b.Lower && p <= b.Upper
+}
+
+// TickSizeFor returns 호가단위 by market
+// ETF/ETN은 2,000원 미만 1원, 이상 5원 (주식은 TickSize)
+func TickSizeFor(market string, p int64) int64 {
+	switch market {
+	case "ETF", "ETN":
+		if p < 2_000 {
+			return 1
+		}
+		return 5
+	}
+	return TickSize(p)
+}
+
+// RoundToTick rounds price onto the tick grid (up: 올림, false: 내림)
+func RoundToTick(market string, p int64, up bool) int64 {
+	if p <= 0 {
+		return 0
+	}
+	tick := TickSizeFor(market, p)
+	down := p / tick * tick
+	if down == p || !up {
+		return down
+	}
+	// 올림 결과가 상위 구간이면 해당 구간 호가단위로 재정렬 (예: 1999 → 2000)
+	rounded := down + tick
+	return rounded / TickSizeFor(market, rounded) * TickSizeFor(market, rounded)
+}
+
+// PriceLimits returns 상한가/하한가 from base price
+// 상한가는 호가단위 미만 절사, 하한가는 호가단위 미만 절상 (KRX 가격제한폭 산정)
+func PriceLimits(market string, base int64) (upper, lower int64) {
+	width := int64(float64(base) * PriceLimitRate)
+	upper = RoundToTick(market, base+width, false)
+	lower = RoundToTick(market, base-width, true)
+	if lower < 1 {
+		lower = 1
+	}
+	return upper, lower
+}
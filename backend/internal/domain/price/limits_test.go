@@ -0,0 +1,35 @@
+package price
+
+import "testing"
+
+// TestPriceLimits tests 상/하한가 rounding to tick grid
+func TestPriceLimits(t *testing.T) {
+	tests := []struct {
+		base         int64
+		upper, lower int64
+	}{
+		{10_000, 13_000, 7_000},
+		{71_500, 92_900, 50_100}, // 92,950 → 92,900 (절사), 50,050 → 50,100 (절상)
+		{1_550, 2_015, 1_085},    // 상한가는 상위 구간 호가단위(5원) 적용
+	}
+
+	for _, tt := range tests {
+		upper, lower := PriceLimits("KOSPI", tt.base)
+		if upper != tt.upper || lower != tt.lower {
+			t.Errorf("PriceLimits(%d) = (%d, %d), want (%d, %d)", tt.base, upper, lower, tt.upper, tt.lower)
+		}
+	}
+}
+
+// TestRoundToTick tests rounding across tick bands
+func TestRoundToTick(t *testing.T) {
+	if got := RoundToTick("KOSPI", 4_997, true); got != 5_000 {
+		t.Errorf("RoundToTick(4997, up) = %d, want 5000", got)
+	}
+	if got := RoundToTick("KOSPI", 4_997, false); got != 4_995 {
+		t.Errorf("RoundToTick(4997, down) = %d, want 4995", got)
+	}
+	if got := RoundToTick("ETF", 25_013, true); got != 25_015 {
+		t.Errorf("RoundToTick(ETF 25013, up) = %d, want 25015", got)
+	}
+}
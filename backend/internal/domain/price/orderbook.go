@@ -0,0 +1,68 @@
+package price
+
+import (
+	"time"
+)
+
+// OrderBookDepth KIS 실시간 호가 단계 수 (H0STASP0)
+const OrderBookDepth = 10
+
+// OrderBookLevel represents a single price level (호가 1단계)
+type OrderBookLevel struct {
+	Price  int64 `json:"price"`
+	Volume int64 `json:"volume"` // 호가 잔량
+}
+
+// OrderBook represents real-time order book (10단계 호가)
+type OrderBook struct {
+	Symbol string `json:"symbol"`
+	Source Source `json:"source"`
+
+	// 매도호가 1~10 (Asks[0] = 최우선 매도호가), 매수호가 1~10 (Bids[0] = 최우선 매수호가)
+	Asks []OrderBookLevel `json:"asks"`
+	Bids []OrderBookLevel `json:"bids"`
+
+	TotalAskVolume int64 `json:"total_ask_volume"` // 총 매도호가 잔량
+	TotalBidVolume int64 `json:"total_bid_volume"` // 총 매수호가 잔량
+
+	TS time.Time `json:"ts"` // 수신 시각
+}
+
+// BestAsk returns best ask level (nil if empty)
+func (b *OrderBook) BestAsk() *OrderBookLevel {
+	if len(b.Asks) == 0 || b.Asks[0].Price <= 0 {
+		return nil
+	}
+	return &b.Asks[0]
+}
+
+// BestBid returns best bid level (nil if empty)
+func (b *OrderBook) BestBid() *OrderBookLevel {
+	if len(b.Bids) == 0 || b.Bids[0].Price <= 0 {
+		return nil
+	}
+	return &b.Bids[0]
+}
+
+// Spread returns best ask - best bid (0 if either side empty)
+func (b *OrderBook) Spread() int64 {
+	ask, bid := b.BestAsk(), b.BestBid()
+	if ask == nil || bid == nil {
+		return 0
+	}
+	return ask.Price - bid.Price
+}
+
+// ApplyTo fills tick top-of-book fields (BidPrice/AskPrice/BidVolume/AskVolume) from the book
+func (b *OrderBook) ApplyTo(tick *Tick) {
+	if ask := b.BestAsk(); ask != nil {
+		p, v := ask.Price, ask.Volume
+		tick.AskPrice = &p
+		tick.AskVolume = &v
+	}
+	if bid := b.BestBid(); bid != nil {
+		p, v := bid.Price, bid.Volume
+		tick.BidPrice = &p
+		tick.BidVolume = &v
+	}
+}
@@ -62,3 +62,9 @@ type PriceRepository interface {
 	BestPriceRepository
 	FreshnessRepository
 }
+
+// BandReader defines interface for today's price band (상/하한가, VI 상태)
+type BandReader interface {
+	// GetPriceBand returns price band for a symbol
+	GetPriceBand(ctx context.Context, symbol string) (*Band, error)
+}
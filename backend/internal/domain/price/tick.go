@@ -0,0 +1,37 @@
+package price
+
+// TickSize returns KRX 호가단위 for a price (2023-01-25 개편, KOSPI/KOSDAQ 공통)
+func TickSize(p int64) int64 {
+	switch {
+	case p < 2_000:
+		return 1
+	case p < 5_000:
+		return 5
+	case p < 20_000:
+		return 10
+	case p < 50_000:
+		return 50
+	case p < 200_000:
+		return 100
+	case p < 500_000:
+		return 500
+	default:
+		return 1_000
+	}
+}
+
+// AddTicks moves price by n ticks (n < 0: 아래로), 구간 경계마다 호가단위 재계산
+// 결과가 1 미만이면 1
+func AddTicks(p int64, n int) int64 {
+	for ; n > 0; n-- {
+		p += TickSize(p)
+	}
+	for ; n < 0; n++ {
+		// 경계 바로 위 가격은 아래 구간 호가단위로 내려감 (예: 2000 → 1999)
+		p -= TickSize(p - 1)
+	}
+	if p < 1 {
+		return 1
+	}
+	return p
+}
@@ -112,6 +112,7 @@ func DefaultRankingCriteria() *RankingCriteria {
 // RiskData 리스크 데이터
 type RiskData struct {
 	Symbol string `json:"symbol"`
+	Sector string `json:"sector"` // 섹터 (다양성 제약용, 없으면 빈 문자열)
 
 	// 변동성
 	Volatility60D float64 `json:"volatility_60d"` // 60일 변동성 (%)
@@ -8,6 +8,9 @@ var (
 	ErrCandidateNotFound  = errors.New("candidate not found")
 	ErrCandidateExists    = errors.New("candidate already exists for exit event")
 
+	// Intent errors
+	ErrEntryIntentExists  = errors.New("entry intent already exists (idempotent)")
+
 	// Control errors
 	ErrControlNotFound    = errors.New("reentry control not found")
 
@@ -9,21 +9,25 @@ import (
 
 // ReentryCandidate represents a reentry candidate FSM
 type ReentryCandidate struct {
-	CandidateID       uuid.UUID       `json:"candidate_id"`        // 후보 고유 ID (PK)
-	ExitEventID       uuid.UUID       `json:"exit_event_id"`       // ExitEvent 참조 (SSOT, UNIQUE)
-	Symbol            string          `json:"symbol"`              // 종목 코드
-	OriginPositionID  uuid.UUID       `json:"origin_position_id"`  // 원 포지션 ID
-	ExitReasonCode    string          `json:"exit_reason_code"`    // SL1/SL2/TRAIL/TP1/TP2/TP3/TIME
-	ExitTS            time.Time       `json:"exit_ts"`             // 청산 시각
-	ExitPrice         decimal.Decimal `json:"exit_price"`          // 청산 가격
-	ExitProfileID     *string         `json:"exit_profile_id"`     // 적용된 Exit 프로파일
-	CooldownUntil     time.Time       `json:"cooldown_until"`      // 쿨다운 종료 시각
-	State             string          `json:"state"`               // FSM 상태
-	MaxReentries      int             `json:"max_reentries"`       // 최대 재진입 횟수
-	ReentryCount      int             `json:"reentry_count"`       // 현재 재진입 횟수
-	ReentryProfileID  *string         `json:"reentry_profile_id"`  // 재진입 프로파일
-	LastEvalTS        *time.Time      `json:"last_eval_ts"`        // 마지막 평가 시각
-	UpdatedTS         time.Time       `json:"updated_ts"`          // 마지막 갱신
+	CandidateID      uuid.UUID        `json:"candidate_id"`       // 후보 고유 ID (PK)
+	ExitEventID      uuid.UUID        `json:"exit_event_id"`      // ExitEvent 참조 (SSOT, UNIQUE)
+	Symbol           string           `json:"symbol"`             // 종목 코드
+	OriginPositionID uuid.UUID        `json:"origin_position_id"` // 원 포지션 ID
+	ExitReasonCode   string           `json:"exit_reason_code"`   // SL1/SL2/TRAIL/TP1/TP2/TP3/TIME
+	ExitTS           time.Time        `json:"exit_ts"`            // 청산 시각
+	ExitPrice        decimal.Decimal  `json:"exit_price"`         // 청산 가격
+	ExitProfileID    *string          `json:"exit_profile_id"`    // 적용된 Exit 프로파일
+	CooldownUntil    time.Time        `json:"cooldown_until"`     // 쿨다운 종료 시각
+	State            string           `json:"state"`              // FSM 상태
+	MaxReentries     int              `json:"max_reentries"`      // 최대 재진입 횟수
+	ReentryCount     int              `json:"reentry_count"`      // 현재 재진입 횟수
+	ReentryProfileID *string          `json:"reentry_profile_id"` // 재진입 프로파일
+	LastEvalTS       *time.Time       `json:"last_eval_ts"`       // 마지막 평가 시각
+	ReadyReasonCode  *string          `json:"ready_reason_code"`  // READY 전이 사유 (REENTRY_*)
+	ReadyPrice       *decimal.Decimal `json:"ready_price"`        // READY 전이 시점 가격
+	ReadyTS          *time.Time       `json:"ready_ts"`           // READY 전이 시각
+	EntryIntentID    *uuid.UUID       `json:"entry_intent_id"`    // 생성된 ENTRY intent (READY → ENTERED 추적)
+	UpdatedTS        time.Time        `json:"updated_ts"`         // 마지막 갱신
 }
 
 // Candidate FSM States
@@ -113,6 +117,19 @@ const (
 	ReasonReentryChase    = "REENTRY_CHASE"    // Chase 전략
 )
 
+// EntryIntent represents an ENTRY order intent (READY → trade.order_intents)
+type EntryIntent struct {
+	IntentID    uuid.UUID        // 생성할 intent ID
+	PositionID  uuid.UUID        // 체결 시 생성될 신규 포지션 ID
+	CandidateID uuid.UUID        // 원 후보 ID
+	Symbol      string           // 종목 코드
+	Qty         int64            // 매수 수량 (Sizing 결과)
+	OrderType   string           // MKT, LMT
+	LimitPrice  *decimal.Decimal // LMT일 때만
+	ReasonCode  string           // REENTRY_REBOUND, REENTRY_BREAKOUT, REENTRY_CHASE
+	ActionKey   string           // {candidate_id}:ENTRY:{n} (unique, idempotency)
+}
+
 // Sizing Modes
 const (
 	SizingModeFixed   = "FIXED"   // 고정 수량
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // CandidateRepository manages reentry candidate persistence
@@ -21,6 +22,15 @@ type CandidateRepository interface {
 	// UpdateCandidateState updates candidate FSM state
 	UpdateCandidateState(ctx context.Context, candidateID uuid.UUID, state string) error
 
+	// MarkReady transitions candidate WATCH → READY with the trigger that fired
+	MarkReady(ctx context.Context, candidateID uuid.UUID, reasonCode string, readyPrice decimal.Decimal) error
+
+	// GetCandidateByEntryIntent retrieves a candidate by the ENTRY intent it created
+	GetCandidateByEntryIntent(ctx context.Context, intentID uuid.UUID) (*ReentryCandidate, error)
+
+	// MarkEntered transitions candidate READY → ENTERED and increments reentry count
+	MarkEntered(ctx context.Context, candidateID uuid.UUID) error
+
 	// UpdateReentryCount increments reentry count
 	UpdateReentryCount(ctx context.Context, candidateID uuid.UUID) error
 
@@ -0,0 +1,14 @@
+package risk
+
+import "errors"
+
+var (
+	// ErrLimitsNotFound 활성 리스크 한도 없음 (Fail-Closed: 주문 차단)
+	ErrLimitsNotFound = errors.New("active risk limits not found")
+
+	// ErrInvalidLimits 리스크 한도 값 오류
+	ErrInvalidLimits = errors.New("invalid risk limits")
+
+	// ErrEmergencyStopNotFound 긴급 정지 row 없음
+	ErrEmergencyStopNotFound = errors.New("emergency stop control not found")
+)
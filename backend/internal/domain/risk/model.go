@@ -0,0 +1,182 @@
+package risk
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// =============================================================================
+// Risk Limits
+// =============================================================================
+
+// RiskLimits 리스크 한도 설정 (control.risk_limits)
+type RiskLimits struct {
+	ID          uuid.UUID `json:"id"`
+	ProfileName string    `json:"profile_name"` // DEFAULT, CONSERVATIVE, AGGRESSIVE
+
+	// 포지션 한도
+	MaxTotalPositions int     `json:"max_total_positions"` // 최대 보유 종목 수
+	MaxSingleWeight   float64 `json:"max_single_weight"`   // 종목당 최대 비중 (%)
+
+	// 집중도 한도
+	MaxSectorWeight float64 `json:"max_sector_weight"` // 섹터당 최대 비중 (%)
+
+	// 손실 한도
+	MaxDailyLossPct float64 `json:"max_daily_loss_pct"` // 일간 최대 손실 (%, 음수)
+
+	// 주문 한도
+	MaxOrderValue        decimal.Decimal `json:"max_order_value"`         // 1회 주문 최대 금액 (원)
+	MaxPriceDeviationPct float64         `json:"max_price_deviation_pct"` // 지정가 vs 현재가 최대 괴리 (%)
+
+	// 메타
+	IsActive  bool      `json:"is_active"`
+	UpdatedBy string    `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// 기본 프로필
+const (
+	ProfileDefault = "DEFAULT"
+
+	DefaultMaxTotalPositions    = 15
+	DefaultMaxSingleWeight      = 15.0 // 15%
+	DefaultMaxSectorWeight      = 40.0 // 40%
+	DefaultMaxDailyLossPct      = -3.0 // -3%
+	DefaultMaxOrderValue        = 20_000_000
+	DefaultMaxPriceDeviationPct = 5.0 // ±5%
+)
+
+// DefaultRiskLimits 기본 리스크 한도
+func DefaultRiskLimits() RiskLimits {
+	return RiskLimits{
+		ProfileName:          ProfileDefault,
+		MaxTotalPositions:    DefaultMaxTotalPositions,
+		MaxSingleWeight:      DefaultMaxSingleWeight,
+		MaxSectorWeight:      DefaultMaxSectorWeight,
+		MaxDailyLossPct:      DefaultMaxDailyLossPct,
+		MaxOrderValue:        decimal.NewFromInt(DefaultMaxOrderValue),
+		MaxPriceDeviationPct: DefaultMaxPriceDeviationPct,
+		IsActive:             true,
+	}
+}
+
+// Validate 한도 값 검증 (DB CHECK 제약과 동일)
+func (l *RiskLimits) Validate() error {
+	switch {
+	case l.MaxTotalPositions <= 0:
+		return ErrInvalidLimits
+	case l.MaxSingleWeight <= 0 || l.MaxSingleWeight > 100:
+		return ErrInvalidLimits
+	case l.MaxSectorWeight <= 0 || l.MaxSectorWeight > 100:
+		return ErrInvalidLimits
+	case l.MaxDailyLossPct >= 0:
+		return ErrInvalidLimits
+	case !l.MaxOrderValue.IsPositive():
+		return ErrInvalidLimits
+	case l.MaxPriceDeviationPct <= 0 || l.MaxPriceDeviationPct > price.PriceLimitRate*100:
+		return ErrInvalidLimits
+	}
+	return nil
+}
+
+// =============================================================================
+// Emergency Stop
+// =============================================================================
+
+// EmergencyStop 긴급 정지 상태 (Singleton)
+// 활성화 시 모든 신규 매수 차단, 매도(청산)는 허용
+type EmergencyStop struct {
+	Enabled   bool      `json:"enabled"`
+	Reason    *string   `json:"reason,omitempty"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedTS time.Time `json:"updated_ts"`
+}
+
+// =============================================================================
+// Risk Check
+// =============================================================================
+
+// Check Sources
+const (
+	SourceIntent = "INTENT" // Execution intent monitor
+	SourceManual = "MANUAL" // 직접 주문 (POST /api/kis/orders)
+)
+
+// RiskCheckRequest 주문 리스크 검증 요청
+type RiskCheckRequest struct {
+	Source     string           `json:"source"`              // INTENT | MANUAL
+	IntentID   *uuid.UUID       `json:"intent_id,omitempty"` // INTENT일 때만
+	AccountID  string           `json:"account_id"`
+	Symbol     string           `json:"symbol"`
+	Side       string           `json:"side"`                  // BUY | SELL
+	IntentType string           `json:"intent_type,omitempty"` // ENTRY, EXIT_*, REBALANCE_* (직접 주문은 빈 값)
+	OrderType  string           `json:"order_type"`            // MKT | LMT
+	Qty        int64            `json:"qty"`
+	LimitPrice *decimal.Decimal `json:"limit_price,omitempty"`
+}
+
+// RiskCheckResult 리스크 검증 결과
+type RiskCheckResult struct {
+	Approved    bool            `json:"approved"`
+	Checks      []RiskCheck     `json:"checks"`
+	FailedCheck *RiskCheck      `json:"failed_check,omitempty"` // 첫 번째 실패
+	RefPrice    decimal.Decimal `json:"ref_price"`              // 기준가 (현재가)
+	OrderValue  decimal.Decimal `json:"order_value"`            // 예상 주문 금액
+	Message     string          `json:"message"`
+	CheckedAt   time.Time       `json:"checked_at"`
+}
+
+// RiskCheck 개별 리스크 검증
+type RiskCheck struct {
+	CheckType    RiskCheckType `json:"check_type"`
+	Passed       bool          `json:"passed"`
+	CurrentValue float64       `json:"current_value"`
+	LimitValue   float64       `json:"limit_value"`
+	Message      string        `json:"message"`
+}
+
+// RiskCheckType 리스크 검증 유형 (차단 사유 코드)
+type RiskCheckType string
+
+const (
+	CheckEmergencyStop  RiskCheckType = "EMERGENCY_STOP"
+	CheckPriceBand      RiskCheckType = "PRICE_BAND"
+	CheckOrderValue     RiskCheckType = "ORDER_VALUE"
+	CheckTotalPositions RiskCheckType = "TOTAL_POSITIONS"
+	CheckSingleWeight   RiskCheckType = "SINGLE_WEIGHT"
+	CheckSectorWeight   RiskCheckType = "SECTOR_WEIGHT"
+	CheckDailyLoss      RiskCheckType = "DAILY_LOSS"
+)
+
+// =============================================================================
+// Risk Block (차단 기록)
+// =============================================================================
+
+// RiskBlock 차단된 주문 기록 (control.risk_blocks)
+type RiskBlock struct {
+	BlockID      uuid.UUID       `json:"block_id"`
+	Source       string          `json:"source"`
+	IntentID     *uuid.UUID      `json:"intent_id,omitempty"`
+	AccountID    string          `json:"account_id"`
+	Symbol       string          `json:"symbol"`
+	Side         string          `json:"side"`
+	IntentType   string          `json:"intent_type,omitempty"`
+	Qty          int64           `json:"qty"`
+	OrderValue   decimal.Decimal `json:"order_value"`
+	CheckType    RiskCheckType   `json:"check_type"`
+	Message      string          `json:"message"`
+	CurrentValue float64         `json:"current_value"`
+	LimitValue   float64         `json:"limit_value"`
+	BlockedAt    time.Time       `json:"blocked_at"`
+}
+
+// SymbolInfo 종목 시장/섹터 (data.stocks)
+type SymbolInfo struct {
+	Symbol string `json:"symbol"`
+	Market string `json:"market"`
+	Sector string `json:"sector"`
+}
@@ -0,0 +1,36 @@
+package risk
+
+import (
+	"context"
+	"time"
+)
+
+// RiskRepository 리스크 한도/긴급 정지/차단 기록 저장소 (control schema)
+type RiskRepository interface {
+	// 활성 한도 조회
+	GetActiveLimits(ctx context.Context) (*RiskLimits, error)
+
+	// 프로필 한도 조회 (계좌별 프로필, 활성 여부 무관)
+	GetLimitsByProfile(ctx context.Context, profileName string) (*RiskLimits, error)
+
+	// 한도 저장 (profile_name 기준 upsert, 해당 프로필 활성화)
+	UpdateLimits(ctx context.Context, limits *RiskLimits) error
+
+	// 긴급 정지 상태 조회
+	GetEmergencyStop(ctx context.Context) (*EmergencyStop, error)
+
+	// 긴급 정지 설정
+	SetEmergencyStop(ctx context.Context, stop *EmergencyStop) error
+
+	// 차단 기록 저장
+	SaveBlock(ctx context.Context, block *RiskBlock) error
+
+	// 차단 기록 조회 (최신순)
+	ListBlocks(ctx context.Context, since time.Time, limit int) ([]*RiskBlock, error)
+}
+
+// SymbolInfoReader 종목 시장/섹터 Reader
+type SymbolInfoReader interface {
+	// 종목별 시장/섹터 조회 (없는 종목은 결과에서 제외)
+	LoadSymbolInfo(ctx context.Context, symbols []string) (map[string]SymbolInfo, error)
+}
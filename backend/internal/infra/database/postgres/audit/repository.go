@@ -182,6 +182,41 @@ func (r *Repository) GetDailyReturns(ctx context.Context, startDate, endDate tim
 	return returns, nil
 }
 
+// GetAlignedReturns 포트폴리오/벤치마크 일별 수익률 (같은 날짜 기준 정렬)
+func (r *Repository) GetAlignedReturns(ctx context.Context, code string, startDate, endDate time.Time) ([]float64, []float64, error) {
+	query := `
+		SELECT s.daily_return, b.daily_return
+		FROM audit.daily_snapshots s
+		JOIN audit.benchmark_data b
+		  ON b.benchmark_date = s.date
+		 AND b.benchmark_code = $1
+		WHERE s.date BETWEEN $2 AND $3
+		  AND s.daily_return IS NOT NULL
+		  AND b.daily_return IS NOT NULL
+		ORDER BY s.date ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, code, startDate, endDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query aligned returns: %w", err)
+	}
+	defer rows.Close()
+
+	portfolio := make([]float64, 0)
+	benchmark := make([]float64, 0)
+
+	for rows.Next() {
+		var p, b float64
+		if err := rows.Scan(&p, &b); err != nil {
+			continue
+		}
+		portfolio = append(portfolio, p)
+		benchmark = append(benchmark, b)
+	}
+
+	return portfolio, benchmark, nil
+}
+
 // =============================================================================
 // PnL Repository
 // =============================================================================
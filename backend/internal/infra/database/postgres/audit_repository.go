@@ -192,6 +192,39 @@ func (r *AuditRepository) GetDailyReturns(ctx context.Context, startDate, endDat
 	return returns, rows.Err()
 }
 
+// GetAlignedReturns 포트폴리오/벤치마크 일별 수익률 (같은 날짜 기준 정렬)
+func (r *AuditRepository) GetAlignedReturns(ctx context.Context, code string, startDate, endDate time.Time) ([]float64, []float64, error) {
+	query := `
+		SELECT s.daily_return, b.daily_return
+		FROM audit.daily_snapshots s
+		JOIN audit.benchmark_data b
+		  ON b.benchmark_date = s.date
+		 AND b.benchmark_code = $1
+		WHERE s.date BETWEEN $2 AND $3
+		  AND s.daily_return IS NOT NULL
+		  AND b.daily_return IS NOT NULL
+		ORDER BY s.date ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, code, startDate, endDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var portfolio, benchmark []float64
+	for rows.Next() {
+		var p, b float64
+		if err := rows.Scan(&p, &b); err != nil {
+			return nil, nil, err
+		}
+		portfolio = append(portfolio, p)
+		benchmark = append(benchmark, b)
+	}
+
+	return portfolio, benchmark, rows.Err()
+}
+
 // =============================================================================
 // PnL Repository
 // =============================================================================
@@ -528,7 +561,7 @@ func (r *AuditRepository) GetAttribution(ctx context.Context, period audit.Perio
 func (r *AuditRepository) GetTrades(ctx context.Context, startDate, endDate time.Time) ([]audit.Trade, error) {
 	query := `
 		SELECT symbol, side, quantity, price, pnl, pnl_percent,
-			   entry_date, exit_date, hold_days
+			   entry_date, exit_date, hold_days, fee, tax, account_id
 		FROM audit.trades
 		WHERE exit_date BETWEEN $1 AND $2
 		ORDER BY exit_date ASC
@@ -553,6 +586,9 @@ func (r *AuditRepository) GetTrades(ctx context.Context, startDate, endDate time
 			&trade.EntryDate,
 			&trade.ExitDate,
 			&trade.HoldDays,
+			&trade.Fee,
+			&trade.Tax,
+			&trade.AccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -566,7 +602,7 @@ func (r *AuditRepository) GetTrades(ctx context.Context, startDate, endDate time
 func (r *AuditRepository) GetTradesBySymbol(ctx context.Context, symbol string, startDate, endDate time.Time) ([]audit.Trade, error) {
 	query := `
 		SELECT symbol, side, quantity, price, pnl, pnl_percent,
-			   entry_date, exit_date, hold_days
+			   entry_date, exit_date, hold_days, fee, tax, account_id
 		FROM audit.trades
 		WHERE symbol = $1
 		  AND exit_date BETWEEN $2 AND $3
@@ -592,6 +628,9 @@ func (r *AuditRepository) GetTradesBySymbol(ctx context.Context, symbol string,
 			&trade.EntryDate,
 			&trade.ExitDate,
 			&trade.HoldDays,
+			&trade.Fee,
+			&trade.Tax,
+			&trade.AccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -603,13 +642,19 @@ func (r *AuditRepository) GetTradesBySymbol(ctx context.Context, symbol string,
 
 // SaveTradeHistory 거래 내역 저장 (중복 방지: symbol + entry_date + exit_date 기준)
 func (r *AuditRepository) SaveTradeHistory(ctx context.Context, trade *audit.Trade) error {
-	// 먼저 중복 확인 (symbol + entry_date + exit_date + entry_price 기준)
+	entryPrice := trade.EntryPrice
+	if entryPrice == 0 {
+		entryPrice = trade.Price // 매수가 미상 (ExitEvent 기반)
+	}
+
+	// 먼저 중복 확인 (account + symbol + entry_date + exit_date + entry_price 기준)
 	checkQuery := `
 		SELECT 1 FROM audit.trade_history
 		WHERE stock_code = $1
 		  AND entry_date = $2
 		  AND exit_date = $3
 		  AND entry_price = $4
+		  AND account_id = $5
 		LIMIT 1
 	`
 	var exists int
@@ -617,7 +662,8 @@ func (r *AuditRepository) SaveTradeHistory(ctx context.Context, trade *audit.Tra
 		trade.Symbol,
 		trade.EntryDate,
 		trade.ExitDate,
-		trade.Price,
+		entryPrice,
+		trade.AccountID,
 	).Scan(&exists)
 
 	if err == nil {
@@ -631,8 +677,8 @@ func (r *AuditRepository) SaveTradeHistory(ctx context.Context, trade *audit.Tra
 			entry_date, entry_price, entry_qty,
 			exit_date, exit_price, exit_qty,
 			realized_pnl, realized_pnl_pct, holding_days,
-			exit_reason
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			exit_reason, fee, tax, account_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		ON CONFLICT (trade_id) DO NOTHING
 	`
 
@@ -642,7 +688,7 @@ func (r *AuditRepository) SaveTradeHistory(ctx context.Context, trade *audit.Tra
 		trade.Symbol,
 		"", // stock_name - optional
 		trade.EntryDate,
-		trade.Price,
+		entryPrice,
 		trade.Quantity,
 		trade.ExitDate,
 		trade.Price,
@@ -651,21 +697,25 @@ func (r *AuditRepository) SaveTradeHistory(ctx context.Context, trade *audit.Tra
 		trade.PnLPercent,
 		trade.HoldDays,
 		trade.ExitReason,
+		trade.Fee,
+		trade.Tax,
+		trade.AccountID,
 	)
 
 	return err
 }
 
-// GetLastTradeDate 마지막 거래 날짜 조회
-func (r *AuditRepository) GetLastTradeDate(ctx context.Context) (time.Time, error) {
+// GetLastTradeDate 계좌별 마지막 거래 날짜 조회
+func (r *AuditRepository) GetLastTradeDate(ctx context.Context, accountID string) (time.Time, error) {
 	query := `
 		SELECT COALESCE(MAX(exit_date), '1970-01-01'::DATE)
 		FROM audit.trade_history
 		WHERE exit_date IS NOT NULL
+		  AND account_id = $1
 	`
 
 	var lastDate time.Time
-	err := r.pool.QueryRow(ctx, query).Scan(&lastDate)
+	err := r.pool.QueryRow(ctx, query, accountID).Scan(&lastDate)
 	return lastDate, err
 }
 
@@ -66,3 +66,63 @@ func (r *ExitControlRepository) UpdateControl(ctx context.Context, mode string,
 
 	return nil
 }
+
+// GetAccountControls retrieves per-account control modes (trade.account_exit_control)
+func (r *ExitControlRepository) GetAccountControls(ctx context.Context) (map[string]*exit.ExitControl, error) {
+	query := `
+		SELECT
+			account_id,
+			mode,
+			reason,
+			updated_by,
+			updated_ts
+		FROM trade.account_exit_control
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query account exit control: %w", err)
+	}
+	defer rows.Close()
+
+	controls := make(map[string]*exit.ExitControl)
+	for rows.Next() {
+		var ctrl exit.ExitControl
+		if err := rows.Scan(
+			&ctrl.AccountID,
+			&ctrl.Mode,
+			&ctrl.Reason,
+			&ctrl.UpdatedBy,
+			&ctrl.UpdatedTS,
+		); err != nil {
+			return nil, fmt.Errorf("scan account exit control: %w", err)
+		}
+		controls[ctrl.AccountID] = &ctrl
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return controls, nil
+}
+
+// UpdateAccountControl upserts control mode of an account
+func (r *ExitControlRepository) UpdateAccountControl(ctx context.Context, accountID, mode string, reason *string, updatedBy string) error {
+	query := `
+		INSERT INTO trade.account_exit_control (account_id, mode, reason, updated_by, updated_ts)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (account_id) DO UPDATE SET
+			mode = EXCLUDED.mode,
+			reason = EXCLUDED.reason,
+			updated_by = EXCLUDED.updated_by,
+			updated_ts = EXCLUDED.updated_ts
+	`
+
+	_, err := r.pool.Exec(ctx, query, accountID, mode, reason, updatedBy)
+	if err != nil {
+		return fmt.Errorf("update account exit control: %w", err)
+	}
+
+	return nil
+}
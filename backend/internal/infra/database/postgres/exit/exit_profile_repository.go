@@ -51,7 +51,7 @@ func (r *ExitProfileRepository) GetProfile(ctx context.Context, profileID string
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("profile not found: %s", profileID)
+			return nil, fmt.Errorf("%w: %s", exit.ErrProfileNotFound, profileID)
 		}
 		return nil, fmt.Errorf("query profile: %w", err)
 	}
@@ -24,6 +24,7 @@ func NewOrderIntentRepository(pool *pgxpool.Pool) *OrderIntentRepository {
 }
 
 // CreateIntent creates a new intent (idempotent via action_key unique constraint)
+// decided_ts: intent.DecidedTS 우선, 미지정 + decided_by 있으면 NOW()
 func (r *OrderIntentRepository) CreateIntent(ctx context.Context, intent *exit.OrderIntent) error {
 	query := `
 		INSERT INTO trade.order_intents (
@@ -38,8 +39,16 @@ func (r *OrderIntentRepository) CreateIntent(ctx context.Context, intent *exit.O
 			reason_detail,
 			action_key,
 			status,
+			account_id,
+			approval_mode,
+			approval_source,
+			approval_deadline,
+			decided_by,
+			decided_ts,
 			created_ts
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+			NULLIF($13, ''), NULLIF($14, ''), $15, NULLIF($16, ''),
+			COALESCE($17, CASE WHEN $16 <> '' THEN NOW() END), NOW())
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -54,6 +63,12 @@ func (r *OrderIntentRepository) CreateIntent(ctx context.Context, intent *exit.O
 		intent.ReasonDetail,
 		intent.ActionKey,
 		intent.Status,
+		intent.AccountID,
+		intent.ApprovalMode,
+		intent.ApprovalSource,
+		intent.ApprovalDeadline,
+		intent.DecidedBy,
+		intent.DecidedTS,
 	)
 
 	if err != nil {
@@ -82,7 +97,8 @@ func (r *OrderIntentRepository) GetIntent(ctx context.Context, intentID uuid.UUI
 			reason_code,
 			action_key,
 			status,
-			created_ts
+			created_ts,
+			account_id
 		FROM trade.order_intents
 		WHERE intent_id = $1
 	`
@@ -100,6 +116,7 @@ func (r *OrderIntentRepository) GetIntent(ctx context.Context, intentID uuid.UUI
 		&intent.ActionKey,
 		&intent.Status,
 		&intent.CreatedTS,
+		&intent.AccountID,
 	)
 
 	if err != nil {
@@ -126,7 +143,8 @@ func (r *OrderIntentRepository) GetIntentByActionKey(ctx context.Context, action
 			reason_code,
 			action_key,
 			status,
-			created_ts
+			created_ts,
+			account_id
 		FROM trade.order_intents
 		WHERE action_key = $1
 	`
@@ -144,6 +162,7 @@ func (r *OrderIntentRepository) GetIntentByActionKey(ctx context.Context, action
 		&intent.ActionKey,
 		&intent.Status,
 		&intent.CreatedTS,
+		&intent.AccountID,
 	)
 
 	if err != nil {
@@ -170,7 +189,8 @@ func (r *OrderIntentRepository) GetActiveIntentsByPosition(ctx context.Context,
 			reason_code,
 			action_key,
 			status,
-			created_ts
+			created_ts,
+			account_id
 		FROM trade.order_intents
 		WHERE position_id = $1
 		  AND status IN ('NEW', 'PENDING_APPROVAL', 'ACK')
@@ -198,6 +218,7 @@ func (r *OrderIntentRepository) GetActiveIntentsByPosition(ctx context.Context,
 			&intent.ActionKey,
 			&intent.Status,
 			&intent.CreatedTS,
+			&intent.AccountID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan intent: %w", err)
@@ -244,7 +265,13 @@ func (r *OrderIntentRepository) GetRecentIntents(ctx context.Context, limit int)
 			COALESCE(i.reason_detail, '') AS reason_detail,
 			i.action_key,
 			i.status,
-			i.created_ts
+			i.created_ts,
+			i.account_id,
+			COALESCE(i.approval_mode, '') AS approval_mode,
+			COALESCE(i.approval_source, '') AS approval_source,
+			i.approval_deadline,
+			COALESCE(i.decided_by, '') AS decided_by,
+			i.decided_ts
 		FROM trade.order_intents i
 		LEFT JOIN LATERAL (
 			SELECT raw FROM trade.holdings
@@ -278,6 +305,12 @@ func (r *OrderIntentRepository) GetRecentIntents(ctx context.Context, limit int)
 			&intent.ActionKey,
 			&intent.Status,
 			&intent.CreatedTS,
+			&intent.AccountID,
+			&intent.ApprovalMode,
+			&intent.ApprovalSource,
+			&intent.ApprovalDeadline,
+			&intent.DecidedBy,
+			&intent.DecidedTS,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan intent: %w", err)
@@ -306,7 +339,8 @@ func (r *OrderIntentRepository) LoadIntentsForPosition(ctx context.Context, posi
 			reason_code,
 			action_key,
 			status,
-			created_ts
+			created_ts,
+			account_id
 		FROM trade.order_intents
 		WHERE position_id = $1
 			AND created_ts >= $2
@@ -359,6 +393,7 @@ func (r *OrderIntentRepository) LoadIntentsForPosition(ctx context.Context, posi
 			&intent.ActionKey,
 			&intent.Status,
 			&intent.CreatedTS,
+			&intent.AccountID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan intent: %w", err)
@@ -388,7 +423,8 @@ func (r *OrderIntentRepository) LoadNewIntents(ctx context.Context) ([]*exit.Ord
 			reason_code,
 			action_key,
 			status,
-			created_ts
+			created_ts,
+			account_id
 		FROM trade.order_intents
 		WHERE status = $1
 		ORDER BY created_ts ASC
@@ -415,6 +451,7 @@ func (r *OrderIntentRepository) LoadNewIntents(ctx context.Context) ([]*exit.Ord
 			&intent.ActionKey,
 			&intent.Status,
 			&intent.CreatedTS,
+			&intent.AccountID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan intent: %w", err)
@@ -430,16 +467,27 @@ func (r *OrderIntentRepository) LoadNewIntents(ctx context.Context) ([]*exit.Ord
 }
 
 // ApproveIntent approves an intent (PENDING_APPROVAL → NEW)
-func (r *OrderIntentRepository) ApproveIntent(ctx context.Context, intentID uuid.UUID) error {
+func (r *OrderIntentRepository) ApproveIntent(ctx context.Context, intentID uuid.UUID, decidedBy string) error {
+	return r.decideIntent(ctx, intentID, exit.IntentStatusNew, decidedBy)
+}
+
+// RejectIntent rejects an intent (PENDING_APPROVAL → CANCELLED)
+func (r *OrderIntentRepository) RejectIntent(ctx context.Context, intentID uuid.UUID, decidedBy string) error {
+	return r.decideIntent(ctx, intentID, exit.IntentStatusCancelled, decidedBy)
+}
+
+// decideIntent moves a PENDING_APPROVAL intent to status and records who decided
+// 사용자 승인과 timeout 처리가 경합해도 먼저 반영된 쪽만 적용 (status 조건)
+func (r *OrderIntentRepository) decideIntent(ctx context.Context, intentID uuid.UUID, status, decidedBy string) error {
 	query := `
 		UPDATE trade.order_intents
-		SET status = $1
-		WHERE intent_id = $2 AND status = $3
+		SET status = $1, decided_by = $2, decided_ts = NOW()
+		WHERE intent_id = $3 AND status = $4
 	`
 
-	result, err := r.pool.Exec(ctx, query, exit.IntentStatusNew, intentID, exit.IntentStatusPendingApproval)
+	result, err := r.pool.Exec(ctx, query, status, decidedBy, intentID, exit.IntentStatusPendingApproval)
 	if err != nil {
-		return fmt.Errorf("approve intent: %w", err)
+		return fmt.Errorf("decide intent: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -449,22 +497,60 @@ func (r *OrderIntentRepository) ApproveIntent(ctx context.Context, intentID uuid
 	return nil
 }
 
-// RejectIntent rejects an intent (PENDING_APPROVAL → CANCELLED)
-func (r *OrderIntentRepository) RejectIntent(ctx context.Context, intentID uuid.UUID) error {
+// LoadExpiredApprovals loads PENDING_APPROVAL intents whose approval deadline passed
+func (r *OrderIntentRepository) LoadExpiredApprovals(ctx context.Context, now time.Time) ([]*exit.OrderIntent, error) {
 	query := `
-		UPDATE trade.order_intents
-		SET status = $1
-		WHERE intent_id = $2 AND status = $3
+		SELECT
+			intent_id,
+			position_id,
+			symbol,
+			intent_type,
+			qty,
+			reason_code,
+			action_key,
+			status,
+			account_id,
+			COALESCE(approval_mode, '') AS approval_mode,
+			COALESCE(approval_source, '') AS approval_source,
+			approval_deadline
+		FROM trade.order_intents
+		WHERE status = $1
+			AND approval_deadline IS NOT NULL
+			AND approval_deadline <= $2
+		ORDER BY approval_deadline ASC
 	`
 
-	result, err := r.pool.Exec(ctx, query, exit.IntentStatusCancelled, intentID, exit.IntentStatusPendingApproval)
+	rows, err := r.pool.Query(ctx, query, exit.IntentStatusPendingApproval, now)
 	if err != nil {
-		return fmt.Errorf("reject intent: %w", err)
+		return nil, fmt.Errorf("query expired approvals: %w", err)
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("intent not found or already processed")
+	var intents []*exit.OrderIntent
+	for rows.Next() {
+		intent := &exit.OrderIntent{}
+		if err := rows.Scan(
+			&intent.IntentID,
+			&intent.PositionID,
+			&intent.Symbol,
+			&intent.IntentType,
+			&intent.Qty,
+			&intent.ReasonCode,
+			&intent.ActionKey,
+			&intent.Status,
+			&intent.AccountID,
+			&intent.ApprovalMode,
+			&intent.ApprovalSource,
+			&intent.ApprovalDeadline,
+		); err != nil {
+			return nil, fmt.Errorf("scan intent: %w", err)
+		}
+		intents = append(intents, intent)
 	}
 
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return intents, nil
 }
@@ -427,6 +427,179 @@ func (r *PositionRepository) GetAvailableQty(ctx context.Context, positionID uui
 	return availableQty, nil
 }
 
+// OpenPosition opens a position from an ENTRY fill
+// trade.positions는 (account_id, symbol) 당 1행:
+//   - 행 없음 → 신규 생성
+//   - CLOSED 또는 수량 0 (직전 포지션 청산 완료) → 기존 position_id로 재오픈, Exit FSM state OPEN 리셋 (이전 HWM/StopFloor 제거)
+//   - 보유 중 (OPEN/CLOSING, 수량 > 0) → 추가 매수: 수량 합산 + 가중평균 단가, phase/state 유지
+//
+// position에는 실제 사용된 position_id / 합산 결과가 반영됨
+func (r *PositionRepository) OpenPosition(ctx context.Context, position *exit.Position) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var existingID uuid.UUID
+	var existingQty int64
+	var existingStatus string
+	err = tx.QueryRow(ctx, `
+		SELECT position_id, qty, status
+		FROM trade.positions
+		WHERE account_id = $1 AND symbol = $2
+		FOR UPDATE
+	`, position.AccountID, position.Symbol).Scan(&existingID, &existingQty, &existingStatus)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("lock position: %w", err)
+	}
+	found := err == nil
+
+	// 보유 중 포지션에 추가 매수 → 합산 (청산 진행 상태 유지)
+	if found && existingStatus != exit.StatusClosed && existingQty > 0 {
+		mergeQuery := `
+			UPDATE trade.positions
+			SET avg_price = (avg_price * qty + $2 * $3) / (qty + $3),
+			    qty = qty + $3,
+			    original_qty = original_qty + $3,
+			    updated_ts = NOW(),
+			    version = version + 1
+			WHERE position_id = $1
+			RETURNING qty, original_qty, avg_price, entry_ts, status, exit_mode, exit_profile_id, strategy_id, version
+		`
+		err = tx.QueryRow(ctx, mergeQuery, existingID, position.AvgPrice, position.Qty).Scan(
+			&position.Qty,
+			&position.OriginalQty,
+			&position.AvgPrice,
+			&position.EntryTS,
+			&position.Status,
+			&position.ExitMode,
+			&position.ExitProfileID,
+			&position.StrategyID,
+			&position.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("merge position: %w", err)
+		}
+		position.PositionID = existingID
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("commit transaction: %w", err)
+		}
+		return nil
+	}
+
+	if found {
+		reopenQuery := `
+			UPDATE trade.positions
+			SET side = $2,
+			    qty = $3,
+			    original_qty = $3,
+			    avg_price = $4,
+			    entry_ts = $5,
+			    status = $6,
+			    exit_mode = $7,
+			    exit_profile_id = $8,
+			    strategy_id = $9,
+			    updated_ts = NOW(),
+			    version = version + 1
+			WHERE position_id = $1
+			RETURNING version
+		`
+		err = tx.QueryRow(ctx, reopenQuery,
+			existingID,
+			position.Side,
+			position.Qty,
+			position.AvgPrice,
+			position.EntryTS,
+			exit.StatusOpen,
+			position.ExitMode,
+			position.ExitProfileID,
+			position.StrategyID,
+		).Scan(&position.Version)
+		if err != nil {
+			return fmt.Errorf("reopen position: %w", err)
+		}
+		position.PositionID = existingID
+	} else {
+		insertQuery := `
+			INSERT INTO trade.positions (
+				position_id,
+				account_id,
+				symbol,
+				side,
+				qty,
+				original_qty,
+				avg_price,
+				entry_ts,
+				status,
+				exit_mode,
+				exit_profile_id,
+				strategy_id,
+				updated_ts,
+				version
+			)
+			VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8, $9, $10, $11, NOW(), 1)
+			RETURNING version
+		`
+		err = tx.QueryRow(ctx, insertQuery,
+			position.PositionID,
+			position.AccountID,
+			position.Symbol,
+			position.Side,
+			position.Qty,
+			position.AvgPrice,
+			position.EntryTS,
+			exit.StatusOpen,
+			position.ExitMode,
+			position.ExitProfileID,
+			position.StrategyID,
+		).Scan(&position.Version)
+		if err != nil {
+			return fmt.Errorf("open position: %w", err)
+		}
+	}
+
+	stateQuery := `
+		INSERT INTO trade.position_state (
+			position_id,
+			phase,
+			hwm_price,
+			stop_floor_price,
+			atr,
+			cooldown_until,
+			last_eval_ts,
+			last_avg_price,
+			stop_floor_breach_ticks,
+			trailing_breach_ticks,
+			updated_ts
+		) VALUES ($1, $2, NULL, NULL, NULL, NULL, NOW(), $3, 0, 0, NOW())
+		ON CONFLICT (position_id) DO UPDATE
+		SET
+			phase = EXCLUDED.phase,
+			hwm_price = NULL,
+			stop_floor_price = NULL,
+			cooldown_until = NULL,
+			stop_floor_breach_ticks = 0,
+			trailing_breach_ticks = 0,
+			last_avg_price = EXCLUDED.last_avg_price,
+			last_eval_ts = NOW(),
+			updated_ts = NOW()
+	`
+
+	if _, err := tx.Exec(ctx, stateQuery, position.PositionID, exit.PhaseOpen, position.AvgPrice); err != nil {
+		return fmt.Errorf("reset position state: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	position.OriginalQty = position.Qty
+	position.Status = exit.StatusOpen
+	return nil
+}
+
 // GetPositionBySymbol retrieves a position by symbol and status
 func (r *PositionRepository) GetPositionBySymbol(ctx context.Context, accountID, symbol, status string) (*exit.Position, error) {
 	query := `
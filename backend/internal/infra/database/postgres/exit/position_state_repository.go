@@ -30,7 +30,10 @@ func (r *PositionStateRepository) GetState(ctx context.Context, positionID uuid.
 			phase,
 			hwm_price,
 			stop_floor_price,
+			trailing_stop_price,
 			atr,
+			atr_won,
+			atr_updated_ts,
 			cooldown_until,
 			last_eval_ts,
 			last_avg_price,
@@ -47,7 +50,10 @@ func (r *PositionStateRepository) GetState(ctx context.Context, positionID uuid.
 		&state.Phase,
 		&state.HWMPrice,
 		&state.StopFloorPrice,
+		&state.TrailingStopPrice,
 		&state.ATR,
+		&state.ATRWon,
+		&state.ATRUpdatedTS,
 		&state.CooldownUntil,
 		&state.LastEvalTS,
 		&state.LastAvgPrice,
@@ -71,6 +77,7 @@ func (r *PositionStateRepository) GetState(ctx context.Context, positionID uuid.
 }
 
 // UpsertState creates or updates position state
+// 기존 행의 atr은 유지 (ATR은 UpdateATR 전용, 평가 중 읽은 값으로 덮어쓰지 않음)
 func (r *PositionStateRepository) UpsertState(ctx context.Context, state *exit.PositionState) error {
 	query := `
 		INSERT INTO trade.position_state (
@@ -88,7 +95,6 @@ func (r *PositionStateRepository) UpsertState(ctx context.Context, state *exit.P
 			phase = EXCLUDED.phase,
 			hwm_price = EXCLUDED.hwm_price,
 			stop_floor_price = EXCLUDED.stop_floor_price,
-			atr = EXCLUDED.atr,
 			cooldown_until = EXCLUDED.cooldown_until,
 			last_eval_ts = EXCLUDED.last_eval_ts,
 			updated_ts = NOW()
@@ -165,19 +171,46 @@ func (r *PositionStateRepository) UpdateStopFloor(ctx context.Context, positionI
 	return nil
 }
 
-// UpdateATR updates cached ATR
-func (r *PositionStateRepository) UpdateATR(ctx context.Context, positionID uuid.UUID, atr decimal.Decimal) error {
+// UpdateATR updates cached ATR% and ATR in won (state 미생성 포지션은 OPEN으로 생성)
+func (r *PositionStateRepository) UpdateATR(ctx context.Context, positionID uuid.UUID, atrPct, atrWon decimal.Decimal) error {
+	query := `
+		INSERT INTO trade.position_state (
+			position_id,
+			phase,
+			atr,
+			atr_won,
+			atr_updated_ts,
+			updated_ts
+		) VALUES ($1, $2, $3, $4, NOW(), NOW())
+		ON CONFLICT (position_id) DO UPDATE
+		SET
+			atr = EXCLUDED.atr,
+			atr_won = EXCLUDED.atr_won,
+			atr_updated_ts = EXCLUDED.atr_updated_ts,
+			updated_ts = NOW()
+	`
+
+	_, err := r.pool.Exec(ctx, query, positionID, exit.PhaseOpen, atrPct, atrWon)
+	if err != nil {
+		return fmt.Errorf("update atr: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTrailingStop updates computed trailing stop price
+func (r *PositionStateRepository) UpdateTrailingStop(ctx context.Context, positionID uuid.UUID, stopPrice decimal.Decimal) error {
 	query := `
 		UPDATE trade.position_state
 		SET
-			atr = $1,
+			trailing_stop_price = $1,
 			updated_ts = NOW()
 		WHERE position_id = $2
 	`
 
-	_, err := r.pool.Exec(ctx, query, atr, positionID)
+	_, err := r.pool.Exec(ctx, query, stopPrice, positionID)
 	if err != nil {
-		return fmt.Errorf("update atr: %w", err)
+		return fmt.Errorf("update trailing stop: %w", err)
 	}
 
 	return nil
@@ -223,6 +256,7 @@ func (r *PositionStateRepository) ResetStateToOpen(ctx context.Context, position
 			phase = 'OPEN',
 			hwm_price = NULL,
 			stop_floor_price = NULL,
+			trailing_stop_price = NULL,
 			stop_floor_breach_ticks = 0,
 			trailing_breach_ticks = 0,
 			last_avg_price = EXCLUDED.last_avg_price,
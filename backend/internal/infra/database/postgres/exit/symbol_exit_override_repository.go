@@ -2,6 +2,7 @@ package exit
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -29,6 +30,7 @@ func (r *SymbolExitOverrideRepository) GetOverride(ctx context.Context, symbol s
 			enabled,
 			effective_from,
 			reason,
+			approval_policy,
 			created_by,
 			created_ts
 		FROM trade.symbol_exit_overrides
@@ -36,28 +38,46 @@ func (r *SymbolExitOverrideRepository) GetOverride(ctx context.Context, symbol s
 	`
 
 	var override exit.SymbolExitOverride
+	var approvalJSON []byte
 	err := r.pool.QueryRow(ctx, query, symbol).Scan(
 		&override.Symbol,
 		&override.ProfileID,
 		&override.Enabled,
 		&override.EffectiveFrom,
 		&override.Reason,
+		&approvalJSON,
 		&override.CreatedBy,
 		&override.CreatedTS,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("override not found for symbol: %s", symbol)
+			return nil, fmt.Errorf("%w for symbol: %s", exit.ErrOverrideNotFound, symbol)
 		}
 		return nil, fmt.Errorf("query override: %w", err)
 	}
 
+	// 승인 정책 (NULL → 프로필 정책)
+	if len(approvalJSON) > 0 {
+		if err := json.Unmarshal(approvalJSON, &override.Approval); err != nil {
+			return nil, fmt.Errorf("unmarshal approval policy: %w", err)
+		}
+	}
+
 	return &override, nil
 }
 
 // SetOverride creates or updates symbol override
 func (r *SymbolExitOverrideRepository) SetOverride(ctx context.Context, override *exit.SymbolExitOverride) error {
+	var approvalJSON []byte
+	if override.Approval != nil {
+		var err error
+		approvalJSON, err = json.Marshal(override.Approval)
+		if err != nil {
+			return fmt.Errorf("marshal approval policy: %w", err)
+		}
+	}
+
 	query := `
 		INSERT INTO trade.symbol_exit_overrides (
 			symbol,
@@ -65,15 +85,17 @@ func (r *SymbolExitOverrideRepository) SetOverride(ctx context.Context, override
 			enabled,
 			effective_from,
 			reason,
+			approval_policy,
 			created_by,
 			created_ts
-		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 		ON CONFLICT (symbol) DO UPDATE
 		SET
 			profile_id = EXCLUDED.profile_id,
 			enabled = EXCLUDED.enabled,
 			effective_from = EXCLUDED.effective_from,
 			reason = EXCLUDED.reason,
+			approval_policy = EXCLUDED.approval_policy,
 			created_by = EXCLUDED.created_by
 	`
 
@@ -83,6 +105,7 @@ func (r *SymbolExitOverrideRepository) SetOverride(ctx context.Context, override
 		override.Enabled,
 		override.EffectiveFrom,
 		override.Reason,
+		approvalJSON,
 		override.CreatedBy,
 	)
 
@@ -106,7 +129,7 @@ func (r *SymbolExitOverrideRepository) DeleteOverride(ctx context.Context, symbo
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("override not found for symbol: %s", symbol)
+		return fmt.Errorf("%w for symbol: %s", exit.ErrOverrideNotFound, symbol)
 	}
 
 	return nil
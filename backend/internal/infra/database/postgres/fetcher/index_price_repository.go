@@ -0,0 +1,122 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/infra/database/postgres"
+)
+
+// IndexPriceRepository PostgreSQL 지수 일봉 저장소 (data.index_prices)
+type IndexPriceRepository struct {
+	pool *postgres.Pool
+}
+
+// NewIndexPriceRepository 저장소 생성
+func NewIndexPriceRepository(pool *postgres.Pool) *IndexPriceRepository {
+	return &IndexPriceRepository{pool: pool}
+}
+
+// UpsertBatch 지수 일봉 일괄 저장
+func (r *IndexPriceRepository) UpsertBatch(ctx context.Context, prices []*fetcher.IndexPrice) (int, error) {
+	if len(prices) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO data.index_prices
+			(index_code, trade_date, open_price, high_price, low_price, close_price, volume, trading_value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (index_code, trade_date) DO UPDATE SET
+			open_price = EXCLUDED.open_price,
+			high_price = EXCLUDED.high_price,
+			low_price = EXCLUDED.low_price,
+			close_price = EXCLUDED.close_price,
+			volume = EXCLUDED.volume,
+			trading_value = EXCLUDED.trading_value
+	`
+
+	for _, p := range prices {
+		batch.Queue(query,
+			p.IndexCode, p.TradeDate,
+			p.OpenPrice, p.HighPrice, p.LowPrice, p.ClosePrice,
+			p.Volume, p.TradingValue,
+		)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	count := 0
+	for range prices {
+		if _, err := br.Exec(); err != nil {
+			return count, fmt.Errorf("batch upsert index price: %w", err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// GetRange 기간별 지수 일봉 조회 (trade_date 오름차순)
+func (r *IndexPriceRepository) GetRange(ctx context.Context, indexCode string, from, to time.Time) ([]*fetcher.IndexPrice, error) {
+	query := `
+		SELECT index_code, trade_date, open_price, high_price, low_price, close_price,
+		       volume, COALESCE(trading_value, 0), created_at
+		FROM data.index_prices
+		WHERE index_code = $1 AND trade_date >= $2 AND trade_date <= $3
+		ORDER BY trade_date ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, indexCode, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query index prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*fetcher.IndexPrice
+	for rows.Next() {
+		var p fetcher.IndexPrice
+		if err := rows.Scan(
+			&p.IndexCode, &p.TradeDate,
+			&p.OpenPrice, &p.HighPrice, &p.LowPrice, &p.ClosePrice,
+			&p.Volume, &p.TradingValue, &p.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan index price: %w", err)
+		}
+		prices = append(prices, &p)
+	}
+
+	return prices, rows.Err()
+}
+
+// GetLatest 최신 지수 일봉 조회
+func (r *IndexPriceRepository) GetLatest(ctx context.Context, indexCode string) (*fetcher.IndexPrice, error) {
+	query := `
+		SELECT index_code, trade_date, open_price, high_price, low_price, close_price,
+		       volume, COALESCE(trading_value, 0), created_at
+		FROM data.index_prices
+		WHERE index_code = $1
+		ORDER BY trade_date DESC
+		LIMIT 1
+	`
+
+	var p fetcher.IndexPrice
+	err := r.pool.QueryRow(ctx, query, indexCode).Scan(
+		&p.IndexCode, &p.TradeDate,
+		&p.OpenPrice, &p.HighPrice, &p.LowPrice, &p.ClosePrice,
+		&p.Volume, &p.TradingValue, &p.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fetcher.ErrIndexPriceNotFound
+		}
+		return nil, fmt.Errorf("get latest index price: %w", err)
+	}
+
+	return &p, nil
+}
@@ -189,19 +189,21 @@ func (r *FillRepository) GetRecentFills(ctx context.Context, limit int) ([]*exec
 	return fills, nil
 }
 
-// GetLastCursor retrieves the last sync cursor from the most recent fill
-func (r *FillRepository) GetLastCursor(ctx context.Context) (*execution.FillCursor, error) {
+// GetLastCursor retrieves the last sync cursor from the most recent fill of an account
+func (r *FillRepository) GetLastCursor(ctx context.Context, accountID string) (*execution.FillCursor, error) {
 	query := `
 		SELECT
-			ts,
-			seq
-		FROM trade.fills
-		ORDER BY ts DESC, seq DESC
+			f.ts,
+			f.seq
+		FROM trade.fills f
+		JOIN trade.orders o ON o.order_id = f.order_id
+		WHERE o.account_id = $1
+		ORDER BY f.ts DESC, f.seq DESC
 		LIMIT 1
 	`
 
 	cursor := &execution.FillCursor{}
-	err := r.pool.QueryRow(ctx, query).Scan(
+	err := r.pool.QueryRow(ctx, query, accountID).Scan(
 		&cursor.LastTS,
 		&cursor.LastSeq,
 	)
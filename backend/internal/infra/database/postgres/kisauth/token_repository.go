@@ -0,0 +1,85 @@
+package kisauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/infra/kis"
+)
+
+// TokenRepository KIS 토큰 공유 저장소 구현 (control.kis_tokens + advisory lock)
+type TokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTokenRepository 새 리포지토리 생성
+func NewTokenRepository(pool *pgxpool.Pool) *TokenRepository {
+	return &TokenRepository{pool: pool}
+}
+
+// Load 저장된 토큰 조회 (없으면 nil)
+func (r *TokenRepository) Load(ctx context.Context, key string) (*kis.StoredToken, error) {
+	var t kis.StoredToken
+	err := r.pool.QueryRow(ctx, `
+		SELECT token_key, kind, token, expires_at, COALESCE(hold_until, 'epoch'::timestamptz), updated_ts
+		FROM control.kis_tokens
+		WHERE token_key = $1
+	`, key).Scan(&t.Key, &t.Kind, &t.Token, &t.ExpiresAt, &t.HoldUntil, &t.UpdatedTS)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("query kis token: %w", err)
+	}
+	return &t, nil
+}
+
+// Save 토큰 upsert
+func (r *TokenRepository) Save(ctx context.Context, token *kis.StoredToken) error {
+	var holdUntil *time.Time
+	if !token.HoldUntil.IsZero() {
+		holdUntil = &token.HoldUntil
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO control.kis_tokens (token_key, kind, token, expires_at, hold_until, updated_ts)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (token_key) DO UPDATE SET
+			token = EXCLUDED.token,
+			expires_at = EXCLUDED.expires_at,
+			hold_until = EXCLUDED.hold_until,
+			updated_ts = EXCLUDED.updated_ts
+	`, token.Key, token.Kind, token.Token, token.ExpiresAt, holdUntil, token.UpdatedTS)
+	if err != nil {
+		return fmt.Errorf("upsert kis token: %w", err)
+	}
+	return nil
+}
+
+// WithLock 세션 advisory lock 보유 중 fn 실행 (프로세스 간 발급 직렬화)
+func (r *TokenRepository) WithLock(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock(hashtextextended($1, 0))`, key); err != nil {
+		return fmt.Errorf("advisory lock: %w", err)
+	}
+	defer func() {
+		// 요청 ctx 취소와 무관하게 해제
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtextextended($1, 0))`, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to release kis token advisory lock")
+		}
+	}()
+
+	return fn(ctx)
+}
+
+var _ kis.TokenStore = (*TokenRepository)(nil)
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+)
+
+// LedgerRepository implements execution.LedgerRepository
+type LedgerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLedgerRepository creates a new LedgerRepository
+func NewLedgerRepository(pool *pgxpool.Pool) *LedgerRepository {
+	return &LedgerRepository{
+		pool: pool,
+	}
+}
+
+// AppendEntry inserts a ledger entry and applies the resulting qty/avg cost to trade.positions
+func (r *LedgerRepository) AppendEntry(ctx context.Context, entry *execution.LedgerEntry) error {
+	if entry.EntryID == (uuid.UUID{}) {
+		entry.EntryID = uuid.New()
+	}
+	if entry.CreatedTS.IsZero() {
+		entry.CreatedTS = time.Now()
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `
+		INSERT INTO trade.position_ledger (
+			entry_id, position_id, account_id, symbol, order_id, intent_id, entry_type, source,
+			qty, price, fee, tax, qty_after, avg_cost_after, realized_pnl, ts, created_ts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+	_, err = tx.Exec(ctx, insertQuery,
+		entry.EntryID,
+		entry.PositionID,
+		entry.AccountID,
+		entry.Symbol,
+		entry.OrderID,
+		entry.IntentID,
+		entry.EntryType,
+		entry.Source,
+		entry.Qty,
+		entry.Price,
+		entry.Fee,
+		entry.Tax,
+		entry.QtyAfter,
+		entry.AvgCostAfter,
+		entry.RealizedPnl,
+		entry.TS,
+		entry.CreatedTS,
+	)
+	if err != nil {
+		return fmt.Errorf("insert ledger entry: %w", err)
+	}
+
+	positionQuery := `
+		UPDATE trade.positions
+		SET qty = $1,
+		    avg_price = $2,
+		    original_qty = CASE WHEN $4 = 'BUY' THEN GREATEST(original_qty, $1) ELSE original_qty END,
+		    updated_ts = NOW()
+		WHERE position_id = $3
+	`
+	result, err := tx.Exec(ctx, positionQuery, entry.QtyAfter, entry.AvgCostAfter, entry.PositionID, entry.EntryType)
+	if err != nil {
+		return fmt.Errorf("apply ledger to position: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("apply ledger to position %s: %w", entry.PositionID, execution.ErrPositionNotFound)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// LoadAppliedTotals loads fill totals already applied for an order (원장 entry + untracked fill)
+func (r *LedgerRepository) LoadAppliedTotals(ctx context.Context, orderID string) (execution.FillTotals, error) {
+	query := `
+		SELECT COALESCE(SUM(qty), 0), COALESCE(SUM(amount), 0), COALESCE(SUM(fee), 0), COALESCE(SUM(tax), 0)
+		FROM (
+			SELECT qty, price * qty AS amount, fee, tax
+			FROM trade.position_ledger
+			WHERE order_id = $1 AND entry_type IN ('BUY', 'SELL')
+			UNION ALL
+			SELECT qty, amount, fee, tax
+			FROM trade.ledger_untracked_fills
+			WHERE order_id = $1
+		) applied
+	`
+
+	var totals execution.FillTotals
+	err := r.pool.QueryRow(ctx, query, orderID).Scan(&totals.Qty, &totals.Amount, &totals.Fee, &totals.Tax)
+	if err != nil {
+		return execution.FillTotals{}, fmt.Errorf("load applied totals: %w", err)
+	}
+	return totals, nil
+}
+
+// RecordUntrackedFill records a fill increment handled without a ledger position
+func (r *LedgerRepository) RecordUntrackedFill(ctx context.Context, accountID, symbol, orderID string, delta execution.FillTotals, ts time.Time) error {
+	query := `
+		INSERT INTO trade.ledger_untracked_fills (account_id, symbol, order_id, qty, amount, fee, tax, ts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.pool.Exec(ctx, query, accountID, symbol, orderID, delta.Qty, delta.Amount, delta.Fee, delta.Tax, ts)
+	if err != nil {
+		return fmt.Errorf("insert untracked fill: %w", err)
+	}
+	return nil
+}
+
+// LoadEntries loads ledger entries for a position since ts
+func (r *LedgerRepository) LoadEntries(ctx context.Context, positionID uuid.UUID, since time.Time) ([]*execution.LedgerEntry, error) {
+	query := `
+		SELECT entry_id, position_id, account_id, symbol, order_id, intent_id, entry_type, source,
+		       qty, price, fee, tax, qty_after, avg_cost_after, realized_pnl, ts, created_ts
+		FROM trade.position_ledger
+		WHERE position_id = $1 AND ts >= $2
+		ORDER BY ts ASC, created_ts ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, positionID, since)
+	if err != nil {
+		return nil, fmt.Errorf("query ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLedgerEntries(rows)
+}
+
+// RaiseDiscrepancy records a discrepancy (미해결 건이 있으면 최신 값으로 갱신)
+func (r *LedgerRepository) RaiseDiscrepancy(ctx context.Context, d *execution.LedgerDiscrepancy) error {
+	if d.DiscrepancyID == (uuid.UUID{}) {
+		d.DiscrepancyID = uuid.New()
+	}
+	if d.DetectedTS.IsZero() {
+		d.DetectedTS = time.Now()
+	}
+
+	query := `
+		INSERT INTO trade.ledger_discrepancies (
+			discrepancy_id, account_id, symbol, kind, ledger_qty, broker_qty, ledger_avg, broker_avg, detail, detected_ts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (account_id, symbol, kind) WHERE resolved_ts IS NULL
+		DO UPDATE SET
+			ledger_qty = EXCLUDED.ledger_qty,
+			broker_qty = EXCLUDED.broker_qty,
+			ledger_avg = EXCLUDED.ledger_avg,
+			broker_avg = EXCLUDED.broker_avg,
+			detail = EXCLUDED.detail
+	`
+	_, err := r.pool.Exec(ctx, query,
+		d.DiscrepancyID,
+		d.AccountID,
+		d.Symbol,
+		d.Kind,
+		d.LedgerQty,
+		d.BrokerQty,
+		d.LedgerAvg,
+		d.BrokerAvg,
+		d.Detail,
+		d.DetectedTS,
+	)
+	if err != nil {
+		return fmt.Errorf("raise discrepancy: %w", err)
+	}
+	return nil
+}
+
+// ResolveDiscrepancies marks unresolved discrepancies of a symbol as resolved
+func (r *LedgerRepository) ResolveDiscrepancies(ctx context.Context, accountID, symbol string) error {
+	query := `
+		UPDATE trade.ledger_discrepancies
+		SET resolved_ts = NOW()
+		WHERE account_id = $1 AND symbol = $2 AND resolved_ts IS NULL
+	`
+	if _, err := r.pool.Exec(ctx, query, accountID, symbol); err != nil {
+		return fmt.Errorf("resolve discrepancies: %w", err)
+	}
+	return nil
+}
+
+// LoadOpenDiscrepancies loads unresolved discrepancies
+func (r *LedgerRepository) LoadOpenDiscrepancies(ctx context.Context, accountID string) ([]*execution.LedgerDiscrepancy, error) {
+	query := `
+		SELECT discrepancy_id, account_id, symbol, kind, ledger_qty, broker_qty, ledger_avg, broker_avg,
+		       detail, detected_ts, resolved_ts
+		FROM trade.ledger_discrepancies
+		WHERE account_id = $1 AND resolved_ts IS NULL
+		ORDER BY detected_ts DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("query discrepancies: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*execution.LedgerDiscrepancy
+	for rows.Next() {
+		d := &execution.LedgerDiscrepancy{}
+		if err := rows.Scan(
+			&d.DiscrepancyID,
+			&d.AccountID,
+			&d.Symbol,
+			&d.Kind,
+			&d.LedgerQty,
+			&d.BrokerQty,
+			&d.LedgerAvg,
+			&d.BrokerAvg,
+			&d.Detail,
+			&d.DetectedTS,
+			&d.ResolvedTS,
+		); err != nil {
+			return nil, fmt.Errorf("scan discrepancy: %w", err)
+		}
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
+
+func scanLedgerEntries(rows pgx.Rows) ([]*execution.LedgerEntry, error) {
+	var entries []*execution.LedgerEntry
+	for rows.Next() {
+		e := &execution.LedgerEntry{}
+		if err := rows.Scan(
+			&e.EntryID,
+			&e.PositionID,
+			&e.AccountID,
+			&e.Symbol,
+			&e.OrderID,
+			&e.IntentID,
+			&e.EntryType,
+			&e.Source,
+			&e.Qty,
+			&e.Price,
+			&e.Fee,
+			&e.Tax,
+			&e.QtyAfter,
+			&e.AvgCostAfter,
+			&e.RealizedPnl,
+			&e.TS,
+			&e.CreatedTS,
+		); err != nil {
+			return nil, fmt.Errorf("scan ledger entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Ensure LedgerRepository implements execution.LedgerRepository
+var _ execution.LedgerRepository = (*LedgerRepository)(nil)
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+)
+
+// OrderEventRepository implements execution.OrderEventRepository
+type OrderEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderEventRepository creates a new OrderEventRepository
+func NewOrderEventRepository(pool *pgxpool.Pool) *OrderEventRepository {
+	return &OrderEventRepository{
+		pool: pool,
+	}
+}
+
+// CreateOrderEvent creates a new order event
+func (r *OrderEventRepository) CreateOrderEvent(ctx context.Context, event *execution.OrderEvent) error {
+	if event.EventID == (uuid.UUID{}) {
+		event.EventID = uuid.New()
+	}
+
+	// ✅ Convert zero UUID to NULL (수동 정정은 intent 없음)
+	var intentID interface{}
+	if event.IntentID != (uuid.UUID{}) {
+		intentID = event.IntentID
+	}
+
+	query := `
+		INSERT INTO trade.order_events (
+			event_id, order_id, new_order_id, intent_id, event_type, attempt,
+			old_order_type, new_order_type, old_price, new_price, qty, reason, raw, created_ts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		event.EventID,
+		event.OrderID,
+		event.NewOrderID,
+		intentID,
+		event.EventType,
+		event.Attempt,
+		event.OldOrderType,
+		event.NewOrderType,
+		event.OldPrice,
+		event.NewPrice,
+		event.Qty,
+		event.Reason,
+		event.Raw,
+		event.CreatedTS,
+	)
+	if err != nil {
+		return fmt.Errorf("insert order event: %w", err)
+	}
+
+	return nil
+}
+
+// LoadOrderEvents loads events where the order is either original or amended order
+func (r *OrderEventRepository) LoadOrderEvents(ctx context.Context, orderID string) ([]*execution.OrderEvent, error) {
+	query := `
+		SELECT event_id, order_id, new_order_id, COALESCE(intent_id, '00000000-0000-0000-0000-000000000000'::uuid),
+		       event_type, attempt, old_order_type, new_order_type, old_price, new_price,
+		       qty, reason, raw, created_ts
+		FROM trade.order_events
+		WHERE order_id = $1 OR new_order_id = $1
+		ORDER BY created_ts ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("query order events: %w", err)
+	}
+
+	return scanOrderEvents(rows)
+}
+
+// LoadOrderEventsByIntent loads all events of an intent
+func (r *OrderEventRepository) LoadOrderEventsByIntent(ctx context.Context, intentID uuid.UUID) ([]*execution.OrderEvent, error) {
+	query := `
+		SELECT event_id, order_id, new_order_id, intent_id,
+		       event_type, attempt, old_order_type, new_order_type, old_price, new_price,
+		       qty, reason, raw, created_ts
+		FROM trade.order_events
+		WHERE intent_id = $1
+		ORDER BY created_ts ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, intentID)
+	if err != nil {
+		return nil, fmt.Errorf("query order events by intent: %w", err)
+	}
+
+	return scanOrderEvents(rows)
+}
+
+func scanOrderEvents(rows pgx.Rows) ([]*execution.OrderEvent, error) {
+	defer rows.Close()
+
+	var events []*execution.OrderEvent
+	for rows.Next() {
+		event := &execution.OrderEvent{}
+		if err := rows.Scan(
+			&event.EventID,
+			&event.OrderID,
+			&event.NewOrderID,
+			&event.IntentID,
+			&event.EventType,
+			&event.Attempt,
+			&event.OldOrderType,
+			&event.NewOrderType,
+			&event.OldPrice,
+			&event.NewPrice,
+			&event.Qty,
+			&event.Reason,
+			&event.Raw,
+			&event.CreatedTS,
+		); err != nil {
+			return nil, fmt.Errorf("scan order event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// Ensure OrderEventRepository implements execution.OrderEventRepository
+var _ execution.OrderEventRepository = (*OrderEventRepository)(nil)
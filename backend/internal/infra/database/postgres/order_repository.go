@@ -36,9 +36,10 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, order *execution.Orde
 			open_qty,
 			filled_qty,
 			raw,
-			updated_ts
+			updated_ts,
+			account_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	// ✅ Convert zero UUID to NULL to avoid FK constraint violation
@@ -60,6 +61,7 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, order *execution.Orde
 		order.FilledQty,
 		order.Raw,
 		order.UpdatedTS,
+		order.AccountID,
 	)
 
 	if err != nil {
@@ -82,7 +84,8 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID string) (*ex
 			open_qty,
 			filled_qty,
 			raw,
-			updated_ts
+			updated_ts,
+			account_id
 		FROM trade.orders
 		WHERE order_id = $1
 	`
@@ -99,6 +102,7 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID string) (*ex
 		&order.FilledQty,
 		&order.Raw,
 		&order.UpdatedTS,
+		&order.AccountID,
 	)
 
 	if err != nil {
@@ -121,7 +125,8 @@ func (r *OrderRepository) GetOrderByIntentID(ctx context.Context, intentID uuid.
 			open_qty,
 			filled_qty,
 			raw,
-			updated_ts
+			updated_ts,
+			account_id
 		FROM trade.orders
 		WHERE intent_id = $1
 	`
@@ -138,6 +143,7 @@ func (r *OrderRepository) GetOrderByIntentID(ctx context.Context, intentID uuid.
 		&order.FilledQty,
 		&order.Raw,
 		&order.UpdatedTS,
+		&order.AccountID,
 	)
 
 	if err != nil {
@@ -163,7 +169,8 @@ func (r *OrderRepository) GetRecentOrders(ctx context.Context, limit int) ([]*ex
 			open_qty,
 			filled_qty,
 			raw,
-			updated_ts
+			updated_ts,
+			account_id
 		FROM trade.orders
 		ORDER BY submitted_ts DESC
 		LIMIT $1
@@ -189,6 +196,7 @@ func (r *OrderRepository) GetRecentOrders(ctx context.Context, limit int) ([]*ex
 			&order.FilledQty,
 			&order.Raw,
 			&order.UpdatedTS,
+			&order.AccountID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan order: %w", err)
@@ -260,7 +268,8 @@ func (r *OrderRepository) LoadOrdersByStatus(ctx context.Context, statuses []str
 			open_qty,
 			filled_qty,
 			raw,
-			updated_ts
+			updated_ts,
+			account_id
 		FROM trade.orders
 		WHERE status = ANY($1)
 		ORDER BY submitted_ts DESC
@@ -286,6 +295,7 @@ func (r *OrderRepository) LoadOrdersByStatus(ctx context.Context, statuses []str
 			&order.FilledQty,
 			&order.Raw,
 			&order.UpdatedTS,
+			&order.AccountID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan order: %w", err)
@@ -305,15 +315,16 @@ func (r *OrderRepository) UpsertOrder(ctx context.Context, order *execution.Orde
 	query := `
 		INSERT INTO trade.orders (
 			order_id, intent_id, submitted_ts, status, broker_status,
-			qty, open_qty, filled_qty, raw, updated_ts
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			qty, open_qty, filled_qty, raw, updated_ts, account_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (order_id) DO UPDATE SET
 			status = EXCLUDED.status,
 			broker_status = EXCLUDED.broker_status,
 			open_qty = EXCLUDED.open_qty,
 			filled_qty = EXCLUDED.filled_qty,
 			raw = EXCLUDED.raw,
-			updated_ts = EXCLUDED.updated_ts
+			updated_ts = EXCLUDED.updated_ts,
+			account_id = COALESCE(NULLIF(EXCLUDED.account_id, ''), trade.orders.account_id)
 	`
 
 	// ✅ Convert zero UUID to NULL to avoid FK constraint violation
@@ -335,6 +346,7 @@ func (r *OrderRepository) UpsertOrder(ctx context.Context, order *execution.Orde
 		order.FilledQty,
 		order.Raw,
 		order.UpdatedTS,
+		order.AccountID,
 	)
 
 	if err != nil {
@@ -0,0 +1,214 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/paper"
+)
+
+// LedgerRepository paper broker 원장 저장소 구현 (paper.accounts, paper.orders, paper.fills, paper.holdings)
+type LedgerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLedgerRepository 새 리포지토리 생성
+func NewLedgerRepository(pool *pgxpool.Pool) *LedgerRepository {
+	return &LedgerRepository{pool: pool}
+}
+
+const orderColumns = `
+	order_id, account_id, symbol, side, order_type, qty, limit_price,
+	filled_qty, status, COALESCE(reject_reason, ''), submitted_ts, eligible_ts, updated_ts
+`
+
+// LoadLedger 계좌 원장 로드 (미체결 주문 + since 이후 주문/체결 + 보유)
+func (r *LedgerRepository) LoadLedger(ctx context.Context, accountID string, since time.Time) (*paper.Ledger, error) {
+	var a paper.Account
+	err := r.pool.QueryRow(ctx, `
+		SELECT account_id, cash, initial_cash, updated_ts
+		FROM paper.accounts
+		WHERE account_id = $1
+	`, accountID).Scan(&a.AccountID, &a.Cash, &a.InitialCash, &a.UpdatedTS)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, paper.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("query paper account: %w", err)
+	}
+
+	ledger := &paper.Ledger{Account: &a}
+
+	// Orders
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+orderColumns+`
+		FROM paper.orders
+		WHERE account_id = $1
+		  AND (status IN ('PENDING', 'PARTIAL') OR submitted_ts >= $2)
+		ORDER BY submitted_ts
+	`, accountID, since)
+	if err != nil {
+		return nil, fmt.Errorf("query paper orders: %w", err)
+	}
+	for rows.Next() {
+		var o paper.Order
+		if err := rows.Scan(
+			&o.OrderID, &o.AccountID, &o.Symbol, &o.Side, &o.OrderType, &o.Qty, &o.LimitPrice,
+			&o.FilledQty, &o.Status, &o.RejectReason, &o.SubmittedTS, &o.EligibleTS, &o.UpdatedTS,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan paper order: %w", err)
+		}
+		ledger.Orders = append(ledger.Orders, &o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate paper orders: %w", err)
+	}
+
+	// Fills
+	rows, err = r.pool.Query(ctx, `
+		SELECT exec_id, order_id, account_id, symbol, side, qty, price, fee, tax, seq, ts
+		FROM paper.fills
+		WHERE account_id = $1 AND ts >= $2
+		ORDER BY ts, seq
+	`, accountID, since)
+	if err != nil {
+		return nil, fmt.Errorf("query paper fills: %w", err)
+	}
+	for rows.Next() {
+		var f paper.Fill
+		if err := rows.Scan(
+			&f.ExecID, &f.OrderID, &f.AccountID, &f.Symbol, &f.Side, &f.Qty, &f.Price, &f.Fee, &f.Tax, &f.Seq, &f.TS,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan paper fill: %w", err)
+		}
+		ledger.Fills = append(ledger.Fills, &f)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate paper fills: %w", err)
+	}
+
+	// Holdings
+	rows, err = r.pool.Query(ctx, `
+		SELECT account_id, symbol, qty, avg_price, updated_ts
+		FROM paper.holdings
+		WHERE account_id = $1 AND qty > 0
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("query paper holdings: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var h paper.Holding
+		if err := rows.Scan(&h.AccountID, &h.Symbol, &h.Qty, &h.AvgPrice, &h.UpdatedTS); err != nil {
+			return nil, fmt.Errorf("scan paper holding: %w", err)
+		}
+		ledger.Holdings = append(ledger.Holdings, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate paper holdings: %w", err)
+	}
+
+	return ledger, nil
+}
+
+// CreateAccount 계좌 생성 (이미 있으면 유지)
+func (r *LedgerRepository) CreateAccount(ctx context.Context, account *paper.Account) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO paper.accounts (account_id, cash, initial_cash, updated_ts)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_id) DO NOTHING
+	`, account.AccountID, account.Cash, account.InitialCash, account.UpdatedTS)
+	if err != nil {
+		return fmt.Errorf("insert paper account: %w", err)
+	}
+	return nil
+}
+
+// SaveOrder 주문 저장 (order_id upsert)
+func (r *LedgerRepository) SaveOrder(ctx context.Context, order *paper.Order) error {
+	return saveOrder(ctx, r.pool, order)
+}
+
+// RecordFill 체결 기록 (주문 + 체결 + 보유 + 현금 단일 트랜잭션)
+func (r *LedgerRepository) RecordFill(ctx context.Context, order *paper.Order, fill *paper.Fill, holding *paper.Holding, account *paper.Account) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := saveOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO paper.fills (exec_id, order_id, account_id, symbol, side, qty, price, fee, tax, seq, ts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (exec_id) DO NOTHING
+	`, fill.ExecID, fill.OrderID, fill.AccountID, fill.Symbol, fill.Side, fill.Qty,
+		fill.Price, fill.Fee, fill.Tax, fill.Seq, fill.TS); err != nil {
+		return fmt.Errorf("insert paper fill: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO paper.holdings (account_id, symbol, qty, avg_price, updated_ts)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (account_id, symbol) DO UPDATE SET
+			qty = EXCLUDED.qty,
+			avg_price = EXCLUDED.avg_price,
+			updated_ts = EXCLUDED.updated_ts
+	`, holding.AccountID, holding.Symbol, holding.Qty, holding.AvgPrice, holding.UpdatedTS); err != nil {
+		return fmt.Errorf("upsert paper holding: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE paper.accounts
+		SET cash = $2, updated_ts = $3
+		WHERE account_id = $1
+	`, account.AccountID, account.Cash, account.UpdatedTS); err != nil {
+		return fmt.Errorf("update paper cash: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// execer pool/tx 공통
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func saveOrder(ctx context.Context, db execer, o *paper.Order) error {
+	var rejectReason *string
+	if o.RejectReason != "" {
+		rejectReason = &o.RejectReason
+	}
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO paper.orders (
+			order_id, account_id, symbol, side, order_type, qty, limit_price,
+			filled_qty, status, reject_reason, submitted_ts, eligible_ts, updated_ts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (order_id) DO UPDATE SET
+			filled_qty = EXCLUDED.filled_qty,
+			status = EXCLUDED.status,
+			reject_reason = EXCLUDED.reject_reason,
+			updated_ts = EXCLUDED.updated_ts
+	`, o.OrderID, o.AccountID, o.Symbol, o.Side, o.OrderType, o.Qty, o.LimitPrice,
+		o.FilledQty, o.Status, rejectReason, o.SubmittedTS, o.EligibleTS, o.UpdatedTS)
+	if err != nil {
+		return fmt.Errorf("upsert paper order: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+)
+
+// RebalanceRepository 리밸런싱 계획 저장소 구현 (portfolio.rebalance_plans + trade.order_intents)
+type RebalanceRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRebalanceRepository 새 리포지토리 생성
+func NewRebalanceRepository(pool *pgxpool.Pool) *RebalanceRepository {
+	return &RebalanceRepository{pool: pool}
+}
+
+// CreatePlan saves the plan and creates its intents (PENDING_APPROVAL) in one transaction
+// created_ts = plan.CreatedAt + seq(ms) → Execution이 매도부터 순서대로 제출 (LoadNewIntents ORDER BY created_ts)
+func (r *RebalanceRepository) CreatePlan(ctx context.Context, plan *portfolio.RebalancePlan) error {
+	ordersJSON, err := json.Marshal(plan.Orders)
+	if err != nil {
+		return err
+	}
+	skippedJSON, err := json.Marshal(plan.Skipped)
+	if err != nil {
+		return err
+	}
+	summaryJSON, err := json.Marshal(plan.Summary)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Insert intents
+	intentQuery := `
+		INSERT INTO trade.order_intents (
+			intent_id, position_id, symbol, intent_type, qty, order_type,
+			limit_price, reason_code, reason_detail, action_key, status, created_ts
+		) VALUES ($1, $2, $3, $4, $5, $6, NULL, $7, $8, $9, $10, $11)
+	`
+
+	for _, o := range plan.Orders {
+		intentType := execution.IntentTypeRebalanceBuy
+		if o.Side == execution.SideSell {
+			intentType = execution.IntentTypeRebalanceSell
+		}
+
+		_, err := tx.Exec(ctx, intentQuery,
+			o.IntentID,
+			o.PositionID,
+			o.Symbol,
+			intentType,
+			o.Qty,
+			exit.OrderTypeMKT,
+			portfolio.ReasonCodeRebalance,
+			fmt.Sprintf("[%d] %s (목표 %d / 보유 %d / 대기 %d)", o.Seq, o.Reason, o.TargetQty, o.CurrentQty, o.PendingQty),
+			fmt.Sprintf("%s:%s:%s", plan.PlanID, portfolio.ReasonCodeRebalance, o.Symbol),
+			exit.IntentStatusPendingApproval,
+			plan.CreatedAt.Add(time.Duration(o.Seq)*time.Millisecond),
+		)
+		if err != nil {
+			return fmt.Errorf("insert rebalance intent %s: %w", o.Symbol, err)
+		}
+	}
+
+	// 2. Insert plan
+	planQuery := `
+		INSERT INTO portfolio.rebalance_plans (
+			plan_id, portfolio_snapshot_id, account_id, created_at, updated_at,
+			status, orders, skipped, summary
+		) VALUES ($1, $2, $3, $4, $4, $5, $6, $7, $8)
+	`
+
+	_, err = tx.Exec(ctx, planQuery,
+		plan.PlanID,
+		plan.PortfolioSnapshotID,
+		plan.AccountID,
+		plan.CreatedAt,
+		plan.Status,
+		ordersJSON,
+		skippedJSON,
+		summaryJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("insert rebalance plan: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlan retrieves a plan by ID
+func (r *RebalanceRepository) GetPlan(ctx context.Context, planID uuid.UUID) (*portfolio.RebalancePlan, error) {
+	query := `
+		SELECT plan_id, portfolio_snapshot_id, account_id, created_at, status, orders, skipped, summary
+		FROM portfolio.rebalance_plans
+		WHERE plan_id = $1
+	`
+
+	return r.scanPlan(r.pool.QueryRow(ctx, query, planID))
+}
+
+// GetLatestPlan retrieves the most recent plan
+func (r *RebalanceRepository) GetLatestPlan(ctx context.Context) (*portfolio.RebalancePlan, error) {
+	query := `
+		SELECT plan_id, portfolio_snapshot_id, account_id, created_at, status, orders, skipped, summary
+		FROM portfolio.rebalance_plans
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return r.scanPlan(r.pool.QueryRow(ctx, query))
+}
+
+// ApprovePlan approves all pending intents of the plan (PENDING_APPROVAL → NEW)
+// 개별 승인/거부된 intent는 그대로 둠
+func (r *RebalanceRepository) ApprovePlan(ctx context.Context, planID uuid.UUID) error {
+	return r.resolvePlan(ctx, planID, portfolio.PlanStatusApproved, exit.IntentStatusNew)
+}
+
+// RejectPlan rejects all pending intents of the plan (PENDING_APPROVAL → CANCELLED)
+func (r *RebalanceRepository) RejectPlan(ctx context.Context, planID uuid.UUID) error {
+	return r.resolvePlan(ctx, planID, portfolio.PlanStatusRejected, exit.IntentStatusCancelled)
+}
+
+// LoadPendingQty returns net pending qty per symbol (+BUY / -SELL)
+// - 미체결 주문: open_qty
+// - 주문 전 intent (NEW/PENDING_APPROVAL/ACK): qty
+func (r *RebalanceRepository) LoadPendingQty(ctx context.Context) (map[string]int64, error) {
+	query := `
+		SELECT i.symbol, i.intent_type, COALESCE(o.open_qty, i.qty)
+		FROM trade.order_intents i
+		LEFT JOIN trade.orders o ON o.intent_id = i.intent_id
+		WHERE (o.order_id IS NOT NULL AND o.status = ANY($1))
+		   OR (o.order_id IS NULL AND i.status = ANY($2))
+	`
+
+	openOrderStatuses := []string{execution.OrderStatusSubmitted, execution.OrderStatusPartial}
+
+	rows, err := r.pool.Query(ctx, query, openOrderStatuses, exit.ActiveIntentStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("query pending qty: %w", err)
+	}
+	defer rows.Close()
+
+	pending := make(map[string]int64)
+	for rows.Next() {
+		var symbol, intentType string
+		var qty int64
+		if err := rows.Scan(&symbol, &intentType, &qty); err != nil {
+			return nil, fmt.Errorf("scan pending qty: %w", err)
+		}
+
+		switch intentType {
+		case execution.IntentTypeEntry, execution.IntentTypeRebalanceBuy:
+			pending[symbol] += qty
+		default:
+			pending[symbol] -= qty
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return pending, nil
+}
+
+// resolvePlan updates plan status and its pending intents in one transaction
+// 매수 intent는 Execution에서 같은 계획의 매도 체결 완료 후 제출됨
+func (r *RebalanceRepository) resolvePlan(ctx context.Context, planID uuid.UUID, planStatus, intentStatus string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Lock plan
+	var status string
+	var ordersJSON []byte
+	err = tx.QueryRow(ctx, `
+		SELECT status, orders
+		FROM portfolio.rebalance_plans
+		WHERE plan_id = $1
+		FOR UPDATE
+	`, planID).Scan(&status, &ordersJSON)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return portfolio.ErrPlanNotFound
+		}
+		return fmt.Errorf("lock plan: %w", err)
+	}
+
+	if status != portfolio.PlanStatusPendingApproval {
+		return portfolio.ErrPlanNotPending
+	}
+
+	var orders []portfolio.RebalanceOrder
+	if err := json.Unmarshal(ordersJSON, &orders); err != nil {
+		return fmt.Errorf("unmarshal orders: %w", err)
+	}
+
+	intentIDs := make([]uuid.UUID, 0, len(orders))
+	for _, o := range orders {
+		intentIDs = append(intentIDs, o.IntentID)
+	}
+
+	// 2. Update pending intents
+	_, err = tx.Exec(ctx, `
+		UPDATE trade.order_intents
+		SET status = $1
+		WHERE intent_id = ANY($2) AND status = $3
+	`, intentStatus, intentIDs, exit.IntentStatusPendingApproval)
+	if err != nil {
+		return fmt.Errorf("update intents: %w", err)
+	}
+
+	// 3. Update plan
+	_, err = tx.Exec(ctx, `
+		UPDATE portfolio.rebalance_plans
+		SET status = $1, updated_at = NOW()
+		WHERE plan_id = $2
+	`, planStatus, planID)
+	if err != nil {
+		return fmt.Errorf("update plan: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// scanPlan scans a plan row
+func (r *RebalanceRepository) scanPlan(row pgx.Row) (*portfolio.RebalancePlan, error) {
+	var plan portfolio.RebalancePlan
+	var ordersJSON, skippedJSON, summaryJSON []byte
+
+	err := row.Scan(
+		&plan.PlanID,
+		&plan.PortfolioSnapshotID,
+		&plan.AccountID,
+		&plan.CreatedAt,
+		&plan.Status,
+		&ordersJSON,
+		&skippedJSON,
+		&summaryJSON,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, portfolio.ErrPlanNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(ordersJSON, &plan.Orders); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(skippedJSON, &plan.Skipped); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(summaryJSON, &plan.Summary); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
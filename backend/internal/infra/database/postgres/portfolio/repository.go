@@ -0,0 +1,156 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+)
+
+// Repository 포트폴리오 스냅샷 저장소 구현 (portfolio.snapshots)
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository 새 리포지토리 생성
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+const snapshotColumns = `
+	snapshot_id, ranking_snapshot_id, account_id, generated_at,
+	total_value, holdings_value, cash, investable,
+	targets, total_weight, stats
+`
+
+// SaveSnapshot 스냅샷 저장
+func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *portfolio.PortfolioSnapshot) error {
+	targetsJSON, err := json.Marshal(snapshot.Targets)
+	if err != nil {
+		return err
+	}
+	statsJSON, err := json.Marshal(snapshot.Stats)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO portfolio.snapshots (` + snapshotColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (snapshot_id) DO UPDATE SET
+			ranking_snapshot_id = EXCLUDED.ranking_snapshot_id,
+			account_id = EXCLUDED.account_id,
+			generated_at = EXCLUDED.generated_at,
+			total_value = EXCLUDED.total_value,
+			holdings_value = EXCLUDED.holdings_value,
+			cash = EXCLUDED.cash,
+			investable = EXCLUDED.investable,
+			targets = EXCLUDED.targets,
+			total_weight = EXCLUDED.total_weight,
+			stats = EXCLUDED.stats
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		snapshot.SnapshotID,
+		snapshot.RankingSnapshotID,
+		snapshot.AccountID,
+		snapshot.GeneratedAt,
+		snapshot.TotalValue,
+		snapshot.HoldingsValue,
+		snapshot.Cash,
+		snapshot.Investable,
+		targetsJSON,
+		snapshot.TotalWeight,
+		statsJSON,
+	)
+
+	return err
+}
+
+// GetLatestSnapshot 최신 스냅샷 조회
+func (r *Repository) GetLatestSnapshot(ctx context.Context) (*portfolio.PortfolioSnapshot, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM portfolio.snapshots
+		ORDER BY generated_at DESC
+		LIMIT 1
+	`
+
+	return r.scanSnapshot(r.pool.QueryRow(ctx, query))
+}
+
+// GetSnapshotByID 특정 스냅샷 조회
+func (r *Repository) GetSnapshotByID(ctx context.Context, snapshotID string) (*portfolio.PortfolioSnapshot, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM portfolio.snapshots
+		WHERE snapshot_id = $1
+	`
+
+	return r.scanSnapshot(r.pool.QueryRow(ctx, query, snapshotID))
+}
+
+// ListSnapshots 스냅샷 목록 조회 (시간 범위)
+func (r *Repository) ListSnapshots(ctx context.Context, from, to time.Time) ([]*portfolio.PortfolioSnapshot, error) {
+	query := `
+		SELECT ` + snapshotColumns + `
+		FROM portfolio.snapshots
+		WHERE generated_at >= $1 AND generated_at <= $2
+		ORDER BY generated_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make([]*portfolio.PortfolioSnapshot, 0)
+	for rows.Next() {
+		snapshot, err := r.scanSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// scanSnapshot scans a snapshot row (pgx.Row or pgx.Rows)
+func (r *Repository) scanSnapshot(row pgx.Row) (*portfolio.PortfolioSnapshot, error) {
+	var snapshot portfolio.PortfolioSnapshot
+	var targetsJSON, statsJSON []byte
+
+	err := row.Scan(
+		&snapshot.SnapshotID,
+		&snapshot.RankingSnapshotID,
+		&snapshot.AccountID,
+		&snapshot.GeneratedAt,
+		&snapshot.TotalValue,
+		&snapshot.HoldingsValue,
+		&snapshot.Cash,
+		&snapshot.Investable,
+		&targetsJSON,
+		&snapshot.TotalWeight,
+		&statsJSON,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, portfolio.ErrSnapshotNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(targetsJSON, &snapshot.Targets); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(statsJSON, &snapshot.Stats); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
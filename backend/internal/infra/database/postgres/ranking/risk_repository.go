@@ -0,0 +1,96 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+)
+
+// minRiskReturns 변동성 계산 최소 일간 수익률 개수 (부족하면 리스크 데이터 없음 → 기본 리스크)
+const minRiskReturns = 20
+
+// RiskDataRepository 순위 리스크 데이터 저장소 구현
+// data.daily_prices (60일 변동성, 20일 평균 거래대금) + data.market_cap (시가총액, 유동비율) + data.stocks (섹터)
+type RiskDataRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRiskDataRepository 새 리포지토리 생성
+func NewRiskDataRepository(pool *pgxpool.Pool) *RiskDataRepository {
+	return &RiskDataRepository{pool: pool}
+}
+
+// GetRiskData 리스크 데이터 조회
+func (r *RiskDataRepository) GetRiskData(ctx context.Context, symbol string) (*ranking.RiskData, error) {
+	data, err := r.GetRiskDataBatch(ctx, []string{symbol})
+	if err != nil {
+		return nil, err
+	}
+	risk, ok := data[symbol]
+	if !ok {
+		return nil, ranking.ErrRiskDataMissing
+	}
+	return risk, nil
+}
+
+// GetRiskDataBatch 배치 리스크 데이터 조회 (일봉 부족 종목은 결과에서 제외)
+// 변동성 = 최근 60거래일 로그수익률 표준편차 × √252 (연율, %)
+func (r *RiskDataRepository) GetRiskDataBatch(ctx context.Context, symbols []string) (map[string]*ranking.RiskData, error) {
+	query := `
+		WITH bars AS (
+			SELECT stock_code,
+			       COALESCE(trading_value, close_price * volume) AS value,
+			       ROW_NUMBER() OVER (PARTITION BY stock_code ORDER BY trade_date DESC) AS rn,
+			       LN(close_price / NULLIF(LAG(close_price) OVER (PARTITION BY stock_code ORDER BY trade_date), 0)) AS ret
+			FROM data.daily_prices
+			WHERE stock_code = ANY($1)
+			  AND trade_date >= CURRENT_DATE - INTERVAL '120 days'
+		),
+		stats AS (
+			SELECT stock_code,
+			       (STDDEV_SAMP(ret) FILTER (WHERE rn <= 60))::float8 * SQRT(252) * 100 AS volatility,
+			       (AVG(value) FILTER (WHERE rn <= 20))::float8 AS avg_value,
+			       COUNT(ret) FILTER (WHERE rn <= 60) AS returns
+			FROM bars
+			GROUP BY stock_code
+		),
+		caps AS (
+			SELECT DISTINCT ON (stock_code) stock_code, market_cap, shares_out, float_shares
+			FROM data.market_cap
+			WHERE stock_code = ANY($1)
+			ORDER BY stock_code, trade_date DESC
+		)
+		SELECT s.code,
+		       COALESCE(s.sector, ''),
+		       st.volatility,
+		       COALESCE(st.avg_value, 0),
+		       COALESCE(c.market_cap, 0)::float8,
+		       CASE WHEN c.shares_out > 0 AND c.float_shares IS NOT NULL
+		            THEN c.float_shares::float8 / c.shares_out * 100
+		            ELSE 0 END
+		FROM data.stocks s
+		JOIN stats st ON st.stock_code = s.code
+		LEFT JOIN caps c ON c.stock_code = s.code
+		WHERE s.code = ANY($1)
+		  AND st.returns >= $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, symbols, minRiskReturns)
+	if err != nil {
+		return nil, fmt.Errorf("query risk data: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*ranking.RiskData, len(symbols))
+	for rows.Next() {
+		var d ranking.RiskData
+		if err := rows.Scan(&d.Symbol, &d.Sector, &d.Volatility60D, &d.AvgDailyValue, &d.MarketCap, &d.FreeFloatRatio); err != nil {
+			return nil, fmt.Errorf("scan risk data: %w", err)
+		}
+		result[d.Symbol] = &d
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,159 @@
+package ranking
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+)
+
+// SnapshotRepository 순위 스냅샷 저장소 구현 (ranking.snapshots)
+type SnapshotRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSnapshotRepository 새 리포지토리 생성
+func NewSnapshotRepository(pool *pgxpool.Pool) *SnapshotRepository {
+	return &SnapshotRepository{pool: pool}
+}
+
+// SaveSnapshot 스냅샷 저장
+func (r *SnapshotRepository) SaveSnapshot(ctx context.Context, snapshot *ranking.RankingSnapshot) error {
+	rankingsJSON, err := json.Marshal(snapshot.Rankings)
+	if err != nil {
+		return err
+	}
+	statsJSON, err := json.Marshal(snapshot.Stats)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ranking.snapshots (
+			snapshot_id, signal_id, generated_at,
+			total_count, selected_count, rankings, stats
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (snapshot_id) DO UPDATE SET
+			signal_id = EXCLUDED.signal_id,
+			generated_at = EXCLUDED.generated_at,
+			total_count = EXCLUDED.total_count,
+			selected_count = EXCLUDED.selected_count,
+			rankings = EXCLUDED.rankings,
+			stats = EXCLUDED.stats
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		snapshot.SnapshotID,
+		snapshot.SignalID,
+		snapshot.GeneratedAt,
+		snapshot.TotalCount,
+		snapshot.SelectedCount,
+		rankingsJSON,
+		statsJSON,
+	)
+
+	return err
+}
+
+// GetLatestSnapshot 최신 스냅샷 조회
+func (r *SnapshotRepository) GetLatestSnapshot(ctx context.Context) (*ranking.RankingSnapshot, error) {
+	query := `
+		SELECT snapshot_id, signal_id, generated_at,
+			   total_count, selected_count, rankings, stats
+		FROM ranking.snapshots
+		ORDER BY generated_at DESC
+		LIMIT 1
+	`
+
+	return r.scanSnapshot(r.pool.QueryRow(ctx, query))
+}
+
+// GetSnapshotByID 특정 스냅샷 조회
+func (r *SnapshotRepository) GetSnapshotByID(ctx context.Context, snapshotID string) (*ranking.RankingSnapshot, error) {
+	query := `
+		SELECT snapshot_id, signal_id, generated_at,
+			   total_count, selected_count, rankings, stats
+		FROM ranking.snapshots
+		WHERE snapshot_id = $1
+	`
+
+	return r.scanSnapshot(r.pool.QueryRow(ctx, query, snapshotID))
+}
+
+// ListSnapshots 스냅샷 목록 조회 (시간 범위)
+func (r *SnapshotRepository) ListSnapshots(ctx context.Context, from, to time.Time) ([]*ranking.RankingSnapshot, error) {
+	query := `
+		SELECT snapshot_id, signal_id, generated_at,
+			   total_count, selected_count, rankings, stats
+		FROM ranking.snapshots
+		WHERE generated_at >= $1 AND generated_at <= $2
+		ORDER BY generated_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make([]*ranking.RankingSnapshot, 0)
+	for rows.Next() {
+		snapshot, err := r.scanSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// GetRankBySymbol 특정 종목의 순위 조회
+func (r *SnapshotRepository) GetRankBySymbol(ctx context.Context, snapshotID, symbol string) (*ranking.RankedStock, error) {
+	snapshot, err := r.GetSnapshotByID(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stock := range snapshot.Rankings {
+		if stock.Symbol == symbol {
+			return &stock, nil
+		}
+	}
+
+	return nil, ranking.ErrRankNotFound
+}
+
+// scanSnapshot scans a snapshot row (pgx.Row or pgx.Rows)
+func (r *SnapshotRepository) scanSnapshot(row pgx.Row) (*ranking.RankingSnapshot, error) {
+	var snapshot ranking.RankingSnapshot
+	var rankingsJSON, statsJSON []byte
+
+	err := row.Scan(
+		&snapshot.SnapshotID,
+		&snapshot.SignalID,
+		&snapshot.GeneratedAt,
+		&snapshot.TotalCount,
+		&snapshot.SelectedCount,
+		&rankingsJSON,
+		&statsJSON,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ranking.ErrSnapshotNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rankingsJSON, &snapshot.Rankings); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(statsJSON, &snapshot.Stats); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,285 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+// Repository 리스크 저장소 구현 (control.risk_limits, control.emergency_stop, control.risk_blocks)
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository 새 리포지토리 생성
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+const limitsColumns = `
+	id, profile_name, max_total_positions, max_single_weight, max_sector_weight,
+	max_daily_loss_pct, max_order_value, max_price_deviation_pct,
+	is_active, updated_by, created_at, updated_at
+`
+
+// GetActiveLimits 활성 한도 조회
+func (r *Repository) GetActiveLimits(ctx context.Context) (*risk.RiskLimits, error) {
+	query := `
+		SELECT ` + limitsColumns + `
+		FROM control.risk_limits
+		WHERE is_active = true
+		ORDER BY updated_at DESC
+		LIMIT 1
+	`
+
+	return r.queryLimits(ctx, query)
+}
+
+// GetLimitsByProfile 프로필 한도 조회 (계좌별 프로필)
+func (r *Repository) GetLimitsByProfile(ctx context.Context, profileName string) (*risk.RiskLimits, error) {
+	query := `
+		SELECT ` + limitsColumns + `
+		FROM control.risk_limits
+		WHERE profile_name = $1
+	`
+
+	return r.queryLimits(ctx, query, profileName)
+}
+
+func (r *Repository) queryLimits(ctx context.Context, query string, args ...any) (*risk.RiskLimits, error) {
+	var l risk.RiskLimits
+	err := r.pool.QueryRow(ctx, query, args...).Scan(
+		&l.ID,
+		&l.ProfileName,
+		&l.MaxTotalPositions,
+		&l.MaxSingleWeight,
+		&l.MaxSectorWeight,
+		&l.MaxDailyLossPct,
+		&l.MaxOrderValue,
+		&l.MaxPriceDeviationPct,
+		&l.IsActive,
+		&l.UpdatedBy,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, risk.ErrLimitsNotFound
+		}
+		return nil, fmt.Errorf("query risk limits: %w", err)
+	}
+
+	return &l, nil
+}
+
+// UpdateLimits 한도 저장 (profile_name upsert + 단일 활성 프로필)
+func (r *Repository) UpdateLimits(ctx context.Context, limits *risk.RiskLimits) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE control.risk_limits
+		SET is_active = false, updated_at = NOW()
+		WHERE is_active = true AND profile_name <> $1
+	`, limits.ProfileName); err != nil {
+		return fmt.Errorf("deactivate limits: %w", err)
+	}
+
+	query := `
+		INSERT INTO control.risk_limits (
+			profile_name, max_total_positions, max_single_weight, max_sector_weight,
+			max_daily_loss_pct, max_order_value, max_price_deviation_pct,
+			is_active, updated_by, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, true, $8, $9)
+		ON CONFLICT (profile_name) DO UPDATE SET
+			max_total_positions = EXCLUDED.max_total_positions,
+			max_single_weight = EXCLUDED.max_single_weight,
+			max_sector_weight = EXCLUDED.max_sector_weight,
+			max_daily_loss_pct = EXCLUDED.max_daily_loss_pct,
+			max_order_value = EXCLUDED.max_order_value,
+			max_price_deviation_pct = EXCLUDED.max_price_deviation_pct,
+			is_active = true,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		limits.ProfileName,
+		limits.MaxTotalPositions,
+		limits.MaxSingleWeight,
+		limits.MaxSectorWeight,
+		limits.MaxDailyLossPct,
+		limits.MaxOrderValue,
+		limits.MaxPriceDeviationPct,
+		limits.UpdatedBy,
+		limits.UpdatedAt,
+	).Scan(&limits.ID, &limits.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert risk limits: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetEmergencyStop 긴급 정지 상태 조회
+func (r *Repository) GetEmergencyStop(ctx context.Context) (*risk.EmergencyStop, error) {
+	query := `
+		SELECT enabled, reason, updated_by, updated_ts
+		FROM control.emergency_stop
+		WHERE id = 1
+	`
+
+	var stop risk.EmergencyStop
+	err := r.pool.QueryRow(ctx, query).Scan(
+		&stop.Enabled,
+		&stop.Reason,
+		&stop.UpdatedBy,
+		&stop.UpdatedTS,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, risk.ErrEmergencyStopNotFound
+		}
+		return nil, fmt.Errorf("query emergency stop: %w", err)
+	}
+
+	return &stop, nil
+}
+
+// SetEmergencyStop 긴급 정지 설정
+func (r *Repository) SetEmergencyStop(ctx context.Context, stop *risk.EmergencyStop) error {
+	query := `
+		INSERT INTO control.emergency_stop (id, enabled, reason, updated_by, updated_ts)
+		VALUES (1, $1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			reason = EXCLUDED.reason,
+			updated_by = EXCLUDED.updated_by,
+			updated_ts = EXCLUDED.updated_ts
+	`
+
+	if _, err := r.pool.Exec(ctx, query, stop.Enabled, stop.Reason, stop.UpdatedBy, stop.UpdatedTS); err != nil {
+		return fmt.Errorf("upsert emergency stop: %w", err)
+	}
+
+	return nil
+}
+
+// SaveBlock 차단 기록 저장
+func (r *Repository) SaveBlock(ctx context.Context, block *risk.RiskBlock) error {
+	query := `
+		INSERT INTO control.risk_blocks (
+			block_id, source, intent_id, account_id, symbol, side, intent_type,
+			qty, order_value, check_type, message, current_value, limit_value, blocked_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		block.BlockID,
+		block.Source,
+		block.IntentID,
+		block.AccountID,
+		block.Symbol,
+		block.Side,
+		block.IntentType,
+		block.Qty,
+		block.OrderValue,
+		string(block.CheckType),
+		block.Message,
+		block.CurrentValue,
+		block.LimitValue,
+		block.BlockedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert risk block: %w", err)
+	}
+
+	return nil
+}
+
+// ListBlocks 차단 기록 조회 (최신순)
+func (r *Repository) ListBlocks(ctx context.Context, since time.Time, limit int) ([]*risk.RiskBlock, error) {
+	query := `
+		SELECT
+			block_id, source, intent_id, account_id, symbol, side, intent_type,
+			qty, order_value, check_type, message, current_value, limit_value, blocked_at
+		FROM control.risk_blocks
+		WHERE blocked_at >= $1
+		ORDER BY blocked_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query risk blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := make([]*risk.RiskBlock, 0)
+	for rows.Next() {
+		var b risk.RiskBlock
+		var intentID *uuid.UUID
+		var checkType string
+
+		if err := rows.Scan(
+			&b.BlockID,
+			&b.Source,
+			&intentID,
+			&b.AccountID,
+			&b.Symbol,
+			&b.Side,
+			&b.IntentType,
+			&b.Qty,
+			&b.OrderValue,
+			&checkType,
+			&b.Message,
+			&b.CurrentValue,
+			&b.LimitValue,
+			&b.BlockedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan risk block: %w", err)
+		}
+
+		b.IntentID = intentID
+		b.CheckType = risk.RiskCheckType(checkType)
+		blocks = append(blocks, &b)
+	}
+
+	return blocks, rows.Err()
+}
+
+// LoadSymbolInfo 종목 시장/섹터 조회 (data.stocks)
+func (r *Repository) LoadSymbolInfo(ctx context.Context, symbols []string) (map[string]risk.SymbolInfo, error) {
+	query := `
+		SELECT code, market, COALESCE(sector, '기타') AS sector
+		FROM data.stocks
+		WHERE code = ANY($1)
+	`
+
+	rows, err := r.pool.Query(ctx, query, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("query symbol info: %w", err)
+	}
+	defer rows.Close()
+
+	infos := make(map[string]risk.SymbolInfo, len(symbols))
+	for rows.Next() {
+		var info risk.SymbolInfo
+		if err := rows.Scan(&info.Symbol, &info.Market, &info.Sector); err != nil {
+			return nil, fmt.Errorf("scan symbol info: %w", err)
+		}
+		infos[info.Symbol] = info
+	}
+
+	return infos, rows.Err()
+}
@@ -11,9 +11,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 	"golang.org/x/sync/singleflight"
 )
 
+// tokenRefreshMargin 만료 전 재발급 여유 시간
+const tokenRefreshMargin = 5 * time.Minute
+
 // AuthClient handles KIS API authentication
 type AuthClient struct {
 	appKey    string
@@ -23,8 +28,7 @@ type AuthClient struct {
 	// Token cache
 	mu          sync.RWMutex
 	accessToken string
-	expiresAt   time.Time
-	nextRefresh time.Time // KIS 6-hour refresh rule
+	expiresAt   time.Time // KIS access_token_token_expired (24시간)
 
 	// Rate limit protection (EGW00133: 1분당 1회)
 	holdUntil time.Time
@@ -32,6 +36,9 @@ type AuthClient struct {
 	// Singleflight to prevent stampede
 	sf singleflight.Group
 
+	// Optional: 프로세스 간 토큰 공유 (nil = 메모리만)
+	store TokenStore
+
 	httpClient *http.Client
 }
 
@@ -45,6 +52,13 @@ func NewAuthClient(appKey, appSecret, baseURL string) *AuthClient {
 	}
 }
 
+// SetTokenStore sets cross-process token store (optional)
+func (c *AuthClient) SetTokenStore(store TokenStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store = store
+}
+
 // TokenResponse represents KIS token API response
 type TokenResponse struct {
 	AccessToken          string `json:"access_token"`
@@ -59,8 +73,8 @@ func (c *AuthClient) GetAccessToken(ctx context.Context) (string, error) {
 	now := time.Now()
 
 	c.mu.RLock()
-	// 1. Check if current token is still valid and not yet time to refresh
-	if c.accessToken != "" && now.Before(c.expiresAt.Add(-30*time.Second)) && now.Before(c.nextRefresh) {
+	// 1. Check if current token is still valid (만료 시각까지 재사용)
+	if c.tokenValid(now) {
 		token := c.accessToken
 		c.mu.RUnlock()
 		return token, nil
@@ -85,18 +99,84 @@ func (c *AuthClient) GetAccessToken(ctx context.Context) (string, error) {
 	return v.(string), nil
 }
 
-// fetchNewToken fetches a new access token from KIS API
+// fetchNewToken fetches a new access token (store 설정 시 다른 프로세스 토큰 우선 재사용)
 func (c *AuthClient) fetchNewToken(ctx context.Context) (string, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
-
 	// Double-check after acquiring lock
-	if c.accessToken != "" && now.Before(c.expiresAt.Add(-30*time.Second)) && now.Before(c.nextRefresh) {
+	if c.tokenValid(time.Now()) {
 		return c.accessToken, nil
 	}
 
+	if c.store == nil {
+		return c.issueToken(ctx)
+	}
+
+	key := TokenKey(TokenKindAccess, c.appKey)
+	var token string
+	locked := false
+	err := c.store.WithLock(ctx, key, func(ctx context.Context) error {
+		locked = true
+
+		// 1. 다른 프로세스가 이미 발급한 토큰 재사용
+		stored, err := c.store.Load(ctx, key)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to load KIS token from store")
+		} else if stored != nil {
+			c.adopt(stored)
+			if c.tokenValid(time.Now()) {
+				token = c.accessToken
+				log.Debug().Time("expires_at", c.expiresAt).Msg("Reusing shared KIS access token")
+				return nil
+			}
+		}
+
+		// 2. 신규 발급 후 공유 (EGW00133 hold 포함)
+		issued, issueErr := c.issueToken(ctx)
+		if issueErr == nil || time.Now().Before(c.holdUntil) {
+			if err := c.store.Save(ctx, &StoredToken{
+				Key:       key,
+				Kind:      TokenKindAccess,
+				Token:     c.accessToken,
+				ExpiresAt: c.expiresAt,
+				HoldUntil: c.holdUntil,
+				UpdatedTS: time.Now(),
+			}); err != nil {
+				log.Warn().Err(err).Msg("Failed to save KIS token to store")
+			}
+		}
+		token = issued
+		return issueErr
+	})
+	if err != nil && !locked {
+		// Store 장애: 메모리 캐시만으로 계속 진행
+		log.Warn().Err(err).Msg("KIS token store unavailable, issuing token without lock")
+		return c.issueToken(ctx)
+	}
+	return token, err
+}
+
+// tokenValid checks cached token validity (caller holds mu)
+func (c *AuthClient) tokenValid(now time.Time) bool {
+	return c.accessToken != "" && now.Before(c.expiresAt.Add(-tokenRefreshMargin))
+}
+
+// adopt merges stored token/hold into memory cache (더 늦게 만료되는 토큰 우선)
+func (c *AuthClient) adopt(stored *StoredToken) {
+	if stored.Token != "" && stored.ExpiresAt.After(c.expiresAt) {
+		c.accessToken = stored.Token
+		c.expiresAt = stored.ExpiresAt
+	}
+	if stored.HoldUntil.After(c.holdUntil) {
+		c.holdUntil = stored.HoldUntil
+	}
+}
+
+// issueToken requests a new access token from KIS API (caller holds mu)
+func (c *AuthClient) issueToken(ctx context.Context) (string, error) {
+	now := time.Now()
+
 	// Check hold period (EGW00133 rate limit)
 	if now.Before(c.holdUntil) {
 		if c.accessToken != "" && now.Before(c.expiresAt) {
@@ -156,14 +236,28 @@ func (c *AuthClient) fetchNewToken(ctx context.Context) (string, error) {
 	}
 
 	// Cache token
-	// KIS: 24시간 유효, 6시간 내 재발급 시 기존 토큰 재사용
+	// KIS: 24시간 유효 → 만료 시각까지 재사용 (재발급은 1분당 1회 제한)
 	c.accessToken = tokenResp.AccessToken
-	c.expiresAt = time.Now().Add(24 * time.Hour)
-	c.nextRefresh = time.Now().Add(6*time.Hour - 5*time.Minute) // 6시간 - 5분 버퍼
+	c.expiresAt = tokenResp.expiry(time.Now())
+
+	log.Info().Time("expires_at", c.expiresAt).Msg("🔑 KIS access token issued")
 
 	return c.accessToken, nil
 }
 
+// expiry returns token expiry (access_token_token_expired → expires_in → 24시간)
+func (r *TokenResponse) expiry(issuedAt time.Time) time.Time {
+	if r.AccessTokenExpired != "" {
+		if t, err := time.ParseInLocation("2006-01-02 15:04:05", r.AccessTokenExpired, calendar.KST); err == nil {
+			return t
+		}
+	}
+	if r.ExpiresIn > 0 {
+		return issuedAt.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return issuedAt.Add(24 * time.Hour)
+}
+
 // isEGW00133 checks if the error is EGW00133 (rate limit)
 func isEGW00133(body string) bool {
 	return strings.Contains(body, "EGW00133") || strings.Contains(body, "1분당 1회")
@@ -175,6 +269,5 @@ func (c *AuthClient) ClearToken() {
 	defer c.mu.Unlock()
 	c.accessToken = ""
 	c.expiresAt = time.Time{}
-	c.nextRefresh = time.Time{}
 	c.holdUntil = time.Time{}
 }
@@ -3,6 +3,7 @@ package kis
 import (
 	"fmt"
 	"os"
+	"strconv"
 )
 
 // Config holds KIS API configuration
@@ -11,6 +12,13 @@ type Config struct {
 	AppSecret string
 	BaseURL   string
 	IsPaper   bool
+
+	// REST 초당 요청 한도 (KIS_RATE_LIMIT_PER_SEC, 0 = 실전/모의 기본값)
+	RateLimitPerSec float64
+
+	// Token store (KIS_TOKEN_STORE: memory | file | postgres)
+	TokenStore     string
+	TokenStorePath string // file 모드 경로
 }
 
 // LoadConfigFromEnv loads KIS config from environment variables
@@ -48,25 +56,57 @@ func LoadConfigFromEnv() (*Config, error) {
 		baseURL = envBaseURL
 	}
 
+	// Token store: 여러 프로세스가 같은 토큰 공유 (EGW00133 1분당 1회 발급 제한 회피)
+	tokenStore := os.Getenv("KIS_TOKEN_STORE")
+	if tokenStore == "" {
+		tokenStore = TokenStoreMemory
+	}
+	switch tokenStore {
+	case TokenStoreMemory, TokenStoreFile, TokenStorePostgres:
+	default:
+		return nil, fmt.Errorf("invalid KIS_TOKEN_STORE %q (memory | file | postgres)", tokenStore)
+	}
+
+	tokenStorePath := os.Getenv("KIS_TOKEN_STORE_PATH")
+	if tokenStorePath == "" {
+		tokenStorePath = DefaultTokenStorePath()
+	}
+
+	var rateLimit float64
+	if v := os.Getenv("KIS_RATE_LIMIT_PER_SEC"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid KIS_RATE_LIMIT_PER_SEC %q", v)
+		}
+		rateLimit = parsed
+	}
+
 	return &Config{
-		AppKey:    appKey,
-		AppSecret: appSecret,
-		BaseURL:   baseURL,
-		IsPaper:   isPaper,
+		AppKey:          appKey,
+		AppSecret:       appSecret,
+		BaseURL:         baseURL,
+		IsPaper:         isPaper,
+		TokenStore:      tokenStore,
+		TokenStorePath:  tokenStorePath,
+		RateLimitPerSec: rateLimit,
 	}, nil
 }
 
 // Client wraps all KIS API clients
 type Client struct {
-	Auth *AuthClient
-	REST *RESTClient
-	WS   *WebSocketClient
+	Config *Config
+	Auth   *AuthClient
+	REST   *RESTClient
+	WS     *WebSocketClient
 }
 
 // NewClient creates a new KIS Client
 func NewClient(config *Config) *Client {
 	auth := NewAuthClient(config.AppKey, config.AppSecret, config.BaseURL)
 	rest := NewRESTClient(auth, config.BaseURL, config.IsPaper)
+	if config.RateLimitPerSec > 0 {
+		rest.SetScheduler(NewScheduler(config.RateLimitPerSec))
+	}
 
 	// WebSocket URL from config (default if not set)
 	wsURL := os.Getenv("KIS_WEBSOCKET_URL")
@@ -75,11 +115,38 @@ func NewClient(config *Config) *Client {
 	}
 	ws := NewWebSocketClient(config.AppKey, config.AppSecret, wsURL)
 
-	return &Client{
-		Auth: auth,
-		REST: rest,
-		WS:   ws,
+	client := &Client{
+		Config: config,
+		Auth:   auth,
+		REST:   rest,
+		WS:     ws,
 	}
+
+	// file 모드는 즉시 설정 (postgres 모드는 DB 연결 후 SetTokenStore)
+	if config.TokenStore == TokenStoreFile {
+		client.SetTokenStore(NewFileTokenStore(config.TokenStorePath))
+	}
+
+	return client
+}
+
+// SetTokenStore shares access token and approval key through store
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.Auth.SetTokenStore(store)
+	c.WS.SetTokenStore(store)
+}
+
+// UsesPostgresTokenStore returns whether tokens must be shared via DB
+func (c *Client) UsesPostgresTokenStore() bool {
+	return c.Config != nil && c.Config.TokenStore == TokenStorePostgres
+}
+
+// WithCredentials returns a copy of config with account-specific app key/secret (계좌별 인증키)
+func (c *Config) WithCredentials(appKey, appSecret string) *Config {
+	copied := *c
+	copied.AppKey = appKey
+	copied.AppSecret = appSecret
+	return &copied
 }
 
 // NewClientFromEnv creates a new KIS Client from environment variables
@@ -33,11 +33,8 @@ func (a *ExecutionAdapter) SubmitOrder(ctx context.Context, req execution.KISOrd
 	accountNo := parts[0]
 	accountProductCode := parts[1]
 
-	// Convert order type
-	orderType := "limit"
-	if req.OrderType == "MKT" || req.OrderType == "market" {
-		orderType = "market"
-	}
+	// Order type (MKT, LMT, COND_LMT, IOC_LMT, ... → ORD_DVSN은 REST에서 매핑)
+	orderType := req.OrderType
 
 	// Convert side
 	side := "buy"
@@ -98,6 +95,46 @@ func (a *ExecutionAdapter) CancelOrder(ctx context.Context, accountID string, or
 	}, nil
 }
 
+// ModifyOrder amends an open order in KIS (정정)
+func (a *ExecutionAdapter) ModifyOrder(ctx context.Context, req execution.KISModifyRequest) (*execution.KISModifyResponse, error) {
+	// Parse account ID (format: XXXXXXXX-XX)
+	parts := strings.Split(req.AccountID, "-")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid account ID format: %s (expected: XXXXXXXX-XX)", req.AccountID)
+	}
+	accountNo := parts[0]
+	accountProductCode := parts[1]
+
+	// Order type (MKT, LMT, COND_LMT, IOC_LMT, ... → ORD_DVSN은 REST에서 매핑)
+	orderType := req.OrderType
+
+	// Get price
+	var price int64 = 0
+	if req.LimitPrice != nil {
+		price = req.LimitPrice.IntPart()
+	}
+
+	// Call KIS API
+	result, err := a.client.REST.ModifyOrder(ctx, accountNo, accountProductCode, req.OrderNo, orderType, req.Qty, price)
+	if err != nil {
+		return nil, fmt.Errorf("modify order in KIS: %w", err)
+	}
+
+	if !result.Success {
+		return nil, fmt.Errorf("KIS modify failed: %s", result.Message)
+	}
+
+	return &execution.KISModifyResponse{
+		OrderNo:    req.OrderNo,
+		NewOrderNo: result.OrderNo,
+		Timestamp:  time.Now(),
+		Raw: map[string]any{
+			"message":    result.Message,
+			"order_time": result.OrderTime,
+		},
+	}, nil
+}
+
 // GetUnfilledOrders retrieves unfilled orders from KIS
 func (a *ExecutionAdapter) GetUnfilledOrders(ctx context.Context, accountID string) ([]*execution.KISUnfilledOrder, error) {
 	// Parse account ID (format: XXXXXXXX-XX)
@@ -213,6 +250,26 @@ func (a *ExecutionAdapter) GetFillsForOrder(ctx context.Context, orderID string)
 	return []*execution.KISFill{}, nil
 }
 
+// GetCash retrieves settled cash (D+2 예수금) from KIS
+// 미결제 매수/매도 대금 반영 → 리밸런싱/비중 계산 기준 현금
+func (a *ExecutionAdapter) GetCash(ctx context.Context, accountID string) (decimal.Decimal, error) {
+	parts := strings.Split(accountID, "-")
+	if len(parts) != 2 {
+		return decimal.Zero, fmt.Errorf("invalid account ID format: %s (expected: XXXXXXXX-XX)", accountID)
+	}
+
+	summary, err := a.client.REST.GetCashBalance(ctx, parts[0], parts[1])
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("get cash balance from KIS: %w", err)
+	}
+
+	cash, err := decimal.NewFromString(summary.SettledCash)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("parse settled cash %q: %w", summary.SettledCash, err)
+	}
+	return cash, nil
+}
+
 // GetHoldings retrieves holdings from KIS
 func (a *ExecutionAdapter) GetHoldings(ctx context.Context, accountID string) ([]*execution.KISHolding, error) {
 	// Parse account ID (format: XXXXXXXX-XX)
@@ -291,3 +348,24 @@ func (a *ExecutionAdapter) GetHoldings(ctx context.Context, accountID string) ([
 
 // Ensure ExecutionAdapter implements execution.KISAdapter
 var _ execution.KISAdapter = (*ExecutionAdapter)(nil)
+
+// ToExecutionNotice converts a WebSocket execution notification (H0STCNI0) to a ledger notice
+func ToExecutionNotice(n ExecutionNotification) execution.ExecutionNotice {
+	side := ""
+	switch n.Side {
+	case "01":
+		side = execution.SideSell
+	case "02":
+		side = execution.SideBuy
+	}
+
+	return execution.ExecutionNotice{
+		OrderID:        n.OrderNo,
+		Symbol:         n.Symbol,
+		Side:           side,
+		FilledQty:      n.FilledQty,
+		FilledPrice:    decimal.NewFromInt(n.FilledPrice),
+		TotalFilledQty: n.TotalFilledQty,
+		Timestamp:      n.Timestamp,
+	}
+}
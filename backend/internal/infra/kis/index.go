@@ -0,0 +1,270 @@
+package kis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// ==============================================================================
+// 국내 업종(지수) 시세 - KOSPI / KOSDAQ / KOSPI200
+// ==============================================================================
+
+// IndexPriceResponse represents KIS 업종 현재지수 API response (FHPUP02100000)
+type IndexPriceResponse struct {
+	RetCode string           `json:"rt_cd"`
+	MsgCode string           `json:"msg_cd"`
+	Msg1    string           `json:"msg1"`
+	Output  IndexPriceOutput `json:"output"`
+}
+
+// IndexPriceOutput represents current index data
+type IndexPriceOutput struct {
+	Value        string `json:"bstp_nmix_prpr"`      // 업종 지수 현재가
+	PrdyVrss     string `json:"bstp_nmix_prdy_vrss"` // 업종 지수 전일대비
+	PrdyVrssSign string `json:"prdy_vrss_sign"`      // 전일대비부호
+	PrdyCtrt     string `json:"bstp_nmix_prdy_ctrt"` // 업종 지수 전일대비율
+	AccuVol      string `json:"acml_vol"`            // 누적거래량
+	AccuTrPbmn   string `json:"acml_tr_pbmn"`        // 누적거래대금
+}
+
+// DailyIndexChartResponse represents KIS 업종기간별시세 API response (FHKUP03500100)
+type DailyIndexChartResponse struct {
+	RetCode string                  `json:"rt_cd"`
+	MsgCode string                  `json:"msg_cd"`
+	Msg1    string                  `json:"msg1"`
+	Output2 []DailyIndexChartOutput `json:"output2"`
+}
+
+// DailyIndexChartOutput represents one daily index bar
+type DailyIndexChartOutput struct {
+	Date       string `json:"stck_bsop_date"` // 영업일자 (YYYYMMDD)
+	Close      string `json:"bstp_nmix_prpr"` // 업종 지수 현재가 (종가)
+	Open       string `json:"bstp_nmix_oprc"` // 시가
+	High       string `json:"bstp_nmix_hgpr"` // 최고가
+	Low        string `json:"bstp_nmix_lwpr"` // 최저가
+	AccuVol    string `json:"acml_vol"`       // 누적거래량
+	AccuTrPbmn string `json:"acml_tr_pbmn"`   // 누적거래대금
+}
+
+// maxIndexChartPages 업종기간별시세 1회 최대 100건 → 페이지 상한 (약 4년)
+const maxIndexChartPages = 10
+
+// GetIndexPrice fetches current index value for an index symbol (KOSPI, KOSDAQ, KOSPI200)
+// LastPrice/ChangePrice는 price.IndexPriceScale 배율 정수
+func (c *RESTClient) GetIndexPrice(ctx context.Context, symbol string) (*price.Tick, error) {
+	code, ok := price.IndexCode(symbol)
+	if !ok {
+		return nil, fmt.Errorf("unknown index symbol: %s", symbol)
+	}
+
+	// 국내주식업종기간별시세 > 국내업종 현재지수
+	req, err := c.newQuotationRequest(ctx, "/uapi/domestic-stock/v1/quotations/inquire-index-price", "FHPUP02100000", map[string]string{
+		"FID_COND_MRKT_DIV_CODE": "U", // U: 업종
+		"FID_INPUT_ISCD":         code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status, respBody, err := c.do(req, PriorityPrice)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("KIS API error: status=%d body=%s", status, string(respBody))
+	}
+
+	var resp IndexPriceResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+	if resp.RetCode != "0" {
+		return nil, fmt.Errorf("KIS API error: code=%s msg=%s", resp.MsgCode, resp.Msg1)
+	}
+
+	value, err := strconv.ParseFloat(resp.Output.Value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse index value: %w", err)
+	}
+
+	now := time.Now()
+	tick := &price.Tick{
+		Symbol:    symbol,
+		Source:    price.SourceKISREST,
+		LastPrice: price.ScaleIndexValue(value),
+		TS:        now,
+		CreatedTS: now,
+	}
+	if vrss, err := strconv.ParseFloat(resp.Output.PrdyVrss, 64); err == nil {
+		change := signedChange(price.ScaleIndexValue(vrss), resp.Output.PrdyVrssSign)
+		tick.ChangePrice = &change
+	}
+	if ctrt, err := strconv.ParseFloat(resp.Output.PrdyCtrt, 64); err == nil {
+		tick.ChangeRate = &ctrt
+	}
+	if vol, err := strconv.ParseInt(resp.Output.AccuVol, 10, 64); err == nil {
+		tick.Volume = &vol
+	}
+
+	return tick, nil
+}
+
+// FetchDailyIndexPrices fetches daily index bars in [from, to] (implements fetcher.IndexSource)
+// indexCode는 지수 심볼 (KOSPI, KOSDAQ, KOSPI200), 결과는 trade_date 오름차순
+func (c *RESTClient) FetchDailyIndexPrices(ctx context.Context, indexCode string, from, to time.Time) ([]*fetcher.IndexPrice, error) {
+	code, ok := price.IndexCode(indexCode)
+	if !ok {
+		return nil, fmt.Errorf("unknown index symbol: %s", indexCode)
+	}
+
+	from = dateOnly(from)
+	end := dateOnly(to)
+	seen := make(map[string]bool)
+	var result []*fetcher.IndexPrice
+
+	for page := 0; page < maxIndexChartPages && !end.Before(from); page++ {
+		req, err := c.newQuotationRequest(ctx, "/uapi/domestic-stock/v1/quotations/inquire-daily-indexchartprice", "FHKUP03500100", map[string]string{
+			"FID_COND_MRKT_DIV_CODE": "U",
+			"FID_INPUT_ISCD":         code,
+			"FID_INPUT_DATE_1":       from.Format("20060102"),
+			"FID_INPUT_DATE_2":       end.Format("20060102"),
+			"FID_PERIOD_DIV_CODE":    "D", // D: 일봉
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// 백필은 주문/시세 조회보다 후순위
+		status, respBody, err := c.do(req, PriorityBackfill)
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("KIS API error: status=%d body=%s", status, string(respBody))
+		}
+
+		var resp DailyIndexChartResponse
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response: %w", err)
+		}
+		if resp.RetCode != "0" {
+			return nil, fmt.Errorf("KIS API error: code=%s msg=%s", resp.MsgCode, resp.Msg1)
+		}
+
+		// output2는 최신순, 페이지당 최대 100건
+		earliest := end
+		added := 0
+		for _, row := range resp.Output2 {
+			if row.Date == "" || seen[row.Date] {
+				continue
+			}
+			bar, err := convertIndexBar(indexCode, row)
+			if err != nil {
+				continue
+			}
+			if bar.TradeDate.Before(from) || bar.TradeDate.After(dateOnly(to)) {
+				continue
+			}
+			seen[row.Date] = true
+			result = append(result, bar)
+			added++
+			if bar.TradeDate.Before(earliest) {
+				earliest = bar.TradeDate
+			}
+		}
+
+		if added == 0 {
+			break
+		}
+		end = earliest.AddDate(0, 0, -1)
+	}
+
+	// 오름차순 정렬 (페이지는 최신 → 과거 순으로 수집됨)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TradeDate.Before(result[j].TradeDate)
+	})
+
+	return result, nil
+}
+
+// newQuotationRequest builds an authenticated GET request for 시세 APIs
+func (c *RESTClient) newQuotationRequest(ctx context.Context, path, trID string, params map[string]string) (*http.Request, error) {
+	token, err := c.auth.GetAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	q := req.URL.Query()
+	for k, v := range params {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("appkey", c.auth.appKey)
+	req.Header.Set("appsecret", c.auth.appSecret)
+	req.Header.Set("tr_id", trID)
+	req.Header.Set("custtype", "P")
+
+	return req, nil
+}
+
+// convertIndexBar converts KIS daily index output to fetcher.IndexPrice
+func convertIndexBar(indexCode string, row DailyIndexChartOutput) (*fetcher.IndexPrice, error) {
+	date, err := time.ParseInLocation("20060102", row.Date, calendar.KST)
+	if err != nil {
+		return nil, fmt.Errorf("parse date: %w", err)
+	}
+
+	closePrice, err := strconv.ParseFloat(row.Close, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse close: %w", err)
+	}
+
+	bar := &fetcher.IndexPrice{
+		IndexCode:  indexCode,
+		TradeDate:  date,
+		ClosePrice: closePrice,
+		OpenPrice:  closePrice,
+		HighPrice:  closePrice,
+		LowPrice:   closePrice,
+	}
+	if v, err := strconv.ParseFloat(row.Open, 64); err == nil && v > 0 {
+		bar.OpenPrice = v
+	}
+	if v, err := strconv.ParseFloat(row.High, 64); err == nil && v > 0 {
+		bar.HighPrice = v
+	}
+	if v, err := strconv.ParseFloat(row.Low, 64); err == nil && v > 0 {
+		bar.LowPrice = v
+	}
+	if v, err := strconv.ParseInt(row.AccuVol, 10, 64); err == nil {
+		bar.Volume = v
+	}
+	if v, err := strconv.ParseInt(row.AccuTrPbmn, 10, 64); err == nil {
+		bar.TradingValue = v
+	}
+
+	return bar, nil
+}
+
+// dateOnly truncates t to KST calendar date
+func dateOnly(t time.Time) time.Time {
+	kst := t.In(calendar.KST)
+	return time.Date(kst.Year(), kst.Month(), kst.Day(), 0, 0, 0, 0, calendar.KST)
+}
@@ -0,0 +1,36 @@
+package kis
+
+import (
+	"testing"
+
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// TestParseIndexMessage tests H0UPCNT0 mapping to an index tick
+func TestParseIndexMessage(t *testing.T) {
+	msg := "0|H0UPCNT0|001|0001^093015^2601.23^5^12.34^123456^7890123^100^200^-0.47"
+	tick, err := parseIndexMessage([]byte(msg))
+	if err != nil {
+		t.Fatalf("parseIndexMessage failed: %v", err)
+	}
+
+	if tick.Symbol != price.IndexKOSPI {
+		t.Errorf("Expected symbol KOSPI, got %s", tick.Symbol)
+	}
+	if tick.LastPrice != 260123 {
+		t.Errorf("Expected scaled price 260123, got %d", tick.LastPrice)
+	}
+	if tick.ChangePrice == nil || *tick.ChangePrice != -1234 {
+		t.Errorf("Expected change -1234 (하락), got %v", tick.ChangePrice)
+	}
+	if tick.ChangeRate == nil || *tick.ChangeRate != -0.47 {
+		t.Errorf("Expected change rate -0.47, got %v", tick.ChangeRate)
+	}
+
+	if trID, trKey := tradeTR(price.IndexKOSDAQ); trID != "H0UPCNT0" || trKey != "1001" {
+		t.Errorf("Expected KOSDAQ → H0UPCNT0/1001, got %s/%s", trID, trKey)
+	}
+	if trID, trKey := tradeTR("005930"); trID != "H0STCNT0" || trKey != "005930" {
+		t.Errorf("Expected stock → H0STCNT0/005930, got %s/%s", trID, trKey)
+	}
+}
@@ -0,0 +1,43 @@
+package kis
+
+import (
+	"fmt"
+
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+)
+
+// kisOrderDivisions 주문유형 → KIS 주문구분 (ORD_DVSN, 주식주문(현금) TTTC0802U/TTTC0801U)
+var kisOrderDivisions = map[string]string{
+	execution.OrderTypeLimit:            "00", // 지정가
+	execution.OrderTypeMarket:           "01", // 시장가
+	execution.OrderTypeConditionalLimit: "02", // 조건부지정가
+	execution.OrderTypeBestLimit:        "03", // 최유리지정가
+	execution.OrderTypePriorityLimit:    "04", // 최우선지정가
+	execution.OrderTypePreMarketClose:   "05", // 장전 시간외
+	execution.OrderTypeAfterHoursClose:  "06", // 장후 시간외
+	execution.OrderTypeAfterHoursSingle: "07", // 시간외 단일가
+	execution.OrderTypeIOCLimit:         "11", // IOC지정가
+	execution.OrderTypeFOKLimit:         "12", // FOK지정가
+	execution.OrderTypeIOCMarket:        "13", // IOC시장가
+	execution.OrderTypeFOKMarket:        "14", // FOK시장가
+	execution.OrderTypeIOCBest:          "15", // IOC최유리
+	execution.OrderTypeFOKBest:          "16", // FOK최유리
+	execution.OrderTypeClosingAuction:   "01", // 장마감 동시호가 시간에 시장가로 접수
+}
+
+// orderDivision returns KIS ORD_DVSN and whether ORD_UNPR is required
+// "limit"/"market"은 기존 호출부 호환용 별칭
+func orderDivision(orderType string) (string, bool, error) {
+	switch orderType {
+	case "limit":
+		orderType = execution.OrderTypeLimit
+	case "market":
+		orderType = execution.OrderTypeMarket
+	}
+
+	code, ok := kisOrderDivisions[orderType]
+	if !ok {
+		return "", false, fmt.Errorf("%w: %s", execution.ErrInvalidOrderType, orderType)
+	}
+	return code, execution.OrderTypeNeedsPrice(orderType), nil
+}
@@ -0,0 +1,55 @@
+package kis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestParseOrderBookMessage tests H0STASP0 field mapping
+func TestParseOrderBookMessage(t *testing.T) {
+	fields := []string{"005930", "093015", "0"}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, fmt.Sprint(70100+i*100)) // 매도호가1~10
+	}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, fmt.Sprint(70000-i*100)) // 매수호가1~10
+	}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, fmt.Sprint(1000+i)) // 매도잔량
+	}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, fmt.Sprint(2000+i)) // 매수잔량
+	}
+	fields = append(fields, "10045", "20045", "0", "0")
+
+	msg := "0|H0STASP0|001|" + strings.Join(fields, "^")
+	book, err := parseOrderBookMessage([]byte(msg))
+	if err != nil {
+		t.Fatalf("parseOrderBookMessage failed: %v", err)
+	}
+
+	if book.Symbol != "005930" {
+		t.Errorf("Expected symbol 005930, got %s", book.Symbol)
+	}
+	if ask := book.BestAsk(); ask == nil || ask.Price != 70100 || ask.Volume != 1000 {
+		t.Errorf("Expected best ask 70100 x 1000, got %+v", ask)
+	}
+	if bid := book.BestBid(); bid == nil || bid.Price != 70000 || bid.Volume != 2000 {
+		t.Errorf("Expected best bid 70000 x 2000, got %+v", bid)
+	}
+	if book.Asks[9].Price != 71000 || book.Bids[9].Volume != 2009 {
+		t.Errorf("Unexpected 10th level: ask %+v, bid %+v", book.Asks[9], book.Bids[9])
+	}
+	if book.TotalAskVolume != 10045 || book.TotalBidVolume != 20045 {
+		t.Errorf("Unexpected totals: ask %d, bid %d", book.TotalAskVolume, book.TotalBidVolume)
+	}
+	if book.Spread() != 100 {
+		t.Errorf("Expected spread 100, got %d", book.Spread())
+	}
+
+	// 체결가 메시지는 호가로 파싱되지 않아야 함
+	if _, err := parseOrderBookMessage([]byte("0|H0STCNT0|001|005930^093015^70000")); err == nil {
+		t.Errorf("Expected error for H0STCNT0 message")
+	}
+}
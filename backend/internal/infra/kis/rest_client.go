@@ -8,29 +8,107 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/wonny/aegis/v14/internal/domain/price"
 )
 
+// KIS 초당 거래건수 제한 (app key 기준, 여유분 반영)
+const (
+	DefaultRateLimitReal  = 18.0 // 실전: 20건/초
+	DefaultRateLimitPaper = 2.0  // 모의: 2건/초
+
+	maxRateLimitRetries = 2 // EGW00201 백오프 후 재시도 횟수
+)
+
 // RESTClient handles KIS REST API requests
 type RESTClient struct {
 	auth       *AuthClient
 	baseURL    string
 	isPaper    bool
 	httpClient *http.Client
+
+	// 모든 REST 호출이 공유하는 rate limiter (우선순위 큐)
+	scheduler *Scheduler
 }
 
 // NewRESTClient creates a new RESTClient
 func NewRESTClient(auth *AuthClient, baseURL string, isPaper bool) *RESTClient {
+	rate := DefaultRateLimitReal
+	if isPaper {
+		rate = DefaultRateLimitPaper
+	}
+
 	return &RESTClient{
 		auth:       auth,
 		baseURL:    baseURL,
 		isPaper:    isPaper,
 		httpClient: &http.Client{Timeout: 10 * time.Second},
+		scheduler:  NewScheduler(rate),
 	}
 }
 
+// SetScheduler replaces the rate limit scheduler (custom rate)
+func (c *RESTClient) SetScheduler(scheduler *Scheduler) {
+	c.scheduler = scheduler
+}
+
+// Scheduler returns the rate limit scheduler (stats 조회용)
+func (c *RESTClient) Scheduler() *Scheduler {
+	return c.scheduler
+}
+
+// do executes request through the scheduler and returns status and body
+// - 우선순위: ctx override (WithPriority) 또는 API별 기본값
+// - EGW00201: 전체 요청 백오프 후 재시도 (KIS가 처리 전 거부하므로 주문도 안전)
+func (c *RESTClient) do(req *http.Request, def Priority) (int, []byte, error) {
+	ctx := req.Context()
+	priority := priorityFrom(ctx, def)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.scheduler.Wait(ctx, priority); err != nil {
+			return 0, nil, fmt.Errorf("wait rate limit: %w", err)
+		}
+
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return 0, nil, fmt.Errorf("reset request body: %w", err)
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := c.httpClient.Do(r)
+		if err != nil {
+			return 0, nil, fmt.Errorf("execute request: %w", err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("read response: %w", err)
+		}
+
+		if isEGW00201(string(respBody)) {
+			c.scheduler.Throttle()
+			if attempt < maxRateLimitRetries {
+				continue
+			}
+			return resp.StatusCode, respBody, nil
+		}
+
+		c.scheduler.Succeeded()
+		return resp.StatusCode, respBody, nil
+	}
+}
+
+// isEGW00201 checks if response is EGW00201 (초당 거래건수 초과)
+func isEGW00201(body string) bool {
+	return strings.Contains(body, "EGW00201") || strings.Contains(body, "초당 거래건수를 초과")
+}
+
 // CurrentPriceResponse represents KIS current price API response
 type CurrentPriceResponse struct {
 	RetCode    string `json:"rt_cd"`    // "0" = success
@@ -56,10 +134,60 @@ type CurrentPriceOutput struct {
 	StckLwpr       string `json:"stck_lwpr"`      // 최저가
 	StckOprc       string `json:"stck_oprc"`      // 시가
 	StckSdpr       string `json:"stck_sdpr"`      // 기준가
+	StckMxpr       string `json:"stck_mxpr"`      // 상한가
+	StckLlam       string `json:"stck_llam"`      // 하한가
+	ViClsCode      string `json:"vi_cls_code"`    // VI적용구분코드 (N: 미발동)
+	TempStopYn     string `json:"temp_stop_yn"`   // 임시정지여부
 }
 
 // GetCurrentPrice fetches current price for a symbol
 func (c *RESTClient) GetCurrentPrice(ctx context.Context, symbol string) (*price.Tick, error) {
+	// 지수 심볼은 업종 현재지수 API로 조회
+	if price.IsIndexSymbol(symbol) {
+		return c.GetIndexPrice(ctx, symbol)
+	}
+
+	output, err := c.inquirePrice(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to Tick
+	tick, err := convertToTick(symbol, *output)
+	if err != nil {
+		return nil, fmt.Errorf("convert to tick: %w", err)
+	}
+
+	return tick, nil
+}
+
+// GetPriceBand fetches today's price band (기준가, 상/하한가, VI 발동 여부)
+func (c *RESTClient) GetPriceBand(ctx context.Context, symbol string) (*price.Band, error) {
+	output, err := c.inquirePrice(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := strconv.ParseInt(output.StckSdpr, 10, 64)
+	if err != nil || base <= 0 {
+		return nil, fmt.Errorf("parse base price %q: %w", output.StckSdpr, price.ErrInvalidPrice)
+	}
+
+	band := price.NewBand(symbol, "", base)
+	if upper, err := strconv.ParseInt(output.StckMxpr, 10, 64); err == nil && upper > 0 {
+		band.Upper = upper
+	}
+	if lower, err := strconv.ParseInt(output.StckLlam, 10, 64); err == nil && lower > 0 {
+		band.Lower = lower
+	}
+	band.VIActive = output.ViClsCode != "" && output.ViClsCode != "N"
+	band.Halted = output.TempStopYn == "Y"
+
+	return band, nil
+}
+
+// inquirePrice calls 주식현재가 시세 (FHKST01010100)
+func (c *RESTClient) inquirePrice(ctx context.Context, symbol string) (*CurrentPriceOutput, error) {
 	// Get access token
 	token, err := c.auth.GetAccessToken(ctx)
 	if err != nil {
@@ -88,21 +216,14 @@ func (c *RESTClient) GetCurrentPrice(ctx context.Context, symbol string) (*price
 	req.Header.Set("appsecret", c.auth.appSecret)
 	req.Header.Set("tr_id", "FHKST01010100") // 국내주식 현재가 시세 조회
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	// Execute request (shared rate limit scheduler)
+	status, respBody, err := c.do(req, PriorityPrice)
 	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("KIS API error: status=%d body=%s", resp.StatusCode, string(respBody))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("KIS API error: status=%d body=%s", status, string(respBody))
 	}
 
 	// Parse response
@@ -115,13 +236,7 @@ func (c *RESTClient) GetCurrentPrice(ctx context.Context, symbol string) (*price
 		return nil, fmt.Errorf("KIS API error: code=%s msg=%s", priceResp.MsgCode, priceResp.Msg1)
 	}
 
-	// Convert to Tick
-	tick, err := convertToTick(symbol, priceResp.Output)
-	if err != nil {
-		return nil, fmt.Errorf("convert to tick: %w", err)
-	}
-
-	return tick, nil
+	return &priceResp.Output, nil
 }
 
 // convertToTick converts KIS API output to price.Tick
@@ -210,16 +325,10 @@ func (c *RESTClient) GetCurrentPrices(ctx context.Context, symbols []string) ([]
 	var lastErr error
 	failCount := 0
 
-	for i, symbol := range symbols {
-		// Rate limiting FIRST (except for first request)
-		// KIS allows ~20 req/sec, so sleep 50ms between requests
-		// This prevents burst on failures
-		if i > 0 {
-			select {
-			case <-ctx.Done():
-				return ticks, ctx.Err()
-			case <-time.After(50 * time.Millisecond):
-			}
+	for _, symbol := range symbols {
+		// Rate limiting은 scheduler가 담당 (주문/잔고 조회보다 후순위)
+		if err := ctx.Err(); err != nil {
+			return ticks, err
 		}
 
 		tick, err := c.GetCurrentPrice(ctx, symbol)
@@ -242,15 +351,21 @@ func (c *RESTClient) GetCurrentPrices(ctx context.Context, symbols []string) ([]
 
 // HoldingResponse represents KIS holdings inquiry API response
 type HoldingResponse struct {
-	RetCode string          `json:"rt_cd"` // "0" = success
-	MsgCode string          `json:"msg_cd"`
-	Msg1    string          `json:"msg1"`
-	Output1 []HoldingOutput `json:"output1"`
-	Output2 []struct {
-		TotalPurchaseAmount  string `json:"pchs_amt_smtl_amt"`  // 매입금액합계금액
-		TotalEvaluateAmount  string `json:"evlu_amt_smtl_amt"`  // 평가금액합계금액
-		TotalEvaluateProfitLoss string `json:"evlu_pfls_smtl_amt"` // 평가손익합계금액
-	} `json:"output2"`
+	RetCode string                 `json:"rt_cd"` // "0" = success
+	MsgCode string                 `json:"msg_cd"`
+	Msg1    string                 `json:"msg1"`
+	Output1 []HoldingOutput        `json:"output1"`
+	Output2 []BalanceSummaryOutput `json:"output2"`
+}
+
+// BalanceSummaryOutput represents account summary of holdings inquiry (예수금/평가 합계)
+type BalanceSummaryOutput struct {
+	CashTotal               string `json:"dnca_tot_amt"`       // 예수금총금액 (D+0)
+	NextDayCash             string `json:"nxdy_excc_amt"`      // 익일정산금액 (D+1)
+	SettledCash             string `json:"prvs_rcdl_excc_amt"` // 가수도정산금액 (D+2 예수금)
+	TotalPurchaseAmount     string `json:"pchs_amt_smtl_amt"`  // 매입금액합계금액
+	TotalEvaluateAmount     string `json:"evlu_amt_smtl_amt"`  // 평가금액합계금액
+	TotalEvaluateProfitLoss string `json:"evlu_pfls_smtl_amt"` // 평가손익합계금액
 }
 
 // HoldingOutput represents holding data
@@ -392,21 +507,14 @@ func (c *RESTClient) getOrdersByDateRange(ctx context.Context, accountNo string,
 	req.Header.Set("appsecret", c.auth.appSecret)
 	req.Header.Set("tr_id", trID)
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Execute request (shared rate limit scheduler)
+	status, respBody, err := c.do(req, PriorityAccount)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("KIS API error: status=%d body=%s", resp.StatusCode, string(respBody))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("KIS API error: status=%d body=%s", status, string(respBody))
 	}
 
 	// Parse response
@@ -494,23 +602,16 @@ func (c *RESTClient) CancelOrder(ctx context.Context, accountNo string, accountP
 	req.Header.Set("appsecret", c.auth.appSecret)
 	req.Header.Set("tr_id", trID)
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Execute request (shared rate limit scheduler)
+	status, respBody, err := c.do(req, PriorityOrder)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if status != http.StatusOK {
 		return &CancelOrderResult{
 			Success: false,
-			Message: fmt.Sprintf("cancel failed: status=%d body=%s", resp.StatusCode, string(respBody)),
+			Message: fmt.Sprintf("cancel failed: status=%d body=%s", status, string(respBody)),
 		}, nil
 	}
 
@@ -535,6 +636,104 @@ func (c *RESTClient) CancelOrder(ctx context.Context, accountNo string, accountP
 	}, nil
 }
 
+// ModifyOrderResult represents modify order result
+type ModifyOrderResult struct {
+	Success   bool
+	OrderNo   string // 정정주문번호 (KIS가 새로 부여)
+	OrderTime string
+	Message   string
+}
+
+// ModifyOrder amends an open order (주문 정정, 잔량 전부)
+// orderType: PlaceOrder와 동일, 시장가 계열 정정 시 price는 무시
+func (c *RESTClient) ModifyOrder(ctx context.Context, accountNo string, accountProductCode string, orderNo string, orderType string, qty int64, price int64) (*ModifyOrderResult, error) {
+	// Get access token
+	token, err := c.auth.GetAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get access token: %w", err)
+	}
+
+	// 모의투자는 지원하지 않음
+	if c.isPaper {
+		return nil, fmt.Errorf("order modify not supported in paper trading mode")
+	}
+
+	// Build request URL (주문정정취소)
+	url := fmt.Sprintf("%s/uapi/domestic-stock/v1/trading/order-rvsecncl", c.baseURL)
+
+	// 주문구분 (ORD_DVSN, PlaceOrder와 동일)
+	ordDvsn, needsPrice, err := orderDivision(orderType)
+	if err != nil {
+		return nil, err
+	}
+	if !needsPrice {
+		price = 0 // 시장가 계열은 가격 0
+	}
+
+	// Request body
+	body := map[string]string{
+		"CANO":               accountNo,
+		"ACNT_PRDT_CD":       accountProductCode,
+		"KRX_FWDG_ORD_ORGNO": "",      // 공백
+		"ORGN_ODNO":          orderNo, // 원주문번호
+		"ORD_DVSN":           ordDvsn,
+		"RVSE_CNCL_DVSN_CD":  "01", // 01: 정정
+		"ORD_QTY":            fmt.Sprintf("%d", qty),
+		"ORD_UNPR":           fmt.Sprintf("%d", price),
+		"QTY_ALL_ORD_YN":     "Y", // Y: 잔량전부
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	// Add headers (TR_ID: 취소와 동일, RVSE_CNCL_DVSN_CD로 구분)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("appkey", c.auth.appKey)
+	req.Header.Set("appsecret", c.auth.appSecret)
+	req.Header.Set("tr_id", "TTTC0803U")
+
+	// Execute request (shared rate limit scheduler)
+	status, respBody, err := c.do(req, PriorityOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return &ModifyOrderResult{
+			Success: false,
+			Message: fmt.Sprintf("modify failed: status=%d body=%s", status, string(respBody)),
+		}, nil
+	}
+
+	// Parse response (취소와 동일 형식)
+	var modifyResp CancelOrderResponse
+	if err := json.Unmarshal(respBody, &modifyResp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	if modifyResp.RetCode != "0" {
+		return &ModifyOrderResult{
+			Success: false,
+			Message: fmt.Sprintf("KIS API error: code=%s msg=%s", modifyResp.MsgCode, modifyResp.Msg1),
+		}, nil
+	}
+
+	return &ModifyOrderResult{
+		Success:   true,
+		OrderNo:   modifyResp.Output.OrderNo,
+		OrderTime: modifyResp.Output.OrderTime,
+		Message:   modifyResp.Msg1,
+	}, nil
+}
+
 // PlaceOrderResult represents place order result
 type PlaceOrderResult struct {
 	Success   bool
@@ -556,7 +755,7 @@ type PlaceOrderResponse struct {
 
 // PlaceOrder submits an order to KIS (현금 매수/매도)
 // side: "buy" or "sell"
-// orderType: "limit" or "market"
+// orderType: "limit", "market" 또는 execution 주문유형 (COND_LMT, IOC_LMT, CLOSE_AUCTION 등)
 func (c *RESTClient) PlaceOrder(ctx context.Context, accountNo string, accountProductCode string, symbol string, side string, orderType string, qty int64, price int64) (*PlaceOrderResult, error) {
 	// Get access token
 	token, err := c.auth.GetAccessToken(ctx)
@@ -580,13 +779,13 @@ func (c *RESTClient) PlaceOrder(ctx context.Context, accountNo string, accountPr
 		trID = "TTTC0801U"
 	}
 
-	// 주문구분: 00(지정가), 01(시장가)
-	var ordDvsn string
-	if orderType == "market" {
-		ordDvsn = "01"
-		price = 0 // 시장가는 가격 0
-	} else {
-		ordDvsn = "00"
+	// 주문구분 (ORD_DVSN): 00 지정가, 01 시장가, 02~16 조건부/최유리/최우선/시간외/IOC/FOK
+	ordDvsn, needsPrice, err := orderDivision(orderType)
+	if err != nil {
+		return nil, err
+	}
+	if !needsPrice {
+		price = 0 // 시장가 계열은 가격 0
 	}
 
 	// Request body
@@ -616,23 +815,16 @@ func (c *RESTClient) PlaceOrder(ctx context.Context, accountNo string, accountPr
 	req.Header.Set("appsecret", c.auth.appSecret)
 	req.Header.Set("tr_id", trID)
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	// Execute request (shared rate limit scheduler)
+	status, respBody, err := c.do(req, PriorityOrder)
 	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
+	if status != http.StatusOK {
 		return &PlaceOrderResult{
 			Success: false,
-			Message: fmt.Sprintf("order failed: status=%d body=%s", resp.StatusCode, string(respBody)),
+			Message: fmt.Sprintf("order failed: status=%d body=%s", status, string(respBody)),
 		}, nil
 	}
 
@@ -659,6 +851,27 @@ func (c *RESTClient) PlaceOrder(ctx context.Context, accountNo string, accountPr
 
 // GetHoldings fetches current holdings (보유종목 조회)
 func (c *RESTClient) GetHoldings(ctx context.Context, accountNo string, accountProductCode string) ([]HoldingOutput, error) {
+	resp, err := c.inquireBalance(ctx, accountNo, accountProductCode)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Output1, nil
+}
+
+// GetCashBalance fetches account cash summary (주식잔고조회 output2: 예수금)
+func (c *RESTClient) GetCashBalance(ctx context.Context, accountNo string, accountProductCode string) (*BalanceSummaryOutput, error) {
+	resp, err := c.inquireBalance(ctx, accountNo, accountProductCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Output2) == 0 {
+		return nil, fmt.Errorf("KIS API error: empty balance summary")
+	}
+	return &resp.Output2[0], nil
+}
+
+// inquireBalance calls 주식잔고조회 (보유종목 output1 + 예수금/합계 output2)
+func (c *RESTClient) inquireBalance(ctx context.Context, accountNo string, accountProductCode string) (*HoldingResponse, error) {
 	// Get access token
 	token, err := c.auth.GetAccessToken(ctx)
 	if err != nil {
@@ -705,21 +918,14 @@ func (c *RESTClient) GetHoldings(ctx context.Context, accountNo string, accountP
 	req.Header.Set("appsecret", c.auth.appSecret)
 	req.Header.Set("tr_id", trID)
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Execute request (shared rate limit scheduler)
+	status, respBody, err := c.do(req, PriorityAccount)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("KIS API error: status=%d body=%s", resp.StatusCode, string(respBody))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("KIS API error: status=%d body=%s", status, string(respBody))
 	}
 
 	// Parse response
@@ -732,5 +938,5 @@ func (c *RESTClient) GetHoldings(ctx context.Context, accountNo string, accountP
 		return nil, fmt.Errorf("KIS API error: code=%s msg=%s", holdingResp.MsgCode, holdingResp.Msg1)
 	}
 
-	return holdingResp.Output1, nil
+	return &holdingResp, nil
 }
@@ -0,0 +1,313 @@
+package kis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Priority KIS REST 요청 우선순위 (낮을수록 먼저)
+type Priority int
+
+const (
+	PriorityOrder    Priority = iota // 주문/취소
+	PriorityAccount                  // 잔고/체결 조회
+	PriorityPrice                    // 시세 폴링
+	PriorityBackfill                 // 감사/백필 배치
+
+	numPriorities = 4
+)
+
+// String returns priority name
+func (p Priority) String() string {
+	switch p {
+	case PriorityOrder:
+		return "order"
+	case PriorityAccount:
+		return "account"
+	case PriorityPrice:
+		return "price"
+	case PriorityBackfill:
+		return "backfill"
+	default:
+		return "unknown"
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority overrides request priority for KIS REST calls made with ctx
+// (기본값은 API별: 주문 > 잔고/체결 > 시세)
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priorityFrom returns ctx priority override or def
+func priorityFrom(ctx context.Context, def Priority) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && p < numPriorities {
+		return p
+	}
+	return def
+}
+
+// Scheduler EGW00201 백오프 설정
+const (
+	throttleBase = 1 * time.Second // KIS 초당 거래건수 제한 → 최소 1초 정지
+	throttleMax  = 5 * time.Second
+)
+
+// Scheduler token-bucket rate limiter with strict priority queues
+// - 모든 REST 호출이 공유 (app key당 초당 거래건수 제한)
+// - 대기 중 요청은 우선순위 순으로 토큰 할당 (시세 폴링이 주문을 굶기지 않음)
+// - EGW00201 수신 시 전체 일시 정지 후 재개 (연속 발생 시 지수 백오프)
+type Scheduler struct {
+	rate  float64 // tokens per second
+	burst float64
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	throttles   int // 연속 EGW00201 횟수
+	queues      [numPriorities][]*waiter
+	dispatching bool
+
+	// Metrics
+	granted        [numPriorities]int64
+	waitTotal      [numPriorities]time.Duration
+	throttledTotal int64
+	maxDepth       int
+
+	now func() time.Time
+}
+
+type waiter struct {
+	ch       chan struct{}
+	granted  bool
+	enqueued time.Time
+}
+
+// SchedulerStats holds scheduler statistics
+type SchedulerStats struct {
+	RatePerSec     float64
+	QueueDepth     map[string]int           // 현재 대기 수 (우선순위별)
+	Granted        map[string]int64         // 누적 처리 수
+	AvgWait        map[string]time.Duration // 평균 대기 시간
+	MaxQueueDepth  int
+	TotalThrottled int64 // 누적 EGW00201
+	PausedUntil    time.Time
+}
+
+// NewScheduler creates a scheduler allowing ratePerSec requests (burst = 1초분)
+func NewScheduler(ratePerSec float64) *Scheduler {
+	if ratePerSec <= 0 {
+		ratePerSec = 1
+	}
+	burst := ratePerSec
+	if burst < 1 {
+		burst = 1
+	}
+
+	s := &Scheduler{
+		rate:   ratePerSec,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+	}
+	s.last = s.now()
+	return s
+}
+
+// Wait blocks until a request slot is granted for priority p (ctx 취소 시 에러)
+func (s *Scheduler) Wait(ctx context.Context, p Priority) error {
+	if p < 0 || p >= numPriorities {
+		p = PriorityBackfill
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.refill(now)
+
+	// Fast path: 대기열 없고 토큰 있음
+	if s.queued() == 0 && !now.Before(s.pausedUntil) && s.tokens >= 1 {
+		s.tokens--
+		s.granted[p]++
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := &waiter{ch: make(chan struct{}), enqueued: now}
+	s.queues[p] = append(s.queues[p], w)
+	if depth := s.queued(); depth > s.maxDepth {
+		s.maxDepth = depth
+	}
+	if !s.dispatching {
+		s.dispatching = true
+		go s.dispatch()
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-w.ch:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if w.granted {
+			return nil // 취소와 동시에 할당됨: 토큰 사용
+		}
+		s.remove(p, w)
+		return ctx.Err()
+	}
+}
+
+// Throttle pauses all requests after EGW00201 (연속 발생 시 1s → 2s → 4s → 5s)
+func (s *Scheduler) Throttle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backoff := throttleBase << s.throttles
+	if backoff > throttleMax {
+		backoff = throttleMax
+	}
+	s.throttles++
+	s.throttledTotal++
+
+	until := s.now().Add(backoff)
+	if until.After(s.pausedUntil) {
+		s.pausedUntil = until
+	}
+	s.tokens = 0
+
+	log.Warn().
+		Dur("backoff", backoff).
+		Int("consecutive", s.throttles).
+		Ints("queue_depth", s.depths()).
+		Msg("⏸️ KIS rate limit (EGW00201) - pausing REST requests")
+}
+
+// Succeeded resets throttle backoff after a successful request
+func (s *Scheduler) Succeeded() {
+	s.mu.Lock()
+	s.throttles = 0
+	s.mu.Unlock()
+}
+
+// Stats returns scheduler statistics
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := SchedulerStats{
+		RatePerSec:     s.rate,
+		QueueDepth:     make(map[string]int, numPriorities),
+		Granted:        make(map[string]int64, numPriorities),
+		AvgWait:        make(map[string]time.Duration, numPriorities),
+		MaxQueueDepth:  s.maxDepth,
+		TotalThrottled: s.throttledTotal,
+		PausedUntil:    s.pausedUntil,
+	}
+	for p := Priority(0); p < numPriorities; p++ {
+		stats.QueueDepth[p.String()] = len(s.queues[p])
+		stats.Granted[p.String()] = s.granted[p]
+		if s.granted[p] > 0 {
+			stats.AvgWait[p.String()] = s.waitTotal[p] / time.Duration(s.granted[p])
+		}
+	}
+	return stats
+}
+
+// dispatch grants tokens to queued waiters by priority until queues are empty
+func (s *Scheduler) dispatch() {
+	for {
+		s.mu.Lock()
+		now := s.now()
+		s.refill(now)
+
+		if !now.Before(s.pausedUntil) {
+			for s.tokens >= 1 {
+				w, p := s.pop()
+				if w == nil {
+					break
+				}
+				s.tokens--
+				s.granted[p]++
+				s.waitTotal[p] += now.Sub(w.enqueued)
+				w.granted = true
+				close(w.ch)
+			}
+		}
+
+		if s.queued() == 0 {
+			s.dispatching = false
+			s.mu.Unlock()
+			return
+		}
+
+		// 다음 토큰 (또는 정지 해제)까지 대기
+		sleep := time.Duration((1 - s.tokens) / s.rate * float64(time.Second))
+		if pause := s.pausedUntil.Sub(now); pause > sleep {
+			sleep = pause
+		}
+		if sleep < time.Millisecond {
+			sleep = time.Millisecond
+		}
+		s.mu.Unlock()
+
+		time.Sleep(sleep)
+	}
+}
+
+// refill adds tokens for elapsed time (caller holds mu)
+func (s *Scheduler) refill(now time.Time) {
+	if elapsed := now.Sub(s.last); elapsed > 0 {
+		s.tokens += elapsed.Seconds() * s.rate
+		if s.tokens > s.burst {
+			s.tokens = s.burst
+		}
+	}
+	s.last = now
+}
+
+// pop removes highest-priority waiter (caller holds mu)
+func (s *Scheduler) pop() (*waiter, Priority) {
+	for p := Priority(0); p < numPriorities; p++ {
+		if len(s.queues[p]) > 0 {
+			w := s.queues[p][0]
+			s.queues[p] = s.queues[p][1:]
+			return w, p
+		}
+	}
+	return nil, 0
+}
+
+// remove drops a cancelled waiter (caller holds mu)
+func (s *Scheduler) remove(p Priority, w *waiter) {
+	q := s.queues[p]
+	for i, x := range q {
+		if x == w {
+			s.queues[p] = append(q[:i], q[i+1:]...)
+			return
+		}
+	}
+}
+
+// queued returns total waiters (caller holds mu)
+func (s *Scheduler) queued() int {
+	n := 0
+	for _, q := range s.queues {
+		n += len(q)
+	}
+	return n
+}
+
+// depths returns queue depth per priority (caller holds mu)
+func (s *Scheduler) depths() []int {
+	out := make([]int, numPriorities)
+	for p, q := range s.queues {
+		out[p] = len(q)
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package kis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitDepth waits until queue depth for priority reaches n
+func waitDepth(t *testing.T, s *Scheduler, p Priority, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for s.Stats().QueueDepth[p.String()] < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d %s waiters", n, p)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestSchedulerPriority tests queued orders are granted before earlier price polling
+func TestSchedulerPriority(t *testing.T) {
+	s := NewScheduler(5)
+	ctx := context.Background()
+
+	// Burst 소진
+	for i := 0; i < 5; i++ {
+		if err := s.Wait(ctx, PriorityPrice); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+
+	order := make(chan Priority, 3)
+	for i := 0; i < 2; i++ {
+		go func() {
+			s.Wait(ctx, PriorityPrice)
+			order <- PriorityPrice
+		}()
+	}
+	waitDepth(t, s, PriorityPrice, 2)
+
+	go func() {
+		s.Wait(ctx, PriorityOrder)
+		order <- PriorityOrder
+	}()
+	waitDepth(t, s, PriorityOrder, 1)
+
+	if first := <-order; first != PriorityOrder {
+		t.Errorf("Expected order request granted first, got %s", first)
+	}
+	<-order
+	<-order
+
+	stats := s.Stats()
+	if stats.Granted["order"] != 1 || stats.Granted["price"] != 7 {
+		t.Errorf("Unexpected granted counts: %v", stats.Granted)
+	}
+}
+
+// TestSchedulerThrottle tests EGW00201 pause blocks requests until backoff ends
+func TestSchedulerThrottle(t *testing.T) {
+	s := NewScheduler(100)
+	s.Throttle()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := s.Wait(ctx, PriorityOrder); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected wait to block during backoff, got %v", err)
+	}
+
+	stats := s.Stats()
+	if stats.TotalThrottled != 1 || stats.QueueDepth["order"] != 0 {
+		t.Errorf("Expected 1 throttle and empty queue after cancel, got %+v", stats)
+	}
+}
@@ -0,0 +1,160 @@
+package kis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Token store modes (KIS_TOKEN_STORE)
+const (
+	TokenStoreMemory   = "memory"   // 프로세스 메모리만 (기본값)
+	TokenStoreFile     = "file"     // 로컬 파일 공유 (flock)
+	TokenStorePostgres = "postgres" // DB 공유 (advisory lock, DB 연결 후 SetTokenStore)
+)
+
+// Token kinds
+const (
+	TokenKindAccess   = "ACCESS_TOKEN" // REST access token (/oauth2/tokenP)
+	TokenKindApproval = "APPROVAL_KEY" // WebSocket approval key (/oauth2/Approval)
+)
+
+// StoredToken is a KIS credential shared across processes
+type StoredToken struct {
+	Key       string    `json:"key"`
+	Kind      string    `json:"kind"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	HoldUntil time.Time `json:"hold_until"` // EGW00133 발급 제한 해제 시각
+	UpdatedTS time.Time `json:"updated_ts"`
+}
+
+// ValidAt returns whether token is usable at t with margin before expiry
+func (t *StoredToken) ValidAt(at time.Time, margin time.Duration) bool {
+	return t != nil && t.Token != "" && at.Before(t.ExpiresAt.Add(-margin))
+}
+
+// TokenStore shares KIS tokens across processes (cmd/api, cmd/runtime, 배치 도구)
+// Save는 WithLock 안에서 호출 (발급 직렬화)
+type TokenStore interface {
+	// Load returns stored token (nil if none)
+	Load(ctx context.Context, key string) (*StoredToken, error)
+
+	// Save upserts token
+	Save(ctx context.Context, token *StoredToken) error
+
+	// WithLock runs fn while holding the cross-process refresh lock for key
+	WithLock(ctx context.Context, key string, fn func(ctx context.Context) error) error
+}
+
+// TokenKey builds store key per token kind and app key (app key는 해시로만 저장)
+func TokenKey(kind, appKey string) string {
+	sum := sha256.Sum256([]byte(appKey))
+	return kind + ":" + hex.EncodeToString(sum[:8])
+}
+
+// FileTokenStore stores tokens in a JSON file guarded by flock
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore creates a file token store (lock file: path + ".lock")
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// DefaultTokenStorePath returns default token file path
+func DefaultTokenStorePath() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "aegis", "kis_tokens.json")
+	}
+	return filepath.Join(os.TempDir(), "aegis_kis_tokens.json")
+}
+
+// Load returns stored token for key
+func (s *FileTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[key], nil
+}
+
+// Save upserts token (tmp 파일 작성 후 rename)
+func (s *FileTokenStore) Save(ctx context.Context, token *StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[token.Key] = token
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal tokens: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("rename token file: %w", err)
+	}
+	return nil
+}
+
+// WithLock runs fn holding an exclusive flock on the lock file
+func (s *FileTokenStore) WithLock(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create token dir: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("open lock file: %w", err)
+	}
+	defer f.Close()
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("lock token file: %w", err)
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	return fn(ctx)
+}
+
+// read loads all tokens (파일 없으면 빈 맵)
+func (s *FileTokenStore) read() (map[string]*StoredToken, error) {
+	tokens := make(map[string]*StoredToken)
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tokens, nil
+		}
+		return nil, fmt.Errorf("read token file: %w", err)
+	}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("unmarshal token file: %w", err)
+	}
+	return tokens, nil
+}
+
+var _ TokenStore = (*FileTokenStore)(nil)
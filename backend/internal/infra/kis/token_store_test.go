@@ -0,0 +1,82 @@
+package kis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// TestAuthClientSharesTokenViaStore tests second process reuses stored token instead of re-issuing
+func TestAuthClientSharesTokenViaStore(t *testing.T) {
+	var issued int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&issued, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","access_token_token_expired":"2099-01-01 09:00:00","token_type":"Bearer","expires_in":86400}`, n)
+	}))
+	defer srv.Close()
+
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "kis_tokens.json"))
+	ctx := context.Background()
+
+	first := NewAuthClient("app-key", "secret", srv.URL)
+	first.SetTokenStore(store)
+	token1, err := first.GetAccessToken(ctx)
+	if err != nil {
+		t.Fatalf("GetAccessToken failed: %v", err)
+	}
+
+	// 다른 프로세스 (별도 메모리 캐시)
+	second := NewAuthClient("app-key", "secret", srv.URL)
+	second.SetTokenStore(store)
+	token2, err := second.GetAccessToken(ctx)
+	if err != nil {
+		t.Fatalf("GetAccessToken failed: %v", err)
+	}
+
+	if token1 != "token-1" || token2 != token1 {
+		t.Errorf("Expected shared token-1, got %s / %s", token1, token2)
+	}
+	if n := atomic.LoadInt32(&issued); n != 1 {
+		t.Errorf("Expected 1 token issue, got %d", n)
+	}
+
+	// 다른 app key는 별도 토큰
+	other := NewAuthClient("other-key", "secret", srv.URL)
+	other.SetTokenStore(store)
+	if token, _ := other.GetAccessToken(ctx); token != "token-2" {
+		t.Errorf("Expected token-2 for other app key, got %s", token)
+	}
+}
+
+// TestAuthClientSharesHold tests EGW00133 hold is shared across processes
+func TestAuthClientSharesHold(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error_code":"EGW00133","error_description":"접근토큰 발급 잠시 후 다시 시도하세요(1분당 1회)"}`)
+	}))
+	defer srv.Close()
+
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "kis_tokens.json"))
+	ctx := context.Background()
+
+	first := NewAuthClient("app-key", "secret", srv.URL)
+	first.SetTokenStore(store)
+	if _, err := first.GetAccessToken(ctx); err == nil {
+		t.Fatal("Expected EGW00133 error")
+	}
+
+	second := NewAuthClient("app-key", "secret", srv.URL)
+	second.SetTokenStore(store)
+	if _, err := second.GetAccessToken(ctx); err == nil {
+		t.Fatal("Expected hold error")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected second client to respect shared hold (1 call), got %d calls", n)
+	}
+}
@@ -36,6 +36,12 @@ type ExecutionNotification struct {
 	Timestamp    time.Time // 체결시간
 }
 
+// Approval key 유효기간 (KIS: 발급 후 24시간)
+const (
+	approvalKeyTTL           = 24 * time.Hour
+	approvalKeyRefreshMargin = 10 * time.Minute
+)
+
 // WebSocketClient handles KIS WebSocket connections for real-time prices
 type WebSocketClient struct {
 	appKey       string
@@ -48,10 +54,11 @@ type WebSocketClient struct {
 	connMu   sync.RWMutex
 	isActive bool
 
-	// Subscriptions (max 40)
-	subscriptions map[string]bool // symbol -> subscribed
-	subMu         sync.RWMutex
-	maxSubs       int
+	// Subscriptions (max 40, 체결가 + 호가 합산)
+	subscriptions     map[string]bool // symbol -> subscribed (H0STCNT0)
+	bookSubscriptions map[string]bool // symbol -> subscribed (H0STASP0)
+	subMu             sync.RWMutex
+	maxSubs           int
 
 	// Execution subscription
 	execSubscribed bool      // 체결통보 구독 여부
@@ -60,6 +67,10 @@ type WebSocketClient struct {
 	// Event handlers
 	onTick      func(tick price.Tick)
 	onExecution func(exec ExecutionNotification)
+	onOrderBook func(book price.OrderBook)
+
+	// Optional: approval key 프로세스 간 공유 (nil = 매 연결 시 발급)
+	store TokenStore
 
 	// Control
 	ctx    context.Context
@@ -73,16 +84,27 @@ func NewWebSocketClient(appKey, appSecret, wsURL string) *WebSocketClient {
 		appKey:        appKey,
 		appSecret:     appSecret,
 		wsURL:         wsURL,
-		subscriptions: make(map[string]bool),
-		maxSubs:       40,
+		subscriptions:     make(map[string]bool),
+		bookSubscriptions: make(map[string]bool),
+		maxSubs:           40,
 	}
 }
 
+// SetTokenStore sets cross-process approval key store (optional)
+func (c *WebSocketClient) SetTokenStore(store TokenStore) {
+	c.store = store
+}
+
 // SetTickHandler sets the tick event handler
 func (c *WebSocketClient) SetTickHandler(handler func(tick price.Tick)) {
 	c.onTick = handler
 }
 
+// SetOrderBookHandler sets the order book (호가) event handler
+func (c *WebSocketClient) SetOrderBookHandler(handler func(book price.OrderBook)) {
+	c.onOrderBook = handler
+}
+
 // SetExecutionHandler sets the execution notification handler
 func (c *WebSocketClient) SetExecutionHandler(handler func(exec ExecutionNotification)) {
 	c.onExecution = handler
@@ -171,12 +193,13 @@ func (c *WebSocketClient) Subscribe(symbol string) error {
 		return nil // Already subscribed
 	}
 
-	// Check max subscriptions
-	if len(c.subscriptions) >= c.maxSubs {
-		return fmt.Errorf("max subscriptions reached (%d/%d)", len(c.subscriptions), c.maxSubs)
+	// Check max subscriptions (체결가 + 호가)
+	if c.usedSlots() >= c.maxSubs {
+		return fmt.Errorf("max subscriptions reached (%d/%d)", c.usedSlots(), c.maxSubs)
 	}
 
 	// Send subscribe message
+	trID, trKey := tradeTR(symbol)
 	msg := map[string]interface{}{
 		"header": map[string]string{
 			"approval_key": c.approvalKey,
@@ -186,8 +209,8 @@ func (c *WebSocketClient) Subscribe(symbol string) error {
 		},
 		"body": map[string]interface{}{
 			"input": map[string]string{
-				"tr_id":  "H0STCNT0", // 실시간 체결가
-				"tr_key": symbol,
+				"tr_id":  trID, // 실시간 체결가 (지수: H0UPCNT0)
+				"tr_key": trKey,
 			},
 		},
 	}
@@ -220,6 +243,7 @@ func (c *WebSocketClient) Unsubscribe(symbol string) error {
 	}
 
 	// Send unsubscribe message
+	trID, trKey := tradeTR(symbol)
 	msg := map[string]interface{}{
 		"header": map[string]string{
 			"approval_key": c.approvalKey,
@@ -229,8 +253,8 @@ func (c *WebSocketClient) Unsubscribe(symbol string) error {
 		},
 		"body": map[string]interface{}{
 			"input": map[string]string{
-				"tr_id":  "H0STCNT0",
-				"tr_key": symbol,
+				"tr_id":  trID,
+				"tr_key": trKey,
 			},
 		},
 	}
@@ -252,6 +276,86 @@ func (c *WebSocketClient) Unsubscribe(symbol string) error {
 	return nil
 }
 
+// SubscribeOrderBook subscribes to real-time 10-level order book for a symbol
+// TR_ID: H0STASP0 (실시간 호가, 체결가 구독과 별도 슬롯 사용)
+func (c *WebSocketClient) SubscribeOrderBook(symbol string) error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	if c.bookSubscriptions[symbol] {
+		return nil // Already subscribed
+	}
+
+	if c.usedSlots() >= c.maxSubs {
+		return fmt.Errorf("max subscriptions reached (%d/%d)", c.usedSlots(), c.maxSubs)
+	}
+
+	if err := c.sendTR("1", "H0STASP0", symbol); err != nil {
+		return fmt.Errorf("send order book subscribe message: %w", err)
+	}
+
+	c.bookSubscriptions[symbol] = true
+	return nil
+}
+
+// UnsubscribeOrderBook unsubscribes from order book updates for a symbol
+func (c *WebSocketClient) UnsubscribeOrderBook(symbol string) error {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+
+	if !c.bookSubscriptions[symbol] {
+		return nil // Not subscribed
+	}
+
+	if err := c.sendTR("2", "H0STASP0", symbol); err != nil {
+		return fmt.Errorf("send order book unsubscribe message: %w", err)
+	}
+
+	delete(c.bookSubscriptions, symbol)
+	return nil
+}
+
+// sendTR sends a register (1) / release (2) message for tr_id and tr_key
+func (c *WebSocketClient) sendTR(trType, trID, trKey string) error {
+	msg := map[string]interface{}{
+		"header": map[string]string{
+			"approval_key": c.approvalKey,
+			"custtype":     "P",
+			"tr_type":      trType,
+			"content-type": "utf-8",
+		},
+		"body": map[string]interface{}{
+			"input": map[string]string{
+				"tr_id":  trID,
+				"tr_key": trKey,
+			},
+		},
+	}
+
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil {
+		return fmt.Errorf("websocket not connected")
+	}
+	return conn.WriteJSON(msg)
+}
+
+// tradeTR returns real-time trade TR for a symbol
+// 지수 심볼 (KOSPI/KOSDAQ/KOSPI200)은 국내지수 실시간체결 (H0UPCNT0, tr_key=업종코드)
+func tradeTR(symbol string) (trID, trKey string) {
+	if code, ok := price.IndexCode(symbol); ok {
+		return "H0UPCNT0", code
+	}
+	return "H0STCNT0", symbol
+}
+
+// usedSlots returns subscription slots in use (caller holds subMu)
+func (c *WebSocketClient) usedSlots() int {
+	return len(c.subscriptions) + len(c.bookSubscriptions)
+}
+
 // SubscribeExecution subscribes to real-time execution notifications for an account
 // TR_ID: H0STCNI0 (실시간 체결통보)
 func (c *WebSocketClient) SubscribeExecution(accountNo string) error {
@@ -365,6 +469,25 @@ func (c *WebSocketClient) GetSubscriptions() []string {
 	return symbols
 }
 
+// GetOrderBookSubscriptions returns symbols with active order book subscription
+func (c *WebSocketClient) GetOrderBookSubscriptions() []string {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+
+	symbols := make([]string, 0, len(c.bookSubscriptions))
+	for symbol := range c.bookSubscriptions {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// GetOrderBookSubscriptionCount returns number of active order book subscriptions
+func (c *WebSocketClient) GetOrderBookSubscriptionCount() int {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	return len(c.bookSubscriptions)
+}
+
 // GetSubscriptionCount returns number of active subscriptions
 func (c *WebSocketClient) GetSubscriptionCount() int {
 	c.subMu.RLock()
@@ -376,7 +499,7 @@ func (c *WebSocketClient) GetSubscriptionCount() int {
 func (c *WebSocketClient) CanSubscribe() bool {
 	c.subMu.RLock()
 	defer c.subMu.RUnlock()
-	return len(c.subscriptions) < c.maxSubs
+	return c.usedSlots() < c.maxSubs
 }
 
 // Start starts the WebSocket client (alias for Connect)
@@ -524,6 +647,22 @@ func (c *WebSocketClient) handleMessages() {
 			continue
 		}
 
+		// Order book (H0STASP0)
+		if book, bookErr := parseOrderBookMessage(message); bookErr == nil && book != nil {
+			if c.onOrderBook != nil {
+				c.onOrderBook(*book)
+			}
+			continue
+		}
+
+		// Index tick (H0UPCNT0) → 일반 체결 틱과 동일한 핸들러로 전달
+		if tick, idxErr := parseIndexMessage(message); idxErr == nil && tick != nil {
+			if c.onTick != nil {
+				c.onTick(*tick)
+			}
+			continue
+		}
+
 		// Parse as tick message
 		tick, err := c.parseMessage(message)
 		if err != nil {
@@ -560,6 +699,10 @@ func (c *WebSocketClient) reconnect() error {
 	for symbol := range c.subscriptions {
 		symbols = append(symbols, symbol)
 	}
+	bookSymbols := make([]string, 0, len(c.bookSubscriptions))
+	for symbol := range c.bookSubscriptions {
+		bookSymbols = append(bookSymbols, symbol)
+	}
 	execSubscribed := c.execSubscribed
 	execAccountNo := c.execAccountNo
 	c.subMu.RUnlock()
@@ -621,6 +764,7 @@ func (c *WebSocketClient) reconnect() error {
 		restoreFailed := false
 		for _, symbol := range symbols {
 			// Send subscribe message directly (don't update subscriptions map)
+			trID, trKey := tradeTR(symbol)
 			msg := map[string]interface{}{
 				"header": map[string]string{
 					"approval_key": c.approvalKey,
@@ -630,8 +774,8 @@ func (c *WebSocketClient) reconnect() error {
 				},
 				"body": map[string]interface{}{
 					"input": map[string]string{
-						"tr_id":  "H0STCNT0",
-						"tr_key": symbol,
+						"tr_id":  trID,
+						"tr_key": trKey,
 					},
 				},
 			}
@@ -649,6 +793,19 @@ func (c *WebSocketClient) reconnect() error {
 			time.Sleep(200 * time.Millisecond)
 		}
 
+		// Restore order book subscriptions
+		if !restoreFailed {
+			for _, symbol := range bookSymbols {
+				if err := c.sendTR("1", "H0STASP0", symbol); err != nil {
+					log.Warn().Err(err).Str("symbol", symbol).Msg("[WS] Failed to restore order book subscription")
+					restoreFailed = true
+					break
+				}
+				restoredCount++
+				time.Sleep(200 * time.Millisecond)
+			}
+		}
+
 		// ✅ If restore failed, close this connection and retry
 		if restoreFailed {
 			c.connMu.Lock()
@@ -709,7 +866,8 @@ func (c *WebSocketClient) reconnect() error {
 
 		log.Info().
 			Int("restored", restoredCount).
-			Int("total", len(symbols)).
+			Int("total", len(symbols)+len(bookSymbols)).
+			Int("order_books", len(bookSymbols)).
 			Bool("exec_subscribed", execSubscribed).
 			Msg("[WS] ✅ Reconnected and subscriptions restored")
 
@@ -858,6 +1016,142 @@ func (c *WebSocketClient) parseMessage(data []byte) (*price.Tick, error) {
 	return tick, nil
 }
 
+// parseIndexMessage parses index trade message (H0UPCNT0)
+// Format: 0|H0UPCNT0|001|업종구분코드^영업시간^현재가지수^전일대비부호^전일대비^누적거래량^누적거래대금^...
+//
+// Field indices for H0UPCNT0:
+// 0: 업종구분코드 (0001 KOSPI, 1001 KOSDAQ, 2001 KOSPI200)
+// 1: 영업시간 (HHMMSS)
+// 2: 현재가지수
+// 3: 전일대비부호
+// 4: 지수 전일대비
+// 5: 누적거래량
+// 6: 누적거래대금
+// 9: 전일대비율
+//
+// 지수 값은 price.IndexPriceScale 배율 정수로 변환 (LastPrice/ChangePrice)
+func parseIndexMessage(data []byte) (*price.Tick, error) {
+	dataStr := string(data)
+
+	if len(dataStr) > 0 && dataStr[0] == '{' {
+		return nil, fmt.Errorf("JSON control message, not index tick")
+	}
+
+	parts := parseDelimited(dataStr, "|")
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid message format: expected 4 parts, got %d", len(parts))
+	}
+	if parts[1] != "H0UPCNT0" {
+		return nil, fmt.Errorf("not an index tick: tr_id=%s", parts[1])
+	}
+	if parts[0] != "0" {
+		return nil, fmt.Errorf("error response: status=%s", parts[0])
+	}
+
+	fields := parseDelimited(parts[3], "^")
+	if len(fields) < 10 {
+		return nil, fmt.Errorf("invalid index output: expected at least 10 fields, got %d", len(fields))
+	}
+
+	symbol, ok := price.IndexSymbolForCode(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("unknown index code: %s", fields[0])
+	}
+
+	value, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse index value: %w", err)
+	}
+
+	now := time.Now()
+	tick := &price.Tick{
+		Symbol:    symbol,
+		Source:    price.SourceKISWebSocket,
+		LastPrice: price.ScaleIndexValue(value),
+		TS:        now,
+		CreatedTS: now,
+	}
+
+	if vrss, err := strconv.ParseFloat(fields[4], 64); err == nil {
+		change := signedChange(price.ScaleIndexValue(vrss), fields[3])
+		tick.ChangePrice = &change
+	}
+	if ctrt, err := strconv.ParseFloat(fields[9], 64); err == nil {
+		tick.ChangeRate = &ctrt
+	}
+	if vol, err := strconv.ParseInt(fields[5], 10, 64); err == nil {
+		tick.Volume = &vol
+	}
+
+	return tick, nil
+}
+
+// signedChange applies 전일대비부호 (4:하한, 5:하락) to an unsigned change value
+func signedChange(v int64, sign string) int64 {
+	if v > 0 && (sign == "4" || sign == "5") {
+		return -v
+	}
+	return v
+}
+
+// parseOrderBookMessage parses order book message (H0STASP0)
+// Format: 0|H0STASP0|001|종목코드^영업시간^시간구분코드^매도호가1~10^매수호가1~10^매도잔량1~10^매수잔량1~10^총매도잔량^총매수잔량^...
+//
+// Field indices for H0STASP0:
+// 0: 종목코드
+// 1: 영업시간 (HHMMSS)
+// 2: 시간구분코드
+// 3-12: 매도호가1~10
+// 13-22: 매수호가1~10
+// 23-32: 매도호가잔량1~10
+// 33-42: 매수호가잔량1~10
+// 43: 총매도호가잔량
+// 44: 총매수호가잔량
+func parseOrderBookMessage(data []byte) (*price.OrderBook, error) {
+	dataStr := string(data)
+
+	if len(dataStr) > 0 && dataStr[0] == '{' {
+		return nil, fmt.Errorf("JSON control message, not order book")
+	}
+
+	parts := parseDelimited(dataStr, "|")
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid message format: expected 4 parts, got %d", len(parts))
+	}
+	if parts[1] != "H0STASP0" {
+		return nil, fmt.Errorf("not an order book: tr_id=%s", parts[1])
+	}
+	if parts[0] != "0" {
+		return nil, fmt.Errorf("error response: status=%s", parts[0])
+	}
+
+	fields := parseDelimited(parts[3], "^")
+	if len(fields) < 45 {
+		return nil, fmt.Errorf("invalid order book output: expected at least 45 fields, got %d", len(fields))
+	}
+
+	atoi := func(s string) int64 {
+		v, _ := strconv.ParseInt(s, 10, 64)
+		return v
+	}
+
+	book := &price.OrderBook{
+		Symbol:         fields[0],
+		Source:         price.SourceKISWebSocket,
+		Asks:           make([]price.OrderBookLevel, price.OrderBookDepth),
+		Bids:           make([]price.OrderBookLevel, price.OrderBookDepth),
+		TotalAskVolume: atoi(fields[43]),
+		TotalBidVolume: atoi(fields[44]),
+		TS:             time.Now(),
+	}
+	for i := 0; i < price.OrderBookDepth; i++ {
+		book.Asks[i] = price.OrderBookLevel{Price: atoi(fields[3+i]), Volume: atoi(fields[23+i])}
+		book.Bids[i] = price.OrderBookLevel{Price: atoi(fields[13+i]), Volume: atoi(fields[33+i])}
+	}
+
+	return book, nil
+}
+
 // parseExecutionMessage parses execution notification message (H0STCNI0)
 // Format: 0|H0STCNI0|001|고객ID^계좌번호^주문번호^원주문번호^매도매수구분^정정취소구분^...
 //
@@ -971,8 +1265,53 @@ func parseDelimited(s, delim string) []string {
 	return result
 }
 
-// getApprovalKey gets WebSocket approval key
+// getApprovalKey returns WebSocket approval key (store 설정 시 유효한 공유 키 재사용)
 func (c *WebSocketClient) getApprovalKey(ctx context.Context) (string, error) {
+	if c.store == nil {
+		return c.requestApprovalKey(ctx)
+	}
+
+	key := TokenKey(TokenKindApproval, c.appKey)
+	var approvalKey string
+	locked := false
+	err := c.store.WithLock(ctx, key, func(ctx context.Context) error {
+		locked = true
+
+		stored, err := c.store.Load(ctx, key)
+		if err != nil {
+			log.Warn().Err(err).Msg("[WS] Failed to load approval key from store")
+		} else if stored.ValidAt(time.Now(), approvalKeyRefreshMargin) {
+			approvalKey = stored.Token
+			log.Debug().Time("expires_at", stored.ExpiresAt).Msg("[WS] Reusing shared approval key")
+			return nil
+		}
+
+		issued, err := c.requestApprovalKey(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := c.store.Save(ctx, &StoredToken{
+			Key:       key,
+			Kind:      TokenKindApproval,
+			Token:     issued,
+			ExpiresAt: now.Add(approvalKeyTTL),
+			UpdatedTS: now,
+		}); err != nil {
+			log.Warn().Err(err).Msg("[WS] Failed to save approval key to store")
+		}
+		approvalKey = issued
+		return nil
+	})
+	if err != nil && !locked {
+		log.Warn().Err(err).Msg("[WS] Token store unavailable, requesting approval key without lock")
+		return c.requestApprovalKey(ctx)
+	}
+	return approvalKey, err
+}
+
+// requestApprovalKey requests a new approval key from KIS API
+func (c *WebSocketClient) requestApprovalKey(ctx context.Context) (string, error) {
 	// KIS WebSocket approval key API
 	// POST /oauth2/Approval
 	url := "https://openapi.koreainvestment.com:9443/oauth2/Approval"
@@ -0,0 +1,895 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/paper"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// Config paper broker 시뮬레이션 설정
+type Config struct {
+	InitialCash       decimal.Decimal // 신규 계좌 초기 현금
+	Latency           time.Duration   // 접수 → 체결 가능까지 지연
+	PartialFillPct    float64         // 매칭 1회당 체결 비율 (1.0 = 전량, 0.3 = 잔량의 30%씩)
+	RejectRate        float64         // 무작위 거부 확률 (0~1)
+	MatchInterval     time.Duration   // 매칭 주기
+	MaxPriceAge       time.Duration   // 체결 기준 가격 최대 경과 시간 (0 = 제한 없음)
+	MatchOutsideHours bool            // 정규장 외 매칭 허용 (로컬 테스트용)
+	Seed              int64           // 난수 시드 (0 = 현재 시각)
+}
+
+// DefaultConfig returns default paper broker config
+func DefaultConfig() Config {
+	return Config{
+		InitialCash:    decimal.NewFromInt(100_000_000),
+		Latency:        300 * time.Millisecond,
+		PartialFillPct: 1.0,
+		RejectRate:     0,
+		MatchInterval:  1 * time.Second,
+		MaxPriceAge:    5 * time.Minute,
+	}
+}
+
+// PriceSource 체결 기준 가격 (pricesync best price)
+type PriceSource interface {
+	GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error)
+}
+
+// Broker in-process simulated broker implementing execution.KISAdapter
+// - 주문은 Latency 이후 pricesync 가격(매수: 매도호가, 매도: 매수호가)에 매칭
+// - 부분 체결 / 무작위 거부 / 수수료·세금 시뮬레이션
+// - 원장(paper schema)에 주문/체결/보유/현금 기록
+type Broker struct {
+	cfg    Config
+	prices PriceSource
+	ledger paper.LedgerRepository
+
+	// Optional: 시장 구분 (매도 세금, nil이면 KOSDAQ 세율)
+	symbolReader risk.SymbolInfoReader
+
+	mu       sync.Mutex
+	accounts map[string]*book
+	markets  map[string]string
+	rng      *rand.Rand
+	lastID   int64
+	seq      int
+	now      func() time.Time
+}
+
+// book 계좌별 메모리 원장
+type book struct {
+	account  *paper.Account
+	orders   map[string]*paper.Order
+	fills    []*paper.Fill
+	holdings map[string]*paper.Holding
+}
+
+// NewBroker creates a new paper broker
+func NewBroker(cfg Config, prices PriceSource, ledger paper.LedgerRepository) *Broker {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if cfg.PartialFillPct <= 0 || cfg.PartialFillPct > 1 {
+		cfg.PartialFillPct = 1.0
+	}
+	if cfg.MatchInterval <= 0 {
+		cfg.MatchInterval = DefaultConfig().MatchInterval
+	}
+
+	return &Broker{
+		cfg:      cfg,
+		prices:   prices,
+		ledger:   ledger,
+		accounts: make(map[string]*book),
+		markets:  make(map[string]string),
+		rng:      rand.New(rand.NewSource(seed)),
+		now:      time.Now,
+	}
+}
+
+// SetSymbolInfoReader sets market lookup for sell tax (optional)
+func (b *Broker) SetSymbolInfoReader(reader risk.SymbolInfoReader) {
+	b.symbolReader = reader
+}
+
+// Start starts the matching loop (ctx 취소 시 종료)
+func (b *Broker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(b.cfg.MatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Paper broker matching loop stopped")
+				return
+			case <-ticker.C:
+				b.matchAll(ctx)
+			}
+		}
+	}()
+
+	log.Info().
+		Dur("latency", b.cfg.Latency).
+		Float64("partial_fill_pct", b.cfg.PartialFillPct).
+		Float64("reject_rate", b.cfg.RejectRate).
+		Dur("match_interval", b.cfg.MatchInterval).
+		Msg("✅ Paper broker matching loop started")
+}
+
+// =============================================================================
+// execution.KISAdapter
+// =============================================================================
+
+// SubmitOrder accepts an order (잔고/보유 검증 후 PENDING, 실패 시 REJECTED + error)
+func (b *Broker) SubmitOrder(ctx context.Context, req execution.KISOrderRequest) (*execution.KISOrderResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := b.now()
+	order := &paper.Order{
+		OrderID:     b.nextID(now),
+		AccountID:   req.AccountID,
+		Symbol:      req.Symbol,
+		Side:        normalizeSide(req.Side),
+		OrderType:   normalizeOrderType(req.OrderType),
+		Qty:         req.Qty,
+		LimitPrice:  req.LimitPrice,
+		Status:      paper.OrderStatusPending,
+		SubmittedTS: now,
+		EligibleTS:  now.Add(b.cfg.Latency),
+		UpdatedTS:   now,
+		TimeInForce: timeInForce(req.OrderType),
+	}
+	if order.OrderType == "MKT" {
+		order.LimitPrice = nil
+	}
+
+	if reason := b.validate(ctx, bk, order); reason != "" {
+		order.Status = paper.OrderStatusRejected
+		order.RejectReason = reason
+		if err := b.ledger.SaveOrder(ctx, order); err != nil {
+			log.Error().Err(err).Str("order_id", order.OrderID).Msg("Failed to save rejected paper order")
+		}
+		bk.orders[order.OrderID] = order
+
+		log.Warn().
+			Str("order_id", order.OrderID).
+			Str("symbol", order.Symbol).
+			Str("side", order.Side).
+			Int64("qty", order.Qty).
+			Str("reason", reason).
+			Msg("Paper order rejected")
+		return nil, fmt.Errorf("paper order rejected: %s", reason)
+	}
+
+	if err := b.ledger.SaveOrder(ctx, order); err != nil {
+		return nil, fmt.Errorf("save paper order: %w", err)
+	}
+	bk.orders[order.OrderID] = order
+
+	log.Info().
+		Str("order_id", order.OrderID).
+		Str("symbol", order.Symbol).
+		Str("side", order.Side).
+		Str("type", order.OrderType).
+		Int64("qty", order.Qty).
+		Msg("📝 Paper order accepted")
+
+	return &execution.KISOrderResponse{
+		OrderID:   order.OrderID,
+		Timestamp: now,
+		Raw: map[string]any{
+			"message": "paper order accepted",
+			"paper":   true,
+		},
+	}, nil
+}
+
+// CancelOrder cancels an open order (잔량 취소)
+func (b *Broker) CancelOrder(ctx context.Context, accountID string, orderNo string) (*execution.KISCancelResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, ok := bk.orders[orderNo]
+	if !ok {
+		return nil, fmt.Errorf("cancel %s: %w", orderNo, paper.ErrOrderNotFound)
+	}
+	if !order.IsOpen() {
+		return nil, fmt.Errorf("cancel %s (%s): %w", orderNo, order.Status, paper.ErrOrderNotCancellable)
+	}
+
+	now := b.now()
+	updated := *order
+	updated.Status = paper.OrderStatusCancelled
+	updated.UpdatedTS = now
+	if err := b.ledger.SaveOrder(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("save paper order: %w", err)
+	}
+	*order = updated
+
+	return &execution.KISCancelResponse{
+		OrderNo:   orderNo,
+		CancelNo:  b.nextID(now),
+		Timestamp: now,
+		Raw: map[string]any{
+			"message":       "paper order cancelled",
+			"cancelled_qty": order.OpenQty(),
+		},
+	}, nil
+}
+
+// ModifyOrder amends an open order (KIS와 동일: 원주문 잔량 취소 → 새 주문번호로 재접수)
+func (b *Broker) ModifyOrder(ctx context.Context, req execution.KISModifyRequest) (*execution.KISModifyResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, ok := bk.orders[req.OrderNo]
+	if !ok {
+		return nil, fmt.Errorf("modify %s: %w", req.OrderNo, paper.ErrOrderNotFound)
+	}
+	if !order.IsOpen() {
+		return nil, fmt.Errorf("modify %s (%s): %w", req.OrderNo, order.Status, paper.ErrOrderNotCancellable)
+	}
+
+	qty := order.OpenQty()
+	if req.Qty > 0 && req.Qty < qty {
+		qty = req.Qty
+	}
+
+	now := b.now()
+	replacement := &paper.Order{
+		OrderID:     b.nextID(now),
+		AccountID:   order.AccountID,
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		OrderType:   normalizeOrderType(req.OrderType),
+		Qty:         qty,
+		LimitPrice:  req.LimitPrice,
+		Status:      paper.OrderStatusPending,
+		SubmittedTS: now,
+		EligibleTS:  now.Add(b.cfg.Latency),
+		UpdatedTS:   now,
+		TimeInForce: timeInForce(req.OrderType),
+	}
+	if replacement.OrderType == "MKT" {
+		replacement.LimitPrice = nil
+	}
+	if replacement.OrderType == "LMT" && (replacement.LimitPrice == nil || !replacement.LimitPrice.IsPositive()) {
+		return nil, fmt.Errorf("paper modify rejected: limit price required")
+	}
+
+	cancelled := *order
+	cancelled.Status = paper.OrderStatusCancelled
+	cancelled.UpdatedTS = now
+	if err := b.ledger.SaveOrder(ctx, &cancelled); err != nil {
+		return nil, fmt.Errorf("save paper order: %w", err)
+	}
+	*order = cancelled
+
+	if err := b.ledger.SaveOrder(ctx, replacement); err != nil {
+		return nil, fmt.Errorf("save paper order: %w", err)
+	}
+	bk.orders[replacement.OrderID] = replacement
+
+	log.Info().
+		Str("order_id", req.OrderNo).
+		Str("new_order_id", replacement.OrderID).
+		Str("type", replacement.OrderType).
+		Str("price", limitString(replacement.LimitPrice)).
+		Int64("qty", qty).
+		Msg("✏️ Paper order modified")
+
+	return &execution.KISModifyResponse{
+		OrderNo:    req.OrderNo,
+		NewOrderNo: replacement.OrderID,
+		Timestamp:  now,
+		Raw: map[string]any{
+			"message": "paper order modified",
+			"paper":   true,
+		},
+	}, nil
+}
+
+// GetUnfilledOrders returns open orders (KIS 미체결 조회와 동일 형식)
+func (b *Broker) GetUnfilledOrders(ctx context.Context, accountID string) ([]*execution.KISUnfilledOrder, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*execution.KISUnfilledOrder, 0)
+	for _, o := range sortedOrders(bk) {
+		if !o.IsOpen() {
+			continue
+		}
+
+		status := "pending"
+		if o.FilledQty > 0 {
+			status = "partial"
+		}
+
+		result = append(result, &execution.KISUnfilledOrder{
+			OrderID:   o.OrderID,
+			Symbol:    o.Symbol,
+			Qty:       o.Qty,
+			OpenQty:   o.OpenQty(),
+			FilledQty: o.FilledQty,
+			Status:    status,
+			Raw: map[string]any{
+				"order_side":  sideName(o.Side),
+				"order_price": limitString(o.LimitPrice),
+				"order_time":  o.SubmittedTS.In(calendar.KST).Format("150405"),
+				"paper":       true,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// GetFills returns fills after since (체결 단위, 증분 수량)
+func (b *Broker) GetFills(ctx context.Context, accountID string, since time.Time) ([]*execution.KISFill, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*execution.KISFill, 0)
+	for _, f := range bk.fills {
+		if f.TS.After(since) {
+			result = append(result, toKISFill(f))
+		}
+	}
+	return result, nil
+}
+
+// GetFillsForOrder returns all fills of an order
+func (b *Broker) GetFillsForOrder(ctx context.Context, orderID string) ([]*execution.KISFill, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]*execution.KISFill, 0)
+	for _, bk := range b.accounts {
+		for _, f := range bk.fills {
+			if f.OrderID == orderID {
+				result = append(result, toKISFill(f))
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetCash returns paper account cash (체결 즉시 정산)
+func (b *Broker) GetCash(ctx context.Context, accountID string) (decimal.Decimal, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, accountID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return bk.account.Cash, nil
+}
+
+// GetHoldings returns holdings valued at current best price
+func (b *Broker) GetHoldings(ctx context.Context, accountID string) ([]*execution.KISHolding, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk, err := b.book(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(bk.holdings))
+	for symbol, h := range bk.holdings {
+		if h.Qty > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	result := make([]*execution.KISHolding, 0, len(symbols))
+	for _, symbol := range symbols {
+		h := bk.holdings[symbol]
+		qty := decimal.NewFromInt(h.Qty)
+
+		current := h.AvgPrice
+		if bp, err := b.prices.GetBestPrice(ctx, symbol); err == nil && bp != nil && bp.BestPrice > 0 {
+			current = decimal.NewFromInt(bp.BestPrice)
+		}
+
+		purchase := h.AvgPrice.Mul(qty)
+		evaluate := current.Mul(qty)
+		pnl := evaluate.Sub(purchase)
+		pnlPct := 0.0
+		if purchase.IsPositive() {
+			pnlPct, _ = pnl.Div(purchase).Mul(decimal.NewFromInt(100)).Round(2).Float64()
+		}
+
+		result = append(result, &execution.KISHolding{
+			AccountID:    accountID,
+			Symbol:       symbol,
+			Qty:          h.Qty,
+			AvgPrice:     h.AvgPrice,
+			CurrentPrice: current,
+			Pnl:          pnl.Round(0),
+			PnlPct:       pnlPct,
+			Raw: map[string]any{
+				"evaluate_amount": evaluate.Round(0).String(),
+				"purchase_amount": purchase.Round(0).String(),
+				"market":          b.market(ctx, symbol),
+				"paper":           true,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// =============================================================================
+// Matching
+// =============================================================================
+
+// matchAll matches eligible open orders of all accounts against current prices
+func (b *Broker) matchAll(ctx context.Context) {
+	now := b.now()
+	if !b.cfg.MatchOutsideHours && !calendar.IsRegularHours(now) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, bk := range b.accounts {
+		for _, o := range sortedOrders(bk) {
+			if !o.IsOpen() || now.Before(o.EligibleTS) {
+				continue
+			}
+			if err := b.matchOrder(ctx, bk, o, now); err != nil {
+				log.Error().Err(err).Str("order_id", o.OrderID).Msg("Failed to match paper order")
+				continue
+			}
+			// IOC/FOK: 첫 체결 시도 후 잔량 즉시 취소
+			if o.TimeInForce != "" && o.IsOpen() {
+				if err := b.cancelRemainder(ctx, o, now); err != nil {
+					log.Error().Err(err).Str("order_id", o.OrderID).Msg("Failed to cancel paper order remainder")
+				}
+			}
+		}
+	}
+}
+
+// matchOrder fills (part of) an order if price condition is met
+func (b *Broker) matchOrder(ctx context.Context, bk *book, o *paper.Order, now time.Time) error {
+	bp, err := b.prices.GetBestPrice(ctx, o.Symbol)
+	if err != nil || bp == nil || bp.IsStale {
+		return nil // 가격 없음: 다음 주기 재시도
+	}
+	if b.cfg.MaxPriceAge > 0 && now.Sub(bp.BestTS) > b.cfg.MaxPriceAge {
+		return nil
+	}
+
+	// 매수: 매도호가, 매도: 매수호가 (없으면 best price)
+	ref := bp.BestPrice
+	if o.Side == execution.SideBuy && bp.AskPrice != nil && *bp.AskPrice > 0 {
+		ref = *bp.AskPrice
+	}
+	if o.Side == execution.SideSell && bp.BidPrice != nil && *bp.BidPrice > 0 {
+		ref = *bp.BidPrice
+	}
+	if ref <= 0 {
+		return nil
+	}
+	fillPrice := decimal.NewFromInt(ref)
+
+	if o.OrderType == "LMT" && o.LimitPrice != nil {
+		if o.Side == execution.SideBuy && fillPrice.GreaterThan(*o.LimitPrice) {
+			return nil
+		}
+		if o.Side == execution.SideSell && fillPrice.LessThan(*o.LimitPrice) {
+			return nil
+		}
+	}
+
+	// 부분 체결 (잔량 × PartialFillPct, 최소 1주)
+	qty := int64(math.Ceil(float64(o.OpenQty()) * b.cfg.PartialFillPct))
+	if qty < 1 {
+		qty = 1
+	}
+	if qty > o.OpenQty() {
+		qty = o.OpenQty()
+	}
+
+	market := b.market(ctx, o.Symbol)
+	holding := &paper.Holding{AccountID: o.AccountID, Symbol: o.Symbol}
+	if h, ok := bk.holdings[o.Symbol]; ok {
+		*holding = *h
+	}
+	account := *bk.account
+
+	var amount, fee, tax decimal.Decimal
+	switch o.Side {
+	case execution.SideBuy:
+		// 현금 부족 시 가능 수량만 체결
+		unitCost := fillPrice.Mul(decimal.NewFromInt(1).Add(execution.BuyCostRate()))
+		if affordable := account.Cash.Div(unitCost).Floor().IntPart(); affordable < qty {
+			qty = affordable
+		}
+		if qty <= 0 {
+			return b.rejectOpen(ctx, o, now, "insufficient cash at fill")
+		}
+		amount = fillPrice.Mul(decimal.NewFromInt(qty))
+		fee, tax = execution.EstimateTradeCost(execution.SideBuy, market, amount)
+
+		newQty := holding.Qty + qty
+		holding.AvgPrice = holding.AvgPrice.Mul(decimal.NewFromInt(holding.Qty)).Add(amount).Div(decimal.NewFromInt(newQty))
+		holding.Qty = newQty
+		account.Cash = account.Cash.Sub(amount).Sub(fee.Round(0))
+	default:
+		if qty > holding.Qty {
+			qty = holding.Qty
+		}
+		if qty <= 0 {
+			return b.rejectOpen(ctx, o, now, "insufficient holding at fill")
+		}
+		amount = fillPrice.Mul(decimal.NewFromInt(qty))
+		fee, tax = execution.EstimateTradeCost(execution.SideSell, market, amount)
+
+		holding.Qty -= qty
+		if holding.Qty == 0 {
+			holding.AvgPrice = decimal.Zero
+		}
+		account.Cash = account.Cash.Add(amount).Sub(fee.Round(0)).Sub(tax.Round(0))
+	}
+	// FOK: 전량 체결 불가 시 체결 없음 (matchAll에서 전량 취소)
+	if o.TimeInForce == execution.TimeInForceFOK && qty < o.OpenQty() {
+		return nil
+	}
+
+	holding.UpdatedTS = now
+	account.UpdatedTS = now
+
+	updated := *o
+	updated.FilledQty += qty
+	updated.Status = paper.OrderStatusPartial
+	if updated.FilledQty >= updated.Qty {
+		updated.Status = paper.OrderStatusFilled
+	}
+	updated.UpdatedTS = now
+
+	// 체결 시각은 µs 단위 (DB cursor 정밀도와 일치 → 중복 조회 방지)
+	ts := now.Truncate(time.Microsecond)
+	b.seq++
+	fill := &paper.Fill{
+		ExecID:    fmt.Sprintf("%s-%d", o.OrderID, b.seq),
+		OrderID:   o.OrderID,
+		AccountID: o.AccountID,
+		Symbol:    o.Symbol,
+		Side:      o.Side,
+		Qty:       qty,
+		Price:     fillPrice,
+		Fee:       fee.Round(0),
+		Tax:       tax.Round(0),
+		Seq:       b.seq,
+		TS:        ts,
+	}
+
+	// 원장 기록 성공 시에만 메모리 반영 (실패 시 다음 주기 재시도)
+	if err := b.ledger.RecordFill(ctx, &updated, fill, holding, &account); err != nil {
+		return fmt.Errorf("record paper fill: %w", err)
+	}
+	*o = updated
+	bk.fills = append(bk.fills, fill)
+	bk.holdings[o.Symbol] = holding
+	*bk.account = account
+
+	log.Info().
+		Str("order_id", o.OrderID).
+		Str("symbol", o.Symbol).
+		Str("side", o.Side).
+		Int64("qty", qty).
+		Str("price", fillPrice.String()).
+		Str("status", o.Status).
+		Msg("✅ Paper fill")
+
+	return nil
+}
+
+// rejectOpen rejects remaining qty of an open order (체결 시점 잔고 부족)
+func (b *Broker) rejectOpen(ctx context.Context, o *paper.Order, now time.Time, reason string) error {
+	updated := *o
+	updated.Status = paper.OrderStatusCancelled
+	if updated.FilledQty == 0 {
+		updated.Status = paper.OrderStatusRejected
+	}
+	updated.RejectReason = reason
+	updated.UpdatedTS = now
+	if err := b.ledger.SaveOrder(ctx, &updated); err != nil {
+		return fmt.Errorf("save paper order: %w", err)
+	}
+	*o = updated
+
+	log.Warn().Str("order_id", o.OrderID).Str("reason", reason).Msg("Paper order remaining qty rejected")
+	return nil
+}
+
+// cancelRemainder cancels remaining qty of an IOC/FOK order (KIS 잔량 자동 취소와 동일)
+func (b *Broker) cancelRemainder(ctx context.Context, o *paper.Order, now time.Time) error {
+	updated := *o
+	updated.Status = paper.OrderStatusCancelled
+	updated.RejectReason = o.TimeInForce + " remaining qty cancelled"
+	updated.UpdatedTS = now
+	if err := b.ledger.SaveOrder(ctx, &updated); err != nil {
+		return fmt.Errorf("save paper order: %w", err)
+	}
+	*o = updated
+
+	log.Info().
+		Str("order_id", o.OrderID).
+		Str("tif", o.TimeInForce).
+		Int64("filled_qty", o.FilledQty).
+		Int64("cancelled_qty", o.OpenQty()).
+		Msg("Paper order remaining qty cancelled")
+	return nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// book returns account book (없으면 원장 로드 또는 초기 현금으로 생성)
+func (b *Broker) book(ctx context.Context, accountID string) (*book, error) {
+	if bk, ok := b.accounts[accountID]; ok {
+		return bk, nil
+	}
+
+	// 당일 주문/체결만 로드 (미체결은 기간 무관)
+	since := calendar.PrevTradingDay(b.now())
+	ledger, err := b.ledger.LoadLedger(ctx, accountID, since)
+	if errors.Is(err, paper.ErrAccountNotFound) {
+		account := &paper.Account{
+			AccountID:   accountID,
+			Cash:        b.cfg.InitialCash,
+			InitialCash: b.cfg.InitialCash,
+			UpdatedTS:   b.now(),
+		}
+		if err := b.ledger.CreateAccount(ctx, account); err != nil {
+			return nil, fmt.Errorf("create paper account: %w", err)
+		}
+		ledger = &paper.Ledger{Account: account}
+		log.Info().
+			Str("account_id", accountID).
+			Str("initial_cash", account.Cash.String()).
+			Msg("Paper account created")
+	} else if err != nil {
+		return nil, fmt.Errorf("load paper ledger: %w", err)
+	}
+
+	bk := &book{
+		account:  ledger.Account,
+		orders:   make(map[string]*paper.Order, len(ledger.Orders)),
+		fills:    ledger.Fills,
+		holdings: make(map[string]*paper.Holding, len(ledger.Holdings)),
+	}
+	for _, o := range ledger.Orders {
+		bk.orders[o.OrderID] = o
+	}
+	for _, h := range ledger.Holdings {
+		bk.holdings[h.Symbol] = h
+	}
+	for _, f := range ledger.Fills {
+		if f.Seq > b.seq {
+			b.seq = f.Seq
+		}
+	}
+
+	b.accounts[accountID] = bk
+	return bk, nil
+}
+
+// validate returns reject reason ("" = accepted)
+func (b *Broker) validate(ctx context.Context, bk *book, o *paper.Order) string {
+	if o.Symbol == "" || o.Qty <= 0 {
+		return "invalid symbol or qty"
+	}
+	if o.Side != execution.SideBuy && o.Side != execution.SideSell {
+		return "invalid side"
+	}
+	if o.OrderType == "LMT" && (o.LimitPrice == nil || !o.LimitPrice.IsPositive()) {
+		return "limit price required"
+	}
+
+	switch o.Side {
+	case execution.SideBuy:
+		// 현금 - 미체결 매수 예약금 >= 주문 금액 (시장가는 현재 매도호가 기준)
+		px := b.orderPrice(ctx, o)
+		if !px.IsPositive() {
+			return "no price for market order"
+		}
+		required := px.Mul(decimal.NewFromInt(o.Qty)).Mul(decimal.NewFromInt(1).Add(execution.BuyCostRate()))
+		reserved := decimal.Zero
+		for _, open := range bk.orders {
+			if open.IsOpen() && open.Side == execution.SideBuy {
+				reserved = reserved.Add(b.orderPrice(ctx, open).Mul(decimal.NewFromInt(open.OpenQty())))
+			}
+		}
+		if bk.account.Cash.Sub(reserved).LessThan(required) {
+			return "insufficient cash"
+		}
+	case execution.SideSell:
+		// 보유 - 미체결 매도 >= 주문 수량
+		var held, pending int64
+		if h, ok := bk.holdings[o.Symbol]; ok {
+			held = h.Qty
+		}
+		for _, open := range bk.orders {
+			if open.IsOpen() && open.Side == execution.SideSell && open.Symbol == o.Symbol {
+				pending += open.OpenQty()
+			}
+		}
+		if held-pending < o.Qty {
+			return "insufficient holding"
+		}
+	}
+
+	if b.cfg.RejectRate > 0 && b.rng.Float64() < b.cfg.RejectRate {
+		return "simulated reject"
+	}
+	return ""
+}
+
+// orderPrice returns limit price or current reference price (예약금 계산용)
+func (b *Broker) orderPrice(ctx context.Context, o *paper.Order) decimal.Decimal {
+	if o.LimitPrice != nil {
+		return *o.LimitPrice
+	}
+	bp, err := b.prices.GetBestPrice(ctx, o.Symbol)
+	if err != nil || bp == nil {
+		return decimal.Zero
+	}
+	if bp.AskPrice != nil && *bp.AskPrice > 0 {
+		return decimal.NewFromInt(*bp.AskPrice)
+	}
+	return decimal.NewFromInt(bp.BestPrice)
+}
+
+// market returns KOSPI/KOSDAQ (cached, 조회 실패 시 "")
+func (b *Broker) market(ctx context.Context, symbol string) string {
+	if m, ok := b.markets[symbol]; ok {
+		return m
+	}
+	if b.symbolReader == nil {
+		return ""
+	}
+
+	infos, err := b.symbolReader.LoadSymbolInfo(ctx, []string{symbol})
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to load symbol market, using KOSDAQ tax rate")
+		return ""
+	}
+	m := infos[symbol].Market
+	b.markets[symbol] = m
+	return m
+}
+
+// nextID returns a unique, increasing paper order number
+func (b *Broker) nextID(now time.Time) string {
+	id := now.UnixMicro()
+	if id <= b.lastID {
+		id = b.lastID + 1
+	}
+	b.lastID = id
+	return fmt.Sprintf("P%d", id)
+}
+
+func sortedOrders(bk *book) []*paper.Order {
+	orders := make([]*paper.Order, 0, len(bk.orders))
+	for _, o := range bk.orders {
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].SubmittedTS.Before(orders[j].SubmittedTS) })
+	return orders
+}
+
+func toKISFill(f *paper.Fill) *execution.KISFill {
+	return &execution.KISFill{
+		ExecID:    f.ExecID,
+		OrderID:   f.OrderID,
+		Symbol:    f.Symbol,
+		Qty:       f.Qty,
+		Price:     f.Price,
+		Fee:       f.Fee,
+		Tax:       f.Tax,
+		Timestamp: f.TS,
+		Seq:       f.Seq,
+		Raw: map[string]any{
+			"order_side": sideName(f.Side),
+			"paper":      true,
+		},
+	}
+}
+
+func normalizeSide(side string) string {
+	switch side {
+	case execution.SideSell, "sell":
+		return execution.SideSell
+	case execution.SideBuy, "buy":
+		return execution.SideBuy
+	}
+	return side
+}
+
+// normalizeOrderType maps order type to paper ledger type (MKT/LMT)
+// 지정가 필수 유형(COND_LMT, IOC_LMT, ...)은 LMT, 그 외(최유리, 동시호가, ...)는 MKT로 근사
+func normalizeOrderType(orderType string) string {
+	switch orderType {
+	case "market":
+		return "MKT"
+	case "limit":
+		return "LMT"
+	}
+	if spec, ok := execution.LookupOrderType(orderType); ok && !spec.NeedsPrice {
+		return "MKT"
+	}
+	return "LMT"
+}
+
+// timeInForce returns IOC/FOK of an order type ("" = 당일)
+func timeInForce(orderType string) string {
+	spec, _ := execution.LookupOrderType(orderType)
+	return spec.TimeInForce
+}
+
+func sideName(side string) string {
+	if side == execution.SideSell {
+		return "매도"
+	}
+	return "매수"
+}
+
+func limitString(p *decimal.Decimal) string {
+	if p == nil {
+		return "0"
+	}
+	return p.String()
+}
+
+// Ensure Broker implements execution.KISAdapter
+var _ execution.KISAdapter = (*Broker)(nil)
@@ -0,0 +1,183 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/paper"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// fakePrices serves fixed bid/ask per symbol
+type fakePrices struct {
+	bid, ask int64
+}
+
+func (f *fakePrices) GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error) {
+	bid, ask := f.bid, f.ask
+	return &price.BestPrice{
+		Symbol:    symbol,
+		BestPrice: (bid + ask) / 2,
+		BestTS:    time.Now(),
+		BidPrice:  &bid,
+		AskPrice:  &ask,
+	}, nil
+}
+
+func testBroker(partial float64) *Broker {
+	return NewBroker(Config{
+		InitialCash:       decimal.NewFromInt(10_000_000),
+		PartialFillPct:    partial,
+		MatchOutsideHours: true,
+		Seed:              1,
+	}, &fakePrices{bid: 9990, ask: 10000}, NewMemoryLedger())
+}
+
+// TestBrokerBuyFillsAtAsk tests market buy fills at ask and updates holdings/cash
+func TestBrokerBuyFillsAtAsk(t *testing.T) {
+	ctx := context.Background()
+	b := testBroker(1.0)
+
+	resp, err := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "BUY", OrderType: "MKT", Qty: 10})
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+	b.matchAll(ctx)
+
+	fills, _ := b.GetFillsForOrder(ctx, resp.OrderID)
+	if len(fills) != 1 || fills[0].Qty != 10 || !fills[0].Price.Equal(decimal.NewFromInt(10000)) {
+		t.Fatalf("Expected 1 fill of 10 @ 10000, got %+v", fills)
+	}
+
+	holdings, _ := b.GetHoldings(ctx, "A")
+	if len(holdings) != 1 || holdings[0].Qty != 10 {
+		t.Fatalf("Expected holding of 10, got %+v", holdings)
+	}
+
+	cash := b.accounts["A"].account.Cash
+	want := decimal.NewFromInt(10_000_000 - 100_000).Sub(fills[0].Fee)
+	if !cash.Equal(want) {
+		t.Errorf("Expected cash %s, got %s", want, cash)
+	}
+}
+
+// TestBrokerPartialFill tests partial fills across matching cycles
+func TestBrokerPartialFill(t *testing.T) {
+	ctx := context.Background()
+	b := testBroker(0.5)
+
+	resp, _ := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "BUY", OrderType: "MKT", Qty: 10})
+	b.matchAll(ctx)
+
+	unfilled, _ := b.GetUnfilledOrders(ctx, "A")
+	if len(unfilled) != 1 || unfilled[0].FilledQty != 5 || unfilled[0].OpenQty != 5 {
+		t.Fatalf("Expected 5 filled / 5 open, got %+v", unfilled)
+	}
+
+	for i := 0; i < 3; i++ {
+		b.matchAll(ctx)
+	}
+	fills, _ := b.GetFillsForOrder(ctx, resp.OrderID)
+	if len(fills) != 4 || b.accounts["A"].orders[resp.OrderID].Status != paper.OrderStatusFilled {
+		t.Errorf("Expected FILLED after 4 fills (5+3+1+1), got %d fills", len(fills))
+	}
+}
+
+// TestBrokerRejectsAndCancels tests sell without holding and limit cancel
+func TestBrokerRejectsAndCancels(t *testing.T) {
+	ctx := context.Background()
+	b := testBroker(1.0)
+
+	if _, err := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "SELL", OrderType: "MKT", Qty: 1}); err == nil {
+		t.Error("Expected sell without holding to be rejected")
+	}
+
+	limit := decimal.NewFromInt(9000)
+	resp, err := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "BUY", OrderType: "LMT", Qty: 10, LimitPrice: &limit})
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+	b.matchAll(ctx) // ask 10000 > limit 9000: 미체결
+
+	if _, err := b.CancelOrder(ctx, "A", resp.OrderID); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	if _, err := b.CancelOrder(ctx, "A", resp.OrderID); !errors.Is(err, paper.ErrOrderNotCancellable) {
+		t.Errorf("Expected ErrOrderNotCancellable, got %v", err)
+	}
+
+	unfilled, _ := b.GetUnfilledOrders(ctx, "A")
+	if len(unfilled) != 0 {
+		t.Errorf("Expected no unfilled orders, got %d", len(unfilled))
+	}
+}
+
+// TestBrokerModifyOrder tests unfilled LMT sell → repriced to bid under a new order number
+func TestBrokerModifyOrder(t *testing.T) {
+	ctx := context.Background()
+	b := testBroker(1.0)
+
+	buy, _ := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "BUY", OrderType: "MKT", Qty: 10})
+	b.matchAll(ctx)
+	if fills, _ := b.GetFillsForOrder(ctx, buy.OrderID); len(fills) != 1 {
+		t.Fatalf("Expected buy to fill, got %+v", fills)
+	}
+
+	high := decimal.NewFromInt(10500)
+	sell, err := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "SELL", OrderType: "LMT", Qty: 10, LimitPrice: &high})
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+	b.matchAll(ctx)
+	if unfilled, _ := b.GetUnfilledOrders(ctx, "A"); len(unfilled) != 1 {
+		t.Fatalf("Expected sell above bid to stay open, got %+v", unfilled)
+	}
+
+	bid := decimal.NewFromInt(9990)
+	resp, err := b.ModifyOrder(ctx, execution.KISModifyRequest{AccountID: "A", OrderNo: sell.OrderID, OrderType: "LMT", Qty: 10, LimitPrice: &bid})
+	if err != nil {
+		t.Fatalf("ModifyOrder failed: %v", err)
+	}
+	if resp.NewOrderNo == "" || resp.NewOrderNo == sell.OrderID {
+		t.Fatalf("Expected new order number, got %q", resp.NewOrderNo)
+	}
+
+	b.matchAll(ctx)
+	if fills, _ := b.GetFillsForOrder(ctx, resp.NewOrderNo); len(fills) != 1 || fills[0].Qty != 10 {
+		t.Errorf("Expected modified order to fill 10 @ bid, got %+v", fills)
+	}
+	if _, err := b.ModifyOrder(ctx, execution.KISModifyRequest{AccountID: "A", OrderNo: sell.OrderID, OrderType: "MKT"}); !errors.Is(err, paper.ErrOrderNotCancellable) {
+		t.Errorf("Expected replaced order to be closed, got %v", err)
+	}
+}
+
+// TestBrokerIOCAndFOK tests IOC cancels remainder after partial fill and FOK fills all or nothing
+func TestBrokerIOCAndFOK(t *testing.T) {
+	ctx := context.Background()
+	b := testBroker(0.5)
+
+	limit := decimal.NewFromInt(10000)
+	ioc, err := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "BUY", OrderType: execution.OrderTypeIOCLimit, Qty: 10, LimitPrice: &limit})
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+	fok, err := b.SubmitOrder(ctx, execution.KISOrderRequest{AccountID: "A", Symbol: "005930", Side: "BUY", OrderType: execution.OrderTypeFOKMarket, Qty: 10})
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+	b.matchAll(ctx)
+
+	if fills, _ := b.GetFillsForOrder(ctx, ioc.OrderID); len(fills) != 1 || fills[0].Qty != 5 {
+		t.Errorf("Expected IOC to fill 5, got %+v", fills)
+	}
+	if fills, _ := b.GetFillsForOrder(ctx, fok.OrderID); len(fills) != 0 {
+		t.Errorf("Expected FOK not to fill partially, got %+v", fills)
+	}
+	if unfilled, _ := b.GetUnfilledOrders(ctx, "A"); len(unfilled) != 0 {
+		t.Errorf("Expected IOC/FOK remainders cancelled, got %+v", unfilled)
+	}
+}
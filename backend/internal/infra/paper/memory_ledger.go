@@ -0,0 +1,97 @@
+package paper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v14/internal/domain/paper"
+)
+
+// MemoryLedger in-memory paper.LedgerRepository (통합 테스트 / DB 없는 로컬 실행용)
+type MemoryLedger struct {
+	mu       sync.Mutex
+	accounts map[string]paper.Account
+	orders   map[string]paper.Order
+	fills    []paper.Fill
+	holdings map[string]map[string]paper.Holding
+}
+
+// NewMemoryLedger creates an empty in-memory ledger
+func NewMemoryLedger() *MemoryLedger {
+	return &MemoryLedger{
+		accounts: make(map[string]paper.Account),
+		orders:   make(map[string]paper.Order),
+		holdings: make(map[string]map[string]paper.Holding),
+	}
+}
+
+// LoadLedger returns a copy of the account ledger
+func (m *MemoryLedger) LoadLedger(ctx context.Context, accountID string, since time.Time) (*paper.Ledger, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	account, ok := m.accounts[accountID]
+	if !ok {
+		return nil, paper.ErrAccountNotFound
+	}
+
+	ledger := &paper.Ledger{Account: &account}
+	for _, o := range m.orders {
+		if o.AccountID == accountID && (o.IsOpen() || !o.SubmittedTS.Before(since)) {
+			copied := o
+			ledger.Orders = append(ledger.Orders, &copied)
+		}
+	}
+	for _, f := range m.fills {
+		if f.AccountID == accountID && !f.TS.Before(since) {
+			copied := f
+			ledger.Fills = append(ledger.Fills, &copied)
+		}
+	}
+	for _, h := range m.holdings[accountID] {
+		if h.Qty > 0 {
+			copied := h
+			ledger.Holdings = append(ledger.Holdings, &copied)
+		}
+	}
+	return ledger, nil
+}
+
+// CreateAccount creates an account (이미 있으면 유지)
+func (m *MemoryLedger) CreateAccount(ctx context.Context, account *paper.Account) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[account.AccountID]; !ok {
+		m.accounts[account.AccountID] = *account
+	}
+	return nil
+}
+
+// SaveOrder upserts an order
+func (m *MemoryLedger) SaveOrder(ctx context.Context, order *paper.Order) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.orders[order.OrderID] = *order
+	return nil
+}
+
+// RecordFill records order + fill + holding + cash
+func (m *MemoryLedger) RecordFill(ctx context.Context, order *paper.Order, fill *paper.Fill, holding *paper.Holding, account *paper.Account) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.orders[order.OrderID] = *order
+	m.fills = append(m.fills, *fill)
+	if m.holdings[holding.AccountID] == nil {
+		m.holdings[holding.AccountID] = make(map[string]paper.Holding)
+	}
+	m.holdings[holding.AccountID][holding.Symbol] = *holding
+	m.accounts[account.AccountID] = *account
+	return nil
+}
+
+// Ensure MemoryLedger implements paper.LedgerRepository
+var _ paper.LedgerRepository = (*MemoryLedger)(nil)
@@ -183,6 +183,23 @@ func (r *ExitEventRepository) ExitEventExists(ctx context.Context, positionID uu
 	return exists, nil
 }
 
+// ExitEventExistsSince checks if an exit event exists for a position lifecycle
+func (r *ExitEventRepository) ExitEventExistsSince(ctx context.Context, positionID uuid.UUID, entryTS time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM trade.exit_events WHERE position_id = $1 AND exit_ts >= $2
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(ctx, query, positionID, entryTS).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check exists: %w", err)
+	}
+
+	return exists, nil
+}
+
 // LoadExitEventsSince loads exit events since timestamp
 func (r *ExitEventRepository) LoadExitEventsSince(ctx context.Context, since time.Time) ([]*execution.ExitEvent, error) {
 	query := `
@@ -192,3 +192,41 @@ func (r *IntentRepository) UpdateIntentStatus(ctx context.Context, intentID uuid
 
 	return nil
 }
+
+// HasOpenRebalanceSells checks if a rebalance plan still has SELL intents not yet filled
+// action_key = {plan_id}:REBALANCE:{symbol}, 재주문(정정)은 같은 intent_id의 새 주문
+func (r *IntentRepository) HasOpenRebalanceSells(ctx context.Context, planID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM trade.order_intents i
+			WHERE i.intent_type = $2
+			  AND i.action_key LIKE $1 || ':%'
+			  AND (
+			      i.status = ANY($3)
+			      OR (i.status = $4 AND (
+			          NOT EXISTS (SELECT 1 FROM trade.orders o WHERE o.intent_id = i.intent_id)
+			          OR EXISTS (SELECT 1 FROM trade.orders o WHERE o.intent_id = i.intent_id AND o.status = ANY($5))
+			      ))
+			  )
+		)
+	`
+
+	openOrderStatuses := []string{execution.OrderStatusSubmitted, execution.OrderStatusPartial}
+
+	var open bool
+	err := r.db.QueryRow(ctx, query,
+		planID,
+		execution.IntentTypeRebalanceSell,
+		exit.ActiveIntentStatuses,
+		execution.IntentStatusSubmitted,
+		openOrderStatuses,
+	).Scan(&open)
+	if err != nil {
+		return false, fmt.Errorf("query open rebalance sells: %w", err)
+	}
+
+	return open, nil
+}
+
+var _ execution.RebalanceSellChecker = (*IntentRepository)(nil)
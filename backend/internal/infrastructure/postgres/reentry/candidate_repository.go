@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/reentry"
 )
 
@@ -69,7 +70,8 @@ func (r *CandidateRepository) GetCandidate(ctx context.Context, candidateID uuid
 		SELECT candidate_id, exit_event_id, symbol, origin_position_id,
 		       exit_reason_code, exit_ts, exit_price, exit_profile_id,
 		       cooldown_until, state, max_reentries, reentry_count,
-		       reentry_profile_id, last_eval_ts, updated_ts
+		       reentry_profile_id, last_eval_ts,
+		       ready_reason_code, ready_price, ready_ts, entry_intent_id, updated_ts
 		FROM trade.reentry_candidates
 		WHERE candidate_id = $1
 	`
@@ -78,6 +80,10 @@ func (r *CandidateRepository) GetCandidate(ctx context.Context, candidateID uuid
 	var exitProfileID sql.NullString
 	var reentryProfileID sql.NullString
 	var lastEvalTS sql.NullTime
+	var readyReasonCode sql.NullString
+	var readyPrice decimal.NullDecimal
+	var readyTS sql.NullTime
+	var entryIntentID uuid.NullUUID
 
 	err := r.db.QueryRow(ctx, query, candidateID).Scan(
 		&candidate.CandidateID,
@@ -94,6 +100,10 @@ func (r *CandidateRepository) GetCandidate(ctx context.Context, candidateID uuid
 		&candidate.ReentryCount,
 		&reentryProfileID,
 		&lastEvalTS,
+		&readyReasonCode,
+		&readyPrice,
+		&readyTS,
+		&entryIntentID,
 		&candidate.UpdatedTS,
 	)
 
@@ -116,6 +126,22 @@ func (r *CandidateRepository) GetCandidate(ctx context.Context, candidateID uuid
 		candidate.LastEvalTS = &lastEvalTS.Time
 	}
 
+	if readyReasonCode.Valid {
+		candidate.ReadyReasonCode = &readyReasonCode.String
+	}
+
+	if readyPrice.Valid {
+		candidate.ReadyPrice = &readyPrice.Decimal
+	}
+
+	if readyTS.Valid {
+		candidate.ReadyTS = &readyTS.Time
+	}
+
+	if entryIntentID.Valid {
+		candidate.EntryIntentID = &entryIntentID.UUID
+	}
+
 	return &candidate, nil
 }
 
@@ -125,7 +151,8 @@ func (r *CandidateRepository) GetCandidateByExitEvent(ctx context.Context, exitE
 		SELECT candidate_id, exit_event_id, symbol, origin_position_id,
 		       exit_reason_code, exit_ts, exit_price, exit_profile_id,
 		       cooldown_until, state, max_reentries, reentry_count,
-		       reentry_profile_id, last_eval_ts, updated_ts
+		       reentry_profile_id, last_eval_ts,
+		       ready_reason_code, ready_price, ready_ts, entry_intent_id, updated_ts
 		FROM trade.reentry_candidates
 		WHERE exit_event_id = $1
 	`
@@ -134,6 +161,10 @@ func (r *CandidateRepository) GetCandidateByExitEvent(ctx context.Context, exitE
 	var exitProfileID sql.NullString
 	var reentryProfileID sql.NullString
 	var lastEvalTS sql.NullTime
+	var readyReasonCode sql.NullString
+	var readyPrice decimal.NullDecimal
+	var readyTS sql.NullTime
+	var entryIntentID uuid.NullUUID
 
 	err := r.db.QueryRow(ctx, query, exitEventID).Scan(
 		&candidate.CandidateID,
@@ -150,6 +181,10 @@ func (r *CandidateRepository) GetCandidateByExitEvent(ctx context.Context, exitE
 		&candidate.ReentryCount,
 		&reentryProfileID,
 		&lastEvalTS,
+		&readyReasonCode,
+		&readyPrice,
+		&readyTS,
+		&entryIntentID,
 		&candidate.UpdatedTS,
 	)
 
@@ -172,6 +207,103 @@ func (r *CandidateRepository) GetCandidateByExitEvent(ctx context.Context, exitE
 		candidate.LastEvalTS = &lastEvalTS.Time
 	}
 
+	if readyReasonCode.Valid {
+		candidate.ReadyReasonCode = &readyReasonCode.String
+	}
+
+	if readyPrice.Valid {
+		candidate.ReadyPrice = &readyPrice.Decimal
+	}
+
+	if readyTS.Valid {
+		candidate.ReadyTS = &readyTS.Time
+	}
+
+	if entryIntentID.Valid {
+		candidate.EntryIntentID = &entryIntentID.UUID
+	}
+
+	return &candidate, nil
+}
+
+// GetCandidateByEntryIntent retrieves a candidate by the ENTRY intent it created
+func (r *CandidateRepository) GetCandidateByEntryIntent(ctx context.Context, intentID uuid.UUID) (*reentry.ReentryCandidate, error) {
+	query := `
+		SELECT candidate_id, exit_event_id, symbol, origin_position_id,
+		       exit_reason_code, exit_ts, exit_price, exit_profile_id,
+		       cooldown_until, state, max_reentries, reentry_count,
+		       reentry_profile_id, last_eval_ts,
+		       ready_reason_code, ready_price, ready_ts, entry_intent_id, updated_ts
+		FROM trade.reentry_candidates
+		WHERE entry_intent_id = $1
+	`
+
+	var candidate reentry.ReentryCandidate
+	var exitProfileID sql.NullString
+	var reentryProfileID sql.NullString
+	var lastEvalTS sql.NullTime
+	var readyReasonCode sql.NullString
+	var readyPrice decimal.NullDecimal
+	var readyTS sql.NullTime
+	var entryIntentID uuid.NullUUID
+
+	err := r.db.QueryRow(ctx, query, intentID).Scan(
+		&candidate.CandidateID,
+		&candidate.ExitEventID,
+		&candidate.Symbol,
+		&candidate.OriginPositionID,
+		&candidate.ExitReasonCode,
+		&candidate.ExitTS,
+		&candidate.ExitPrice,
+		&exitProfileID,
+		&candidate.CooldownUntil,
+		&candidate.State,
+		&candidate.MaxReentries,
+		&candidate.ReentryCount,
+		&reentryProfileID,
+		&lastEvalTS,
+		&readyReasonCode,
+		&readyPrice,
+		&readyTS,
+		&entryIntentID,
+		&candidate.UpdatedTS,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, reentry.ErrCandidateNotFound
+		}
+		return nil, fmt.Errorf("query candidate by entry intent: %w", err)
+	}
+
+	if exitProfileID.Valid {
+		candidate.ExitProfileID = &exitProfileID.String
+	}
+
+	if reentryProfileID.Valid {
+		candidate.ReentryProfileID = &reentryProfileID.String
+	}
+
+	if lastEvalTS.Valid {
+		candidate.LastEvalTS = &lastEvalTS.Time
+	}
+
+	if readyReasonCode.Valid {
+		candidate.ReadyReasonCode = &readyReasonCode.String
+	}
+
+	if readyPrice.Valid {
+		candidate.ReadyPrice = &readyPrice.Decimal
+	}
+
+	if readyTS.Valid {
+		candidate.ReadyTS = &readyTS.Time
+	}
+
+	if entryIntentID.Valid {
+		candidate.EntryIntentID = &entryIntentID.UUID
+	}
+
 	return &candidate, nil
 }
 
@@ -195,6 +327,60 @@ func (r *CandidateRepository) UpdateCandidateState(ctx context.Context, candidat
 	return nil
 }
 
+// MarkReady transitions candidate WATCH → READY with the trigger that fired
+// Guarded by state = WATCH so a concurrent transition is not overwritten
+func (r *CandidateRepository) MarkReady(ctx context.Context, candidateID uuid.UUID, reasonCode string, readyPrice decimal.Decimal) error {
+	query := `
+		UPDATE trade.reentry_candidates
+		SET state = $1, ready_reason_code = $2, ready_price = $3, ready_ts = $4, updated_ts = $4
+		WHERE candidate_id = $5 AND state = $6
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		reentry.StateReady,
+		reasonCode,
+		readyPrice,
+		time.Now(),
+		candidateID,
+		reentry.StateWatch,
+	)
+	if err != nil {
+		return fmt.Errorf("mark ready: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return reentry.ErrCandidateNotFound
+	}
+
+	return nil
+}
+
+// MarkEntered transitions candidate READY → ENTERED and increments reentry count
+// Guarded by state = READY so a duplicate fill notification does not double count
+func (r *CandidateRepository) MarkEntered(ctx context.Context, candidateID uuid.UUID) error {
+	query := `
+		UPDATE trade.reentry_candidates
+		SET state = $1, reentry_count = reentry_count + 1, updated_ts = $2
+		WHERE candidate_id = $3 AND state = $4
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		reentry.StateEntered,
+		time.Now(),
+		candidateID,
+		reentry.StateReady,
+	)
+	if err != nil {
+		return fmt.Errorf("mark entered: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return reentry.ErrCandidateNotFound
+	}
+
+	return nil
+}
+
 // UpdateReentryCount increments reentry count
 func (r *CandidateRepository) UpdateReentryCount(ctx context.Context, candidateID uuid.UUID) error {
 	query := `
@@ -241,7 +427,8 @@ func (r *CandidateRepository) LoadCandidatesByState(ctx context.Context, states
 		SELECT candidate_id, exit_event_id, symbol, origin_position_id,
 		       exit_reason_code, exit_ts, exit_price, exit_profile_id,
 		       cooldown_until, state, max_reentries, reentry_count,
-		       reentry_profile_id, last_eval_ts, updated_ts
+		       reentry_profile_id, last_eval_ts,
+		       ready_reason_code, ready_price, ready_ts, entry_intent_id, updated_ts
 		FROM trade.reentry_candidates
 		WHERE state = ANY($1)
 		ORDER BY updated_ts DESC
@@ -259,6 +446,10 @@ func (r *CandidateRepository) LoadCandidatesByState(ctx context.Context, states
 		var exitProfileID sql.NullString
 		var reentryProfileID sql.NullString
 		var lastEvalTS sql.NullTime
+		var readyReasonCode sql.NullString
+		var readyPrice decimal.NullDecimal
+		var readyTS sql.NullTime
+		var entryIntentID uuid.NullUUID
 
 		err := rows.Scan(
 			&candidate.CandidateID,
@@ -275,6 +466,10 @@ func (r *CandidateRepository) LoadCandidatesByState(ctx context.Context, states
 			&candidate.ReentryCount,
 			&reentryProfileID,
 			&lastEvalTS,
+			&readyReasonCode,
+			&readyPrice,
+			&readyTS,
+			&entryIntentID,
 			&candidate.UpdatedTS,
 		)
 		if err != nil {
@@ -293,6 +488,22 @@ func (r *CandidateRepository) LoadCandidatesByState(ctx context.Context, states
 			candidate.LastEvalTS = &lastEvalTS.Time
 		}
 
+		if readyReasonCode.Valid {
+			candidate.ReadyReasonCode = &readyReasonCode.String
+		}
+
+		if readyPrice.Valid {
+			candidate.ReadyPrice = &readyPrice.Decimal
+		}
+
+		if readyTS.Valid {
+			candidate.ReadyTS = &readyTS.Time
+		}
+
+		if entryIntentID.Valid {
+			candidate.EntryIntentID = &entryIntentID.UUID
+		}
+
 		candidates = append(candidates, &candidate)
 	}
 
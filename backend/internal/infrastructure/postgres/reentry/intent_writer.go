@@ -0,0 +1,84 @@
+package reentry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/reentry"
+)
+
+// IntentWriter implements reentry IntentWriter (trade.order_intents ENTRY rows)
+type IntentWriter struct {
+	db *pgxpool.Pool
+}
+
+// NewIntentWriter creates a new entry intent writer
+func NewIntentWriter(db *pgxpool.Pool) *IntentWriter {
+	return &IntentWriter{db: db}
+}
+
+// CreateEntryIntent creates a new ENTRY intent and links it to the candidate
+// Idempotent via action_key unique constraint ({candidate_id}:ENTRY:{n})
+func (w *IntentWriter) CreateEntryIntent(ctx context.Context, intent *reentry.EntryIntent) error {
+	tx, err := w.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Insert intent (status=NEW → Execution이 바로 제출)
+	insertQuery := `
+		INSERT INTO trade.order_intents (
+			intent_id, position_id, symbol, intent_type, qty, order_type,
+			limit_price, reason_code, reason_detail, action_key, status, created_ts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		ON CONFLICT (action_key) DO NOTHING
+	`
+
+	result, err := tx.Exec(ctx, insertQuery,
+		intent.IntentID,
+		intent.PositionID,
+		intent.Symbol,
+		execution.IntentTypeEntry,
+		intent.Qty,
+		intent.OrderType,
+		intent.LimitPrice,
+		intent.ReasonCode,
+		fmt.Sprintf("재진입 후보 %s", intent.CandidateID),
+		intent.ActionKey,
+		exit.IntentStatusNew,
+	)
+	if err != nil {
+		return fmt.Errorf("insert entry intent: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return reentry.ErrEntryIntentExists
+	}
+
+	// 2. Link intent to candidate (READY 상태에서 한 번만)
+	updateQuery := `
+		UPDATE trade.reentry_candidates
+		SET entry_intent_id = $1, updated_ts = $2
+		WHERE candidate_id = $3 AND state = $4 AND entry_intent_id IS NULL
+	`
+
+	result, err = tx.Exec(ctx, updateQuery, intent.IntentID, time.Now(), intent.CandidateID, reentry.StateReady)
+	if err != nil {
+		return fmt.Errorf("link entry intent: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return reentry.ErrCandidateNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+// Package calendar provides the KRX trading calendar (holidays, special sessions, session phases)
+// 시장 시간/거래일 판단은 모두 이 패키지를 통해서만 수행 (SSOT)
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// KST 한국 시간대
+var KST = time.FixedZone("KST", 9*60*60)
+
+const dateLayout = "2006-01-02"
+
+// Phase KRX 세션 단계
+type Phase string
+
+const (
+	PhaseClosed           Phase = "CLOSED"             // 휴장 / 장 종료
+	PhasePreOpenAuction   Phase = "PRE_OPEN_AUCTION"   // 장전 동시호가 (08:30~09:00)
+	PhaseRegular          Phase = "REGULAR"            // 정규장 접속매매 (09:00~15:20)
+	PhaseClosingAuction   Phase = "CLOSING_AUCTION"    // 장마감 동시호가 (15:20~15:30)
+	PhaseAfterHoursClose  Phase = "AFTER_HOURS_CLOSE"  // 장후 시간외 종가 (15:30~16:00)
+	PhaseAfterHoursSingle Phase = "AFTER_HOURS_SINGLE" // 시간외 단일가 (16:00~18:00)
+)
+
+// 정규 세션 시각 (분, 00:00 기준)
+const (
+	preOpenMin          = 8*60 + 30
+	openMin             = 9 * 60
+	closingAuctionMin   = 15*60 + 20
+	closeMin            = 15*60 + 30
+	afterHoursSingleMin = 16 * 60
+	afterHoursEndMin    = 18 * 60
+)
+
+// Holiday KRX 휴장일
+type Holiday struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Name string `json:"name"`
+}
+
+// SpecialSession 개장/폐장 시각 변경일 (연초 개장일, 수능일 등)
+type SpecialSession struct {
+	Date          string `json:"date"` // YYYY-MM-DD
+	Name          string `json:"name"`
+	OpenDelayMin  int    `json:"open_delay_min"`  // 장전 동시호가/개장 지연 (분)
+	CloseDelayMin int    `json:"close_delay_min"` // 장마감 동시호가/폐장/시간외 지연 (분)
+}
+
+// File 휴장일 파일 포맷 (KRX_CALENDAR_FILE)
+type File struct {
+	Holidays        []Holiday        `json:"holidays"`
+	SpecialSessions []SpecialSession `json:"special_sessions"`
+}
+
+// Session 특정 거래일의 세션 시각
+type Session struct {
+	Date             time.Time `json:"date"`
+	PreOpen          time.Time `json:"pre_open"`
+	Open             time.Time `json:"open"`
+	ClosingAuction   time.Time `json:"closing_auction"`
+	Close            time.Time `json:"close"`
+	AfterHoursSingle time.Time `json:"after_hours_single"`
+	AfterHoursEnd    time.Time `json:"after_hours_end"`
+	Special          string    `json:"special,omitempty"` // 특별 세션명
+}
+
+// Calendar KRX 거래일 캘린더
+type Calendar struct {
+	mu       sync.RWMutex
+	holidays map[string]string         // date → name
+	special  map[string]SpecialSession // date → session
+}
+
+// New creates a calendar with the built-in holiday table
+func New() *Calendar {
+	c := &Calendar{
+		holidays: make(map[string]string),
+		special:  make(map[string]SpecialSession),
+	}
+	c.merge(File{Holidays: builtinHolidays, SpecialSessions: builtinSpecialSessions})
+	return c
+}
+
+// Load merges holidays/special sessions from JSON (같은 날짜는 덮어씀)
+func (c *Calendar) Load(r io.Reader) error {
+	var f File
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return fmt.Errorf("decode calendar: %w", err)
+	}
+
+	for _, h := range f.Holidays {
+		if _, err := time.ParseInLocation(dateLayout, h.Date, KST); err != nil {
+			return fmt.Errorf("invalid holiday date %q: %w", h.Date, err)
+		}
+	}
+	for _, s := range f.SpecialSessions {
+		if _, err := time.ParseInLocation(dateLayout, s.Date, KST); err != nil {
+			return fmt.Errorf("invalid special session date %q: %w", s.Date, err)
+		}
+	}
+
+	c.merge(f)
+	return nil
+}
+
+// LoadFile merges holidays from a JSON file
+func (c *Calendar) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open calendar file: %w", err)
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
+
+func (c *Calendar) merge(f File) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, h := range f.Holidays {
+		c.holidays[h.Date] = h.Name
+	}
+	for _, s := range f.SpecialSessions {
+		c.special[s.Date] = s
+	}
+}
+
+// =============================================================================
+// Trading Day
+// =============================================================================
+
+// IsTradingDay checks if the KST date of t is a KRX trading day
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	d := t.In(KST)
+	if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		return false
+	}
+
+	c.mu.RLock()
+	_, holiday := c.holidays[d.Format(dateLayout)]
+	c.mu.RUnlock()
+
+	return !holiday
+}
+
+// HolidayName returns holiday name ("" = not a listed holiday)
+func (c *Calendar) HolidayName(t time.Time) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.holidays[t.In(KST).Format(dateLayout)]
+}
+
+// NextTradingDay returns the first trading day after t's date (00:00 KST)
+func (c *Calendar) NextTradingDay(t time.Time) time.Time {
+	d := dateOf(t)
+	for i := 0; i < 366; i++ {
+		d = d.AddDate(0, 0, 1)
+		if c.IsTradingDay(d) {
+			return d
+		}
+	}
+	return d
+}
+
+// PrevTradingDay returns the last trading day before t's date (00:00 KST)
+func (c *Calendar) PrevTradingDay(t time.Time) time.Time {
+	d := dateOf(t)
+	for i := 0; i < 366; i++ {
+		d = d.AddDate(0, 0, -1)
+		if c.IsTradingDay(d) {
+			return d
+		}
+	}
+	return d
+}
+
+// TradingDaysAgo returns the time n trading days before t (시각 유지)
+func (c *Calendar) TradingDaysAgo(t time.Time, n int) time.Time {
+	k := t.In(KST)
+	d := dateOf(k)
+	for i := 0; i < n; i++ {
+		d = c.PrevTradingDay(d)
+	}
+	return time.Date(d.Year(), d.Month(), d.Day(), k.Hour(), k.Minute(), k.Second(), k.Nanosecond(), KST)
+}
+
+// TradingDaysBetween counts trading days in (from, to] by KST date
+// 예: 금요일 진입 → 다음 월요일 = 1 거래일 보유
+func (c *Calendar) TradingDaysBetween(from, to time.Time) int {
+	start, end := dateOf(from), dateOf(to)
+	if !end.After(start) {
+		return 0
+	}
+
+	count := 0
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if c.IsTradingDay(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// =============================================================================
+// Session
+// =============================================================================
+
+// SessionFor returns session times for t's date (false = 휴장일)
+func (c *Calendar) SessionFor(t time.Time) (Session, bool) {
+	if !c.IsTradingDay(t) {
+		return Session{}, false
+	}
+
+	d := dateOf(t)
+	c.mu.RLock()
+	sp, special := c.special[d.Format(dateLayout)]
+	c.mu.RUnlock()
+
+	at := func(min int) time.Time { return d.Add(time.Duration(min) * time.Minute) }
+	openDelay, closeDelay := sp.OpenDelayMin, sp.CloseDelayMin
+
+	s := Session{
+		Date:             d,
+		PreOpen:          at(preOpenMin + openDelay),
+		Open:             at(openMin + openDelay),
+		ClosingAuction:   at(closingAuctionMin + closeDelay),
+		Close:            at(closeMin + closeDelay),
+		AfterHoursSingle: at(afterHoursSingleMin + closeDelay),
+		AfterHoursEnd:    at(afterHoursEndMin + closeDelay),
+	}
+	if special {
+		s.Special = sp.Name
+	}
+	return s, true
+}
+
+// Phase returns the KRX session phase at t
+func (c *Calendar) Phase(t time.Time) Phase {
+	s, ok := c.SessionFor(t)
+	if !ok {
+		return PhaseClosed
+	}
+
+	switch {
+	case t.Before(s.PreOpen):
+		return PhaseClosed
+	case t.Before(s.Open):
+		return PhasePreOpenAuction
+	case t.Before(s.ClosingAuction):
+		return PhaseRegular
+	case t.Before(s.Close):
+		return PhaseClosingAuction
+	case t.Before(s.AfterHoursSingle):
+		return PhaseAfterHoursClose
+	case t.Before(s.AfterHoursEnd):
+		return PhaseAfterHoursSingle
+	default:
+		return PhaseClosed
+	}
+}
+
+// IsRegularHours checks if t is within regular hours (개장 ~ 폐장, 장마감 동시호가 포함)
+func (c *Calendar) IsRegularHours(t time.Time) bool {
+	p := c.Phase(t)
+	return p == PhaseRegular || p == PhaseClosingAuction
+}
+
+// IsMarketActive checks if any session is running (장전 동시호가 ~ 시간외 단일가)
+func (c *Calendar) IsMarketActive(t time.Time) bool {
+	return c.Phase(t) != PhaseClosed
+}
+
+// dateOf returns 00:00 KST of t's date
+func dateOf(t time.Time) time.Time {
+	k := t.In(KST)
+	return time.Date(k.Year(), k.Month(), k.Day(), 0, 0, 0, 0, KST)
+}
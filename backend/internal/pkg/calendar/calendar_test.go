@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func kst(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, KST)
+}
+
+// TestIsTradingDay tests weekends, holidays and year-end closing
+func TestIsTradingDay(t *testing.T) {
+	c := New()
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"Weekday", kst(2026, 10, 16, 10, 0), true},
+		{"Saturday", kst(2026, 10, 17, 10, 0), false},
+		{"Chuseok", kst(2026, 9, 24, 10, 0), false},
+		{"Year-end closing", kst(2026, 12, 31, 10, 0), false},
+		{"UTC evening is next KST day", time.Date(2026, 9, 23, 16, 0, 0, 0, time.UTC), false}, // 09-24 01:00 KST
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.IsTradingDay(tt.t); got != tt.want {
+				t.Errorf("IsTradingDay(%s) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPhase tests regular and delayed (수능) session phases
+func TestPhase(t *testing.T) {
+	c := New()
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want Phase
+	}{
+		{"Before pre-open", kst(2026, 10, 16, 8, 29), PhaseClosed},
+		{"Pre-open auction", kst(2026, 10, 16, 8, 30), PhasePreOpenAuction},
+		{"Regular", kst(2026, 10, 16, 9, 0), PhaseRegular},
+		{"Closing auction", kst(2026, 10, 16, 15, 25), PhaseClosingAuction},
+		{"After-hours close", kst(2026, 10, 16, 15, 30), PhaseAfterHoursClose},
+		{"After-hours single", kst(2026, 10, 16, 17, 0), PhaseAfterHoursSingle},
+		{"After 18:00", kst(2026, 10, 16, 18, 0), PhaseClosed},
+		{"Holiday", kst(2026, 10, 9, 10, 0), PhaseClosed},
+		{"CSAT pre-open", kst(2026, 11, 19, 9, 30), PhasePreOpenAuction},
+		{"CSAT regular", kst(2026, 11, 19, 10, 0), PhaseRegular},
+		{"CSAT closing auction", kst(2026, 11, 19, 16, 25), PhaseClosingAuction},
+		{"New year open delayed", kst(2026, 1, 2, 9, 30), PhasePreOpenAuction},
+		{"New year close unchanged", kst(2026, 1, 2, 15, 25), PhaseClosingAuction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Phase(tt.t); got != tt.want {
+				t.Errorf("Phase(%s) = %s, want %s", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTradingDayArithmetic tests next/prev, N days ago and day counting
+func TestTradingDayArithmetic(t *testing.T) {
+	c := New()
+
+	t.Run("NextTradingDay skips Chuseok and weekend", func(t *testing.T) {
+		got := c.NextTradingDay(kst(2026, 9, 23, 14, 0))
+		if want := kst(2026, 9, 28, 0, 0); !got.Equal(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("PrevTradingDay skips year-end closing", func(t *testing.T) {
+		got := c.PrevTradingDay(kst(2027, 1, 1, 9, 0))
+		if want := kst(2026, 12, 30, 0, 0); !got.Equal(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("TradingDaysAgo keeps time of day", func(t *testing.T) {
+		got := c.TradingDaysAgo(kst(2026, 9, 28, 10, 15), 2)
+		if want := kst(2026, 9, 22, 10, 15); !got.Equal(want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("TradingDaysBetween", func(t *testing.T) {
+		if got := c.TradingDaysBetween(kst(2026, 9, 22, 10, 0), kst(2026, 9, 28, 9, 0)); got != 2 {
+			t.Errorf("Expected 2 trading days (9/23, 9/28), got %d", got)
+		}
+		if got := c.TradingDaysBetween(kst(2026, 10, 16, 9, 0), kst(2026, 10, 16, 15, 0)); got != 0 {
+			t.Errorf("Expected 0 trading days on same date, got %d", got)
+		}
+	})
+}
+
+// TestLoad tests merging holidays from JSON
+func TestLoad(t *testing.T) {
+	c := New()
+
+	data := `{"holidays":[{"date":"2027-01-01","name":"신정"}],"special_sessions":[{"date":"2027-01-04","name":"연초 개장일","open_delay_min":60}]}`
+	if err := c.Load(strings.NewReader(data)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if c.IsTradingDay(kst(2027, 1, 1, 10, 0)) {
+		t.Error("Expected 2027-01-01 to be a holiday after Load")
+	}
+	if got := c.HolidayName(kst(2027, 1, 1, 10, 0)); got != "신정" {
+		t.Errorf("Expected holiday name 신정, got %q", got)
+	}
+	if got := c.Phase(kst(2027, 1, 4, 9, 30)); got != PhasePreOpenAuction {
+		t.Errorf("Expected delayed open on 2027-01-04, got %s", got)
+	}
+
+	if err := c.Load(strings.NewReader(`{"holidays":[{"date":"2027/01/01"}]}`)); err == nil {
+		t.Error("Expected error for invalid date format")
+	}
+}
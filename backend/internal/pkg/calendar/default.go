@@ -0,0 +1,56 @@
+package calendar
+
+import "time"
+
+// defaultCalendar 프로세스 공용 캘린더 (built-in + LoadFile)
+var defaultCalendar = New()
+
+// Default returns the shared calendar
+func Default() *Calendar {
+	return defaultCalendar
+}
+
+// LoadFile merges a holiday file into the shared calendar
+func LoadFile(path string) error {
+	return defaultCalendar.LoadFile(path)
+}
+
+// IsTradingDay checks if t's KST date is a trading day (shared calendar)
+func IsTradingDay(t time.Time) bool {
+	return defaultCalendar.IsTradingDay(t)
+}
+
+// NextTradingDay returns the next trading day after t (shared calendar)
+func NextTradingDay(t time.Time) time.Time {
+	return defaultCalendar.NextTradingDay(t)
+}
+
+// PrevTradingDay returns the previous trading day before t (shared calendar)
+func PrevTradingDay(t time.Time) time.Time {
+	return defaultCalendar.PrevTradingDay(t)
+}
+
+// TradingDaysAgo returns the time n trading days before t (shared calendar)
+func TradingDaysAgo(t time.Time, n int) time.Time {
+	return defaultCalendar.TradingDaysAgo(t, n)
+}
+
+// TradingDaysBetween counts trading days in (from, to] (shared calendar)
+func TradingDaysBetween(from, to time.Time) int {
+	return defaultCalendar.TradingDaysBetween(from, to)
+}
+
+// CurrentPhase returns the session phase at t (shared calendar)
+func CurrentPhase(t time.Time) Phase {
+	return defaultCalendar.Phase(t)
+}
+
+// IsRegularHours checks regular hours at t (shared calendar)
+func IsRegularHours(t time.Time) bool {
+	return defaultCalendar.IsRegularHours(t)
+}
+
+// IsMarketActive checks if any session is running at t (shared calendar)
+func IsMarketActive(t time.Time) bool {
+	return defaultCalendar.IsMarketActive(t)
+}
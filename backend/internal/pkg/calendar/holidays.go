@@ -0,0 +1,54 @@
+package calendar
+
+// builtinHolidays KRX 휴장일 (주말 제외)
+// 다른 연도는 KRX_CALENDAR_FILE (JSON)로 로드
+var builtinHolidays = []Holiday{
+	// 2025
+	{Date: "2025-01-01", Name: "신정"},
+	{Date: "2025-01-27", Name: "임시공휴일"},
+	{Date: "2025-01-28", Name: "설날"},
+	{Date: "2025-01-29", Name: "설날"},
+	{Date: "2025-01-30", Name: "설날"},
+	{Date: "2025-03-03", Name: "삼일절 대체공휴일"},
+	{Date: "2025-05-01", Name: "근로자의 날"},
+	{Date: "2025-05-05", Name: "어린이날/부처님오신날"},
+	{Date: "2025-05-06", Name: "대체공휴일"},
+	{Date: "2025-06-03", Name: "대통령 선거"},
+	{Date: "2025-06-06", Name: "현충일"},
+	{Date: "2025-08-15", Name: "광복절"},
+	{Date: "2025-10-03", Name: "개천절"},
+	{Date: "2025-10-06", Name: "추석"},
+	{Date: "2025-10-07", Name: "추석"},
+	{Date: "2025-10-08", Name: "추석 대체공휴일"},
+	{Date: "2025-10-09", Name: "한글날"},
+	{Date: "2025-12-25", Name: "성탄절"},
+	{Date: "2025-12-31", Name: "연말 휴장일"},
+
+	// 2026
+	{Date: "2026-01-01", Name: "신정"},
+	{Date: "2026-02-16", Name: "설날"},
+	{Date: "2026-02-17", Name: "설날"},
+	{Date: "2026-02-18", Name: "설날"},
+	{Date: "2026-03-02", Name: "삼일절 대체공휴일"},
+	{Date: "2026-05-01", Name: "근로자의 날"},
+	{Date: "2026-05-05", Name: "어린이날"},
+	{Date: "2026-05-25", Name: "부처님오신날 대체공휴일"},
+	{Date: "2026-06-03", Name: "지방 선거"},
+	{Date: "2026-08-17", Name: "광복절 대체공휴일"},
+	{Date: "2026-09-24", Name: "추석"},
+	{Date: "2026-09-25", Name: "추석"},
+	{Date: "2026-10-05", Name: "개천절 대체공휴일"},
+	{Date: "2026-10-09", Name: "한글날"},
+	{Date: "2026-12-25", Name: "성탄절"},
+	{Date: "2026-12-31", Name: "연말 휴장일"},
+}
+
+// builtinSpecialSessions 개장/폐장 시각 변경일
+// - 연초 개장일: 10:00 개장 (폐장 동일)
+// - 수능일: 전체 1시간 지연 (10:00 ~ 16:30)
+var builtinSpecialSessions = []SpecialSession{
+	{Date: "2025-01-02", Name: "연초 개장일", OpenDelayMin: 60},
+	{Date: "2025-11-13", Name: "수능", OpenDelayMin: 60, CloseDelayMin: 60},
+	{Date: "2026-01-02", Name: "연초 개장일", OpenDelayMin: 60},
+	{Date: "2026-11-19", Name: "수능", OpenDelayMin: 60, CloseDelayMin: 60},
+}
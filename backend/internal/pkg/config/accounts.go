@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultAccountName 단일 계좌 설정(KIS_ACCOUNT_ID) 계좌 별칭
+const DefaultAccountName = "default"
+
+// AccountConfig 운용 계좌 설정 (계좌별 KIS 인증키 / 리스크 프로필)
+type AccountConfig struct {
+	Name        string // 별칭 (main, isa, ...)
+	ID          string // 계좌번호 XXXXXXXX-XX (종합계좌 8자리 + 상품코드 2자리)
+	AppKey      string // "" → KIS_APP_KEY 공유
+	AppSecret   string // "" → KIS_APP_SECRET 공유
+	RiskProfile string // control.risk_limits.profile_name ("" → 활성 프로필)
+}
+
+// AccountNo returns 8-digit account number
+func (a AccountConfig) AccountNo() string {
+	if idx := strings.Index(a.ID, "-"); idx > 0 {
+		return a.ID[:idx]
+	}
+	return a.ID
+}
+
+// ProductCode returns 2-digit account product code (없으면 "01")
+func (a AccountConfig) ProductCode() string {
+	if idx := strings.Index(a.ID, "-"); idx > 0 {
+		return a.ID[idx+1:]
+	}
+	return "01"
+}
+
+// loadAccounts loads trading accounts from environment
+//
+//	KIS_ACCOUNTS=main,isa
+//	KIS_MAIN_ACCOUNT_ID=12345678-01
+//	KIS_ISA_ACCOUNT_ID=87654321-01
+//	KIS_ISA_APP_KEY / KIS_ISA_APP_SECRET   (선택, 없으면 KIS_APP_KEY/KIS_APP_SECRET)
+//	KIS_ISA_RISK_PROFILE=isa               (선택)
+//
+// KIS_ACCOUNTS 미설정 → KIS_ACCOUNT_ID (또는 KIS_ACCOUNT_NO) 단일 계좌, paper 모드는 "PAPER"
+// 첫 번째 계좌가 기본 계좌 (계좌 미지정 ENTRY/리밸런싱 intent 처리)
+func loadAccounts(brokerMode string) ([]AccountConfig, error) {
+	names := os.Getenv("KIS_ACCOUNTS")
+	if strings.TrimSpace(names) == "" {
+		id := getEnv("KIS_ACCOUNT_ID", os.Getenv("KIS_ACCOUNT_NO"))
+		if id == "" && brokerMode == BrokerModePaper {
+			id = "PAPER" // paper 원장 기본 계좌
+		}
+		if id == "" {
+			return nil, nil
+		}
+		return []AccountConfig{{
+			Name:        DefaultAccountName,
+			ID:          id,
+			RiskProfile: os.Getenv("KIS_RISK_PROFILE"),
+		}}, nil
+	}
+
+	var accounts []AccountConfig
+	seen := make(map[string]string)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "KIS_" + strings.ToUpper(name) + "_"
+		id := os.Getenv(prefix + "ACCOUNT_ID")
+		if id == "" {
+			return nil, fmt.Errorf("%sACCOUNT_ID is required for account %q", prefix, name)
+		}
+		if brokerMode == BrokerModeKIS && !strings.Contains(id, "-") {
+			return nil, fmt.Errorf("invalid %sACCOUNT_ID %q (expected: XXXXXXXX-XX)", prefix, id)
+		}
+		if other, ok := seen[id]; ok {
+			return nil, fmt.Errorf("account %s is configured twice (%s, %s)", id, other, name)
+		}
+		seen[id] = name
+
+		account := AccountConfig{
+			Name:        name,
+			ID:          id,
+			AppKey:      os.Getenv(prefix + "APP_KEY"),
+			AppSecret:   os.Getenv(prefix + "APP_SECRET"),
+			RiskProfile: os.Getenv(prefix + "RISK_PROFILE"),
+		}
+		if (account.AppKey == "") != (account.AppSecret == "") {
+			return nil, fmt.Errorf("%sAPP_KEY and %sAPP_SECRET must be set together", prefix, prefix)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
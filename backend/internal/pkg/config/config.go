@@ -17,6 +17,13 @@ type Config struct {
 	Logging  LoggingConfig
 	KIS      KISConfig
 	Naver    NaverConfig
+	Market   MarketConfig
+	Broker   BrokerConfig
+	Reprice  RepriceConfig
+	Ledger   LedgerConfig
+	Cost     CostConfig
+	ATR      ATRConfig
+	Accounts []AccountConfig // 운용 계좌 (첫 번째 = 기본 계좌)
 }
 
 type ServerConfig struct {
@@ -69,6 +76,61 @@ type NaverConfig struct {
 	BaseURL string
 }
 
+// MarketConfig KRX 시장 캘린더 설정
+type MarketConfig struct {
+	CalendarFile string // 추가 휴장일 JSON (built-in 테이블에 병합)
+}
+
+// Broker modes
+const (
+	BrokerModeKIS   = "kis"   // 실계좌 (KIS REST/WS)
+	BrokerModePaper = "paper" // in-process 모의 체결 (KIS 접속 불필요)
+)
+
+// BrokerConfig 주문 체결 브로커 설정
+type BrokerConfig struct {
+	Mode  string // kis | paper
+	Paper PaperBrokerConfig
+}
+
+// PaperBrokerConfig paper broker 시뮬레이션 설정
+type PaperBrokerConfig struct {
+	InitialCash       int64   // 신규 계좌 초기 현금 (원)
+	LatencyMs         int     // 접수 → 체결 가능 지연
+	PartialFillPct    float64 // 매칭 1회당 체결 비율 (1.0 = 전량)
+	RejectRate        float64 // 무작위 거부 확률 (0~1)
+	MatchIntervalMs   int     // 매칭 주기
+	MatchOutsideHours bool    // 정규장 외 매칭 허용
+	Seed              int64   // 난수 시드 (0 = 현재 시각)
+}
+
+// RepriceConfig 미체결 LMT 청산 주문 자동 재호가 설정
+type RepriceConfig struct {
+	Enabled         bool // 자동 재호가 사용 여부
+	TimeoutSec      int  // 제출(또는 직전 정정) 후 대기 시간
+	ChaseTicks      int  // 최우선 매수호가 대비 추격 틱 수 (0 = 매수호가)
+	MaxAttempts     int  // 지정가 재호가 최대 횟수
+	ConvertToMarket bool // 재호가 소진 후 시장가 전환
+}
+
+// LedgerConfig 체결 기반 포지션 원장 설정
+type LedgerConfig struct {
+	Enabled bool // true: 체결로 포지션 갱신 + 잔고 대사, false: 잔고 polling으로 포지션 동기화 (legacy)
+}
+
+// CostConfig 거래 비용 / 취득원가 설정 (거래세율은 시장·일자별 내장 테이블)
+type CostConfig struct {
+	CommissionRate float64 // 위탁수수료율 (0.00015 = 0.015%)
+	Method         string  // 실현손익 lot 매칭: FIFO | AVERAGE
+}
+
+// ATRConfig daily ATR job 설정 (Exit SL/TP 변동성 스케일링)
+type ATRConfig struct {
+	Enabled bool   // 장전 ATR 계산 job 사용 여부
+	Period  int    // Wilder ATR 기간 (일봉)
+	RunAt   string // 실행 시각 KST HH:MM
+}
+
 // Load loads configuration from .env file
 // SSOT: .env 파일이 모든 설정의 유일한 진실 소스
 func Load() (*Config, error) {
@@ -123,8 +185,52 @@ func Load() (*Config, error) {
 		Naver: NaverConfig{
 			BaseURL: getEnv("NAVER_BASE_URL", "https://finance.naver.com"),
 		},
+		Market: MarketConfig{
+			CalendarFile: getEnv("KRX_CALENDAR_FILE", ""),
+		},
+		Broker: BrokerConfig{
+			Mode: getEnv("BROKER_MODE", BrokerModeKIS),
+			Paper: PaperBrokerConfig{
+				InitialCash:       int64(getIntEnv("PAPER_INITIAL_CASH", 100_000_000)),
+				LatencyMs:         getIntEnv("PAPER_LATENCY_MS", 300),
+				PartialFillPct:    getFloatEnv("PAPER_PARTIAL_FILL_PCT", 1.0),
+				RejectRate:        getFloatEnv("PAPER_REJECT_RATE", 0),
+				MatchIntervalMs:   getIntEnv("PAPER_MATCH_INTERVAL_MS", 1000),
+				MatchOutsideHours: getBoolEnv("PAPER_MATCH_OUTSIDE_HOURS", false),
+				Seed:              int64(getIntEnv("PAPER_SEED", 0)),
+			},
+		},
+		Reprice: RepriceConfig{
+			Enabled:         getBoolEnv("REPRICE_ENABLED", false),
+			TimeoutSec:      getIntEnv("REPRICE_TIMEOUT_SEC", 30),
+			ChaseTicks:      getIntEnv("REPRICE_CHASE_TICKS", 0),
+			MaxAttempts:     getIntEnv("REPRICE_MAX_ATTEMPTS", 2),
+			ConvertToMarket: getBoolEnv("REPRICE_CONVERT_TO_MARKET", true),
+		},
+		Ledger: LedgerConfig{
+			Enabled: getBoolEnv("LEDGER_ENABLED", false),
+		},
+		Cost: CostConfig{
+			CommissionRate: getFloatEnv("BROKER_COMMISSION_RATE", 0.00015),
+			Method:         getEnv("COST_BASIS_METHOD", "FIFO"),
+		},
+		ATR: ATRConfig{
+			Enabled: getBoolEnv("ATR_JOB_ENABLED", true),
+			Period:  getIntEnv("ATR_PERIOD", 14),
+			RunAt:   getEnv("ATR_JOB_TIME", "08:30"),
+		},
 	}
 
+	if config.Broker.Mode != BrokerModeKIS && config.Broker.Mode != BrokerModePaper {
+		return nil, fmt.Errorf("invalid BROKER_MODE: %s (expected: %s or %s)", config.Broker.Mode, BrokerModeKIS, BrokerModePaper)
+	}
+
+	accounts, err := loadAccounts(config.Broker.Mode)
+	if err != nil {
+		return nil, err
+	}
+	config.Accounts = accounts
+
 	return config, nil
 }
 
@@ -157,3 +263,16 @@ func getIntEnv(key string, fallback int) int {
 	}
 	return result
 }
+
+// getFloatEnv gets float environment variable with fallback
+func getFloatEnv(key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	var result float64
+	if _, err := fmt.Sscanf(value, "%g", &result); err != nil {
+		return fallback
+	}
+	return result
+}
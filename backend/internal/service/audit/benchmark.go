@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/audit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// benchmarkCode 성과 리포트 기본 벤치마크
+const benchmarkCode = price.IndexKOSPI
+
+// benchmarkLookbackDays 첫 일간 수익률 계산용 전일 종가 탐색 범위 (연휴 포함)
+const benchmarkLookbackDays = 14
+
+// IndexPriceReader reads daily index prices (data.index_prices, trade_date 오름차순)
+type IndexPriceReader interface {
+	GetRange(ctx context.Context, indexCode string, from, to time.Time) ([]*fetcher.IndexPrice, error)
+}
+
+// SetIndexPriceReader sets index price source for benchmark sync (optional)
+func (s *Service) SetIndexPriceReader(reader IndexPriceReader) {
+	s.indexReader = reader
+}
+
+// SyncBenchmarks 지수 일봉으로 audit.benchmark_data 갱신 (KOSPI/KOSDAQ/KOSPI200)
+// 일간 수익률 = 종가 / 전일 종가 - 1, 저장 건수 반환
+func (s *Service) SyncBenchmarks(ctx context.Context, startDate, endDate time.Time) (int, error) {
+	if s.indexReader == nil {
+		return 0, fmt.Errorf("index price reader not configured")
+	}
+
+	saved := 0
+	for _, code := range price.IndexSymbols() {
+		bars, err := s.indexReader.GetRange(ctx, code, startDate.AddDate(0, 0, -benchmarkLookbackDays), endDate)
+		if err != nil {
+			return saved, fmt.Errorf("get index prices %s: %w", code, err)
+		}
+
+		for _, data := range benchmarkFromIndex(code, bars, startDate) {
+			if err := s.repo.SaveBenchmark(ctx, data); err != nil {
+				return saved, fmt.Errorf("save benchmark %s: %w", code, err)
+			}
+			saved++
+		}
+	}
+
+	log.Debug().
+		Int("saved", saved).
+		Time("start", startDate).
+		Time("end", endDate).
+		Msg("Benchmark data synced from index prices")
+
+	return saved, nil
+}
+
+// benchmarkFromIndex converts ascending index bars to benchmark rows on/after startDate
+// 전일 종가가 없는 첫 봉은 수익률 계산 불가 → 제외
+func benchmarkFromIndex(code string, bars []*fetcher.IndexPrice, startDate time.Time) []*audit.BenchmarkData {
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+
+	var result []*audit.BenchmarkData
+	for i := 1; i < len(bars); i++ {
+		prev, cur := bars[i-1], bars[i]
+		if cur.TradeDate.Before(start) || prev.ClosePrice <= 0 {
+			continue
+		}
+		result = append(result, &audit.BenchmarkData{
+			Date:        cur.TradeDate,
+			Code:        code,
+			ClosePrice:  cur.ClosePrice,
+			DailyReturn: cur.ClosePrice/prev.ClosePrice - 1,
+		})
+	}
+	return result
+}
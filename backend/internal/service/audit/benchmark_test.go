@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// TestBenchmarkFromIndex tests daily return calculation from index closes
+func TestBenchmarkFromIndex(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, calendar.KST) }
+	bars := []*fetcher.IndexPrice{
+		{TradeDate: day(8), ClosePrice: 2500},
+		{TradeDate: day(13), ClosePrice: 2550}, // 연휴 이후 첫 거래일
+		{TradeDate: day(14), ClosePrice: 2524.5},
+	}
+
+	rows := benchmarkFromIndex("KOSPI", bars, day(13))
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 benchmark rows, got %d", len(rows))
+	}
+	if !rows[0].Date.Equal(day(13)) || math.Abs(rows[0].DailyReturn-0.02) > 1e-9 {
+		t.Errorf("Expected 2026-10-13 return 0.02, got %s %f", rows[0].Date, rows[0].DailyReturn)
+	}
+	if math.Abs(rows[1].DailyReturn-(-0.01)) > 1e-9 || rows[1].ClosePrice != 2524.5 {
+		t.Errorf("Expected -0.01 @ 2524.5, got %f @ %f", rows[1].DailyReturn, rows[1].ClosePrice)
+	}
+}
@@ -2,12 +2,16 @@ package audit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/audit"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
 	"github.com/wonny/aegis/v14/internal/infra/kis"
 )
 
@@ -21,6 +25,11 @@ type KISAuditBuilder struct {
 	kisClient          *kis.Client
 	defaultAccountNo   string
 	defaultProductCode string
+
+	// Cost basis (lot 매칭 방식 + 수수료/세금 모델)
+	costMethod   string
+	costModel    *execution.CostModel  // nil → execution.DefaultCostModel()
+	symbolReader risk.SymbolInfoReader // 종목별 시장 (거래세율), nil → KOSDAQ 세율
 }
 
 // NewKISAuditBuilder 새 KIS Audit Builder 생성
@@ -30,7 +39,24 @@ func NewKISAuditBuilder(service *Service, kisClient *kis.Client, defaultAccountN
 		kisClient:          kisClient,
 		defaultAccountNo:   defaultAccountNo,
 		defaultProductCode: defaultProductCode,
+		costMethod:         execution.CostMethodFIFO,
+	}
+}
+
+// SetCostBasis sets the lot matching method (FIFO | AVERAGE) and cost model
+func (b *KISAuditBuilder) SetCostBasis(method string, model *execution.CostModel) error {
+	normalized, err := execution.NormalizeCostMethod(method)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, method)
 	}
+	b.costMethod = normalized
+	b.costModel = model
+	return nil
+}
+
+// SetSymbolInfoReader sets the optional symbol market reader (시장별 거래세)
+func (b *KISAuditBuilder) SetSymbolInfoReader(reader risk.SymbolInfoReader) {
+	b.symbolReader = reader
 }
 
 // =============================================================================
@@ -47,8 +73,9 @@ func (b *KISAuditBuilder) BuildFromKIS(ctx context.Context, accountNo, accountPr
 		accountProductCode = b.defaultProductCode
 	}
 
-	// 기존 데이터 확인 및 증분 동기화
-	lastTradeDate, err := b.service.repo.GetLastTradeDate(ctx)
+	// 기존 데이터 확인 및 증분 동기화 (계좌별)
+	accountID := accountNo + "-" + accountProductCode
+	lastTradeDate, err := b.service.repo.GetLastTradeDate(ctx, accountID)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to get last trade date, doing full sync")
 	} else if !lastTradeDate.IsZero() && lastTradeDate.Year() > 1970 {
@@ -76,8 +103,8 @@ func (b *KISAuditBuilder) BuildFromKIS(ctx context.Context, accountNo, accountPr
 		Str("end_date", endDate.Format("2006-01-02")).
 		Msg("Building audit data from KIS")
 
-	// 1. KIS에서 체결 내역 조회
-	orders, err := b.kisClient.REST.GetFilledOrdersByDateRange(ctx, accountNo, accountProductCode, startDate, endDate)
+	// 1. KIS에서 체결 내역 조회 (백필: 주문/시세보다 후순위)
+	orders, err := b.kisClient.REST.GetFilledOrdersByDateRange(kis.WithPriority(ctx, kis.PriorityBackfill), accountNo, accountProductCode, startDate, endDate)
 	if err != nil {
 		return fmt.Errorf("get filled orders: %w", err)
 	}
@@ -89,47 +116,57 @@ func (b *KISAuditBuilder) BuildFromKIS(ctx context.Context, accountNo, accountPr
 		return nil
 	}
 
-	// 2. 종목별로 그룹화 (매수/매도 매칭용)
-	tradesBySymbol := make(map[string][]*audit.Trade)
+	// 2. 종목별로 그룹화 (매수/매도 lot 매칭용)
+	fillsBySymbol := make(map[string][]execution.LotFill)
 
 	for _, order := range orders {
 		// Parse order data
 		qty, _ := strconv.ParseInt(order.TotalExecQty, 10, 64)
-		price, _ := strconv.ParseFloat(order.AvgExecPrice, 64)
+		price, _ := decimal.NewFromString(order.AvgExecPrice)
 
-		if qty == 0 || price == 0 {
+		if qty == 0 || price.IsZero() {
 			continue
 		}
 
-		// Parse date
-		orderDate, err := time.Parse("20060102", order.OrderDate)
+		// 총체결금액 기준 단가 (체결평균가는 반올림됨)
+		if amount, err := decimal.NewFromString(order.TotalExecAmount); err == nil && amount.IsPositive() {
+			price = amount.Div(decimal.NewFromInt(qty))
+		}
+
+		// Parse date (+ 주문시간: 같은 날 매수→매도 순서 보존)
+		orderTS, err := time.Parse("20060102150405", order.OrderDate+order.OrderTime)
 		if err != nil {
-			log.Warn().Err(err).Str("order_date", order.OrderDate).Msg("Failed to parse order date")
-			continue
+			orderTS, err = time.Parse("20060102", order.OrderDate)
+			if err != nil {
+				log.Warn().Err(err).Str("order_date", order.OrderDate).Msg("Failed to parse order date")
+				continue
+			}
 		}
 
 		// Determine side (01:매도, 02:매수)
-		side := "BUY"
+		side := execution.SideBuy
 		if order.OrderSide == "01" {
-			side = "SELL"
+			side = execution.SideSell
 		}
 
-		trade := &audit.Trade{
-			Symbol:    order.StockCode,
-			Side:      side,
-			Quantity:  int(qty),
-			Price:     int64(price),
-			EntryDate: orderDate,
-			ExitDate:  orderDate,
-		}
-
-		tradesBySymbol[order.StockCode] = append(tradesBySymbol[order.StockCode], trade)
+		fillsBySymbol[order.StockCode] = append(fillsBySymbol[order.StockCode], execution.LotFill{
+			Side:  side,
+			Qty:   qty,
+			Price: price,
+			TS:    orderTS,
+		})
 	}
 
-	// 3. 매수/매도 매칭하여 trade_history 생성
+	// 시장 구분 (거래세율)
+	markets := b.loadMarkets(ctx, fillsBySymbol)
+
+	// 3. 매수/매도 lot 매칭하여 trade_history 생성
 	var tradeHistories []audit.Trade
-	for symbol, trades := range tradesBySymbol {
-		matched := b.matchTrades(symbol, trades)
+	for symbol, fills := range fillsBySymbol {
+		for i := range fills {
+			fills[i].Market = markets[symbol]
+		}
+		matched := b.matchTrades(accountID, symbol, fills)
 		tradeHistories = append(tradeHistories, matched...)
 	}
 
@@ -172,62 +209,68 @@ func (b *KISAuditBuilder) BuildFromKIS(ctx context.Context, accountNo, accountPr
 // Trade Matching
 // =============================================================================
 
-// matchTrades 매수/매도 매칭 (FIFO)
-func (b *KISAuditBuilder) matchTrades(symbol string, trades []*audit.Trade) []audit.Trade {
-	var buyQueue []*audit.Trade
-	var matched []audit.Trade
+// matchTrades 매수/매도 lot 매칭 (FIFO / 이동평균, 수수료·거래세 반영)
+func (b *KISAuditBuilder) matchTrades(accountID, symbol string, fills []execution.LotFill) []audit.Trade {
+	execution.SortLotFills(fills)
+	book := execution.NewLotBook(b.costMethod, b.costModel)
 
-	// 날짜순 정렬
-	// TODO: 더 정교한 정렬 필요
+	var matched []audit.Trade
+	for _, fill := range fills {
+		lots, err := book.Apply(fill)
+		if errors.Is(err, execution.ErrLedgerOversell) {
+			// 조회 기간 이전 매수분 매도 → 취득원가 불명, 매칭 가능한 수량만 기록
+			log.Warn().
+				Str("symbol", symbol).
+				Int64("sell_qty", fill.Qty).
+				Time("ts", fill.TS).
+				Msg("Sell exceeds matched buy lots (bought before sync range?)")
+		}
 
-	for _, trade := range trades {
-		if trade.Side == "BUY" {
-			buyQueue = append(buyQueue, trade)
-		} else if trade.Side == "SELL" {
-			// 매도 시 FIFO로 매칭
-			sellQty := trade.Quantity
-			sellPrice := trade.Price
-
-			for sellQty > 0 && len(buyQueue) > 0 {
-				buy := buyQueue[0]
-
-				matchQty := buy.Quantity
-				if sellQty < matchQty {
-					matchQty = sellQty
-				}
-
-				// 매칭된 거래 생성
-				pnl := float64(matchQty) * (float64(sellPrice) - float64(buy.Price))
-				pnlPercent := (float64(sellPrice) - float64(buy.Price)) / float64(buy.Price)
-				holdDays := int(trade.ExitDate.Sub(buy.EntryDate).Hours() / 24)
-
-				matched = append(matched, audit.Trade{
-					Symbol:     symbol,
-					Side:       "SELL",
-					Quantity:   matchQty,
-					Price:      sellPrice,
-					PnL:        pnl,
-					PnLPercent: pnlPercent,
-					EntryDate:  buy.EntryDate,
-					ExitDate:   trade.ExitDate,
-					HoldDays:   holdDays,
-				})
-
-				// 수량 차감
-				buy.Quantity -= matchQty
-				sellQty -= matchQty
-
-				// 매수 수량이 0이면 큐에서 제거
-				if buy.Quantity == 0 {
-					buyQueue = buyQueue[1:]
-				}
-			}
+		for _, lot := range lots {
+			matched = append(matched, audit.Trade{
+				Symbol:     symbol,
+				Side:       "SELL",
+				Quantity:   int(lot.Qty),
+				Price:      lot.ExitPrice.Round(0).IntPart(),
+				EntryPrice: lot.EntryPrice.Round(0).IntPart(),
+				PnL:        lot.PnL.InexactFloat64(),
+				PnLPercent: lot.PnLPct / 100.0,
+				Fee:        lot.Fee.InexactFloat64(),
+				Tax:        lot.Tax.InexactFloat64(),
+				EntryDate:  lot.OpenTS,
+				ExitDate:   lot.CloseTS,
+				HoldDays:   lot.HoldDays,
+				AccountID:  accountID,
+			})
 		}
 	}
 
 	return matched
 }
 
+// loadMarkets loads market by symbol ("" → KOSDAQ 세율)
+func (b *KISAuditBuilder) loadMarkets(ctx context.Context, fillsBySymbol map[string][]execution.LotFill) map[string]string {
+	markets := make(map[string]string, len(fillsBySymbol))
+	if b.symbolReader == nil {
+		return markets
+	}
+
+	symbols := make([]string, 0, len(fillsBySymbol))
+	for symbol := range fillsBySymbol {
+		symbols = append(symbols, symbol)
+	}
+
+	infos, err := b.symbolReader.LoadSymbolInfo(ctx, symbols)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load symbol markets, using default tax rate")
+		return markets
+	}
+	for symbol, info := range infos {
+		markets[symbol] = info.Market
+	}
+	return markets
+}
+
 // =============================================================================
 // Daily PnL Calculation
 // =============================================================================
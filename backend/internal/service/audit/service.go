@@ -16,6 +16,9 @@ import (
 // Service 성과 분석 서비스
 type Service struct {
 	repo audit.Repository
+
+	// 지수 일봉 (data.index_prices) → audit.benchmark_data 동기화 (optional)
+	indexReader IndexPriceReader
 }
 
 // NewService 새 서비스 생성
@@ -64,8 +67,15 @@ func (s *Service) GeneratePerformanceReport(ctx context.Context, period audit.Pe
 
 	tradingMetrics := CalculateTradingMetrics(trades)
 
+	// 벤치마크 동기화 (지수 일봉 → audit.benchmark_data)
+	if s.indexReader != nil {
+		if _, err := s.SyncBenchmarks(ctx, startDate, endDate); err != nil {
+			log.Warn().Err(err).Msg("Failed to sync benchmark data")
+		}
+	}
+
 	// 벤치마크 비교 (KOSPI)
-	benchmarkReturns, err := s.repo.GetBenchmarkReturns(ctx, "KOSPI", startDate, endDate)
+	benchmarkReturns, err := s.repo.GetBenchmarkReturns(ctx, benchmarkCode, startDate, endDate)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to get benchmark returns")
 		benchmarkReturns = nil
@@ -75,7 +85,14 @@ func (s *Service) GeneratePerformanceReport(ctx context.Context, period audit.Pe
 	if len(benchmarkReturns) > 0 {
 		benchmark = CalculateTotalReturn(benchmarkReturns)
 		alpha = CalculateAlpha(totalReturn, benchmark)
-		beta = CalculateBeta(dailyReturns, benchmarkReturns)
+
+		// 베타는 같은 날짜의 수익률끼리 비교
+		portfolioAligned, benchmarkAligned, err := s.repo.GetAlignedReturns(ctx, benchmarkCode, startDate, endDate)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to get aligned returns")
+		} else {
+			beta = CalculateBeta(portfolioAligned, benchmarkAligned)
+		}
 	}
 
 	report := &audit.PerformanceReport{
@@ -106,6 +123,30 @@ func (s *Service) GeneratePerformanceReport(ctx context.Context, period audit.Pe
 	return report, nil
 }
 
+// GenerateAccountReport 계좌별 + 통합 실현 성과 리포트 생성 (audit.trade_history 기준)
+func (s *Service) GenerateAccountReport(ctx context.Context, period audit.Period) (*audit.AccountReport, error) {
+	if !period.IsValid() {
+		return nil, audit.ErrInvalidPeriod
+	}
+
+	startDate, endDate := s.calculateDateRange(period)
+
+	trades, err := s.repo.GetTrades(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	consolidated, accounts := CalculateAccountPerformances(trades)
+
+	return &audit.AccountReport{
+		Period:       period,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Consolidated: consolidated,
+		Accounts:     accounts,
+	}, nil
+}
+
 // GetPerformanceReport 성과 리포트 조회
 func (s *Service) GetPerformanceReport(ctx context.Context, period audit.Period) (*audit.PerformanceReport, error) {
 	if !period.IsValid() {
@@ -44,6 +44,7 @@ func (w *TradeWriter) SaveExitTrade(ctx context.Context, event *execution.ExitEv
 		ExitDate:   event.ExitTS,
 		HoldDays:   holdDays,
 		ExitReason: event.ExitReasonCode,
+		AccountID:  event.AccountID,
 	}
 
 	// Save to audit.trade_history
@@ -2,6 +2,7 @@ package audit
 
 import (
 	"math"
+	"sort"
 
 	"github.com/wonny/aegis/v14/internal/domain/audit"
 )
@@ -189,3 +190,49 @@ func CalculateTradingMetrics(trades []audit.Trade) TradingMetrics {
 		LargestLoss:       largestLoss,
 	}
 }
+
+// ConsolidatedAccountID 통합 성과 계좌 ID
+const ConsolidatedAccountID = "ALL"
+
+// CalculateAccountPerformance 계좌 실현 성과 계산
+func CalculateAccountPerformance(accountID string, trades []audit.Trade) audit.AccountPerformance {
+	avgWin, avgLoss := CalculateAvgWinLoss(trades)
+
+	perf := audit.AccountPerformance{
+		AccountID:    accountID,
+		TotalTrades:  len(trades),
+		WinRate:      sanitizeFloat(CalculateWinRate(trades)),
+		AvgWin:       sanitizeFloat(avgWin),
+		AvgLoss:      sanitizeFloat(avgLoss),
+		ProfitFactor: sanitizeFloat(CalculateProfitFactor(trades)),
+		AvgHoldDays:  CalculateAvgHoldDays(trades),
+	}
+	for _, t := range trades {
+		perf.RealizedPnL += t.PnL
+		perf.Fees += t.Fee
+		perf.Taxes += t.Tax
+	}
+
+	return perf
+}
+
+// CalculateAccountPerformances 계좌별 + 통합 성과 계산 (계좌는 account_id 오름차순)
+func CalculateAccountPerformances(trades []audit.Trade) (consolidated audit.AccountPerformance, accounts []audit.AccountPerformance) {
+	byAccount := make(map[string][]audit.Trade)
+	for _, t := range trades {
+		byAccount[t.AccountID] = append(byAccount[t.AccountID], t)
+	}
+
+	ids := make([]string, 0, len(byAccount))
+	for id := range byAccount {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	accounts = make([]audit.AccountPerformance, 0, len(ids))
+	for _, id := range ids {
+		accounts = append(accounts, CalculateAccountPerformance(id, byAccount[id]))
+	}
+
+	return CalculateAccountPerformance(ConsolidatedAccountID, trades), accounts
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/wonny/aegis/v14/internal/domain/audit"
+)
+
+// TestCalculateAccountPerformances tests per-account and consolidated realized performance
+func TestCalculateAccountPerformances(t *testing.T) {
+	trades := []audit.Trade{
+		{Symbol: "005930", PnL: 10_000, Fee: 30, Tax: 300, AccountID: "22222222-01"},
+		{Symbol: "000660", PnL: -4_000, Fee: 20, Tax: 200, AccountID: "11111111-01"},
+		{Symbol: "035720", PnL: 6_000, Fee: 10, Tax: 100, AccountID: "11111111-01"},
+	}
+
+	consolidated, accounts := CalculateAccountPerformances(trades)
+
+	if consolidated.AccountID != ConsolidatedAccountID || consolidated.TotalTrades != 3 {
+		t.Errorf("Expected consolidated 3 trades, got %+v", consolidated)
+	}
+	if consolidated.RealizedPnL != 12_000 || consolidated.Fees != 60 || consolidated.Taxes != 600 {
+		t.Errorf("Expected consolidated pnl 12000 / fees 60 / taxes 600, got %+v", consolidated)
+	}
+
+	if len(accounts) != 2 || accounts[0].AccountID != "11111111-01" || accounts[1].AccountID != "22222222-01" {
+		t.Fatalf("Expected 2 accounts sorted by id, got %+v", accounts)
+	}
+	if accounts[0].TotalTrades != 2 || accounts[0].RealizedPnL != 2_000 || accounts[0].WinRate != 0.5 {
+		t.Errorf("Expected 2 trades / pnl 2000 / win rate 0.5, got %+v", accounts[0])
+	}
+	if accounts[0].ProfitFactor != 1.5 {
+		t.Errorf("Expected profit factor 1.5, got %f", accounts[0].ProfitFactor)
+	}
+}
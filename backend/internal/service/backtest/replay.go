@@ -0,0 +1,367 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/backtest"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+	exitservice "github.com/wonny/aegis/v14/internal/service/exit"
+)
+
+const (
+	atrPeriod        = 14 // ATR 기간 (일봉)
+	atrLookbackDays  = 40 // ATR 계산용 과거 조회 (달력일)
+	maxFillsPerPoint = 5  // 동일 가격 지점 연속 트리거 상한 (TP1 → TP2 등)
+)
+
+// 일봉 경로 근사 시각 (KST, 분)
+var dailyPathMinutes = [4]int{9 * 60, 11 * 60, 13*60 + 30, 15*60 + 20}
+
+// pricePoint 리플레이 평가 지점
+type pricePoint struct {
+	ts    time.Time
+	price decimal.Decimal
+}
+
+// dailyMark 일별 포지션 가치 (실현 대금 + 잔량 평가)
+type dailyMark struct {
+	date  time.Time
+	value decimal.Decimal
+}
+
+// entryReplay 진입 1건 리플레이 결과
+type entryReplay struct {
+	trade     backtest.TradeResult
+	entryCost decimal.Decimal // 진입금액 + 매수 수수료
+	marks     []dailyMark
+}
+
+// replayEntry replays one entry over daily bars or ticks
+func (s *Service) replayEntry(
+	ctx context.Context,
+	profile *exit.ExitProfile,
+	entry backtest.Entry,
+	market string,
+	source backtest.Source,
+	endDate time.Time,
+	slippage decimal.Decimal,
+) (*entryReplay, error) {
+	entryDay := kstDate(entry.EntryDate)
+	rp := &entryReplay{trade: backtest.TradeResult{
+		Symbol:    entry.Symbol,
+		Market:    market,
+		EntryDate: entryDay,
+		Qty:       entry.Qty,
+		Fills:     []backtest.Fill{},
+	}}
+
+	// 1. Daily bars (ATR, 진입가, DAILY 경로)
+	bars, entryIdx, err := s.loadDailyBars(ctx, entry.Symbol, entryDay, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Entry price (요청값 > 진입일 종가)
+	switch {
+	case entry.EntryPrice != nil:
+		rp.trade.EntryPrice = *entry.EntryPrice
+	case entryIdx >= 0:
+		rp.trade.EntryPrice = decimal.NewFromFloat(bars[entryIdx].ClosePrice)
+	default:
+		return skipped(rp, "no daily price on or after entry date"), nil
+	}
+
+	// 진입 시각: 진입일 종가 (다음 평가부터 Exit 적용)
+	entryTS := entryDay.Add(15*time.Hour + 30*time.Minute)
+	entryAmount := rp.trade.EntryPrice.Mul(decimal.NewFromInt(entry.Qty))
+	// 수수료/세금은 체결일 세율 (KRXTaxSchedule)
+	entryCost := execution.DefaultCostModel().Cost(execution.SideBuy, market, entryTS, entryAmount)
+	rp.trade.EntryFee = entryCost.Commission.Round(0)
+	rp.entryCost = entryAmount.Add(rp.trade.EntryFee)
+
+	// 3. ATR (진입 직전 일봉 기준)
+	var atr, atrWon *decimal.Decimal
+	if entryIdx > 0 {
+		if won, pct, ok := dailyATR(bars[:entryIdx+1]); ok {
+			atr, atrWon = decimalPtr(pct), decimalPtr(won)
+			rp.trade.ATR = &pct
+		}
+	}
+
+	// 4. Price path
+	var points []pricePoint
+	switch source {
+	case backtest.SourceTick:
+		points, err = s.tickPath(ctx, entry.Symbol, entryTS, endDate)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if entryIdx >= 0 {
+			points = dailyPath(bars[entryIdx+1:])
+		}
+	}
+	if len(points) == 0 {
+		return skipped(rp, "no prices after entry"), nil
+	}
+
+	// 5. Replay through real Exit Engine
+	res, err := replayPath(ctx, profile, entry.Symbol, entry.Qty, rp.trade.EntryPrice, entryTS, atr, atrWon, points, market, slippage)
+	if err != nil {
+		return nil, err
+	}
+	rp.marks = append([]dailyMark{{date: entryDay, value: rp.entryCost}}, res.marks...) // 진입일: 진입금액 기준
+
+	// 6. Summary
+	rp.trade.Fills = res.fills
+	rp.trade.RemainingQty = res.qty
+	rp.trade.LastPrice = res.lastPrice
+	rp.trade.Status = backtest.TradeStatusOpen
+	holdUntil := res.lastTS
+	if len(rp.trade.Fills) > 0 {
+		last := rp.trade.Fills[len(rp.trade.Fills)-1]
+		rp.trade.ExitReason = last.ReasonCode
+		if rp.trade.RemainingQty == 0 {
+			rp.trade.Status = backtest.TradeStatusClosed
+			rp.trade.ExitDate = &last.TS
+			holdUntil = last.TS
+		}
+	}
+	rp.trade.HoldDays = calendar.TradingDaysBetween(entryTS, holdUntil)
+
+	final := res.proceeds.Add(res.lastPrice.Mul(decimal.NewFromInt(rp.trade.RemainingQty)))
+	rp.trade.NetPnL = final.Sub(rp.entryCost).Round(0)
+	rp.trade.PnLPct, _ = rp.trade.NetPnL.Div(rp.entryCost).Mul(decimal.NewFromInt(100)).Round(4).Float64()
+
+	return rp, nil
+}
+
+// loadDailyBars loads ascending daily bars from ATR lookback to endDate
+// entryIdx: 진입일 이후 첫 일봉 index (-1 = 없음)
+func (s *Service) loadDailyBars(ctx context.Context, symbol string, entryDay, endDate time.Time) ([]*fetcher.DailyPrice, int, error) {
+	bars, err := s.dailyRepo.GetRange(ctx, symbol, entryDay.AddDate(0, 0, -atrLookbackDays), endDate)
+	if err != nil {
+		return nil, -1, fmt.Errorf("get daily prices: %w", err)
+	}
+	sort.Slice(bars, func(i, j int) bool { return bars[i].TradeDate.Before(bars[j].TradeDate) })
+
+	for i, b := range bars {
+		if !kstDate(b.TradeDate).Before(entryDay) {
+			return bars, i, nil
+		}
+	}
+	return bars, -1, nil
+}
+
+// pathResult 가격 경로 리플레이 결과
+type pathResult struct {
+	fills     []backtest.Fill
+	proceeds  decimal.Decimal // 매도 대금 - 수수료 - 세금
+	qty       int64           // 잔량
+	phase     string          // 최종 FSM phase
+	lastPrice decimal.Decimal
+	lastTS    time.Time
+	marks     []dailyMark
+}
+
+// replayPath replays a price path through the real Exit Engine (trigger 평가 + FSM 전이 + 체결 시뮬레이션)
+func replayPath(
+	ctx context.Context,
+	profile *exit.ExitProfile,
+	symbol string,
+	qty int64,
+	avgPrice decimal.Decimal,
+	entryTS time.Time,
+	atr *decimal.Decimal,
+	atrWon *decimal.Decimal,
+	points []pricePoint,
+	market string,
+	slippage decimal.Decimal,
+) (*pathResult, error) {
+	replayer := exitservice.NewReplayer(profile, symbol, qty, avgPrice, entryTS, atr, atrWon)
+	res := &pathResult{
+		fills:     []backtest.Fill{},
+		proceeds:  decimal.Zero,
+		lastPrice: avgPrice,
+	}
+	addMark := func() {
+		res.marks = append(res.marks, dailyMark{
+			date:  kstDate(res.lastTS),
+			value: res.proceeds.Add(res.lastPrice.Mul(decimal.NewFromInt(replayer.Qty()))),
+		})
+	}
+
+	for _, p := range points {
+		if replayer.Qty() == 0 {
+			break
+		}
+
+		// 일자 변경 시 전일 가치 기록
+		if !res.lastTS.IsZero() && !kstDate(p.ts).Equal(kstDate(res.lastTS)) {
+			addMark()
+		}
+		res.lastTS, res.lastPrice = p.ts, p.price
+
+		for n := 0; n < maxFillsPerPoint && replayer.Qty() > 0; n++ {
+			phase := replayer.Phase(ctx)
+			trigger, err := replayer.Evaluate(ctx, p.ts, p.price)
+			if err != nil {
+				return nil, fmt.Errorf("evaluate: %w", err)
+			}
+			if trigger == nil {
+				break
+			}
+
+			fill := simulateFill(p, phase, trigger, market, slippage)
+			if err := replayer.ApplyFill(ctx, trigger, fill.FillPrice); err != nil {
+				return nil, fmt.Errorf("apply fill: %w", err)
+			}
+			fill.NextPhase = replayer.Phase(ctx)
+
+			amount := fill.FillPrice.Mul(decimal.NewFromInt(fill.Qty))
+			res.proceeds = res.proceeds.Add(amount).Sub(fill.Fee).Sub(fill.Tax)
+			res.fills = append(res.fills, fill)
+		}
+	}
+	if !res.lastTS.IsZero() {
+		addMark()
+	}
+
+	res.qty = replayer.Qty()
+	res.phase = replayer.Phase(ctx)
+	return res, nil
+}
+
+// skipped marks entry as skipped (가격 데이터 없음)
+func skipped(rp *entryReplay, msg string) *entryReplay {
+	rp.trade.Status = backtest.TradeStatusSkipped
+	rp.trade.RemainingQty = rp.trade.Qty
+	rp.trade.Message = msg
+	return rp
+}
+
+// simulateFill simulates exit fill with slippage/fee/tax
+// - MKT: 평가 가격에서 슬리피지만큼 불리하게 체결
+// - LMT: 지정가(평가 가격) 체결
+func simulateFill(p pricePoint, phase string, trigger *exit.ExitTrigger, market string, slippage decimal.Decimal) backtest.Fill {
+	var fillPrice decimal.Decimal
+	if execution.OrderTypeNeedsPrice(trigger.OrderType) && trigger.LimitPrice != nil {
+		fillPrice = *trigger.LimitPrice
+	} else {
+		fillPrice = p.price.Mul(decimal.NewFromInt(1).Sub(slippage)).Floor()
+	}
+
+	amount := fillPrice.Mul(decimal.NewFromInt(trigger.Qty))
+	cost := execution.DefaultCostModel().Cost(execution.SideSell, market, p.ts, amount)
+
+	return backtest.Fill{
+		TS:           p.ts,
+		ReasonCode:   trigger.ReasonCode,
+		Phase:        phase,
+		Qty:          trigger.Qty,
+		TriggerPrice: p.price,
+		FillPrice:    fillPrice,
+		Fee:          cost.Commission.Round(0),
+		Tax:          cost.Tax().Round(0),
+	}
+}
+
+// dailyPath approximates intraday path from OHLC bars
+// 양봉: O → L → H → C, 음봉: O → H → L → C (통상적인 OHLC 경로 가정)
+func dailyPath(bars []*fetcher.DailyPrice) []pricePoint {
+	points := make([]pricePoint, 0, len(bars)*4)
+	for _, b := range bars {
+		day := kstDate(b.TradeDate)
+		if !calendar.IsTradingDay(day) {
+			continue
+		}
+
+		prices := [4]float64{b.OpenPrice, b.LowPrice, b.HighPrice, b.ClosePrice}
+		if b.ClosePrice < b.OpenPrice {
+			prices[1], prices[2] = b.HighPrice, b.LowPrice
+		}
+
+		for i, v := range prices {
+			if v <= 0 {
+				continue
+			}
+			points = append(points, pricePoint{
+				ts:    day.Add(time.Duration(dailyPathMinutes[i]) * time.Minute),
+				price: decimal.NewFromFloat(v),
+			})
+		}
+	}
+	return points
+}
+
+// tickPath loads stored ticks after entry (bid 우선, 없으면 체결가)
+func (s *Service) tickPath(ctx context.Context, symbol string, from, to time.Time) ([]pricePoint, error) {
+	if s.tickRepo == nil {
+		return nil, nil
+	}
+	ticks, err := s.tickRepo.GetTicksInTimeRange(ctx, symbol, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get ticks: %w", err)
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i].TS.Before(ticks[j].TS) })
+
+	points := make([]pricePoint, 0, len(ticks))
+	for _, t := range ticks {
+		if !t.TS.After(from) {
+			continue
+		}
+		px := t.LastPrice
+		if t.BidPrice != nil && *t.BidPrice > 0 {
+			px = *t.BidPrice
+		}
+		if px <= 0 {
+			continue
+		}
+		points = append(points, pricePoint{ts: t.TS, price: decimal.NewFromInt(px)})
+	}
+	return points, nil
+}
+
+// dailyATR calculates ATR(14) in won and ATR / last close from ascending daily bars
+func dailyATR(bars []*fetcher.DailyPrice) (float64, float64, bool) {
+	if len(bars) < 2 {
+		return 0, 0, false
+	}
+
+	start := 1
+	if len(bars)-1 > atrPeriod {
+		start = len(bars) - atrPeriod
+	}
+
+	var sum float64
+	for i := start; i < len(bars); i++ {
+		prevClose := bars[i-1].ClosePrice
+		tr := bars[i].HighPrice - bars[i].LowPrice
+		if v := bars[i].HighPrice - prevClose; v > tr {
+			tr = v
+		}
+		if v := prevClose - bars[i].LowPrice; v > tr {
+			tr = v
+		}
+		sum += tr
+	}
+
+	lastClose := bars[len(bars)-1].ClosePrice
+	if lastClose <= 0 {
+		return 0, 0, false
+	}
+	atr := sum / float64(len(bars)-start)
+	return atr, atr / lastClose, true
+}
+
+func decimalPtr(v float64) *decimal.Decimal {
+	d := decimal.NewFromFloat(v)
+	return &d
+}
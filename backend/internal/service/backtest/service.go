@@ -0,0 +1,305 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/audit"
+	"github.com/wonny/aegis/v14/internal/domain/backtest"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+	auditservice "github.com/wonny/aegis/v14/internal/service/audit"
+)
+
+// Service replays historical entries through the Exit Engine (profile 비교용 백테스트)
+type Service struct {
+	dailyRepo   fetcher.PriceRepository
+	tickRepo    price.TickRepository
+	profileRepo exit.ExitProfileRepository
+
+	// Optional: 시장 구분 (매도 세금 계산, nil이면 요청 Market 또는 KOSDAQ 세율)
+	symbolReader risk.SymbolInfoReader
+
+	// Optional: 라이브 포지션 조회 (what-if 시뮬레이션)
+	positionResolver PositionResolver
+}
+
+// NewService creates a new backtest service
+func NewService(
+	dailyRepo fetcher.PriceRepository,
+	tickRepo price.TickRepository,
+	profileRepo exit.ExitProfileRepository,
+) *Service {
+	return &Service{
+		dailyRepo:   dailyRepo,
+		tickRepo:    tickRepo,
+		profileRepo: profileRepo,
+	}
+}
+
+// SetSymbolInfoReader sets market lookup for sell tax (optional)
+func (s *Service) SetSymbolInfoReader(reader risk.SymbolInfoReader) {
+	s.symbolReader = reader
+}
+
+// Run replays all entries and builds per-trade results + performance report
+func (s *Service) Run(ctx context.Context, req *backtest.Request) (*backtest.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.resolveProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate := time.Now()
+	if req.EndDate != nil {
+		endDate = *req.EndDate
+	}
+
+	markets := s.loadMarkets(ctx, req.Entries)
+	slippage := decimal.NewFromFloat(*req.SlippageBps).Div(decimal.NewFromInt(10000))
+
+	result := &backtest.Result{
+		ProfileID:   profile.ProfileID,
+		Profile:     profile.Config,
+		Source:      req.Source,
+		SlippageBps: *req.SlippageBps,
+		EndDate:     endDate,
+		Trades:      make([]backtest.TradeResult, 0, len(req.Entries)),
+		RanAt:       time.Now(),
+	}
+
+	var replays []*entryReplay
+	for _, entry := range req.Entries {
+		market := entry.Market
+		if market == "" {
+			market = markets[entry.Symbol]
+		}
+
+		rp, err := s.replayEntry(ctx, profile, entry, market, req.Source, endDate, slippage)
+		if err != nil {
+			return nil, fmt.Errorf("replay %s: %w", entry.Symbol, err)
+		}
+
+		result.Trades = append(result.Trades, rp.trade)
+		if rp.trade.Status != backtest.TradeStatusSkipped {
+			replays = append(replays, rp)
+		}
+	}
+
+	for _, rp := range replays {
+		if result.StartDate.IsZero() || rp.trade.EntryDate.Before(result.StartDate) {
+			result.StartDate = rp.trade.EntryDate
+		}
+	}
+	result.Equity = buildEquityCurve(replays)
+	result.Report = buildReport(result, replays)
+
+	log.Info().
+		Str("profile_id", profile.ProfileID).
+		Str("source", string(req.Source)).
+		Int("entries", len(req.Entries)).
+		Int("trades", result.Report.TotalTrades).
+		Float64("total_return", result.Report.TotalReturn).
+		Msg("✅ Backtest completed")
+
+	return result, nil
+}
+
+// resolveProfile inline config > profile_id > DB default
+func (s *Service) resolveProfile(ctx context.Context, req *backtest.Request) (*exit.ExitProfile, error) {
+	if req.Profile != nil {
+		return &exit.ExitProfile{
+			ProfileID: "inline",
+			Name:      "Inline Profile",
+			Config:    *req.Profile,
+			IsActive:  true,
+		}, nil
+	}
+
+	if req.ProfileID != "" {
+		profile, err := s.profileRepo.GetProfile(ctx, req.ProfileID)
+		if err != nil {
+			if errors.Is(err, exit.ErrProfileNotFound) {
+				return nil, backtest.ErrProfileNotFound
+			}
+			return nil, fmt.Errorf("get profile: %w", err)
+		}
+		return profile, nil
+	}
+
+	profile, err := s.profileRepo.GetDefaultProfile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get default profile: %w", err)
+	}
+	return profile, nil
+}
+
+// loadMarkets looks up markets for entries without explicit market (best-effort)
+func (s *Service) loadMarkets(ctx context.Context, entries []backtest.Entry) map[string]string {
+	markets := make(map[string]string)
+	if s.symbolReader == nil {
+		return markets
+	}
+
+	var symbols []string
+	for _, e := range entries {
+		if e.Market == "" {
+			symbols = append(symbols, e.Symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		return markets
+	}
+
+	infos, err := s.symbolReader.LoadSymbolInfo(ctx, symbols)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load symbol markets, using KOSDAQ tax rate")
+		return markets
+	}
+	for symbol, info := range infos {
+		markets[symbol] = info.Market
+	}
+	return markets
+}
+
+// =============================================================================
+// Equity curve / Report
+// =============================================================================
+
+// buildEquityCurve aggregates daily position values over the union of replay dates
+// 진입 전/청산 후 구간은 현금(진입금액/청산대금)으로 유지
+func buildEquityCurve(replays []*entryReplay) []backtest.EquityPoint {
+	dateSet := make(map[time.Time]bool)
+	for _, rp := range replays {
+		for _, m := range rp.marks {
+			dateSet[m.date] = true
+		}
+	}
+
+	dates := make([]time.Time, 0, len(dateSet))
+	for d := range dateSet {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	curve := make([]backtest.EquityPoint, 0, len(dates))
+	idx := make([]int, len(replays))
+	for _, d := range dates {
+		equity := decimal.Zero
+		for i, rp := range replays {
+			for idx[i] < len(rp.marks) && !rp.marks[idx[i]].date.After(d) {
+				idx[i]++
+			}
+			if idx[i] == 0 {
+				equity = equity.Add(rp.entryCost) // 진입 전: 현금 대기
+			} else {
+				equity = equity.Add(rp.marks[idx[i]-1].value)
+			}
+		}
+		curve = append(curve, backtest.EquityPoint{Date: d, Equity: equity})
+	}
+
+	return curve
+}
+
+// buildReport builds audit.PerformanceReport from equity curve and trades
+func buildReport(result *backtest.Result, replays []*entryReplay) audit.PerformanceReport {
+	report := audit.PerformanceReport{
+		Period:    audit.PeriodBacktest,
+		StartDate: result.StartDate,
+		EndDate:   result.EndDate,
+	}
+
+	var dailyReturns []float64
+	for i := 1; i < len(result.Equity); i++ {
+		prev := result.Equity[i-1].Equity
+		if !prev.IsPositive() {
+			continue
+		}
+		r, _ := result.Equity[i].Equity.Div(prev).Sub(decimal.NewFromInt(1)).Float64()
+		dailyReturns = append(dailyReturns, r)
+	}
+
+	trades := make([]audit.Trade, 0, len(replays))
+	for _, rp := range replays {
+		trades = append(trades, toAuditTrade(rp.trade))
+	}
+	metrics := auditservice.CalculateTradingMetrics(trades)
+
+	totalReturn := auditservice.CalculateTotalReturn(dailyReturns)
+	annualReturn := auditservice.CalculateAnnualizedReturn(totalReturn, len(dailyReturns))
+	volatility := auditservice.CalculateVolatility(dailyReturns)
+
+	report.TotalReturn = sanitizeFloat(totalReturn)
+	report.AnnualReturn = sanitizeFloat(annualReturn)
+	report.Volatility = sanitizeFloat(volatility)
+	report.Sharpe = sanitizeFloat(auditservice.CalculateSharpe(annualReturn, volatility))
+	report.Sortino = sanitizeFloat(auditservice.CalculateSortino(dailyReturns))
+	report.MaxDrawdown = sanitizeFloat(auditservice.CalculateMaxDrawdown(dailyReturns))
+	report.WinRate = sanitizeFloat(metrics.WinRate)
+	report.AvgWin = sanitizeFloat(metrics.AvgWin)
+	report.AvgLoss = sanitizeFloat(metrics.AvgLoss)
+	report.ProfitFactor = sanitizeFloat(metrics.ProfitFactor)
+	report.TotalTrades = metrics.TotalTrades
+
+	return report
+}
+
+// toAuditTrade converts a replayed entry to audit.Trade (진입 1건 = 거래 1건)
+func toAuditTrade(t backtest.TradeResult) audit.Trade {
+	exitDate := t.EntryDate
+	if t.ExitDate != nil {
+		exitDate = *t.ExitDate
+	}
+
+	// 평균 청산가 (잔량은 마지막 가격으로 평가)
+	soldQty := t.Qty - t.RemainingQty
+	proceeds := t.LastPrice.Mul(decimal.NewFromInt(t.RemainingQty))
+	for _, f := range t.Fills {
+		proceeds = proceeds.Add(f.FillPrice.Mul(decimal.NewFromInt(f.Qty)))
+	}
+	avgExit := t.LastPrice
+	if soldQty+t.RemainingQty > 0 {
+		avgExit = proceeds.Div(decimal.NewFromInt(soldQty + t.RemainingQty))
+	}
+
+	pnl, _ := t.NetPnL.Float64()
+	return audit.Trade{
+		Symbol:     t.Symbol,
+		Side:       "SELL",
+		Quantity:   int(t.Qty),
+		Price:      avgExit.Round(0).IntPart(),
+		PnL:        pnl,
+		PnLPercent: t.PnLPct,
+		EntryDate:  t.EntryDate,
+		ExitDate:   exitDate,
+		HoldDays:   t.HoldDays,
+		ExitReason: t.ExitReason,
+	}
+}
+
+// sanitizeFloat NaN/Inf → 0 (JSON 직렬화)
+func sanitizeFloat(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+// kstDate returns 00:00 KST of t's KST date
+func kstDate(t time.Time) time.Time {
+	k := t.In(calendar.KST)
+	return time.Date(k.Year(), k.Month(), k.Day(), 0, 0, 0, 0, calendar.KST)
+}
@@ -0,0 +1,207 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/backtest"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// fakeDailyRepo serves fixed daily bars per symbol
+type fakeDailyRepo struct {
+	fetcher.PriceRepository
+	bars map[string][]*fetcher.DailyPrice
+}
+
+func (r *fakeDailyRepo) GetRange(ctx context.Context, stockCode string, from, to time.Time) ([]*fetcher.DailyPrice, error) {
+	var out []*fetcher.DailyPrice
+	for _, b := range r.bars[stockCode] {
+		if !b.TradeDate.Before(from) && !b.TradeDate.After(to) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func bar(day int, o, h, l, c float64) *fetcher.DailyPrice {
+	return &fetcher.DailyPrice{
+		TradeDate:  time.Date(2026, 10, day, 0, 0, 0, 0, calendar.KST),
+		OpenPrice:  o,
+		HighPrice:  h,
+		LowPrice:   l,
+		ClosePrice: c,
+	}
+}
+
+func testProfileConfig() *exit.ExitProfileConfig {
+	stopFloor := 0.006
+	return &exit.ExitProfileConfig{
+		SL1:      exit.TriggerConfig{BasePct: -0.03, QtyPct: 0.5},
+		SL2:      exit.TriggerConfig{BasePct: -0.05, QtyPct: 1.0},
+		TP1:      exit.TriggerConfig{BasePct: 0.05, QtyPct: 0.5, StopFloorProfit: &stopFloor},
+		TP2:      exit.TriggerConfig{BasePct: 0.10, QtyPct: 0.25},
+		TP3:      exit.TriggerConfig{BasePct: 0.15, QtyPct: 0.2, StartTrailing: true},
+		Trailing: exit.TrailingConfig{PctTrail: 0.04},
+	}
+}
+
+// TestRunDaily tests TP1 partial fill → Stop Floor exit over daily bars
+func TestRunDaily(t *testing.T) {
+	repo := &fakeDailyRepo{bars: map[string][]*fetcher.DailyPrice{
+		"005930": {
+			bar(12, 10000, 10100, 9900, 10000),
+			bar(13, 10000, 10700, 9950, 10600), // 양봉: 고가 10700에서 TP1 (+7%)
+			bar(14, 10500, 10550, 9900, 9950),  // 음봉: 9900/9950 연속 이탈 → Stop Floor
+			bar(15, 9950, 10000, 9800, 9900),
+		},
+	}}
+	svc := NewService(repo, nil, nil)
+
+	end := time.Date(2026, 10, 16, 0, 0, 0, 0, calendar.KST)
+	req := &backtest.Request{
+		Profile: testProfileConfig(),
+		EndDate: &end,
+		Entries: []backtest.Entry{
+			{Symbol: "005930", EntryDate: time.Date(2026, 10, 12, 0, 0, 0, 0, calendar.KST), Qty: 100, Market: "KOSPI"},
+			{Symbol: "000660", EntryDate: time.Date(2026, 10, 12, 0, 0, 0, 0, calendar.KST), Qty: 10, Market: "KOSPI"},
+		},
+	}
+
+	result, err := svc.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(result.Trades) != 2 {
+		t.Fatalf("Expected 2 trades, got %d", len(result.Trades))
+	}
+
+	trade := result.Trades[0]
+	if trade.Status != backtest.TradeStatusClosed {
+		t.Fatalf("Expected CLOSED, got %s (%s)", trade.Status, trade.Message)
+	}
+	if len(trade.Fills) != 2 {
+		t.Fatalf("Expected 2 fills, got %d: %+v", len(trade.Fills), trade.Fills)
+	}
+
+	tp1 := trade.Fills[0]
+	if tp1.ReasonCode != exit.ReasonTP1 || tp1.Qty != 50 || !tp1.FillPrice.Equal(decimal.NewFromInt(10700)) {
+		t.Errorf("Expected TP1 50 @ 10700 (LMT), got %s %d @ %s", tp1.ReasonCode, tp1.Qty, tp1.FillPrice)
+	}
+
+	sf := trade.Fills[1]
+	if sf.ReasonCode != exit.ReasonStopFloor || sf.Qty != 50 || sf.Phase != exit.PhaseTP1Done {
+		t.Errorf("Expected STOP_FLOOR 50 in TP1_DONE, got %s %d in %s", sf.ReasonCode, sf.Qty, sf.Phase)
+	}
+	if want := decimal.NewFromInt(9945); !sf.FillPrice.Equal(want) { // 9950 × (1 - 5bp), 내림
+		t.Errorf("Expected MKT fill %s with slippage, got %s", want, sf.FillPrice)
+	}
+
+	if !trade.NetPnL.IsPositive() {
+		t.Errorf("Expected positive net PnL, got %s", trade.NetPnL)
+	}
+	if trade.ExitReason != exit.ReasonStopFloor || trade.HoldDays != 2 {
+		t.Errorf("Expected exit by STOP_FLOOR after 2 days, got %s after %d", trade.ExitReason, trade.HoldDays)
+	}
+
+	if result.Trades[1].Status != backtest.TradeStatusSkipped {
+		t.Errorf("Expected entry without prices to be SKIPPED, got %s", result.Trades[1].Status)
+	}
+	if result.Report.TotalTrades != 1 {
+		t.Errorf("Expected 1 trade in report, got %d", result.Report.TotalTrades)
+	}
+	if result.Report.TotalReturn <= 0 {
+		t.Errorf("Expected positive total return, got %f", result.Report.TotalReturn)
+	}
+}
+
+// TestRunValidation tests request validation
+func TestRunValidation(t *testing.T) {
+	svc := NewService(&fakeDailyRepo{}, nil, nil)
+
+	if _, err := svc.Run(context.Background(), &backtest.Request{}); err != backtest.ErrNoEntries {
+		t.Errorf("Expected ErrNoEntries, got %v", err)
+	}
+
+	req := &backtest.Request{Source: "MINUTE", Entries: []backtest.Entry{{Symbol: "005930"}}}
+	if _, err := svc.Run(context.Background(), req); err != backtest.ErrInvalidSource {
+		t.Errorf("Expected ErrInvalidSource, got %v", err)
+	}
+}
+
+// fakeResolver returns a fixed position and current profile
+type fakeResolver struct {
+	pos     *exit.Position
+	profile *exit.ExitProfile
+}
+
+func (r *fakeResolver) GetPositionWithProfile(ctx context.Context, positionID uuid.UUID) (*exit.Position, *exit.ExitProfile, error) {
+	if positionID != r.pos.PositionID {
+		return nil, nil, exit.ErrPositionNotFound
+	}
+	return r.pos, r.profile, nil
+}
+
+// TestSimulatePosition tests candidate vs current profile on a live position's path
+func TestSimulatePosition(t *testing.T) {
+	repo := &fakeDailyRepo{bars: map[string][]*fetcher.DailyPrice{
+		"005930": {
+			bar(12, 10000, 10100, 9900, 10000),
+			bar(13, 10000, 10700, 9950, 10600),
+			bar(14, 10500, 10550, 9900, 9950),
+		},
+	}}
+
+	// 현재 프로필: TP1 +10% (미도달)
+	currentCfg := testProfileConfig()
+	currentCfg.TP1.BasePct = 0.10
+	currentCfg.TP2.BasePct = 0.20
+	currentCfg.TP3.BasePct = 0.30
+
+	pos := &exit.Position{
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(10000),
+		EntryTS:     time.Date(2026, 10, 12, 14, 0, 0, 0, calendar.KST),
+	}
+	svc := NewService(repo, nil, nil)
+	svc.SetPositionResolver(&fakeResolver{pos: pos, profile: &exit.ExitProfile{ProfileID: "current", Config: *currentCfg}})
+
+	sim, err := svc.SimulatePosition(context.Background(), &backtest.SimulationRequest{
+		PositionID: pos.PositionID,
+		Profile:    testProfileConfig(),
+	})
+	if err != nil {
+		t.Fatalf("SimulatePosition failed: %v", err)
+	}
+
+	if sim.Source != backtest.SourceDaily {
+		t.Errorf("Expected DAILY fallback without ticks, got %s", sim.Source)
+	}
+	if len(sim.Current.Fills) != 0 || sim.Current.RemainingQty != 100 {
+		t.Errorf("Expected no fills for current profile, got %d fills (remaining %d)", len(sim.Current.Fills), sim.Current.RemainingQty)
+	}
+	if len(sim.Candidate.Fills) != 2 {
+		t.Fatalf("Expected 2 candidate fills, got %d", len(sim.Candidate.Fills))
+	}
+	if got := sim.Candidate.Fills[0].NextPhase; got != exit.PhaseTP1Done {
+		t.Errorf("Expected TP1 fill to move phase to TP1_DONE, got %s", got)
+	}
+	if sim.Candidate.FinalPhase != exit.PhaseExited {
+		t.Errorf("Expected candidate final phase EXITED, got %s", sim.Candidate.FinalPhase)
+	}
+	if !sim.RealizedPnLDiff.IsPositive() {
+		t.Errorf("Expected positive realized PnL diff, got %s", sim.RealizedPnLDiff)
+	}
+
+	if _, err := svc.SimulatePosition(context.Background(), &backtest.SimulationRequest{PositionID: uuid.New(), Profile: testProfileConfig()}); err != backtest.ErrPositionNotFound {
+		t.Errorf("Expected ErrPositionNotFound, got %v", err)
+	}
+}
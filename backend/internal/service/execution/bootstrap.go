@@ -64,6 +64,7 @@ func (s *Service) syncUnfilledOrders(ctx context.Context) error {
 			OpenQty:      uo.OpenQty,
 			FilledQty:    uo.FilledQty,
 			Raw:          uo.Raw,
+			AccountID:    s.accountID,
 			UpdatedTS:    time.Now(),
 		}
 
@@ -98,10 +99,7 @@ func (s *Service) bootstrapFills(ctx context.Context) error {
 // recomputeOrderStates recomputes order statuses from fills
 func (s *Service) recomputeOrderStates(ctx context.Context) error {
 	// Load all open orders
-	openOrders, err := s.orderRepo.LoadOrdersByStatus(ctx, []string{
-		execution.OrderStatusSubmitted,
-		execution.OrderStatusPartial,
-	})
+	openOrders, err := s.loadOpenOrders(ctx)
 	if err != nil {
 		return fmt.Errorf("load open orders: %w", err)
 	}
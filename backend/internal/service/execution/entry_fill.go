@@ -0,0 +1,114 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+const entryStrategyID = "REENTRY" // ENTRY intent로 생성된 포지션의 strategy_id
+
+// handleEntryFilled opens a new position when an ENTRY order is fully filled
+// EXIT 주문은 holdings sync에서 처리 (qty 동기화 / ExitEvent)
+func (s *Service) handleEntryFilled(ctx context.Context, order *execution.Order) error {
+	if order.IntentID == uuid.Nil {
+		return nil // Placeholder order (unknown intent)
+	}
+
+	// 1. Load intent (ENTRY only)
+	intent, err := s.intentRepo.GetIntent(ctx, order.IntentID)
+	if err != nil {
+		return fmt.Errorf("get intent: %w", err)
+	}
+
+	if intent.IntentType != execution.IntentTypeEntry {
+		return nil
+	}
+
+	// Ledger mode: 포지션은 BUY 체결 반영 시 이미 생성됨 → hook만 호출
+	if s.ledgerRepo != nil {
+		position, err := s.positionRepo.GetPositionBySymbol(ctx, s.accountID, intent.Symbol, exit.StatusOpen)
+		if err != nil {
+			return fmt.Errorf("get ledger position: %w", err)
+		}
+		if s.entryFillHandler != nil {
+			if err := s.entryFillHandler.OnEntryFilled(ctx, intent, position); err != nil {
+				return fmt.Errorf("entry fill handler: %w", err)
+			}
+		}
+		return nil
+	}
+
+	// 2. Calculate entry avg price from fills
+	avgPrice, entryTS, err := s.calculateEntryAvgPrice(ctx, order.OrderID)
+	if err != nil {
+		return err
+	}
+
+	// 3. Open position (position_id from intent)
+	position := &exit.Position{
+		PositionID:  intent.PositionID,
+		AccountID:   s.accountID,
+		Symbol:      intent.Symbol,
+		Side:        "LONG",
+		Qty:         order.FilledQty,
+		OriginalQty: order.FilledQty,
+		AvgPrice:    avgPrice,
+		EntryTS:     entryTS,
+		ExitMode:    exit.ExitModeEnabled,
+		StrategyID:  entryStrategyID,
+	}
+
+	if err := s.exitPositionRepo.OpenPosition(ctx, position); err != nil {
+		return fmt.Errorf("open position: %w", err)
+	}
+
+	log.Info().
+		Str("intent_id", intent.IntentID.String()).
+		Str("order_id", order.OrderID).
+		Str("position_id", position.PositionID.String()).
+		Str("symbol", position.Symbol).
+		Int64("qty", position.Qty).
+		Str("avg_price", avgPrice.String()).
+		Msg("✅ Position opened from ENTRY fill")
+
+	// 4. Notify hook (Reentry → ENTERED)
+	if s.entryFillHandler != nil {
+		if err := s.entryFillHandler.OnEntryFilled(ctx, intent, position); err != nil {
+			return fmt.Errorf("entry fill handler: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// calculateEntryAvgPrice calculates weighted average fill price and last fill time for an order
+func (s *Service) calculateEntryAvgPrice(ctx context.Context, orderID string) (decimal.Decimal, time.Time, error) {
+	fills, err := s.fillRepo.LoadFills(ctx, orderID)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("load fills: %w", err)
+	}
+
+	totalValue := decimal.Zero
+	totalQty := int64(0)
+	lastTS := time.Time{}
+	for _, fill := range fills {
+		totalValue = totalValue.Add(fill.Price.Mul(decimal.NewFromInt(fill.Qty)))
+		totalQty += fill.Qty
+		if fill.TS.After(lastTS) {
+			lastTS = fill.TS
+		}
+	}
+
+	if totalQty == 0 {
+		return decimal.Zero, time.Time{}, fmt.Errorf("no fills for order %s", orderID)
+	}
+
+	return totalValue.Div(decimal.NewFromInt(totalQty)), lastTS, nil
+}
@@ -13,7 +13,7 @@ import (
 // syncFills syncs fills from KIS since last cursor
 func (s *Service) syncFills(ctx context.Context) error {
 	// 1. Load last cursor
-	cursor, err := s.fillRepo.GetLastCursor(ctx)
+	cursor, err := s.fillRepo.GetLastCursor(ctx, s.accountID)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to load cursor, using default")
 		// Default: today's market open
@@ -73,6 +73,17 @@ func (s *Service) syncFills(ctx context.Context) error {
 			continue
 		}
 
+		// Apply to position ledger (qty/avg cost/realized P&L)
+		if s.ledgerRepo != nil {
+			sideHint := execution.SideFromName(fmt.Sprint(kf.Raw["order_side"]))
+			if err := s.applyOrderFills(ctx, kf.OrderID, kf.Symbol, sideHint); err != nil {
+				log.Error().
+					Err(err).
+					Str("order_id", kf.OrderID).
+					Msg("Failed to apply fills to ledger")
+			}
+		}
+
 		// Update order filled_qty
 		if err := s.orderRepo.UpdateFilledQty(ctx, kf.OrderID, kf.Qty); err != nil {
 			log.Error().
@@ -133,6 +144,16 @@ func (s *Service) deriveAndUpdateOrderStatus(ctx context.Context, orderID string
 			Str("old_status", order.Status).
 			Str("new_status", newStatus).
 			Msg("Order status updated")
+
+		// 4. ENTRY order fully filled → open position
+		if newStatus == execution.OrderStatusFilled {
+			if err := s.handleEntryFilled(ctx, order); err != nil {
+				log.Error().
+					Err(err).
+					Str("order_id", orderID).
+					Msg("Failed to handle entry fill")
+			}
+		}
 	}
 
 	return nil
@@ -140,6 +161,11 @@ func (s *Service) deriveAndUpdateOrderStatus(ctx context.Context, orderID string
 
 // deriveOrderStatus derives order status from filled_qty and open_qty
 func (s *Service) deriveOrderStatus(order *execution.Order) string {
+	// 0. 정정으로 대체된 주문 (잔량은 정정주문으로 이관)
+	if order.BrokerStatus == execution.OrderStatusReplaced {
+		return execution.OrderStatusReplaced
+	}
+
 	// 1. Check if fully filled
 	if order.FilledQty >= order.Qty {
 		return execution.OrderStatusFilled
@@ -208,6 +234,7 @@ func (s *Service) ensureOrderExists(ctx context.Context, orderID string) error {
 					OpenQty:      uo.OpenQty,
 					FilledQty:    uo.FilledQty,
 					Raw:          uo.Raw,
+					AccountID:    s.accountID,
 					UpdatedTS:    time.Now(),
 				}
 
@@ -238,6 +265,7 @@ func (s *Service) ensureOrderExists(ctx context.Context, orderID string) error {
 		OpenQty:      0,
 		FilledQty:    0,
 		Raw:          map[string]interface{}{},
+		AccountID:    s.accountID,
 		UpdatedTS:    time.Now(),
 	}
 
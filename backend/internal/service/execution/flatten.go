@@ -0,0 +1,62 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// cancelConflictingOrders cancels resting orders of the flatten symbol before submission
+// EMERGENCY_FLATTEN은 포지션 전량 매도 → 같은 종목 미체결 주문(청산 LMT, 진입 매수)이 수량을 잠그거나 재진입하지 않도록 취소
+// 취소 실패 시 error (flatten intent는 NEW 유지, 다음 주기 재시도)
+func (s *Service) cancelConflictingOrders(ctx context.Context, flatten *exit.OrderIntent) error {
+	openOrders, err := s.loadOpenOrders(ctx)
+	if err != nil {
+		return fmt.Errorf("load open orders: %w", err)
+	}
+
+	for _, order := range openOrders {
+		if order.IntentID == uuid.Nil || order.IntentID == flatten.IntentID {
+			continue // 수동/HTS 주문은 종목 확인 불가
+		}
+
+		intent, err := s.intentRepo.GetIntent(ctx, order.IntentID)
+		if err != nil {
+			log.Warn().Err(err).Str("order_id", order.OrderID).Msg("Failed to load intent of open order")
+			continue
+		}
+		if intent.Symbol != flatten.Symbol {
+			continue
+		}
+
+		resp, err := s.kisAdapter.CancelOrder(ctx, s.accountID, order.OrderID)
+		if err != nil {
+			return fmt.Errorf("KIS cancel %s: %w", order.OrderID, err)
+		}
+
+		order.Status = execution.OrderStatusCancelled
+		if order.FilledQty > 0 {
+			order.Status = execution.OrderStatusCancelledPartial
+		}
+		order.BrokerStatus = order.Status
+		order.OpenQty = 0
+		order.UpdatedTS = resp.Timestamp
+		if err := s.orderRepo.UpsertOrder(ctx, order); err != nil {
+			log.Error().Err(err).Str("order_id", order.OrderID).Msg("Failed to mark order as cancelled")
+		}
+
+		log.Warn().
+			Str("order_id", order.OrderID).
+			Str("symbol", intent.Symbol).
+			Str("type", intent.IntentType).
+			Str("reason", intent.ReasonCode).
+			Str("flatten_intent_id", flatten.IntentID.String()).
+			Msg("🚨 Resting order cancelled for emergency flatten")
+	}
+
+	return nil
+}
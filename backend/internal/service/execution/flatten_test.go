@@ -0,0 +1,133 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// fakeOpenOrderRepo 미체결 주문 목록 + 갱신 기록
+type fakeOpenOrderRepo struct {
+	execution.OrderRepository
+	open     []*execution.Order
+	upserted []*execution.Order
+}
+
+func (f *fakeOpenOrderRepo) LoadOrdersByStatus(ctx context.Context, statuses []string) ([]*execution.Order, error) {
+	return append([]*execution.Order(nil), f.open...), nil
+}
+
+func (f *fakeOpenOrderRepo) UpsertOrder(ctx context.Context, order *execution.Order) error {
+	f.upserted = append(f.upserted, order)
+	return nil
+}
+
+// fakeIntentLookup intent_id → intent
+type fakeIntentLookup struct {
+	execution.IntentReader
+	intents map[uuid.UUID]*exit.OrderIntent
+}
+
+func (f fakeIntentLookup) GetIntent(ctx context.Context, intentID uuid.UUID) (*exit.OrderIntent, error) {
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return nil, execution.ErrIntentNotFound
+	}
+	return intent, nil
+}
+
+// fakeCancelAdapter 취소 요청 기록 (failOn 주문은 취소 실패)
+type fakeCancelAdapter struct {
+	execution.KISAdapter
+	failOn    string
+	cancelled []string
+}
+
+func (f *fakeCancelAdapter) CancelOrder(ctx context.Context, accountID string, orderNo string) (*execution.KISCancelResponse, error) {
+	if orderNo == f.failOn {
+		return nil, errors.New("broker rejected cancel")
+	}
+	f.cancelled = append(f.cancelled, orderNo)
+	return &execution.KISCancelResponse{OrderNo: orderNo, Timestamp: time.Now()}, nil
+}
+
+// TestCancelConflictingOrders tests resting order cancellation before emergency flatten submission
+func TestCancelConflictingOrders(t *testing.T) {
+	ctx := context.Background()
+	const account = "11111111-01"
+
+	flatten := &exit.OrderIntent{IntentID: uuid.New(), Symbol: "005930", IntentType: exit.IntentTypeExitFull, ReasonCode: exit.ReasonEmergencyFlatten}
+	intentOf := func(symbol, intentType, reason string) *exit.OrderIntent {
+		return &exit.OrderIntent{IntentID: uuid.New(), Symbol: symbol, IntentType: intentType, ReasonCode: reason}
+	}
+	tpSell := intentOf("005930", exit.IntentTypeExitPartial, exit.ReasonTP1)
+	entryBuy := intentOf("005930", execution.IntentTypeEntry, "")
+	otherSymbol := intentOf("000660", exit.IntentTypeExitPartial, exit.ReasonTP1)
+
+	tests := []struct {
+		name       string
+		order      *execution.Order
+		intent     *exit.OrderIntent
+		wantCancel bool
+		wantStatus string
+	}{
+		{"Same symbol exit LMT", &execution.Order{OrderID: "O1", IntentID: tpSell.IntentID, Qty: 10, OpenQty: 10, AccountID: account}, tpSell, true, execution.OrderStatusCancelled},
+		{"Same symbol entry partially filled", &execution.Order{OrderID: "O2", IntentID: entryBuy.IntentID, Qty: 10, OpenQty: 4, FilledQty: 6, AccountID: account}, entryBuy, true, execution.OrderStatusCancelledPartial},
+		{"Other symbol", &execution.Order{OrderID: "O3", IntentID: otherSymbol.IntentID, Qty: 10, OpenQty: 10, AccountID: account}, otherSymbol, false, ""},
+		{"Manual order (no intent)", &execution.Order{OrderID: "O4", Qty: 10, OpenQty: 10, AccountID: account}, nil, false, ""},
+		{"Flatten order itself", &execution.Order{OrderID: "O5", IntentID: flatten.IntentID, Qty: 10, OpenQty: 10, AccountID: account}, flatten, false, ""},
+		{"Other account", &execution.Order{OrderID: "O6", IntentID: tpSell.IntentID, Qty: 10, OpenQty: 10, AccountID: "22222222-01"}, tpSell, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := &fakeOpenOrderRepo{open: []*execution.Order{tt.order}}
+			intents := fakeIntentLookup{intents: map[uuid.UUID]*exit.OrderIntent{}}
+			if tt.intent != nil {
+				intents.intents[tt.intent.IntentID] = tt.intent
+			}
+			broker := &fakeCancelAdapter{}
+			s := NewService(ctx, orders, nil, nil, nil, intents, nil, nil, broker, account)
+
+			if err := s.cancelConflictingOrders(ctx, flatten); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := len(broker.cancelled) == 1; got != tt.wantCancel {
+				t.Fatalf("Expected cancel=%v, got %v", tt.wantCancel, broker.cancelled)
+			}
+			if !tt.wantCancel {
+				if len(orders.upserted) != 0 {
+					t.Errorf("Expected order untouched, got %+v", orders.upserted[0])
+				}
+				return
+			}
+			if len(orders.upserted) != 1 {
+				t.Fatalf("Expected cancelled order persisted, got %d upserts", len(orders.upserted))
+			}
+			if got := orders.upserted[0]; got.Status != tt.wantStatus || got.OpenQty != 0 {
+				t.Errorf("Expected %s with open_qty 0, got %s (open_qty=%d)", tt.wantStatus, got.Status, got.OpenQty)
+			}
+		})
+	}
+
+	t.Run("Cancel failure keeps flatten intent pending", func(t *testing.T) {
+		orders := &fakeOpenOrderRepo{open: []*execution.Order{
+			{OrderID: "O1", IntentID: tpSell.IntentID, Qty: 10, OpenQty: 10, AccountID: account},
+		}}
+		intents := fakeIntentLookup{intents: map[uuid.UUID]*exit.OrderIntent{tpSell.IntentID: tpSell}}
+		broker := &fakeCancelAdapter{failOn: "O1"}
+		s := NewService(ctx, orders, nil, nil, nil, intents, nil, nil, broker, account)
+
+		if err := s.cancelConflictingOrders(ctx, flatten); err == nil {
+			t.Fatal("Expected error when cancel fails")
+		}
+		if len(orders.upserted) != 0 {
+			t.Errorf("Expected order status unchanged on cancel failure")
+		}
+	})
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/wonny/aegis/v14/internal/domain/execution"
 )
 
-// syncHoldings syncs holdings from KIS and detects ExitEvents
+// syncHoldings syncs holdings from KIS and detects ExitEvents (ledger mode: reconciles ledger)
 func (s *Service) syncHoldings(ctx context.Context) error {
 	// 1. Fetch holdings from KIS
 	kisHoldings, err := s.kisAdapter.GetHoldings(ctx, s.accountID)
@@ -57,35 +57,38 @@ func (s *Service) syncHoldings(ctx context.Context) error {
 			continue
 		}
 
-		// Sync Position with KIS holding (source of truth)
-		// 1. Sync qty/avg_price for existing positions (OPEN or CLOSING)
-		// 2. Auto-create if position doesn't exist
+		// Ledger mode: 포지션은 체결 원장이 관리 → 대사만 수행 (step 4)
+		if s.ledgerRepo == nil {
+			// Sync Position with KIS holding (source of truth)
+			// 1. Sync qty/avg_price for existing positions (OPEN or CLOSING)
+			// 2. Auto-create if position doesn't exist
 
-		// First, try to sync qty/avg_price (works for OPEN and CLOSING positions)
-		if err := s.exitPositionRepo.SyncQtyAndAvgPrice(ctx, kh.AccountID, kh.Symbol, kh.Qty, kh.AvgPrice); err != nil {
-			log.Warn().
-				Err(err).
-				Str("symbol", kh.Symbol).
-				Msg("Failed to sync position qty/avg_price")
-		}
+			// First, try to sync qty/avg_price (works for OPEN and CLOSING positions)
+			if err := s.exitPositionRepo.SyncQtyAndAvgPrice(ctx, kh.AccountID, kh.Symbol, kh.Qty, kh.AvgPrice); err != nil {
+				log.Warn().
+					Err(err).
+					Str("symbol", kh.Symbol).
+					Msg("Failed to sync position qty/avg_price")
+			}
 
-		// Then, check if position exists. If not, create it.
-		_, err = s.positionRepo.GetPositionBySymbol(ctx, kh.AccountID, kh.Symbol, "OPEN")
-		if err != nil {
-			// Also check CLOSING status
-			_, err2 := s.positionRepo.GetPositionBySymbol(ctx, kh.AccountID, kh.Symbol, "CLOSING")
-			if err2 != nil {
-				// Neither OPEN nor CLOSING position exists → create new one
-				if errors.Is(err, execution.ErrPositionNotFound) || strings.Contains(err.Error(), "position not found") {
-					if err := s.exitPositionRepo.UpdateExitModeBySymbol(ctx, kh.AccountID, kh.Symbol, "ENABLED", nil, nil); err != nil {
-						log.Warn().
-							Err(err).
-							Str("symbol", kh.Symbol).
-							Msg("Failed to auto-create position for new holding")
-					} else {
-						log.Info().
-							Str("symbol", kh.Symbol).
-							Msg("Auto-created position for new holding")
+			// Then, check if position exists. If not, create it.
+			_, err = s.positionRepo.GetPositionBySymbol(ctx, kh.AccountID, kh.Symbol, "OPEN")
+			if err != nil {
+				// Also check CLOSING status
+				_, err2 := s.positionRepo.GetPositionBySymbol(ctx, kh.AccountID, kh.Symbol, "CLOSING")
+				if err2 != nil {
+					// Neither OPEN nor CLOSING position exists → create new one
+					if errors.Is(err, execution.ErrPositionNotFound) || strings.Contains(err.Error(), "position not found") {
+						if err := s.exitPositionRepo.UpdateExitModeBySymbol(ctx, kh.AccountID, kh.Symbol, "ENABLED", nil, nil); err != nil {
+							log.Warn().
+								Err(err).
+								Str("symbol", kh.Symbol).
+								Msg("Failed to auto-create position for new holding")
+						} else {
+							log.Info().
+								Str("symbol", kh.Symbol).
+								Msg("Auto-created position for new holding")
+						}
 					}
 				}
 			}
@@ -122,12 +125,14 @@ func (s *Service) syncHoldings(ctx context.Context) error {
 						Msg("Holding cleared (not in KIS response)")
 				}
 
-				// Position qty도 0으로 동기화 (이미 0인 holding도 처리)
-				if err := s.exitPositionRepo.SyncQtyAndAvgPrice(ctx, dbHolding.AccountID, dbHolding.Symbol, 0, dbHolding.AvgPrice); err != nil {
-					log.Warn().
-						Err(err).
-						Str("symbol", dbHolding.Symbol).
-						Msg("Failed to sync position qty to zero")
+				if s.ledgerRepo == nil {
+					// Position qty도 0으로 동기화 (이미 0인 holding도 처리)
+					if err := s.exitPositionRepo.SyncQtyAndAvgPrice(ctx, dbHolding.AccountID, dbHolding.Symbol, 0, dbHolding.AvgPrice); err != nil {
+						log.Warn().
+							Err(err).
+							Str("symbol", dbHolding.Symbol).
+							Msg("Failed to sync position qty to zero")
+					}
 				}
 
 				// Add to currHoldings with qty=0 for ExitEvent detection
@@ -147,8 +152,13 @@ func (s *Service) syncHoldings(ctx context.Context) error {
 		}
 	}
 
-	// 4. Detect and create ExitEvents (qty: N → 0)
-	if err := s.detectAndCreateExitEvents(ctx, s.prevHoldings, currHoldings); err != nil {
+	// 4. Ledger mode: 원장 vs KIS 잔고 대사 (ExitEvent는 SELL 체결 반영 시 생성)
+	//    Legacy: Detect and create ExitEvents (qty: N → 0)
+	if s.ledgerRepo != nil {
+		if err := s.reconcileLedger(ctx, kisHoldings); err != nil {
+			log.Error().Err(err).Msg("Failed to reconcile ledger with holdings")
+		}
+	} else if err := s.detectAndCreateExitEvents(ctx, s.prevHoldings, currHoldings); err != nil {
 		log.Error().Err(err).Msg("Failed to detect exit events")
 		// Don't return error - holdings sync succeeded
 	}
@@ -221,7 +231,7 @@ func (s *Service) createExitEvent(ctx context.Context, accountID, symbol string)
 	}
 
 	// 2. Check if exit event already exists (idempotency)
-	exists, err := s.exitEventRepo.ExitEventExists(ctx, position.PositionID)
+	exists, err := s.exitEventRepo.ExitEventExistsSince(ctx, position.PositionID, position.EntryTS)
 	if err != nil {
 		return fmt.Errorf("check exit event exists: %w", err)
 	}
@@ -267,31 +277,36 @@ func (s *Service) createExitEvent(ctx context.Context, accountID, symbol string)
 		CreatedTS:      time.Now(),
 	}
 
+	return s.saveExitEvent(ctx, exitEvent, position.EntryTS)
+}
+
+// saveExitEvent persists an ExitEvent and mirrors it to audit.trade_history
+func (s *Service) saveExitEvent(ctx context.Context, exitEvent *execution.ExitEvent, entryTS time.Time) error {
 	if err := s.exitEventRepo.CreateExitEvent(ctx, exitEvent); err != nil {
 		return fmt.Errorf("create exit event: %w", err)
 	}
 
 	log.Info().
 		Str("exit_event_id", exitEvent.ExitEventID.String()).
-		Str("position_id", position.PositionID.String()).
-		Str("symbol", symbol).
-		Str("exit_reason", exitReasonCode).
-		Str("source", source).
-		Str("realized_pnl", realizedPnl.StringFixed(2)).
-		Float64("realized_pnl_pct", realizedPnlPct).
+		Str("position_id", exitEvent.PositionID.String()).
+		Str("symbol", exitEvent.Symbol).
+		Str("exit_reason", exitEvent.ExitReasonCode).
+		Str("source", exitEvent.Source).
+		Str("realized_pnl", exitEvent.RealizedPnl.StringFixed(2)).
+		Float64("realized_pnl_pct", exitEvent.RealizedPnlPct).
 		Msg("ExitEvent created")
 
 	// Save to audit.trade_history for performance page (optional hook)
 	if s.auditTradeWriter != nil {
-		if err := s.auditTradeWriter.SaveExitTrade(ctx, exitEvent, position.EntryTS); err != nil {
+		if err := s.auditTradeWriter.SaveExitTrade(ctx, exitEvent, entryTS); err != nil {
 			log.Warn().
 				Err(err).
-				Str("symbol", symbol).
+				Str("symbol", exitEvent.Symbol).
 				Msg("Failed to save trade to audit (performance page)")
 			// Don't return error - exit event was created successfully
 		} else {
 			log.Debug().
-				Str("symbol", symbol).
+				Str("symbol", exitEvent.Symbol).
 				Msg("Trade saved to audit.trade_history")
 		}
 	}
@@ -350,48 +365,8 @@ func (s *Service) calculateExitAvgPrice(ctx context.Context, positionID uuid.UUI
 }
 
 // calculateRealPnL calculates real PnL (HTS-style) including sell fees and taxes
+// 감사 거래내역과 동일한 비용 모델 사용 (수수료 설정값 + 시장/일자별 거래세)
 func (s *Service) calculateRealPnL(kh *execution.KISHolding) (decimal.Decimal, float64) {
-	// Simple PnL (without fees)
-	simplePnl := kh.CurrentPrice.Sub(kh.AvgPrice).Mul(decimal.NewFromInt(kh.Qty))
-
-	// Sell amount
-	sellAmount := kh.CurrentPrice.Mul(decimal.NewFromInt(kh.Qty))
-
-	// Determine fee rate by market
-	var feeRate decimal.Decimal
-	market, ok := kh.Raw["market"].(string)
-	if !ok {
-		market = "UNKNOWN"
-	}
-
-	switch market {
-	case "KOSPI":
-		// Securities transaction tax: 0.15%
-		// Rural special tax: 0.15%
-		// Commission: 0.015%
-		// Total: 0.315%
-		feeRate = decimal.NewFromFloat(0.00315)
-	case "KOSDAQ":
-		// Securities transaction tax: 0.23%
-		// Commission: 0.015%
-		// Total: 0.245%
-		feeRate = decimal.NewFromFloat(0.00245)
-	default:
-		// Default to KOSDAQ rate
-		feeRate = decimal.NewFromFloat(0.00245)
-	}
-
-	// Calculate fees
-	fees := sellAmount.Mul(feeRate)
-
-	// Real PnL (HTS-style)
-	realPnl := simplePnl.Sub(fees)
-
-	// Real PnL %
-	var realPnlPct float64
-	if !kh.AvgPrice.IsZero() {
-		realPnlPct, _ = realPnl.Div(kh.AvgPrice.Mul(decimal.NewFromInt(kh.Qty))).Mul(decimal.NewFromInt(100)).Float64()
-	}
-
-	return realPnl, realPnlPct
+	market, _ := kh.Raw["market"].(string)
+	return execution.DefaultCostModel().UnrealizedPnL(market, time.Now(), kh.Qty, kh.AvgPrice, kh.CurrentPrice)
 }
@@ -2,42 +2,28 @@ package execution
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
-// 한국 시간대
-var kst = time.FixedZone("KST", 9*60*60)
-
-// isMarketOpen checks if Korean stock market is open
-// Market hours: 09:00 - 15:30 KST (weekdays only)
+// isMarketOpen checks if KRX regular session is open (calendar SSOT: 휴장일/수능 지연 개장 반영)
 func isMarketOpen() bool {
-	now := time.Now().In(kst)
-
-	// Check weekday (Monday = 1, Sunday = 0)
-	weekday := now.Weekday()
-	if weekday == time.Saturday || weekday == time.Sunday {
-		return false
-	}
-
-	// Check time (09:00 - 15:30)
-	hour, min, _ := now.Clock()
-	timeMinutes := hour*60 + min
-
-	marketOpen := 9*60 + 0   // 09:00
-	marketClose := 15*60 + 30 // 15:30
-
-	return timeMinutes >= marketOpen && timeMinutes < marketClose
+	return calendar.IsRegularHours(time.Now())
 }
 
 // processNewIntents processes all NEW intents
 func (s *Service) processNewIntents(ctx context.Context) error {
-	// 0. Check market hours - skip processing if market is closed
-	if !isMarketOpen() {
+	// 0. Check session phase - skip processing if market is closed
+	phase := calendar.CurrentPhase(time.Now())
+	if phase == calendar.PhaseClosed {
 		// Market closed - keep intents in NEW status, will be processed when market opens
 		return nil
 	}
@@ -56,6 +42,35 @@ func (s *Service) processNewIntents(ctx context.Context) error {
 
 	// 2. Process each intent
 	for _, intent := range intents {
+		// 다른 계좌 intent는 해당 계좌 service가 처리
+		if !s.ownsAccount(intent.AccountID) {
+			continue
+		}
+
+		// 주문유형별 접수 가능 세션 확인 (장마감 동시호가, 시간외 등)
+		availability, err := execution.CheckSession(intent.OrderType, phase)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("intent_id", intent.IntentID.String()).
+				Str("order_type", intent.OrderType).
+				Msg("Unsupported order type, rejecting intent")
+			if err := s.intentRepo.UpdateIntentStatus(ctx, intent.IntentID, exit.IntentStatusRejected); err != nil {
+				log.Error().Err(err).Str("intent_id", intent.IntentID.String()).Msg("Failed to update intent status")
+			}
+			continue
+		}
+		if availability != execution.SessionAvailable {
+			// LATER: 당일 이후 세션 대기, MISSED: 익일 세션 대기 (NEW 유지)
+			log.Debug().
+				Str("intent_id", intent.IntentID.String()).
+				Str("order_type", intent.OrderType).
+				Str("phase", string(phase)).
+				Str("availability", string(availability)).
+				Msg("Order type not available in current session, waiting")
+			continue
+		}
+
 		if err := s.processIntent(ctx, intent); err != nil {
 			log.Error().
 				Err(err).
@@ -71,6 +86,26 @@ func (s *Service) processNewIntents(ctx context.Context) error {
 
 // processIntent processes a single intent
 func (s *Service) processIntent(ctx context.Context, intent *exit.OrderIntent) error {
+	// 0. REBALANCE_BUY: 같은 계획의 매도가 모두 체결될 때까지 NEW 유지 (매도 대금으로 매수)
+	if intent.IntentType == execution.IntentTypeRebalanceBuy {
+		if s.rebalanceSells == nil {
+			return fmt.Errorf("rebalance sell checker not configured")
+		}
+		planID, _, _ := strings.Cut(intent.ActionKey, ":")
+		open, err := s.rebalanceSells.HasOpenRebalanceSells(ctx, planID)
+		if err != nil {
+			return fmt.Errorf("check rebalance sells: %w", err)
+		}
+		if open {
+			log.Debug().
+				Str("intent_id", intent.IntentID.String()).
+				Str("plan_id", planID).
+				Str("symbol", intent.Symbol).
+				Msg("Rebalance buy waiting for plan sells to fill")
+			return nil
+		}
+	}
+
 	// 1. Check for duplicate (order already exists for this intent)
 	existingOrder, err := s.orderRepo.GetOrderByIntentID(ctx, intent.IntentID)
 	if err != nil && err != execution.ErrOrderNotFound {
@@ -92,7 +127,42 @@ func (s *Service) processIntent(ctx context.Context, intent *exit.OrderIntent) e
 		return nil
 	}
 
-	// 2. Submit order to KIS
+	// 2. 지정가 계열은 가격 필수
+	if execution.OrderTypeNeedsPrice(intent.OrderType) && intent.LimitPrice == nil {
+		if err := s.intentRepo.UpdateIntentStatus(ctx, intent.IntentID, execution.IntentStatusFailed); err != nil {
+			log.Error().Err(err).Str("intent_id", intent.IntentID.String()).Msg("Failed to update intent status")
+		}
+		return fmt.Errorf("%s order requires limit price", intent.OrderType)
+	}
+
+	// 2.5. EMERGENCY_FLATTEN: 같은 종목 미체결 주문 취소 후 전량 매도
+	if intent.ReasonCode == exit.ReasonEmergencyFlatten {
+		if err := s.cancelConflictingOrders(ctx, intent); err != nil {
+			return fmt.Errorf("cancel conflicting orders: %w", err)
+		}
+	}
+
+	// 3. 호가단위/가격제한폭/VI 검증
+	if s.priceGuard != nil {
+		proceed, err := s.guardPrice(ctx, intent)
+		if err != nil || !proceed {
+			return err
+		}
+	}
+
+	// 4. Pre-trade risk gate
+	if s.riskGate != nil {
+		approved, err := s.checkRisk(ctx, intent)
+		if err != nil {
+			// Fail-Closed: 검증 불가 → 제출하지 않고 NEW 유지 (다음 주기 재시도)
+			return fmt.Errorf("risk check: %w", err)
+		}
+		if !approved {
+			return nil
+		}
+	}
+
+	// 5. Submit order to KIS
 	orderID, err := s.submitOrder(ctx, intent)
 	if err != nil {
 		// Submit failed - update intent status to FAILED
@@ -102,7 +172,7 @@ func (s *Service) processIntent(ctx context.Context, intent *exit.OrderIntent) e
 		return fmt.Errorf("submit order: %w", err)
 	}
 
-	// 3. Update intent status to SUBMITTED
+	// 6. Update intent status to SUBMITTED
 	if err := s.intentRepo.UpdateIntentStatus(ctx, intent.IntentID, execution.IntentStatusSubmitted); err != nil {
 		log.Error().Err(err).Str("intent_id", intent.IntentID.String()).Msg("Failed to update intent status")
 	}
@@ -118,6 +188,83 @@ func (s *Service) processIntent(ctx context.Context, intent *exit.OrderIntent) e
 	return nil
 }
 
+// guardPrice normalizes intent limit price onto tick grid and checks today's band
+// - 당일 체결 불가 (가격제한폭 밖) → REJECTED
+// - VI 발동/거래정지 → NEW 유지 (해제 후 재시도)
+func (s *Service) guardPrice(ctx context.Context, intent *exit.OrderIntent) (bool, error) {
+	limit, err := s.priceGuard.NormalizeLimitPrice(ctx, intent.Symbol, s.intentTypeToSide(intent.IntentType), intent.OrderType, intent.LimitPrice)
+	switch {
+	case errors.Is(err, execution.ErrPriceOutOfBand):
+		if err := s.intentRepo.UpdateIntentStatus(ctx, intent.IntentID, exit.IntentStatusRejected); err != nil {
+			return false, fmt.Errorf("update intent status: %w", err)
+		}
+		log.Warn().
+			Err(err).
+			Str("intent_id", intent.IntentID.String()).
+			Str("symbol", intent.Symbol).
+			Str("type", intent.IntentType).
+			Msg("Intent rejected: limit price cannot be filled today")
+		return false, nil
+	case errors.Is(err, execution.ErrVIActive), errors.Is(err, execution.ErrTradingHalted):
+		log.Debug().
+			Err(err).
+			Str("intent_id", intent.IntentID.String()).
+			Str("symbol", intent.Symbol).
+			Msg("Intent deferred until trading resumes")
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("price guard: %w", err)
+	}
+
+	if limit != nil && intent.LimitPrice != nil && !limit.Equal(*intent.LimitPrice) {
+		log.Info().
+			Str("intent_id", intent.IntentID.String()).
+			Str("symbol", intent.Symbol).
+			Str("limit_price", intent.LimitPrice.String()).
+			Str("normalized", limit.String()).
+			Msg("Limit price normalized to KRX tick/band")
+	}
+	intent.LimitPrice = limit
+	return true, nil
+}
+
+// checkRisk runs the risk gate for an intent
+// 한도 위반 시 intent → REJECTED (사유는 control.risk_blocks에 기록)
+func (s *Service) checkRisk(ctx context.Context, intent *exit.OrderIntent) (bool, error) {
+	intentID := intent.IntentID
+	result, err := s.riskGate.CheckOrder(ctx, risk.RiskCheckRequest{
+		Source:     risk.SourceIntent,
+		IntentID:   &intentID,
+		AccountID:  s.accountID,
+		Symbol:     intent.Symbol,
+		Side:       s.intentTypeToSide(intent.IntentType),
+		IntentType: intent.IntentType,
+		OrderType:  intent.OrderType,
+		Qty:        intent.Qty,
+		LimitPrice: intent.LimitPrice,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if result.Approved {
+		return true, nil
+	}
+
+	if err := s.intentRepo.UpdateIntentStatus(ctx, intent.IntentID, exit.IntentStatusRejected); err != nil {
+		return false, fmt.Errorf("update intent status: %w", err)
+	}
+
+	log.Warn().
+		Str("intent_id", intent.IntentID.String()).
+		Str("symbol", intent.Symbol).
+		Str("type", intent.IntentType).
+		Str("reason", string(result.FailedCheck.CheckType)).
+		Msg("Intent rejected by risk gate")
+
+	return false, nil
+}
+
 // submitOrder submits an order to KIS and creates order row
 func (s *Service) submitOrder(ctx context.Context, intent *exit.OrderIntent) (string, error) {
 	// 1. Build KIS request
@@ -147,6 +294,7 @@ func (s *Service) submitOrder(ctx context.Context, intent *exit.OrderIntent) (st
 		OpenQty:      intent.Qty,
 		FilledQty:    0,
 		Raw:          resp.Raw,
+		AccountID:    s.accountID,
 		UpdatedTS:    resp.Timestamp,
 	}
 
@@ -167,9 +315,9 @@ func (s *Service) submitOrder(ctx context.Context, intent *exit.OrderIntent) (st
 // intentTypeToSide converts intent type to KIS side
 func (s *Service) intentTypeToSide(intentType string) string {
 	switch intentType {
-	case execution.IntentTypeEntry:
+	case execution.IntentTypeEntry, execution.IntentTypeRebalanceBuy:
 		return execution.SideBuy
-	case exit.IntentTypeExitPartial, exit.IntentTypeExitFull:
+	case exit.IntentTypeExitPartial, exit.IntentTypeExitFull, execution.IntentTypeRebalanceSell:
 		return execution.SideSell
 	default:
 		log.Warn().Str("intent_type", intentType).Msg("Unknown intent type, defaulting to SELL")
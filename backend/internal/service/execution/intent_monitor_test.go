@@ -0,0 +1,108 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// fakePlanIntentReader 계획별 미체결 매도 여부 + intent 상태 기록
+type fakePlanIntentReader struct {
+	execution.IntentReader
+	openSells map[string]bool // plan_id → 미체결 매도 있음
+	statuses  map[uuid.UUID]string
+}
+
+func (f *fakePlanIntentReader) HasOpenRebalanceSells(ctx context.Context, planID string) (bool, error) {
+	return f.openSells[planID], nil
+}
+
+func (f *fakePlanIntentReader) UpdateIntentStatus(ctx context.Context, intentID uuid.UUID, status string) error {
+	f.statuses[intentID] = status
+	return nil
+}
+
+// fakeSubmitOrderRepo 기존 주문 없음, 생성 주문 기록
+type fakeSubmitOrderRepo struct {
+	execution.OrderRepository
+	created []*execution.Order
+}
+
+func (f *fakeSubmitOrderRepo) GetOrderByIntentID(ctx context.Context, intentID uuid.UUID) (*execution.Order, error) {
+	return nil, execution.ErrOrderNotFound
+}
+
+func (f *fakeSubmitOrderRepo) CreateOrder(ctx context.Context, order *execution.Order) error {
+	f.created = append(f.created, order)
+	return nil
+}
+
+// fakeSubmitAdapter 제출 요청 기록
+type fakeSubmitAdapter struct {
+	execution.KISAdapter
+	submitted []execution.KISOrderRequest
+}
+
+func (f *fakeSubmitAdapter) SubmitOrder(ctx context.Context, req execution.KISOrderRequest) (*execution.KISOrderResponse, error) {
+	f.submitted = append(f.submitted, req)
+	return &execution.KISOrderResponse{OrderID: uuid.NewString(), Timestamp: time.Now()}, nil
+}
+
+// TestRebalanceBuyWaitsForPlanSells tests that rebalance buys stay NEW until the plan's sells are filled
+func TestRebalanceBuyWaitsForPlanSells(t *testing.T) {
+	ctx := context.Background()
+	planID := uuid.NewString()
+
+	intents := &fakePlanIntentReader{
+		openSells: map[string]bool{planID: true},
+		statuses:  make(map[uuid.UUID]string),
+	}
+	orders := &fakeSubmitOrderRepo{}
+	broker := &fakeSubmitAdapter{}
+	s := NewService(ctx, orders, nil, nil, nil, intents, nil, nil, broker, "11111111-01")
+	s.SetRebalanceSellChecker(intents)
+
+	intent := func(intentType, symbol string) *exit.OrderIntent {
+		return &exit.OrderIntent{
+			IntentID:   uuid.New(),
+			Symbol:     symbol,
+			IntentType: intentType,
+			Qty:        10,
+			OrderType:  execution.OrderTypeMarket,
+			ActionKey:  planID + ":REBALANCE:" + symbol,
+			Status:     exit.IntentStatusNew,
+		}
+	}
+
+	// 1. 매도 미체결 → 매수 보류 (NEW 유지), 매도는 제출
+	buy := intent(execution.IntentTypeRebalanceBuy, "000660")
+	sell := intent(execution.IntentTypeRebalanceSell, "005930")
+	if err := s.processIntent(ctx, buy); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.processIntent(ctx, sell); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(broker.submitted) != 1 || broker.submitted[0].Side != execution.SideSell {
+		t.Fatalf("Expected only the sell submitted, got %+v", broker.submitted)
+	}
+	if _, ok := intents.statuses[buy.IntentID]; ok {
+		t.Errorf("Expected buy intent to stay NEW, got %s", intents.statuses[buy.IntentID])
+	}
+
+	// 2. 매도 체결 완료 → 다음 주기에 매수 제출
+	intents.openSells[planID] = false
+	if err := s.processIntent(ctx, buy); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(broker.submitted) != 2 || broker.submitted[1].Side != execution.SideBuy {
+		t.Fatalf("Expected buy submitted after sells filled, got %+v", broker.submitted)
+	}
+	if intents.statuses[buy.IntentID] != execution.IntentStatusSubmitted {
+		t.Errorf("Expected buy intent SUBMITTED, got %s", intents.statuses[buy.IntentID])
+	}
+}
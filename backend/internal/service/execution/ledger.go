@@ -0,0 +1,558 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+const (
+	manualStrategyID = "MANUAL" // 수동/HTS 주문 또는 기초잔고로 생성된 포지션의 strategy_id
+
+	// 연속 N회 대사 불일치 시에만 discrepancy 기록 (fills sync 지연으로 인한 일시적 차이 흡수)
+	ledgerMismatchThreshold = 2
+)
+
+// SetLedger enables the fill-driven position ledger
+// 활성화 시 포지션 수량/평단은 체결(trade.fills, H0STCNI0)로만 갱신되고
+// holdings sync는 원장 vs KIS 잔고 대사(불일치 기록)만 수행
+func (s *Service) SetLedger(repo execution.LedgerRepository, symbols risk.SymbolInfoReader) {
+	s.ledgerRepo = repo
+	s.symbolReader = symbols
+	s.ledgerMismatch = make(map[string]int)
+}
+
+// SetCostBasis sets the ledger lot matching method (FIFO | AVERAGE, audit과 동일 설정)
+func (s *Service) SetCostBasis(method string) error {
+	normalized, err := execution.NormalizeCostMethod(method)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, method)
+	}
+	s.costMethod = normalized
+	return nil
+}
+
+// applyOrderFills applies fills of an order not yet reflected in the ledger
+func (s *Service) applyOrderFills(ctx context.Context, orderID, symbol, sideHint string) error {
+	fills, err := s.fillRepo.LoadFills(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("load fills: %w", err)
+	}
+	if len(fills) == 0 {
+		return nil
+	}
+
+	lastTS := time.Time{}
+	for _, f := range fills {
+		if f.TS.After(lastTS) {
+			lastTS = f.TS
+		}
+	}
+
+	s.ledgerMu.Lock()
+	defer s.ledgerMu.Unlock()
+
+	return s.applyLedger(ctx, orderID, symbol, sideHint, execution.LedgerSourceFill, execution.SumFills(fills), lastTS)
+}
+
+// OnExecutionNotice applies a real-time execution notice (H0STCNI0) ahead of fills sync
+// 주문 누적 체결수량 기준으로 반영 → 이후 REST fills sync는 남은 증분만 반영 (중복 없음)
+func (s *Service) OnExecutionNotice(ctx context.Context, n execution.ExecutionNotice) {
+	if s.ledgerRepo == nil || n.OrderID == "" || n.TotalFilledQty <= 0 {
+		return
+	}
+
+	s.ledgerMu.Lock()
+	defer s.ledgerMu.Unlock()
+
+	applied, err := s.ledgerRepo.LoadAppliedTotals(ctx, n.OrderID)
+	if err != nil {
+		log.Error().Err(err).Str("order_id", n.OrderID).Msg("Failed to load ledger totals for execution notice")
+		return
+	}
+	if n.TotalFilledQty <= applied.Qty {
+		return // 이미 반영됨 (fills sync 선행 또는 중복 통보)
+	}
+
+	// 통보에는 수수료/세금이 없음 → applyLedger에서 추정
+	totals := applied
+	totals.Qty = n.TotalFilledQty
+	totals.Amount = applied.Amount.Add(n.FilledPrice.Mul(decimal.NewFromInt(n.TotalFilledQty - applied.Qty)))
+
+	ts := n.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	if err := s.applyLedger(ctx, n.OrderID, n.Symbol, n.Side, execution.LedgerSourceNotice, totals, ts); err != nil {
+		log.Error().Err(err).Str("order_id", n.OrderID).Str("symbol", n.Symbol).Msg("Failed to apply execution notice to ledger")
+	}
+}
+
+// applyLedger applies the increment between order fill totals and already applied totals
+// caller must hold ledgerMu
+func (s *Service) applyLedger(ctx context.Context, orderID, symbol, sideHint, source string, totals execution.FillTotals, ts time.Time) error {
+	applied, err := s.ledgerRepo.LoadAppliedTotals(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("load applied totals: %w", err)
+	}
+
+	delta := totals.Sub(applied)
+	if delta.Qty <= 0 {
+		return nil
+	}
+
+	// 1. Side: intent 기준 (수동/HTS 주문은 체결 구분명)
+	intent := s.orderIntent(ctx, orderID)
+	side := sideHint
+	if intent != nil {
+		side = s.intentTypeToSide(intent.IntentType)
+	}
+	if side != execution.SideBuy && side != execution.SideSell {
+		return fmt.Errorf("unknown side for order %s", orderID)
+	}
+
+	// 2. Fee/Tax: 브로커 미제공(KIS REST, 체결통보) → 체결일 세율로 추정 (원 미만 절사, audit과 동일)
+	market := s.symbolMarket(ctx, symbol)
+	if !delta.Fee.IsPositive() && !delta.Tax.IsPositive() {
+		cost := execution.DefaultCostModel().Cost(side, market, ts, delta.Amount).Truncated()
+		delta.Fee, delta.Tax = cost.Commission, cost.Tax()
+	}
+
+	// 3. Current ledger position → open lots (audit과 같은 LotBook, FIFO/AVERAGE)
+	position := s.ledgerPosition(ctx, symbol)
+	book, err := s.ledgerLotBook(ctx, position)
+	if err != nil {
+		return err
+	}
+
+	prevQty, prevAvg := book.Qty(), book.AvgCost()
+	if side == execution.SideSell && prevQty == 0 {
+		// 처리 완료로 기록 (반영 누적 포함) → 다음 sync 재감지 / 재진입 포지션에 뒤늦게 반영 방지
+		if err := s.ledgerRepo.RecordUntrackedFill(ctx, s.accountID, symbol, orderID, delta, ts); err != nil {
+			return fmt.Errorf("record untracked fill: %w", err)
+		}
+		s.raiseDiscrepancy(ctx, execution.DiscrepancyUntrackedSell, symbol, 0, 0, decimal.Zero, decimal.Zero,
+			fmt.Sprintf("order %s sold %d without ledger position", orderID, delta.Qty))
+		return nil
+	}
+
+	price := delta.Amount.Div(decimal.NewFromInt(delta.Qty))
+	lots, err := book.Apply(execution.LotFill{
+		Side:   side,
+		Qty:    delta.Qty,
+		Price:  price,
+		TS:     ts,
+		Market: market,
+		Cost:   &execution.TradeCost{Commission: delta.Fee, TransactionTax: delta.Tax, AgriTax: decimal.Zero},
+	})
+	realized := decimal.Zero
+	for _, lot := range lots {
+		realized = realized.Add(lot.PnL)
+	}
+	if errors.Is(err, execution.ErrLedgerOversell) {
+		// 보유분까지만 반영, 초과분은 불일치로 기록 (주문 누적은 전량 기록해 재반영 방지)
+		s.raiseDiscrepancy(ctx, execution.DiscrepancyUntrackedSell, symbol, prevQty, 0, prevAvg, decimal.Zero,
+			fmt.Sprintf("order %s sold %d, ledger held %d", orderID, delta.Qty, prevQty))
+	}
+	qtyAfter, avgAfter := book.Qty(), book.AvgCost()
+
+	// 4. BUY on flat position → open (Exit FSM reset)
+	if side == execution.SideBuy && prevQty == 0 {
+		opened, err := s.openLedgerPosition(ctx, symbol, intent, qtyAfter, avgAfter, ts)
+		if err != nil {
+			return err
+		}
+		position = opened
+	}
+
+	// 5. Append entry (trade.positions qty/avg_price 동일 트랜잭션 갱신)
+	var intentID *uuid.UUID
+	if intent != nil {
+		intentID = &intent.IntentID
+	}
+	entry := &execution.LedgerEntry{
+		PositionID:   position.PositionID,
+		AccountID:    s.accountID,
+		Symbol:       symbol,
+		OrderID:      orderID,
+		IntentID:     intentID,
+		EntryType:    side,
+		Source:       source,
+		Qty:          delta.Qty,
+		Price:        price,
+		Fee:          delta.Fee,
+		Tax:          delta.Tax,
+		QtyAfter:     qtyAfter,
+		AvgCostAfter: avgAfter,
+		RealizedPnl:  realized,
+		TS:           ts,
+	}
+	if err := s.ledgerRepo.AppendEntry(ctx, entry); err != nil {
+		return fmt.Errorf("append ledger entry: %w", err)
+	}
+
+	log.Info().
+		Str("order_id", orderID).
+		Str("symbol", symbol).
+		Str("side", side).
+		Str("source", source).
+		Int64("qty", delta.Qty).
+		Str("price", entry.Price.StringFixed(2)).
+		Int64("qty_after", qtyAfter).
+		Str("avg_cost", avgAfter.StringFixed(2)).
+		Str("realized_pnl", realized.StringFixed(0)).
+		Msg("📒 Ledger entry applied")
+
+	// 6. SELL → flat: ExitEvent
+	if side == execution.SideSell && qtyAfter == 0 {
+		if err := s.closeLedgerPosition(ctx, position, intent, ts); err != nil {
+			log.Error().Err(err).Str("symbol", symbol).Msg("Failed to create exit event from ledger")
+		}
+	}
+
+	return nil
+}
+
+// ledgerLotBook rebuilds open lots of a position from its ledger entries (entry_ts 이후 lifecycle)
+// 원장 합계가 포지션 수량과 다르면 (원장 도입 이전 포지션 등) 포지션 평단 1개 lot으로 대체
+func (s *Service) ledgerLotBook(ctx context.Context, position *exit.Position) (*execution.LotBook, error) {
+	book := execution.NewLotBook(s.costMethod, nil)
+	if position == nil || position.Qty <= 0 {
+		return book, nil
+	}
+
+	entries, err := s.ledgerRepo.LoadEntries(ctx, position.PositionID, position.EntryTS)
+	if err != nil {
+		return nil, fmt.Errorf("load ledger entries: %w", err)
+	}
+	for _, e := range entries {
+		side := e.EntryType
+		if side == execution.LedgerEntryOpening {
+			side = execution.SideBuy
+		}
+		// 초과 매도는 반영 당시 discrepancy 기록됨 → 보유분까지만 재현
+		_, _ = book.Apply(execution.LotFill{
+			Side:  side,
+			Qty:   e.Qty,
+			Price: e.Price,
+			TS:    e.TS,
+			Cost:  &execution.TradeCost{Commission: e.Fee, TransactionTax: e.Tax, AgriTax: decimal.Zero},
+		})
+	}
+	if book.Qty() == position.Qty {
+		return book, nil
+	}
+
+	log.Warn().
+		Str("symbol", position.Symbol).
+		Int64("ledger_qty", book.Qty()).
+		Int64("position_qty", position.Qty).
+		Msg("Ledger entries do not match position qty, using position average cost as a single lot")
+
+	book = execution.NewLotBook(s.costMethod, nil)
+	_, _ = book.Apply(execution.LotFill{
+		Side:  execution.SideBuy,
+		Qty:   position.Qty,
+		Price: position.AvgPrice,
+		TS:    position.EntryTS,
+		Cost:  &execution.TradeCost{Commission: decimal.Zero, TransactionTax: decimal.Zero, AgriTax: decimal.Zero},
+	})
+	return book, nil
+}
+
+// openLedgerPosition opens a position for the first BUY fill on a flat symbol
+func (s *Service) openLedgerPosition(ctx context.Context, symbol string, intent *exit.OrderIntent, qty int64, avgCost decimal.Decimal, ts time.Time) (*exit.Position, error) {
+	position := &exit.Position{
+		PositionID:  uuid.New(),
+		AccountID:   s.accountID,
+		Symbol:      symbol,
+		Side:        "LONG",
+		Qty:         qty,
+		OriginalQty: qty,
+		AvgPrice:    avgCost,
+		EntryTS:     ts,
+		ExitMode:    exit.ExitModeEnabled,
+		StrategyID:  manualStrategyID,
+	}
+	if intent != nil && intent.IntentType == execution.IntentTypeEntry {
+		position.PositionID = intent.PositionID
+		position.StrategyID = entryStrategyID
+	}
+
+	// (account_id, symbol) 기존 행이 있으면 재사용 → PositionID 갱신됨
+	if err := s.exitPositionRepo.OpenPosition(ctx, position); err != nil {
+		return nil, fmt.Errorf("open position: %w", err)
+	}
+
+	log.Info().
+		Str("position_id", position.PositionID.String()).
+		Str("symbol", symbol).
+		Int64("qty", position.Qty).
+		Str("avg_cost", position.AvgPrice.StringFixed(2)).
+		Msg("✅ Position opened from ledger BUY fill")
+
+	return position, nil
+}
+
+// closeLedgerPosition creates an ExitEvent from ledger SELL entries when a position goes flat
+func (s *Service) closeLedgerPosition(ctx context.Context, position *exit.Position, intent *exit.OrderIntent, ts time.Time) error {
+	// 같은 position_id로 재진입한 경우 이전 lifecycle의 ExitEvent는 무시 (entry_ts 이후만)
+	exists, err := s.exitEventRepo.ExitEventExistsSince(ctx, position.PositionID, position.EntryTS)
+	if err != nil {
+		return fmt.Errorf("check exit event exists: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	entries, err := s.ledgerRepo.LoadEntries(ctx, position.PositionID, position.EntryTS)
+	if err != nil {
+		return fmt.Errorf("load ledger entries: %w", err)
+	}
+
+	// 매도 체결 집계: 청산 평균가 = Σ체결금액/Σ수량, 원가 = 순매도금액 - 실현손익
+	exitQty := int64(0)
+	exitAmount := decimal.Zero
+	proceeds := decimal.Zero
+	realizedPnl := decimal.Zero
+	for _, e := range entries {
+		if e.EntryType != execution.LedgerEntrySell {
+			continue
+		}
+		amount := e.Price.Mul(decimal.NewFromInt(e.Qty))
+		exitQty += e.Qty
+		exitAmount = exitAmount.Add(amount)
+		proceeds = proceeds.Add(amount.Sub(e.Fee).Sub(e.Tax))
+		realizedPnl = realizedPnl.Add(e.RealizedPnl)
+	}
+	if exitQty == 0 {
+		return nil
+	}
+
+	exitAvgPrice := exitAmount.Div(decimal.NewFromInt(exitQty))
+	costBasis := proceeds.Sub(realizedPnl)
+	realizedPnlPct := 0.0
+	if costBasis.IsPositive() {
+		realizedPnlPct, _ = realizedPnl.Div(costBasis).Mul(decimal.NewFromInt(100)).Float64()
+	}
+
+	// 청산 사유: 체결 주문의 EXIT intent 우선, 없으면 최근 EXIT intent 탐색
+	var exitReasonCode, source string
+	var intentID *uuid.UUID
+	if intent != nil && (intent.IntentType == exit.IntentTypeExitFull || intent.IntentType == exit.IntentTypeExitPartial) {
+		exitReasonCode, source, intentID = intent.ReasonCode, execution.ExitSourceAutoExit, &intent.IntentID
+	} else {
+		exitReasonCode, source, intentID = s.determineExitReason(ctx, position.PositionID)
+	}
+
+	exitEvent := &execution.ExitEvent{
+		ExitEventID:    uuid.New(),
+		PositionID:     position.PositionID,
+		AccountID:      position.AccountID,
+		Symbol:         position.Symbol,
+		ExitTS:         ts,
+		ExitQty:        exitQty,
+		ExitAvgPrice:   exitAvgPrice,
+		ExitReasonCode: exitReasonCode,
+		Source:         source,
+		IntentID:       intentID,
+		ExitProfileID:  position.ExitProfileID,
+		RealizedPnl:    realizedPnl,
+		RealizedPnlPct: realizedPnlPct,
+		CreatedTS:      time.Now(),
+	}
+
+	return s.saveExitEvent(ctx, exitEvent, position.EntryTS)
+}
+
+// reconcileLedger compares ledger positions with KIS holdings and records discrepancies
+// 원장은 덮어쓰지 않음. 예외: 원장에 없는 보유분은 기초잔고(OPENING)로 편입해야 청산 관리 가능
+func (s *Service) reconcileLedger(ctx context.Context, kisHoldings []*execution.KISHolding) error {
+	s.ledgerMu.Lock()
+	defer s.ledgerMu.Unlock()
+
+	positions, err := s.exitPositionRepo.GetOpenPositions(ctx, s.accountID)
+	if err != nil {
+		return fmt.Errorf("load ledger positions: %w", err)
+	}
+	positionMap := make(map[string]*exit.Position, len(positions))
+	for _, p := range positions {
+		positionMap[p.Symbol] = p
+	}
+
+	openSymbols := make(map[string]bool)
+	if open, err := s.ledgerRepo.LoadOpenDiscrepancies(ctx, s.accountID); err != nil {
+		log.Warn().Err(err).Msg("Failed to load open ledger discrepancies")
+	} else {
+		for _, d := range open {
+			openSymbols[d.Symbol] = true
+		}
+	}
+
+	brokerSymbols := make(map[string]bool, len(kisHoldings))
+	for _, kh := range kisHoldings {
+		brokerSymbols[kh.Symbol] = true
+
+		position := positionMap[kh.Symbol]
+		ledgerQty := int64(0)
+		ledgerAvg := decimal.Zero
+		if position != nil {
+			ledgerQty = position.Qty
+			ledgerAvg = position.AvgPrice
+		}
+
+		if ledgerQty == kh.Qty {
+			s.clearLedgerMismatch(ctx, kh.Symbol, openSymbols[kh.Symbol])
+			continue
+		}
+		if !s.ledgerMismatchPersisted(kh.Symbol) {
+			continue
+		}
+
+		if ledgerQty == 0 {
+			s.raiseDiscrepancy(ctx, execution.DiscrepancyUntrackedHolding, kh.Symbol, 0, kh.Qty, decimal.Zero, kh.AvgPrice,
+				"holding not in ledger, adopted as opening balance")
+			if err := s.adoptHolding(ctx, kh); err != nil {
+				log.Error().Err(err).Str("symbol", kh.Symbol).Msg("Failed to adopt untracked holding into ledger")
+			}
+			continue
+		}
+
+		s.raiseDiscrepancy(ctx, execution.DiscrepancyQtyMismatch, kh.Symbol, ledgerQty, kh.Qty, ledgerAvg, kh.AvgPrice,
+			fmt.Sprintf("ledger qty %d != broker qty %d", ledgerQty, kh.Qty))
+	}
+
+	// 원장 보유, KIS 잔고 없음
+	for _, p := range positions {
+		if brokerSymbols[p.Symbol] {
+			continue
+		}
+		if p.Qty <= 0 {
+			s.clearLedgerMismatch(ctx, p.Symbol, openSymbols[p.Symbol])
+			continue
+		}
+		if !s.ledgerMismatchPersisted(p.Symbol) {
+			continue
+		}
+		s.raiseDiscrepancy(ctx, execution.DiscrepancyMissingHolding, p.Symbol, p.Qty, 0, p.AvgPrice, decimal.Zero,
+			"ledger position not in broker holdings")
+	}
+
+	return nil
+}
+
+// adoptHolding opens a ledger position from a broker holding (OPENING entry)
+func (s *Service) adoptHolding(ctx context.Context, kh *execution.KISHolding) error {
+	now := time.Now()
+
+	position, err := s.openLedgerPosition(ctx, kh.Symbol, nil, kh.Qty, kh.AvgPrice, now)
+	if err != nil {
+		return err
+	}
+
+	entry := &execution.LedgerEntry{
+		PositionID:   position.PositionID,
+		AccountID:    s.accountID,
+		Symbol:       kh.Symbol,
+		EntryType:    execution.LedgerEntryOpening,
+		Source:       execution.LedgerSourceHoldings,
+		Qty:          kh.Qty,
+		Price:        kh.AvgPrice,
+		Fee:          decimal.Zero,
+		Tax:          decimal.Zero,
+		QtyAfter:     kh.Qty,
+		AvgCostAfter: kh.AvgPrice,
+		RealizedPnl:  decimal.Zero,
+		TS:           now,
+	}
+	return s.ledgerRepo.AppendEntry(ctx, entry)
+}
+
+// ledgerMismatchPersisted counts a mismatch and reports whether it reached the threshold
+func (s *Service) ledgerMismatchPersisted(symbol string) bool {
+	s.ledgerMismatch[symbol]++
+	return s.ledgerMismatch[symbol] >= ledgerMismatchThreshold
+}
+
+// clearLedgerMismatch resets the mismatch counter and resolves open discrepancies of a symbol
+func (s *Service) clearLedgerMismatch(ctx context.Context, symbol string, hasOpen bool) {
+	delete(s.ledgerMismatch, symbol)
+	if !hasOpen {
+		return
+	}
+	if err := s.ledgerRepo.ResolveDiscrepancies(ctx, s.accountID, symbol); err != nil {
+		log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to resolve ledger discrepancies")
+		return
+	}
+	log.Info().Str("symbol", symbol).Msg("✅ Ledger discrepancy resolved")
+}
+
+// raiseDiscrepancy records a ledger discrepancy (failures are logged only)
+func (s *Service) raiseDiscrepancy(ctx context.Context, kind, symbol string, ledgerQty, brokerQty int64, ledgerAvg, brokerAvg decimal.Decimal, detail string) {
+	d := &execution.LedgerDiscrepancy{
+		AccountID: s.accountID,
+		Symbol:    symbol,
+		Kind:      kind,
+		LedgerQty: ledgerQty,
+		BrokerQty: brokerQty,
+		LedgerAvg: ledgerAvg,
+		BrokerAvg: brokerAvg,
+		Detail:    detail,
+	}
+	if err := s.ledgerRepo.RaiseDiscrepancy(ctx, d); err != nil {
+		log.Error().Err(err).Str("symbol", symbol).Str("kind", kind).Msg("Failed to record ledger discrepancy")
+		return
+	}
+
+	log.Warn().
+		Str("symbol", symbol).
+		Str("kind", kind).
+		Int64("ledger_qty", ledgerQty).
+		Int64("broker_qty", brokerQty).
+		Str("detail", detail).
+		Msg("⚠️ Ledger discrepancy")
+}
+
+// ledgerPosition loads the active (OPEN/CLOSING) position of a symbol (nil = 없음)
+func (s *Service) ledgerPosition(ctx context.Context, symbol string) *exit.Position {
+	for _, status := range []string{exit.StatusOpen, exit.StatusClosing} {
+		if position, err := s.positionRepo.GetPositionBySymbol(ctx, s.accountID, symbol, status); err == nil {
+			return position
+		}
+	}
+	return nil
+}
+
+// orderIntent loads the intent of an order (nil = 수동/HTS 주문 또는 placeholder)
+func (s *Service) orderIntent(ctx context.Context, orderID string) *exit.OrderIntent {
+	order, err := s.orderRepo.GetOrder(ctx, orderID)
+	if err != nil || order.IntentID == uuid.Nil {
+		return nil
+	}
+	intent, err := s.intentRepo.GetIntent(ctx, order.IntentID)
+	if err != nil {
+		return nil
+	}
+	return intent
+}
+
+// symbolMarket returns the market of a symbol ("" = 알 수 없음 → KOSDAQ 요율)
+func (s *Service) symbolMarket(ctx context.Context, symbol string) string {
+	if s.symbolReader == nil {
+		return ""
+	}
+	infos, err := s.symbolReader.LoadSymbolInfo(ctx, []string{symbol})
+	if err != nil {
+		return ""
+	}
+	return infos[symbol].Market
+}
@@ -0,0 +1,296 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// fakePositionStore trade.positions 대체: (account_id, symbol) 당 1행, 재오픈 시 position_id 재사용
+type fakePositionStore struct {
+	exit.PositionRepository
+	rows map[string]*exit.Position // symbol → row
+}
+
+func (f *fakePositionStore) OpenPosition(ctx context.Context, position *exit.Position) error {
+	if row, ok := f.rows[position.Symbol]; ok {
+		position.PositionID = row.PositionID
+	}
+	row := *position
+	row.Status = exit.StatusOpen
+	f.rows[position.Symbol] = &row
+	return nil
+}
+
+func (f *fakePositionStore) GetPositionBySymbol(ctx context.Context, accountID, symbol, status string) (*exit.Position, error) {
+	row, ok := f.rows[symbol]
+	if !ok || row.Status != status {
+		return nil, execution.ErrPositionNotFound
+	}
+	p := *row
+	return &p, nil
+}
+
+func (f *fakePositionStore) GetPosition(ctx context.Context, positionID uuid.UUID) (*exit.Position, error) {
+	for _, row := range f.rows {
+		if row.PositionID == positionID {
+			p := *row
+			return &p, nil
+		}
+	}
+	return nil, execution.ErrPositionNotFound
+}
+
+// fakeLedgerRepo in-memory position_ledger (AppendEntry applies qty/avg to positions)
+type fakeLedgerRepo struct {
+	execution.LedgerRepository
+	positions     *fakePositionStore
+	entries       []*execution.LedgerEntry
+	untracked     map[string]execution.FillTotals // order_id → 원장 밖 처리 누적
+	discrepancies []*execution.LedgerDiscrepancy
+}
+
+func (f *fakeLedgerRepo) AppendEntry(ctx context.Context, entry *execution.LedgerEntry) error {
+	f.entries = append(f.entries, entry)
+	for _, row := range f.positions.rows {
+		if row.PositionID == entry.PositionID {
+			row.Qty = entry.QtyAfter
+			row.AvgPrice = entry.AvgCostAfter
+		}
+	}
+	return nil
+}
+
+func (f *fakeLedgerRepo) LoadAppliedTotals(ctx context.Context, orderID string) (execution.FillTotals, error) {
+	totals := execution.FillTotals{Amount: decimal.Zero, Fee: decimal.Zero, Tax: decimal.Zero}
+	for _, e := range f.entries {
+		if e.OrderID == orderID {
+			totals.Qty += e.Qty
+			totals.Amount = totals.Amount.Add(e.Price.Mul(decimal.NewFromInt(e.Qty)))
+			totals.Fee = totals.Fee.Add(e.Fee)
+			totals.Tax = totals.Tax.Add(e.Tax)
+		}
+	}
+	if u, ok := f.untracked[orderID]; ok {
+		totals.Qty += u.Qty
+		totals.Amount = totals.Amount.Add(u.Amount)
+		totals.Fee = totals.Fee.Add(u.Fee)
+		totals.Tax = totals.Tax.Add(u.Tax)
+	}
+	return totals, nil
+}
+
+func (f *fakeLedgerRepo) RecordUntrackedFill(ctx context.Context, accountID, symbol, orderID string, delta execution.FillTotals, ts time.Time) error {
+	if f.untracked == nil {
+		f.untracked = make(map[string]execution.FillTotals)
+	}
+	u := f.untracked[orderID]
+	u.Qty += delta.Qty
+	u.Amount = delta.Amount.Add(u.Amount)
+	u.Fee = delta.Fee.Add(u.Fee)
+	u.Tax = delta.Tax.Add(u.Tax)
+	f.untracked[orderID] = u
+	return nil
+}
+
+func (f *fakeLedgerRepo) LoadEntries(ctx context.Context, positionID uuid.UUID, since time.Time) ([]*execution.LedgerEntry, error) {
+	var entries []*execution.LedgerEntry
+	for _, e := range f.entries {
+		if e.PositionID == positionID && !e.TS.Before(since) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeLedgerRepo) RaiseDiscrepancy(ctx context.Context, d *execution.LedgerDiscrepancy) error {
+	f.discrepancies = append(f.discrepancies, d)
+	return nil
+}
+
+// fakeExitEventRepo in-memory trade.exit_events
+type fakeExitEventRepo struct {
+	execution.ExitEventRepository
+	events []*execution.ExitEvent
+}
+
+func (f *fakeExitEventRepo) CreateExitEvent(ctx context.Context, event *execution.ExitEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeExitEventRepo) ExitEventExistsSince(ctx context.Context, positionID uuid.UUID, entryTS time.Time) (bool, error) {
+	for _, e := range f.events {
+		if e.PositionID == positionID && !e.ExitTS.Before(entryTS) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fakeOrderRepo 주문 없음 → 수동/HTS 주문으로 처리
+type fakeOrderRepo struct {
+	execution.OrderRepository
+}
+
+func (fakeOrderRepo) GetOrder(ctx context.Context, orderID string) (*execution.Order, error) {
+	return nil, execution.ErrOrderNotFound
+}
+
+// fakeIntentReader intent 없음
+type fakeIntentReader struct {
+	execution.IntentReader
+}
+
+func (fakeIntentReader) LoadIntentsForPosition(ctx context.Context, positionID uuid.UUID, intentTypes []string, statuses []string, since time.Time) ([]*exit.OrderIntent, error) {
+	return nil, nil
+}
+
+// TestLedgerReentryCreatesExitEventPerLifecycle tests buy → sell → buy → sell on a reused position_id
+func TestLedgerReentryCreatesExitEventPerLifecycle(t *testing.T) {
+	ctx := context.Background()
+	positions := &fakePositionStore{rows: make(map[string]*exit.Position)}
+	events := &fakeExitEventRepo{}
+
+	s := NewService(ctx, fakeOrderRepo{}, nil, nil, events, fakeIntentReader{}, positions, positions, nil, "11111111-01")
+	s.SetLedger(&fakeLedgerRepo{positions: positions}, nil)
+
+	base := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	steps := []struct {
+		orderID string
+		side    string
+		price   int64
+	}{
+		{"B1", execution.SideBuy, 10_000},
+		{"S1", execution.SideSell, 11_000},
+		{"B2", execution.SideBuy, 12_000},
+		{"S2", execution.SideSell, 11_500},
+	}
+	for i, step := range steps {
+		s.OnExecutionNotice(ctx, execution.ExecutionNotice{
+			OrderID:        step.orderID,
+			Symbol:         "005930",
+			Side:           step.side,
+			FilledQty:      10,
+			FilledPrice:    decimal.NewFromInt(step.price),
+			TotalFilledQty: 10,
+			Timestamp:      base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	if len(events.events) != 2 {
+		t.Fatalf("Expected one exit event per lifecycle (2), got %d", len(events.events))
+	}
+	first, second := events.events[0], events.events[1]
+	if first.PositionID != second.PositionID {
+		t.Errorf("Expected position_id reused across lifecycles")
+	}
+	if !first.ExitAvgPrice.Equal(decimal.NewFromInt(11_000)) || !second.ExitAvgPrice.Equal(decimal.NewFromInt(11_500)) {
+		t.Errorf("Expected exit prices 11000/11500, got %s/%s", first.ExitAvgPrice, second.ExitAvgPrice)
+	}
+	if !first.RealizedPnl.IsPositive() || !second.RealizedPnl.IsNegative() {
+		t.Errorf("Expected profit then loss, got %s/%s", first.RealizedPnl, second.RealizedPnl)
+	}
+
+	// 같은 체결 재통보 → 중복 ExitEvent 없음
+	s.OnExecutionNotice(ctx, execution.ExecutionNotice{
+		OrderID: "S2", Symbol: "005930", Side: execution.SideSell,
+		FilledQty: 10, FilledPrice: decimal.NewFromInt(11_500), TotalFilledQty: 10, Timestamp: base.Add(4 * time.Minute),
+	})
+	if len(events.events) != 2 {
+		t.Errorf("Expected duplicate notice ignored, got %d exit events", len(events.events))
+	}
+}
+
+// TestLedgerCostBasisMatchesLotBook tests that ledger realized P&L uses the shared LotBook (FIFO/AVERAGE)
+func TestLedgerCostBasisMatchesLotBook(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		orderID string
+		side    string
+		qty     int64
+		price   int64
+	}{
+		{"B1", execution.SideBuy, 10, 10_000},
+		{"B2", execution.SideBuy, 10, 12_000},
+		{"S1", execution.SideSell, 15, 13_000},
+	}
+
+	for _, method := range []string{execution.CostMethodFIFO, execution.CostMethodAverage} {
+		t.Run(method, func(t *testing.T) {
+			positions := &fakePositionStore{rows: make(map[string]*exit.Position)}
+			ledger := &fakeLedgerRepo{positions: positions}
+			s := NewService(ctx, fakeOrderRepo{}, nil, nil, &fakeExitEventRepo{}, fakeIntentReader{}, positions, positions, nil, "11111111-01")
+			s.SetLedger(ledger, nil)
+			if err := s.SetCostBasis(method); err != nil {
+				t.Fatalf("SetCostBasis failed: %v", err)
+			}
+
+			book := execution.NewLotBook(method, nil)
+			want := decimal.Zero
+			for i, step := range steps {
+				ts := base.Add(time.Duration(i) * time.Minute)
+				s.OnExecutionNotice(ctx, execution.ExecutionNotice{
+					OrderID: step.orderID, Symbol: "005930", Side: step.side,
+					FilledQty: step.qty, FilledPrice: decimal.NewFromInt(step.price), TotalFilledQty: step.qty, Timestamp: ts,
+				})
+				lots, err := book.Apply(execution.LotFill{Side: step.side, Qty: step.qty, Price: decimal.NewFromInt(step.price), TS: ts})
+				if err != nil {
+					t.Fatalf("LotBook apply failed: %v", err)
+				}
+				for _, lot := range lots {
+					want = want.Add(lot.PnL)
+				}
+			}
+
+			sell := ledger.entries[len(ledger.entries)-1]
+			if !sell.RealizedPnl.Equal(want) {
+				t.Errorf("Expected ledger realized P&L %s (LotBook), got %s", want, sell.RealizedPnl)
+			}
+			if sell.QtyAfter != book.Qty() || !sell.AvgCostAfter.Equal(book.AvgCost()) {
+				t.Errorf("Expected %d @ %s after sell, got %d @ %s", book.Qty(), book.AvgCost(), sell.QtyAfter, sell.AvgCostAfter)
+			}
+		})
+	}
+}
+
+// TestLedgerUntrackedSellHandledOnce tests that a sell without ledger position is recorded as applied
+func TestLedgerUntrackedSellHandledOnce(t *testing.T) {
+	ctx := context.Background()
+	positions := &fakePositionStore{rows: make(map[string]*exit.Position)}
+	ledger := &fakeLedgerRepo{positions: positions}
+	s := NewService(ctx, fakeOrderRepo{}, nil, nil, &fakeExitEventRepo{}, fakeIntentReader{}, positions, positions, nil, "11111111-01")
+	s.SetLedger(ledger, nil)
+
+	base := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	sell := execution.FillTotals{Qty: 10, Amount: decimal.NewFromInt(110_000), Fee: decimal.Zero, Tax: decimal.Zero}
+
+	// 같은 주문 체결이 매 sync마다 다시 들어옴
+	for i := 0; i < 2; i++ {
+		if err := s.applyLedger(ctx, "S0", "005930", execution.SideSell, execution.LedgerSourceFill, sell, base); err != nil {
+			t.Fatalf("applyLedger failed: %v", err)
+		}
+	}
+	if len(ledger.discrepancies) != 1 || ledger.discrepancies[0].Kind != execution.DiscrepancyUntrackedSell {
+		t.Fatalf("Expected one UNTRACKED_SELL discrepancy, got %d", len(ledger.discrepancies))
+	}
+
+	// 이후 매수로 포지션 오픈 → 과거 매도가 새 포지션에 반영되지 않아야 함
+	buy := execution.FillTotals{Qty: 10, Amount: decimal.NewFromInt(100_000), Fee: decimal.Zero, Tax: decimal.Zero}
+	if err := s.applyLedger(ctx, "B1", "005930", execution.SideBuy, execution.LedgerSourceFill, buy, base.Add(time.Minute)); err != nil {
+		t.Fatalf("applyLedger buy failed: %v", err)
+	}
+	if err := s.applyLedger(ctx, "S0", "005930", execution.SideSell, execution.LedgerSourceFill, sell, base); err != nil {
+		t.Fatalf("applyLedger failed: %v", err)
+	}
+	if got := positions.rows["005930"].Qty; got != 10 {
+		t.Errorf("Expected reopened position qty 10, got %d", got)
+	}
+}
@@ -11,10 +11,7 @@ import (
 // reconcileOrders reconciles order states with KIS
 func (s *Service) reconcileOrders(ctx context.Context) error {
 	// 1. Load open orders (SUBMITTED, PARTIAL)
-	openOrders, err := s.orderRepo.LoadOrdersByStatus(ctx, []string{
-		execution.OrderStatusSubmitted,
-		execution.OrderStatusPartial,
-	})
+	openOrders, err := s.loadOpenOrders(ctx)
 	if err != nil {
 		return fmt.Errorf("load open orders: %w", err)
 	}
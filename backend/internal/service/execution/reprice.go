@@ -0,0 +1,244 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// PriceReader 현재가 Reader (PriceSync, 최우선 매수호가)
+type PriceReader interface {
+	GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error)
+}
+
+// SetRepricer enables automatic repricing of unfilled LMT exit orders (optional)
+func (s *Service) SetRepricer(eventRepo execution.OrderEventRepository, prices PriceReader, policy execution.RepricePolicy) {
+	s.orderEventRepo = eventRepo
+	s.priceReader = prices
+	s.repricePolicy = policy
+}
+
+// repriceLoop reprices stale LMT exit orders
+func (s *Service) repriceLoop() {
+	ticker := time.NewTicker(repriceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.repriceOrders(s.ctx); err != nil {
+				log.Error().Err(err).Msg("Reprice failed")
+			}
+
+		case <-s.ctx.Done():
+			log.Info().Msg("Reprice loop stopped")
+			return
+		}
+	}
+}
+
+// repriceOrders reprices open LMT exit orders unfilled for longer than policy timeout
+// reconcileOrders(2분)를 기다리지 않고 매수호가 추격 → 시장가 전환
+func (s *Service) repriceOrders(ctx context.Context) error {
+	if !s.repricePolicy.Enabled || !isMarketOpen() {
+		return nil
+	}
+
+	openOrders, err := s.loadOpenOrders(ctx)
+	if err != nil {
+		return fmt.Errorf("load open orders: %w", err)
+	}
+
+	now := time.Now()
+	var stale []*execution.Order
+	for _, order := range openOrders {
+		if order.IntentID == (uuid.UUID{}) || now.Sub(order.SubmittedTS) < s.repricePolicy.Timeout {
+			continue
+		}
+		stale = append(stale, order)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	// 정정 수량은 KIS 미체결 잔량 기준 (DB open_qty는 reconcile 주기만큼 지연)
+	unfilledOrders, err := s.kisAdapter.GetUnfilledOrders(ctx, s.accountID)
+	if err != nil {
+		return fmt.Errorf("fetch unfilled orders: %w", err)
+	}
+	unfilledMap := make(map[string]*execution.KISUnfilledOrder, len(unfilledOrders))
+	for _, uo := range unfilledOrders {
+		unfilledMap[uo.OrderID] = uo
+	}
+
+	for _, order := range stale {
+		unfilled, ok := unfilledMap[order.OrderID]
+		if !ok || unfilled.OpenQty <= 0 {
+			continue // 체결/취소됨 → reconcile이 처리
+		}
+
+		if err := s.repriceOrder(ctx, order, unfilled.OpenQty); err != nil {
+			log.Error().
+				Err(err).
+				Str("order_id", order.OrderID).
+				Msg("Failed to reprice order")
+		}
+	}
+
+	return nil
+}
+
+// repriceOrder applies reprice policy to a single open order
+func (s *Service) repriceOrder(ctx context.Context, order *execution.Order, openQty int64) error {
+	intent, err := s.intentRepo.GetIntent(ctx, order.IntentID)
+	if err != nil {
+		return fmt.Errorf("get intent: %w", err)
+	}
+	if intent.IntentType != exit.IntentTypeExitPartial && intent.IntentType != exit.IntentTypeExitFull {
+		return nil // 청산 주문만 추격
+	}
+
+	// 현재 주문유형/가격 = 마지막 정정 결과 (없으면 intent 원본)
+	events, err := s.orderEventRepo.LoadOrderEventsByIntent(ctx, intent.IntentID)
+	if err != nil {
+		return fmt.Errorf("load order events: %w", err)
+	}
+	orderType, limitPrice := intent.OrderType, intent.LimitPrice
+	attempts := 0
+	for _, e := range events {
+		orderType, limitPrice = e.NewOrderType, e.NewPrice
+		if e.EventType == execution.OrderEventReprice {
+			attempts++
+		}
+	}
+	if orderType != execution.OrderTypeLimit || limitPrice == nil {
+		return nil
+	}
+
+	decision := s.repricePolicy.Decide(attempts, time.Since(order.SubmittedTS), *limitPrice, s.bestBid(ctx, intent.Symbol))
+
+	event := &execution.OrderEvent{
+		IntentID:     intent.IntentID,
+		OldOrderType: orderType,
+		OldPrice:     limitPrice,
+		Qty:          openQty,
+	}
+	switch decision.Action {
+	case execution.RepriceLimit:
+		newPrice := decision.Price
+		if s.priceGuard != nil {
+			// 하한가 아래 추격 방지 + VI 중 정정 보류
+			normalized, err := s.priceGuard.NormalizeLimitPrice(ctx, intent.Symbol, s.intentTypeToSide(intent.IntentType), execution.OrderTypeLimit, &newPrice)
+			if err != nil {
+				log.Debug().Err(err).Str("order_id", order.OrderID).Msg("Reprice skipped by price guard")
+				return nil
+			}
+			newPrice = *normalized
+		}
+		event.EventType = execution.OrderEventReprice
+		event.Attempt = attempts + 1
+		event.NewOrderType = execution.OrderTypeLimit
+		event.NewPrice = &newPrice
+		event.Reason = fmt.Sprintf("unfilled %s, chase bid -%d tick", s.repricePolicy.Timeout, s.repricePolicy.ChaseTicks)
+	case execution.RepriceMarket:
+		event.EventType = execution.OrderEventConvertMkt
+		event.Attempt = attempts
+		event.NewOrderType = execution.OrderTypeMarket
+		event.Reason = fmt.Sprintf("unfilled after %d reprice attempts (%s since last submit)", attempts, time.Since(order.SubmittedTS).Round(time.Second))
+	default:
+		return nil
+	}
+
+	return s.amendOrder(ctx, order, event)
+}
+
+// amendOrder sends 정정 to KIS and records it
+// 원주문 → REPLACED, 정정주문번호로 새 order row (동일 intent), order_events에 이력
+func (s *Service) amendOrder(ctx context.Context, order *execution.Order, event *execution.OrderEvent) error {
+	resp, err := s.kisAdapter.ModifyOrder(ctx, execution.KISModifyRequest{
+		AccountID:  s.accountID,
+		OrderNo:    order.OrderID,
+		OrderType:  event.NewOrderType,
+		Qty:        event.Qty,
+		LimitPrice: event.NewPrice,
+	})
+	if err != nil {
+		return fmt.Errorf("KIS modify: %w", err)
+	}
+
+	// 1. 원주문 REPLACED (잔량은 정정주문으로 이관)
+	order.Status = execution.OrderStatusReplaced
+	order.BrokerStatus = execution.OrderStatusReplaced
+	order.OpenQty = 0
+	order.UpdatedTS = resp.Timestamp
+	if err := s.orderRepo.UpsertOrder(ctx, order); err != nil {
+		log.Error().Err(err).Str("order_id", order.OrderID).Msg("Failed to mark order as replaced")
+	}
+
+	// 2. 정정주문 row
+	newOrder := &execution.Order{
+		OrderID:      resp.NewOrderNo,
+		IntentID:     order.IntentID,
+		SubmittedTS:  resp.Timestamp,
+		Status:       execution.OrderStatusSubmitted,
+		BrokerStatus: "SUBMITTED",
+		Qty:          event.Qty,
+		OpenQty:      event.Qty,
+		FilledQty:    0,
+		Raw:          resp.Raw,
+		AccountID:    s.accountID,
+		UpdatedTS:    resp.Timestamp,
+	}
+	if err := s.orderRepo.CreateOrder(ctx, newOrder); err != nil {
+		log.Error().
+			Err(err).
+			Str("order_id", resp.NewOrderNo).
+			Str("orig_order_id", order.OrderID).
+			Msg("Failed to create amended order row (orphan order!)")
+		return fmt.Errorf("create order: %w", err)
+	}
+
+	// 3. 정정 이력
+	event.OrderID = order.OrderID
+	event.NewOrderID = resp.NewOrderNo
+	event.Raw = resp.Raw
+	event.CreatedTS = resp.Timestamp
+	if err := s.orderEventRepo.CreateOrderEvent(ctx, event); err != nil {
+		return fmt.Errorf("create order event: %w", err)
+	}
+
+	log.Info().
+		Str("order_id", order.OrderID).
+		Str("new_order_id", resp.NewOrderNo).
+		Str("event", event.EventType).
+		Int("attempt", event.Attempt).
+		Str("old_price", priceString(event.OldPrice)).
+		Str("new_price", priceString(event.NewPrice)).
+		Int64("qty", event.Qty).
+		Msg("✏️ Order amended")
+
+	return nil
+}
+
+// bestBid returns 최우선 매수호가 (없거나 stale이면 0)
+func (s *Service) bestBid(ctx context.Context, symbol string) int64 {
+	bp, err := s.priceReader.GetBestPrice(ctx, symbol)
+	if err != nil || bp == nil || bp.IsStale || bp.BidPrice == nil {
+		return 0
+	}
+	return *bp.BidPrice
+}
+
+func priceString(p *decimal.Decimal) string {
+	if p == nil {
+		return "MKT"
+	}
+	return p.String()
+}
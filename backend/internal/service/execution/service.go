@@ -2,11 +2,13 @@ package execution
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/wonny/aegis/v14/internal/domain/execution"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
 )
 
 const (
@@ -14,12 +16,13 @@ const (
 	reconcileInterval     = 120 * time.Second // Reconciliation 주기 (2분, rate limit 완화)
 	holdingsSyncInterval  = 120 * time.Second // Holdings sync 주기 (2분, rate limit 완화)
 	fillsSyncInterval     = 60 * time.Second  // Fills sync 주기 (1분, rate limit 완화)
+	repriceInterval       = 5 * time.Second   // 미체결 LMT 청산 재호가 점검 주기
 
 	// ✅ 2026-01-18: Fills/Holdings sync 간격 증가
 	// Portfolio 가격(Tier0)은 2.5초로 유지 (Exit Engine 우선)
 	// Fills/Holdings는 실시간성보다 안정성 우선
 
-	// Fills sync backoff (EGW00201 대응)
+	// Fills sync loop backoff (요청 단위 EGW00201 백오프는 kis.Scheduler가 담당)
 	fillsSyncBaseBackoff = 5 * time.Second
 	fillsSyncMaxBackoff  = 120 * time.Second
 	fillsSyncMaxRetries  = 5
@@ -43,10 +46,27 @@ type Service struct {
 	kisAdapter execution.KISAdapter
 
 	// Optional hooks
-	auditTradeWriter execution.AuditTradeWriter // For saving trades to audit (performance page)
+	auditTradeWriter execution.AuditTradeWriter     // For saving trades to audit (performance page)
+	entryFillHandler execution.EntryFillHandler     // For ENTRY fill notification (Reentry → ENTERED)
+	riskGate         execution.RiskGate             // Pre-trade risk check (nil → no gate)
+	priceGuard       execution.PriceGuard           // 호가단위/가격제한폭/VI 검증 (nil → 원가격 전송)
+	rebalanceSells   execution.RebalanceSellChecker // REBALANCE_BUY 매도 체결 대기 (nil → 매수 보류)
+
+	// Optional: 미체결 LMT 청산 자동 재호가 (nil → 비활성)
+	orderEventRepo execution.OrderEventRepository
+	priceReader    PriceReader
+	repricePolicy  execution.RepricePolicy
+
+	// Optional: 체결 기반 포지션 원장 (nil → holdings 동기화가 포지션 source of truth)
+	ledgerRepo     execution.LedgerRepository
+	symbolReader   risk.SymbolInfoReader
+	ledgerMu       sync.Mutex     // 원장 반영 직렬화 (fills sync / 체결통보 / 대사)
+	ledgerMismatch map[string]int // symbol → 연속 대사 불일치 횟수
+	costMethod     string         // 원장 lot 매칭 (FIFO | AVERAGE, "" = FIFO)
 
 	// Config
 	accountID string
+	primary   bool // 계좌 미지정("") intent/주문 처리 (다계좌 runtime에서 기본 계좌 1개만 true)
 
 	// State
 	prevHoldings []*execution.Holding // Previous holdings snapshot for ExitEvent detection
@@ -80,15 +100,70 @@ func NewService(
 		exitPositionRepo: exitPositionRepo,
 		kisAdapter:       kisAdapter,
 		accountID:        accountID,
+		primary:          true,
 		prevHoldings:     []*execution.Holding{},
 	}
 }
 
+// AccountID returns the account managed by this service
+func (s *Service) AccountID() string {
+	return s.accountID
+}
+
+// SetPrimaryAccount sets whether this service handles intents/orders without account (기본 계좌)
+func (s *Service) SetPrimaryAccount(primary bool) {
+	s.primary = primary
+}
+
+// ownsAccount checks if an intent/order account belongs to this service
+func (s *Service) ownsAccount(accountID string) bool {
+	return accountID == s.accountID || (accountID == "" && s.primary)
+}
+
+// loadOpenOrders loads SUBMITTED/PARTIAL orders of this account
+func (s *Service) loadOpenOrders(ctx context.Context) ([]*execution.Order, error) {
+	orders, err := s.orderRepo.LoadOrdersByStatus(ctx, []string{
+		execution.OrderStatusSubmitted,
+		execution.OrderStatusPartial,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	owned := orders[:0]
+	for _, order := range orders {
+		if s.ownsAccount(order.AccountID) {
+			owned = append(owned, order)
+		}
+	}
+	return owned, nil
+}
+
 // SetAuditTradeWriter sets the optional audit trade writer hook
 func (s *Service) SetAuditTradeWriter(writer execution.AuditTradeWriter) {
 	s.auditTradeWriter = writer
 }
 
+// SetEntryFillHandler sets the optional ENTRY fill handler hook
+func (s *Service) SetEntryFillHandler(handler execution.EntryFillHandler) {
+	s.entryFillHandler = handler
+}
+
+// SetRiskGate sets the optional pre-trade risk gate
+func (s *Service) SetRiskGate(gate execution.RiskGate) {
+	s.riskGate = gate
+}
+
+// SetPriceGuard sets the optional limit price guard
+func (s *Service) SetPriceGuard(guard execution.PriceGuard) {
+	s.priceGuard = guard
+}
+
+// SetRebalanceSellChecker sets the rebalance plan sell checker (REBALANCE_BUY 제출 전 필수)
+func (s *Service) SetRebalanceSellChecker(checker execution.RebalanceSellChecker) {
+	s.rebalanceSells = checker
+}
+
 // Start starts the Execution Engine
 func (s *Service) Start() error {
 	log.Info().Msg("Starting Execution Engine")
@@ -104,6 +179,9 @@ func (s *Service) Start() error {
 	go s.reconcileLoop()
 	go s.holdingsSyncLoop()
 	go s.fillsSyncLoop()
+	if s.orderEventRepo != nil && s.priceReader != nil {
+		go s.repriceLoop()
+	}
 
 	log.Info().Msg("Execution Engine started")
 	return nil
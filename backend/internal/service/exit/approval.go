@@ -0,0 +1,83 @@
+package exit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// resolveApproval resolves approval policy of an automated exit intent
+// 우선순위: symbol override > 적용 프로필 > default 프로필 (레벨 내: control mode > reason > default)
+// override 조회 실패(not found 제외) → 종목 정책을 알 수 없으므로 MANUAL (Fail-Closed)
+func (s *Service) resolveApproval(ctx context.Context, pos *exit.Position, profile *exit.ExitProfile, reasonCode, controlMode string) exit.ApprovalDecision {
+	var levels []exit.ApprovalLevel
+
+	override, err := s.symbolOverrideRepo.GetOverride(ctx, pos.Symbol)
+	if err != nil && !errors.Is(err, exit.ErrOverrideNotFound) {
+		log.Error().
+			Err(err).
+			Str("symbol", pos.Symbol).
+			Str("reason", reasonCode).
+			Msg("Failed to load symbol override, approval falls back to manual")
+		return exit.ApprovalDecision{Mode: exit.ApprovalModeManual, Source: "fallback:error"}
+	}
+	if err == nil && override != nil && override.Enabled && override.Approval != nil {
+		levels = append(levels, exit.ApprovalLevel{Name: "symbol:" + pos.Symbol, Policy: override.Approval})
+	}
+	if profile != nil {
+		levels = append(levels, exit.ApprovalLevel{Name: "profile:" + profile.ProfileID, Policy: profile.Config.Approval})
+	}
+	if s.defaultProfile != nil && s.defaultProfile != profile {
+		levels = append(levels, exit.ApprovalLevel{Name: "profile:" + s.defaultProfile.ProfileID, Policy: s.defaultProfile.Config.Approval})
+	}
+
+	return exit.ResolveApproval(levels, reasonCode, controlMode)
+}
+
+// processApprovalTimeouts applies TIMEOUT_APPROVE / TIMEOUT_CANCEL to expired PENDING_APPROVAL intents
+func (s *Service) processApprovalTimeouts(ctx context.Context) error {
+	intents, err := s.intentRepo.LoadExpiredApprovals(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("load expired approvals: %w", err)
+	}
+
+	for _, intent := range intents {
+		var err error
+		switch intent.ApprovalMode {
+		case exit.ApprovalModeTimeoutApprove:
+			err = s.intentRepo.ApproveIntent(ctx, intent.IntentID, exit.DecidedByTimeout)
+		case exit.ApprovalModeTimeoutCancel:
+			err = s.intentRepo.RejectIntent(ctx, intent.IntentID, exit.DecidedByTimeout)
+		default:
+			continue
+		}
+		if err != nil {
+			// 사용자가 먼저 승인/거부한 경우 포함
+			log.Debug().Err(err).Str("intent_id", intent.IntentID.String()).Msg("Approval timeout not applied")
+			continue
+		}
+
+		log.Info().
+			Str("intent_id", intent.IntentID.String()).
+			Str("symbol", intent.Symbol).
+			Str("reason", intent.ReasonCode).
+			Str("approval_mode", intent.ApprovalMode).
+			Str("approval_source", intent.ApprovalSource).
+			Str("decided_by", exit.DecidedByTimeout).
+			Msg("Intent approval timed out")
+	}
+
+	return nil
+}
+
+// validateApprovalPolicy validates an approval policy (profile / symbol override)
+func validateApprovalPolicy(policy *exit.ApprovalPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("%w: approval %v", exit.ErrInvalidProfile, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package exit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// fakeOverrideRepo 종목 override 조회 결과 고정
+type fakeOverrideRepo struct {
+	exit.SymbolExitOverrideRepository
+	override *exit.SymbolExitOverride
+	err      error
+}
+
+func (f fakeOverrideRepo) GetOverride(ctx context.Context, symbol string) (*exit.SymbolExitOverride, error) {
+	return f.override, f.err
+}
+
+// TestResolveApprovalOverrideLookup tests that override lookup failures fail closed to MANUAL
+func TestResolveApprovalOverrideLookup(t *testing.T) {
+	ctx := context.Background()
+	pos := &exit.Position{Symbol: "005930"}
+	profile := &exit.ExitProfile{ProfileID: "default"}
+	profile.Config.Approval = &exit.ApprovalPolicy{Default: &exit.ApprovalRule{Mode: exit.ApprovalModeAuto}}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantMode   string
+		wantSource string
+	}{
+		{"Override not found uses profile", fmt.Errorf("%w for symbol: 005930", exit.ErrOverrideNotFound), exit.ApprovalModeAuto, "profile:default/default"},
+		{"Override query error falls back to manual", errors.New("connection refused"), exit.ApprovalModeManual, "fallback:error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &Service{symbolOverrideRepo: fakeOverrideRepo{err: tt.err}}
+
+			got := svc.resolveApproval(ctx, pos, profile, exit.ReasonSL1, "")
+			if got.Mode != tt.wantMode || got.Source != tt.wantSource {
+				t.Errorf("Expected %s (%s), got %s (%s)", tt.wantMode, tt.wantSource, got.Mode, got.Source)
+			}
+		})
+	}
+}
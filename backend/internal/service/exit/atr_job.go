@@ -0,0 +1,233 @@
+package exit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// DefaultATRPeriod Wilder ATR 기간 (일봉)
+const DefaultATRPeriod = 14
+
+// atrSignalRule trade.exit_signals rule name for daily ATR records
+const atrSignalRule = "ATR"
+
+// DailyPriceReader reads recent daily bars (data.daily_prices, trade_date 내림차순)
+type DailyPriceReader interface {
+	GetLatestN(ctx context.Context, stockCode string, n int) ([]*fetcher.DailyPrice, error)
+}
+
+// ATRJobConfig daily ATR job schedule
+type ATRJobConfig struct {
+	Period int    // ATR(N), 0 → DefaultATRPeriod
+	RunAt  string // 실행 시각 KST "HH:MM" (장 시작 전)
+}
+
+// SetDailyPriceReader sets daily bar source for the ATR job and custom rule variables (ma*, avg_volume)
+func (s *Service) SetDailyPriceReader(reader DailyPriceReader) {
+	s.dailyPrices = reader
+}
+
+// WilderATR calculates Wilder ATR(period) from ascending daily bars
+// 첫 ATR = 최초 period개 TR 평균, 이후 ATR = (이전 ATR × (N-1) + TR) / N
+// 최소 period+1개 일봉 필요 (첫 TR에 전일 종가 사용)
+func WilderATR(bars []*fetcher.DailyPrice, period int) (float64, bool) {
+	if period <= 0 || len(bars) < period+1 {
+		return 0, false
+	}
+
+	trueRange := func(i int) float64 {
+		prevClose := bars[i-1].ClosePrice
+		tr := bars[i].HighPrice - bars[i].LowPrice
+		if v := bars[i].HighPrice - prevClose; v > tr {
+			tr = v
+		}
+		if v := prevClose - bars[i].LowPrice; v > tr {
+			tr = v
+		}
+		return tr
+	}
+
+	var sum float64
+	for i := 1; i <= period; i++ {
+		sum += trueRange(i)
+	}
+	atr := sum / float64(period)
+
+	for i := period + 1; i < len(bars); i++ {
+		atr = (atr*float64(period-1) + trueRange(i)) / float64(period)
+	}
+
+	return atr, true
+}
+
+// ATRStatus returns ATR freshness of a position state (OK | MISSING | STALE)
+// 매 거래일 장전 갱신 기준, 직전 거래일보다 오래된 값은 STALE
+func ATRStatus(state *exit.PositionState, now time.Time) string {
+	if state == nil || state.ATR == nil || state.ATR.IsZero() {
+		return exit.ATRStatusMissing
+	}
+	if state.ATRUpdatedTS == nil || calendar.TradingDaysBetween(*state.ATRUpdatedTS, now) > 1 {
+		return exit.ATRStatusStale
+	}
+	return exit.ATRStatusOK
+}
+
+// RefreshATR computes ATR% for all open positions and caches it in position state
+// 계산된 ATR factor는 trade.exit_signals에 기록 (rule_name = ATR)
+func (s *Service) RefreshATR(ctx context.Context, period int) (int, error) {
+	if s.dailyPrices == nil {
+		return 0, fmt.Errorf("daily price reader not configured")
+	}
+	if period <= 0 {
+		period = DefaultATRPeriod
+	}
+
+	positions, err := s.posRepo.GetAllOpenPositions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get open positions: %w", err)
+	}
+
+	bySymbol := make(map[string]symbolATR) // 종목당 1회 계산 (계좌별 중복 보유)
+
+	updated := 0
+	for _, pos := range positions {
+		v, cached := bySymbol[pos.Symbol]
+		if !cached {
+			v = s.computeATR(ctx, pos.Symbol, period)
+			bySymbol[pos.Symbol] = v
+		}
+		if !v.ok {
+			continue
+		}
+
+		atrPct := decimal.NewFromFloat(v.pct).Round(6)
+		atrWon := decimal.NewFromFloat(v.atr).Round(4)
+		if err := s.stateRepo.UpdateATR(ctx, pos.PositionID, atrPct, atrWon); err != nil {
+			log.Error().Err(err).Str("symbol", pos.Symbol).Msg("Failed to update ATR")
+			continue
+		}
+		updated++
+
+		profile := s.resolveExitProfile(ctx, pos)
+		factor := 1.0
+		if profile != nil {
+			factor = calculateATRFactor(&atrPct, profile.Config.ATR)
+		}
+
+		factorDec := decimal.NewFromFloat(factor).Round(4)
+		signal := &exit.ExitSignal{
+			SignalID:    uuid.New(),
+			PositionID:  pos.PositionID,
+			RuleName:    atrSignalRule,
+			IsTriggered: false,
+			Reason:      fmt.Sprintf("ATR(%d)=%.1f (%.2f%%) factor=%.2f", period, v.atr, v.pct*100, factor),
+			Distance:    &factorDec, // ATR factor
+			Price:       decimal.NewFromFloat(v.lastClose),
+			EvaluatedTS: time.Now(),
+		}
+		if err := s.signalRepo.InsertSignal(ctx, signal); err != nil {
+			log.Warn().Err(err).Str("symbol", pos.Symbol).Msg("Failed to record ATR signal (non-fatal)")
+		}
+	}
+
+	log.Info().
+		Int("positions", len(positions)).
+		Int("updated", updated).
+		Int("period", period).
+		Msg("✅ Daily ATR refreshed")
+
+	return updated, nil
+}
+
+// symbolATR daily ATR of a symbol
+type symbolATR struct {
+	atr       float64 // ATR (원)
+	pct       float64 // ATR / 전일 종가
+	lastClose float64
+	ok        bool
+}
+
+// computeATR loads daily bars and returns ATR, ATR% and last close of a symbol
+func (s *Service) computeATR(ctx context.Context, symbol string, period int) symbolATR {
+	var result symbolATR
+
+	// Wilder 평활 수렴을 위해 기간의 3배 조회
+	bars, err := s.dailyPrices.GetLatestN(ctx, symbol, period*3+1)
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to load daily prices for ATR")
+		return result
+	}
+
+	// 내림차순 → 오름차순
+	for i, j := 0, len(bars)-1; i < j; i, j = i+1, j-1 {
+		bars[i], bars[j] = bars[j], bars[i]
+	}
+
+	atr, ok := WilderATR(bars, period)
+	lastClose := 0.0
+	if len(bars) > 0 {
+		lastClose = bars[len(bars)-1].ClosePrice
+	}
+	if !ok || lastClose <= 0 {
+		log.Warn().Str("symbol", symbol).Int("bars", len(bars)).Msg("Insufficient daily prices for ATR")
+		return result
+	}
+
+	result.atr = atr
+	result.pct = atr / lastClose
+	result.lastClose = lastClose
+	result.ok = true
+	return result
+}
+
+// StartATRJob runs RefreshATR at startup and every trading day at cfg.RunAt (KST)
+func (s *Service) StartATRJob(ctx context.Context, cfg ATRJobConfig) error {
+	runAt, err := time.Parse("15:04", cfg.RunAt)
+	if err != nil {
+		return fmt.Errorf("invalid ATR job time %q (expected HH:MM): %w", cfg.RunAt, err)
+	}
+
+	go func() {
+		// 기동 시 1회 (장중 재시작 대비)
+		if _, err := s.RefreshATR(ctx, cfg.Period); err != nil {
+			log.Error().Err(err).Msg("Initial ATR refresh failed")
+		}
+
+		for {
+			next := nextATRRun(time.Now(), runAt.Hour(), runAt.Minute())
+			log.Debug().Time("next_run", next).Msg("ATR job scheduled")
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				if _, err := s.RefreshATR(ctx, cfg.Period); err != nil {
+					log.Error().Err(err).Msg("Daily ATR refresh failed")
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// nextATRRun returns the next trading day run time after now (KST)
+func nextATRRun(now time.Time, hour, minute int) time.Time {
+	k := now.In(calendar.KST)
+	today := time.Date(k.Year(), k.Month(), k.Day(), hour, minute, 0, 0, calendar.KST)
+	if today.After(k) && calendar.IsTradingDay(today) {
+		return today
+	}
+	d := calendar.NextTradingDay(k)
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, calendar.KST)
+}
@@ -0,0 +1,75 @@
+package exit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// TestWilderATR tests Wilder smoothing of true range
+func TestWilderATR(t *testing.T) {
+	bar := func(high, low, close float64) *fetcher.DailyPrice {
+		return &fetcher.DailyPrice{HighPrice: high, LowPrice: low, ClosePrice: close}
+	}
+	bars := []*fetcher.DailyPrice{
+		bar(10, 8, 9),
+		bar(11, 9, 10),  // TR 2
+		bar(12, 10, 11), // TR 2
+		bar(13, 9, 12),  // TR 4 → ATR(3) = 8/3
+		bar(12, 11, 11), // TR 1 (전일 종가 12) → (8/3×2 + 1) / 3 = 19/9
+	}
+
+	atr, ok := WilderATR(bars, 3)
+	if !ok || math.Abs(atr-19.0/9.0) > 1e-9 {
+		t.Errorf("Expected ATR 19/9, got %f (ok=%v)", atr, ok)
+	}
+
+	if _, ok := WilderATR(bars[:3], 3); ok {
+		t.Errorf("Expected insufficient bars for ATR(3) with 3 bars")
+	}
+}
+
+// TestATRStatus tests ATR freshness by trading days
+func TestATRStatus(t *testing.T) {
+	kst := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 30, 0, 0, calendar.KST)
+	}
+	now := kst(10, 10) // 화요일
+	atr := decimal.RequireFromString("0.025")
+
+	state := func(updated *time.Time) *exit.PositionState {
+		return &exit.PositionState{ATR: &atr, ATRUpdatedTS: updated}
+	}
+	monday, thursday := kst(9, 8), kst(5, 8)
+
+	if got := ATRStatus(&exit.PositionState{}, now); got != exit.ATRStatusMissing {
+		t.Errorf("Expected MISSING, got %s", got)
+	}
+	if got := ATRStatus(state(&monday), now); got != exit.ATRStatusOK {
+		t.Errorf("Expected OK for previous trading day, got %s", got)
+	}
+	if got := ATRStatus(state(&thursday), now); got != exit.ATRStatusStale {
+		t.Errorf("Expected STALE for 3 trading days ago, got %s", got)
+	}
+	if got := ATRStatus(state(nil), now); got != exit.ATRStatusStale {
+		t.Errorf("Expected STALE without timestamp, got %s", got)
+	}
+}
+
+// TestNextATRRun tests job schedule on trading days
+func TestNextATRRun(t *testing.T) {
+	friday := time.Date(2026, 3, 6, 9, 0, 0, 0, calendar.KST)
+	if got := nextATRRun(friday, 8, 30); !got.Equal(time.Date(2026, 3, 9, 8, 30, 0, 0, calendar.KST)) {
+		t.Errorf("Expected Monday 08:30, got %s", got)
+	}
+
+	monday := time.Date(2026, 3, 9, 7, 0, 0, 0, calendar.KST)
+	if got := nextATRRun(monday, 8, 30); !got.Equal(time.Date(2026, 3, 9, 8, 30, 0, 0, calendar.KST)) {
+		t.Errorf("Expected same day 08:30, got %s", got)
+	}
+}
@@ -0,0 +1,287 @@
+package exit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// ruleMAPeriods moving average periods exposed to rule expressions (ma5, ma20, ...)
+var ruleMAPeriods = map[string]int{
+	exit.RuleVarMA5:   5,
+	exit.RuleVarMA20:  20,
+	exit.RuleVarMA60:  60,
+	exit.RuleVarMA120: 120,
+}
+
+// ruleAvgVolumeDays avg_volume 기간 (거래일)
+const ruleAvgVolumeDays = 20
+
+// ruleDailyStats daily-bar based rule variables (종목별 거래일 1회 계산)
+type ruleDailyStats struct {
+	date    string             // KST 거래일 (YYYY-MM-DD)
+	numbers map[string]float64 // ma5, ma20, ..., avg_volume
+}
+
+// cachedRuleExpr parsed expression of a custom rule (src 변경 시 재파싱)
+type cachedRuleExpr struct {
+	src  string
+	expr *exit.RuleExpr
+}
+
+// validateCustomRules validates custom rules of a profile (expression 문법/변수 포함)
+func validateCustomRules(rules []exit.CustomExitRule) error {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("%w: custom rule %s: %v", exit.ErrInvalidProfile, rule.ID, err)
+		}
+	}
+	return nil
+}
+
+// buildRuleEnv builds rule variables for a position at current price
+// 일봉 기반 변수(MA, 평균 거래량)는 데이터 없으면 제외 → 참조하는 규칙은 미발동 (Fail-Closed)
+func (s *Service) buildRuleEnv(
+	ctx context.Context,
+	snapshot PositionSnapshot,
+	state *exit.PositionState,
+	bestPrice *price.BestPrice,
+	currentPrice decimal.Decimal,
+	pnlPct decimal.Decimal,
+) exit.RuleEnv {
+	now := s.clock().In(calendar.KST)
+
+	env := exit.RuleEnv{
+		Numbers: map[string]float64{
+			exit.RuleVarPnLPct:   pnlPct.InexactFloat64(),
+			exit.RuleVarPrice:    currentPrice.InexactFloat64(),
+			exit.RuleVarAvgPrice: snapshot.AvgPrice.InexactFloat64(),
+			exit.RuleVarDaysHeld: float64(calendar.TradingDaysBetween(snapshot.EntryTS, now)),
+			exit.RuleVarTime:     float64(now.Hour()*60 + now.Minute()),
+		},
+		Strings: map[string]string{
+			exit.RuleVarPhase: state.Phase,
+		},
+	}
+
+	drawdown := 0.0
+	if state.HWMPrice != nil && state.HWMPrice.IsPositive() && currentPrice.LessThan(*state.HWMPrice) {
+		drawdown = state.HWMPrice.Sub(currentPrice).Div(*state.HWMPrice).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	}
+	env.Numbers[exit.RuleVarDrawdownPct] = drawdown
+
+	if bestPrice.Volume != nil {
+		env.Numbers[exit.RuleVarVolume] = float64(*bestPrice.Volume)
+	}
+
+	if stats := s.dailyRuleStats(ctx, snapshot.Symbol, now.Format("2006-01-02")); stats != nil {
+		for name, v := range stats.numbers {
+			env.Numbers[name] = v
+		}
+	}
+
+	if volume, ok := env.Numbers[exit.RuleVarVolume]; ok {
+		if avg, ok := env.Numbers[exit.RuleVarAvgVolume]; ok && avg > 0 {
+			env.Numbers[exit.RuleVarVolumeRatio] = volume / avg
+		}
+	}
+
+	return env
+}
+
+// dailyRuleStats returns cached daily-bar rule variables (전일 종가까지, 당일 봉 제외)
+func (s *Service) dailyRuleStats(ctx context.Context, symbol, today string) *ruleDailyStats {
+	if s.dailyPrices == nil {
+		return nil
+	}
+
+	s.ruleStatsMu.Lock()
+	defer s.ruleStatsMu.Unlock()
+
+	if stats, ok := s.ruleStats[symbol]; ok && stats.date == today {
+		return stats
+	}
+
+	// 실패해도 당일은 캐시 (평가 주기마다 재조회 방지)
+	stats := &ruleDailyStats{date: today, numbers: make(map[string]float64)}
+	if s.ruleStats == nil {
+		s.ruleStats = make(map[string]*ruleDailyStats)
+	}
+	s.ruleStats[symbol] = stats
+
+	bars, err := s.dailyPrices.GetLatestN(ctx, symbol, 120+1)
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to load daily bars for custom rules")
+		return stats
+	}
+
+	// trade_date 내림차순 → 당일 봉 제외
+	closes := make([]float64, 0, len(bars))
+	volumes := make([]float64, 0, len(bars))
+	for _, bar := range bars {
+		if bar.TradeDate.Format("2006-01-02") >= today {
+			continue
+		}
+		closes = append(closes, bar.ClosePrice)
+		volumes = append(volumes, float64(bar.Volume))
+	}
+
+	for name, period := range ruleMAPeriods {
+		if len(closes) >= period {
+			stats.numbers[name] = mean(closes[:period])
+		}
+	}
+	if len(volumes) >= ruleAvgVolumeDays {
+		stats.numbers[exit.RuleVarAvgVolume] = mean(volumes[:ruleAvgVolumeDays])
+	}
+
+	return stats
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// evaluateCustomRuleCondition evaluates a custom rule condition
+// env는 expression 규칙에서만 필요 → 최초 사용 시 생성
+func (s *Service) evaluateCustomRuleCondition(rule exit.CustomExitRule, pnlPct decimal.Decimal, env func() exit.RuleEnv) (bool, error) {
+	switch rule.Condition {
+	case exit.CustomConditionProfitAbove:
+		return pnlPct.InexactFloat64() >= rule.Threshold, nil
+	case exit.CustomConditionProfitBelow:
+		return pnlPct.InexactFloat64() <= rule.Threshold, nil
+	case exit.CustomConditionExpression:
+		expr, err := s.customRuleExpr(rule)
+		if err != nil {
+			return false, fmt.Errorf("parse expression: %w", err)
+		}
+		return expr.Eval(env())
+	}
+	return false, fmt.Errorf("unknown condition %q", rule.Condition)
+}
+
+// customRuleExpr returns the parsed expression of a rule (rule ID별 캐시, 평가 주기마다 재파싱 방지)
+func (s *Service) customRuleExpr(rule exit.CustomExitRule) (*exit.RuleExpr, error) {
+	s.ruleExprMu.Lock()
+	defer s.ruleExprMu.Unlock()
+
+	if cached, ok := s.ruleExprs[rule.ID]; ok && cached.src == rule.Expression {
+		return cached.expr, nil
+	}
+
+	expr, err := exit.ParseRuleExpr(rule.Expression)
+	if err != nil {
+		return nil, err
+	}
+	if s.ruleExprs == nil {
+		s.ruleExprs = make(map[string]*cachedRuleExpr)
+	}
+	s.ruleExprs[rule.ID] = &cachedRuleExpr{src: rule.Expression, expr: expr}
+	return expr, nil
+}
+
+// warnRuleVarUnavailable logs missing rule variables at Warn once per symbol per trading day
+// (일봉 미적재/리더 미설정 → 규칙이 조용히 미발동되지 않도록)
+func (s *Service) warnRuleVarUnavailable(symbol string, rule exit.CustomExitRule, err error) {
+	today := s.clock().In(calendar.KST).Format("2006-01-02")
+
+	s.ruleStatsMu.Lock()
+	if s.ruleWarned[symbol] == today {
+		s.ruleStatsMu.Unlock()
+		return
+	}
+	if s.ruleWarned == nil {
+		s.ruleWarned = make(map[string]string)
+	}
+	s.ruleWarned[symbol] = today
+	s.ruleStatsMu.Unlock()
+
+	log.Warn().
+		Err(err).
+		Str("symbol", symbol).
+		Str("rule_id", rule.ID).
+		Str("expression", rule.Expression).
+		Bool("daily_reader", s.dailyPrices != nil).
+		Msg("Custom rule variable unavailable, rule not evaluated (logged once per day)")
+}
+
+// PreviewCustomRule validates an expression and evaluates it against a position's current values (optional)
+func (s *Service) PreviewCustomRule(ctx context.Context, expression string, positionID *uuid.UUID) (*exit.RulePreview, error) {
+	preview := &exit.RulePreview{Expression: expression}
+
+	expr, err := exit.ParseRuleExpr(expression)
+	if err != nil {
+		preview.Error = err.Error()
+		return preview, nil
+	}
+	preview.Valid = true
+	preview.Variables = expr.Variables()
+
+	if positionID == nil {
+		return preview, nil
+	}
+
+	pos, err := s.posRepo.GetPosition(ctx, *positionID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.stateRepo.GetState(ctx, *positionID)
+	if err != nil {
+		return nil, fmt.Errorf("get state: %w", err)
+	}
+	bestPrice, err := s.priceSync.GetBestPrice(ctx, pos.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("get best price: %w", err)
+	}
+
+	// 평가 루프와 동일: 매수호가 우선
+	currentPriceInt := bestPrice.BestPrice
+	if bestPrice.BidPrice != nil {
+		currentPriceInt = *bestPrice.BidPrice
+	}
+	currentPrice := decimal.NewFromInt(currentPriceInt)
+	pnlPct := currentPrice.Sub(pos.AvgPrice).Div(pos.AvgPrice).Mul(decimal.NewFromInt(100))
+
+	snapshot := PositionSnapshot{
+		PositionID:  pos.PositionID,
+		Symbol:      pos.Symbol,
+		Qty:         pos.Qty,
+		OriginalQty: pos.OriginalQty,
+		AvgPrice:    pos.AvgPrice,
+		EntryTS:     pos.EntryTS,
+		Version:     pos.Version,
+		Phase:       state.Phase,
+	}
+	env := s.buildRuleEnv(ctx, snapshot, state, bestPrice, currentPrice, pnlPct)
+
+	preview.PositionID = positionID
+	preview.Symbol = pos.Symbol
+	preview.Values = make(map[string]interface{}, len(preview.Variables))
+	for _, name := range preview.Variables {
+		if v, ok := env.Numbers[name]; ok {
+			preview.Values[name] = v
+		} else if v, ok := env.Strings[name]; ok {
+			preview.Values[name] = v
+		}
+	}
+
+	result, err := expr.Eval(env)
+	if err != nil {
+		// 변수 없음 (MA 데이터 없음 등) / 0 나누기 → 평가 불가 (라이브에서는 미발동)
+		preview.EvalError = err.Error()
+		return preview, nil
+	}
+	preview.Result = &result
+
+	return preview, nil
+}
@@ -0,0 +1,112 @@
+package exit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+// fakeIntentRepo 활성 intent 없음
+type fakeIntentRepo struct {
+	exit.OrderIntentRepository
+}
+
+func (fakeIntentRepo) GetActiveIntentsByPosition(ctx context.Context, positionID uuid.UUID) ([]*exit.OrderIntent, error) {
+	return nil, nil
+}
+
+// fakeDailyPrices 종목별 일봉 (trade_date 내림차순), 조회 횟수 기록
+type fakeDailyPrices struct {
+	bars  map[string][]*fetcher.DailyPrice
+	calls int
+}
+
+func (f *fakeDailyPrices) GetLatestN(ctx context.Context, stockCode string, n int) ([]*fetcher.DailyPrice, error) {
+	f.calls++
+	bars := f.bars[stockCode]
+	if len(bars) > n {
+		bars = bars[:n]
+	}
+	return bars, nil
+}
+
+// TestEvaluateCustomRulesExpression tests expression rules through evaluateCustomRules/buildRuleEnv
+func TestEvaluateCustomRulesExpression(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, calendar.KST)
+
+	// 전일까지 20거래일 종가 10000, 거래량 1000 (+ 당일 봉은 제외되어야 함)
+	bars := []*fetcher.DailyPrice{{TradeDate: now, ClosePrice: 5000, Volume: 1}}
+	for i := 1; i <= 20; i++ {
+		bars = append(bars, &fetcher.DailyPrice{TradeDate: now.AddDate(0, 0, -i), ClosePrice: 10000, Volume: 1000})
+	}
+	daily := &fakeDailyPrices{bars: map[string][]*fetcher.DailyPrice{"005930": bars}}
+
+	svc := &Service{
+		intentRepo:  fakeIntentRepo{},
+		dailyPrices: daily,
+		now:         func() time.Time { return now },
+	}
+
+	snapshot := PositionSnapshot{
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(10000),
+		EntryTS:     calendar.TradingDaysAgo(now, 3),
+	}
+	state := &exit.PositionState{Phase: exit.PhaseOpen}
+	volume := int64(3000)
+	bestPrice := &price.BestPrice{Symbol: "005930", BestPrice: 9700, Volume: &volume}
+	currentPrice := decimal.NewFromInt(9700)
+	pnlPct := decimal.NewFromInt(-3)
+
+	profile := &exit.ExitProfile{Config: exit.ExitProfileConfig{CustomRules: []exit.CustomExitRule{
+		// 우선순위 높지만 ma120 데이터 없음 → 미발동, 다음 규칙 평가
+		{ID: "ma120", Enabled: true, Condition: exit.CustomConditionExpression, Expression: "price < ma120", ExitPercent: 100, Priority: 0},
+		{ID: "ma20", Enabled: true, Condition: exit.CustomConditionExpression, Expression: "price < ma20 * 0.98 AND volume_ratio >= 3 AND days_held >= 3", ExitPercent: 50, Priority: 1},
+	}}}
+
+	trigger := svc.evaluateCustomRules(ctx, snapshot, state, bestPrice, currentPrice, pnlPct, profile)
+	if trigger == nil {
+		t.Fatal("Expected ma20 rule to trigger, got nil")
+	}
+	if trigger.ReasonCode != exit.ReasonCustom || trigger.Qty != 50 {
+		t.Errorf("Expected CUSTOM 50, got %s %d", trigger.ReasonCode, trigger.Qty)
+	}
+
+	// 변수 없음 경고: 종목당 거래일 1회
+	if svc.ruleWarned["005930"] != "2026-10-16" {
+		t.Errorf("Expected unavailable variable warning recorded for today, got %q", svc.ruleWarned["005930"])
+	}
+
+	// 파싱 캐시 + 일봉 캐시: 재평가 시 재파싱/재조회 없음
+	cached := svc.ruleExprs["ma20"]
+	if cached == nil {
+		t.Fatal("Expected parsed expression cached by rule ID")
+	}
+	svc.evaluateCustomRules(ctx, snapshot, state, bestPrice, currentPrice, pnlPct, profile)
+	if svc.ruleExprs["ma20"] != cached {
+		t.Errorf("Expected cached expression reused")
+	}
+	if daily.calls != 1 {
+		t.Errorf("Expected daily bars loaded once per day, got %d", daily.calls)
+	}
+
+	// 식 변경 → 재파싱 (조건 불충족)
+	profile.Config.CustomRules[1].Expression = "price < ma20 * 0.95"
+	if trigger := svc.evaluateCustomRules(ctx, snapshot, state, bestPrice, currentPrice, pnlPct, profile); trigger != nil {
+		t.Errorf("Expected no trigger after expression change, got %+v", trigger)
+	}
+	if svc.ruleExprs["ma20"] == cached {
+		t.Errorf("Expected expression re-parsed after change")
+	}
+}
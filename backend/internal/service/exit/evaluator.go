@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
 const (
@@ -30,6 +31,11 @@ func (s *Service) evaluationLoop() {
 				log.Error().Err(err).Msg("Exit evaluation failed")
 			}
 
+			// 승인 대기 timeout 처리 (TIMEOUT_APPROVE / TIMEOUT_CANCEL)
+			if err := s.processApprovalTimeouts(s.ctx); err != nil {
+				log.Error().Err(err).Msg("Approval timeout processing failed")
+			}
+
 		case <-s.ctx.Done():
 			return
 		}
@@ -38,13 +44,27 @@ func (s *Service) evaluationLoop() {
 
 // evaluateAllPositions evaluates all OPEN and CLOSING positions for exit triggers
 func (s *Service) evaluateAllPositions(ctx context.Context) error {
-	// 1. Check Control Gate
+	// 0. Check Control Gate
 	control, err := s.controlRepo.GetControl(ctx)
 	if err != nil {
 		return fmt.Errorf("get control: %w", err)
 	}
 
-	log.Debug().Str("mode", control.Mode).Msg("Exit control mode")
+	// 계좌별 control (global과 비교해 더 강한 제한 적용)
+	accountControls, err := s.controlRepo.GetAccountControls(ctx)
+	if err != nil {
+		return fmt.Errorf("get account controls: %w", err)
+	}
+
+	log.Debug().Str("mode", control.Mode).Int("account_controls", len(accountControls)).Msg("Exit control mode")
+
+	// 1. Check market hours (정규장 외에는 주문 불가 → 평가 생략, EMERGENCY_FLATTEN은 세션 무관)
+	scope := flattenScope{global: control, accounts: accountControls}
+	regularHours := calendar.IsRegularHours(time.Now())
+	if !regularHours && !scope.active() {
+		s.trackFlattenProgress(nil)
+		return nil
+	}
 
 	// 2. Load OPEN and CLOSING positions (모든 계정)
 	// NOTE: CLOSING 포지션도 포함하여 부분 청산 후 남은 수량도 계속 평가
@@ -53,6 +73,12 @@ func (s *Service) evaluateAllPositions(ctx context.Context) error {
 		return fmt.Errorf("get open positions: %w", err)
 	}
 
+	// 2.5. EMERGENCY_FLATTEN 계좌 포지션은 전량 청산 (일반 평가 제외)
+	positions = s.flattenPositions(ctx, scope, positions)
+	if !regularHours {
+		return nil
+	}
+
 	log.Debug().Int("count", len(positions)).Msg("Evaluating positions")
 
 	// 3. Evaluate each position
@@ -64,8 +90,13 @@ func (s *Service) evaluateAllPositions(ctx context.Context) error {
 			continue
 		}
 
+		controlMode := control.Mode
+		if accountControl, ok := accountControls[pos.AccountID]; ok {
+			controlMode = exit.EffectiveControlMode(control.Mode, accountControl.Mode)
+		}
+
 		// Evaluate position with retry
-		if err := s.evaluatePositionWithRetry(ctx, pos, control.Mode, 0); err != nil {
+		if err := s.evaluatePositionWithRetry(ctx, pos, controlMode, 0); err != nil {
 			// Skip logging for expected business logic conditions
 			if err == exit.ErrNoAvailableQty {
 				// Normal case: all quantity is locked, skip silently
@@ -304,8 +335,11 @@ func (s *Service) evaluatePosition(ctx context.Context, pos *exit.Position, cont
 		return nil
 	}
 
-	// 10. Create intent (v10 방어: Intent 생성 직전 DB 재확인)
-	return s.createIntentWithVersionCheck(ctx, snapshot, trigger)
+	// 10. Resolve approval policy (reason / profile / symbol override / control mode)
+	approval := s.resolveApproval(ctx, pos, profile, trigger.ReasonCode, controlMode)
+
+	// 11. Create intent (v10 방어: Intent 생성 직전 DB 재확인)
+	return s.createIntentWithVersionCheck(ctx, snapshot, trigger, approval)
 }
 
 // getActiveIntents retrieves active intents for a position
@@ -316,7 +350,7 @@ func (s *Service) getActiveIntents(ctx context.Context, positionID uuid.UUID) ([
 }
 
 // isMoreSevere checks if the new trigger is more severe than existing intents
-// Severity order: SL2 > SL1 > TP3 > TP2 > TP1 > TRAIL
+// Severity order: EMERGENCY_FLATTEN > SL2 > SL1 > TP3 > TP2 > TP1 > TRAIL
 func (s *Service) isMoreSevere(newReasonCode string, existingIntents []*exit.OrderIntent) bool {
 	newSeverity := getTriggerSeverity(newReasonCode)
 
@@ -336,6 +370,8 @@ func (s *Service) isMoreSevere(newReasonCode string, existingIntents []*exit.Ord
 // getTriggerSeverity returns severity score (higher = more severe)
 func getTriggerSeverity(reasonCode string) int {
 	switch reasonCode {
+	case exit.ReasonEmergencyFlatten:
+		return 1000 // Kill switch (전량 청산)
 	case exit.ReasonSL2:
 		return 100 // Most severe (full stop loss)
 	case exit.ReasonSL1:
@@ -370,7 +406,7 @@ type PositionSnapshot struct {
 }
 
 // createIntentWithVersionCheck creates an intent with version check (v10 방어)
-func (s *Service) createIntentWithVersionCheck(ctx context.Context, snapshot PositionSnapshot, trigger *exit.ExitTrigger) error {
+func (s *Service) createIntentWithVersionCheck(ctx context.Context, snapshot PositionSnapshot, trigger *exit.ExitTrigger, approval exit.ApprovalDecision) error {
 	// 1. Re-check position version (v10 방어: 버전 기반 낙관적 잠금)
 	pos, err := s.posRepo.GetPosition(ctx, snapshot.PositionID)
 	if err != nil {
@@ -420,9 +456,10 @@ func (s *Service) createIntentWithVersionCheck(ctx context.Context, snapshot Pos
 		intentType = exit.IntentTypeExitFull
 	}
 
-	// 6. Create intent (멱등) - PENDING_APPROVAL 상태로 생성 (사용자 승인 대기)
+	// 6. Create intent (멱등) - 승인 정책에 따라 NEW(AUTO) 또는 PENDING_APPROVAL(사용자 승인 대기)
 	// action_key에 Phase 포함 → 평단가 리셋 후 재발동 가능
 	actionKey := fmt.Sprintf("%s:%s:%s", snapshot.PositionID.String(), snapshot.Phase, trigger.ReasonCode)
+	now := time.Now()
 	intent := &exit.OrderIntent{
 		IntentID:     uuid.New(),
 		PositionID:   snapshot.PositionID,
@@ -434,7 +471,16 @@ func (s *Service) createIntentWithVersionCheck(ctx context.Context, snapshot Pos
 		ReasonCode:   trigger.ReasonCode,
 		ReasonDetail: trigger.ReasonDetail, // 상세 사유 (Custom rule description)
 		ActionKey:    actionKey,
-		Status:       exit.IntentStatusPendingApproval, // 사용자 승인 대기
+		Status:       approval.InitialStatus(),
+		AccountID:    pos.AccountID,
+
+		ApprovalMode:     approval.Mode,
+		ApprovalSource:   approval.Source,
+		ApprovalDeadline: approval.Deadline(now),
+	}
+	if approval.Mode == exit.ApprovalModeAuto {
+		intent.DecidedBy = exit.DecidedByPolicy
+		intent.DecidedTS = &now // 생성 = 승인 시각
 	}
 
 	err = s.intentRepo.CreateIntent(ctx, intent)
@@ -456,6 +502,10 @@ func (s *Service) createIntentWithVersionCheck(ctx context.Context, snapshot Pos
 		Str("reason", trigger.ReasonCode).
 		Int64("qty", qty).
 		Str("type", intentType).
+		Str("status", intent.Status).
+		Str("approval_mode", approval.Mode).
+		Str("approval_source", approval.Source).
+		Dur("approval_timeout", approval.Timeout).
 		Msg("Exit intent created")
 
 	// 7. Update position status to CLOSING (Exit Engine owns status)
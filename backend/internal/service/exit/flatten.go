@@ -0,0 +1,365 @@
+package exit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
+)
+
+const (
+	maxFlattenAttempts   = 10               // 포지션당 실패(FAILED/REJECTED) 허용 횟수, 초과 시 수동 개입
+	flattenRetryInterval = 30 * time.Second // 제출된 flatten 주문 잔량 재청산 최소 간격 (체결/잔고 반영 대기)
+)
+
+// flattenScope resolves EMERGENCY_FLATTEN start time per account
+// 전역/계좌 중 flatten 모드인 쪽의 전환 시각 (둘 다면 더 이른 시각)
+type flattenScope struct {
+	global   *exit.ExitControl
+	accounts map[string]*exit.ExitControl
+}
+
+// since returns flatten start time of an account (ok=false → flatten 아님)
+func (f flattenScope) since(accountID string) (time.Time, bool) {
+	var since time.Time
+	ok := false
+	if f.global != nil && f.global.Mode == exit.ControlModeEmergencyFlatten {
+		since, ok = f.global.UpdatedTS, true
+	}
+	if ctrl, found := f.accounts[accountID]; found && ctrl.Mode == exit.ControlModeEmergencyFlatten {
+		if !ok || ctrl.UpdatedTS.Before(since) {
+			since = ctrl.UpdatedTS
+		}
+		ok = true
+	}
+	return since, ok
+}
+
+// active checks if any account is in EMERGENCY_FLATTEN
+func (f flattenScope) active() bool {
+	if f.global != nil && f.global.Mode == exit.ControlModeEmergencyFlatten {
+		return true
+	}
+	for _, ctrl := range f.accounts {
+		if ctrl.Mode == exit.ControlModeEmergencyFlatten {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenPositions creates EXIT_FULL intents for positions under EMERGENCY_FLATTEN
+// 세션 무관 실행 (세션별 주문유형), exit_mode(DISABLED/MANUAL_ONLY)도 무시하는 Kill Switch
+// 반환: flatten 대상이 아닌 포지션 (일반 평가 대상)
+func (s *Service) flattenPositions(ctx context.Context, scope flattenScope, positions []*exit.Position) []*exit.Position {
+	if !scope.active() {
+		s.trackFlattenProgress(nil)
+		return positions
+	}
+
+	phase := calendar.CurrentPhase(time.Now())
+	orderType := execution.FlattenOrderType(phase)
+
+	var remaining []*exit.Position
+	flattening := []*exit.Position{}
+	for _, pos := range positions {
+		since, ok := scope.since(pos.AccountID)
+		if !ok {
+			remaining = append(remaining, pos)
+			continue
+		}
+		if pos.Qty <= 0 {
+			continue
+		}
+		flattening = append(flattening, pos)
+
+		if err := s.flattenPosition(ctx, pos, since, orderType); err != nil {
+			log.Error().
+				Err(err).
+				Str("symbol", pos.Symbol).
+				Str("account_id", pos.AccountID).
+				Str("position_id", pos.PositionID.String()).
+				Msg("Emergency flatten failed")
+		}
+	}
+
+	s.trackFlattenProgress(flattening)
+	return remaining
+}
+
+// flattenPosition drives a single position to flat
+// 1. 승인 대기/미제출 일반 intent 취소 (PENDING_APPROVAL 우회)
+// 2. 최근 flatten intent 상태에 따라 대기 / 세션 주문유형 교체 / 잔량 재청산
+func (s *Service) flattenPosition(ctx context.Context, pos *exit.Position, since time.Time, orderType string) error {
+	active, err := s.intentRepo.GetActiveIntentsByPosition(ctx, pos.PositionID)
+	if err != nil {
+		return fmt.Errorf("get active intents: %w", err)
+	}
+	for _, intent := range active {
+		if intent.ReasonCode == exit.ReasonEmergencyFlatten {
+			continue
+		}
+		if intent.Status != exit.IntentStatusPendingApproval && intent.Status != exit.IntentStatusNew {
+			continue // ACK: Execution 처리 중 (주문은 flatten 제출 시 취소)
+		}
+		if err := s.intentRepo.UpdateIntentStatus(ctx, intent.IntentID, exit.IntentStatusCancelled); err != nil {
+			return fmt.Errorf("cancel intent %s: %w", intent.IntentID, err)
+		}
+		log.Info().
+			Str("symbol", pos.Symbol).
+			Str("intent_id", intent.IntentID.String()).
+			Str("reason", intent.ReasonCode).
+			Msg("Intent superseded by emergency flatten")
+	}
+
+	attempts, err := s.loadFlattenIntents(ctx, pos.PositionID, since)
+	if err != nil {
+		return err
+	}
+
+	if len(attempts) > 0 {
+		latest := attempts[len(attempts)-1]
+		switch latest.Status {
+		case exit.IntentStatusNew:
+			if latest.OrderType == orderType {
+				return nil // 제출 대기
+			}
+			// 세션 전환 → 현재 세션 주문유형으로 교체 (예: 시간외 종가 → 시간외 단일가)
+			if err := s.intentRepo.UpdateIntentStatus(ctx, latest.IntentID, exit.IntentStatusCancelled); err != nil {
+				return fmt.Errorf("cancel stale flatten intent: %w", err)
+			}
+		case exit.IntentStatusAck:
+			return nil
+		default:
+			// 제출/실패 이후: 잔량이 주문에 잠겨 있지 않을 때만 재청산
+			if time.Since(latest.CreatedTS) < flattenRetryInterval {
+				return nil
+			}
+			availableQty, err := s.posRepo.GetAvailableQty(ctx, pos.PositionID)
+			if err != nil {
+				return fmt.Errorf("get available qty: %w", err)
+			}
+			if availableQty <= 0 {
+				return nil
+			}
+			if flattenFailures(attempts) >= maxFlattenAttempts {
+				return nil // progress 리포트에 stuck 표시
+			}
+		}
+	}
+
+	return s.createFlattenIntent(ctx, pos, orderType, since, len(attempts)+1)
+}
+
+// createFlattenIntent creates an auto-approved EXIT_FULL intent for the whole position
+// 수량 = 포지션 전량 (충돌 미체결 주문은 Execution이 제출 전 취소)
+func (s *Service) createFlattenIntent(ctx context.Context, pos *exit.Position, orderType string, since time.Time, attempt int) error {
+	actionKey := fmt.Sprintf("%s:%s:%d:%d", pos.PositionID.String(), exit.ReasonEmergencyFlatten, since.Unix(), attempt)
+	now := time.Now()
+	intent := &exit.OrderIntent{
+		IntentID:     uuid.New(),
+		PositionID:   pos.PositionID,
+		Symbol:       pos.Symbol,
+		IntentType:   exit.IntentTypeExitFull,
+		Qty:          pos.Qty,
+		OrderType:    orderType,
+		ReasonCode:   exit.ReasonEmergencyFlatten,
+		ReasonDetail: fmt.Sprintf("emergency flatten attempt %d", attempt),
+		ActionKey:    actionKey,
+		Status:       exit.IntentStatusNew, // 승인 생략
+		AccountID:    pos.AccountID,
+
+		ApprovalMode:   exit.ApprovalModeAuto,
+		ApprovalSource: "emergency_flatten",
+		DecidedBy:      exit.DecidedByKillSwitch,
+		DecidedTS:      &now,
+	}
+
+	if execution.OrderTypeNeedsPrice(orderType) {
+		limit, err := s.referenceLimitPrice(ctx, pos.Symbol)
+		if err != nil {
+			return fmt.Errorf("get best price for %s order: %w", orderType, err)
+		}
+		intent.LimitPrice = &limit
+	}
+
+	err := s.intentRepo.CreateIntent(ctx, intent)
+	if err == exit.ErrIntentExists {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("create flatten intent: %w", err)
+	}
+
+	log.Warn().
+		Str("symbol", pos.Symbol).
+		Str("account_id", pos.AccountID).
+		Int64("qty", pos.Qty).
+		Str("order_type", orderType).
+		Int("attempt", attempt).
+		Msg("🚨 Emergency flatten intent created")
+
+	if pos.Status == exit.StatusOpen {
+		if err := s.posRepo.UpdateStatus(ctx, pos.PositionID, exit.StatusClosing, pos.Version); err != nil {
+			log.Warn().Err(err).Str("symbol", pos.Symbol).Msg("Failed to update position status to CLOSING (non-fatal)")
+		}
+	}
+
+	return nil
+}
+
+// loadFlattenIntents loads flatten intents of a position since flatten start (생성순)
+func (s *Service) loadFlattenIntents(ctx context.Context, positionID uuid.UUID, since time.Time) ([]*exit.OrderIntent, error) {
+	intents, err := s.intentRepo.LoadIntentsForPosition(ctx, positionID, []string{exit.IntentTypeExitFull}, nil, since)
+	if err != nil {
+		return nil, fmt.Errorf("load flatten intents: %w", err)
+	}
+
+	flatten := intents[:0]
+	for _, intent := range intents {
+		if intent.ReasonCode == exit.ReasonEmergencyFlatten {
+			flatten = append(flatten, intent)
+		}
+	}
+	return flatten, nil
+}
+
+// flattenFailures counts failed flatten attempts (FAILED/REJECTED)
+func flattenFailures(attempts []*exit.OrderIntent) int {
+	n := 0
+	for _, intent := range attempts {
+		if intent.Status == execution.IntentStatusFailed || intent.Status == exit.IntentStatusRejected {
+			n++
+		}
+	}
+	return n
+}
+
+// trackFlattenProgress logs flatten progress on change (평가 루프 전용)
+func (s *Service) trackFlattenProgress(flattening []*exit.Position) {
+	if flattening == nil && !s.flattenActive {
+		return
+	}
+
+	var qty int64
+	for _, pos := range flattening {
+		qty += pos.Qty
+	}
+
+	switch {
+	case flattening == nil:
+		log.Info().Msg("Emergency flatten released")
+		s.flattenActive = false
+		s.flattenRemaining = 0
+		return
+	case !s.flattenActive:
+		log.Warn().Int("positions", len(flattening)).Int64("qty", qty).Msg("🚨 EMERGENCY_FLATTEN active - flattening positions")
+	case len(flattening) == s.flattenRemaining:
+		return
+	case len(flattening) == 0:
+		log.Info().Msg("✅ Emergency flatten complete - all positions flat")
+	default:
+		log.Warn().Int("positions", len(flattening)).Int64("qty", qty).Msg("Emergency flatten progress")
+	}
+
+	s.flattenActive = true
+	s.flattenRemaining = len(flattening)
+}
+
+// GetFlattenProgress reports EMERGENCY_FLATTEN progress (accountID "" → 전체 계좌)
+func (s *Service) GetFlattenProgress(ctx context.Context, accountID string) (*exit.FlattenProgress, error) {
+	control, err := s.controlRepo.GetControl(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get control: %w", err)
+	}
+	accountControls, err := s.controlRepo.GetAccountControls(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get account controls: %w", err)
+	}
+	scope := flattenScope{global: control, accounts: accountControls}
+
+	phase := calendar.CurrentPhase(time.Now())
+	progress := &exit.FlattenProgress{
+		AccountID: accountID,
+		Phase:     string(phase),
+		OrderType: execution.FlattenOrderType(phase),
+		Positions: []exit.FlattenPositionProgress{},
+	}
+
+	if accountID != "" {
+		if since, ok := scope.since(accountID); ok {
+			progress.Active = true
+			progress.StartedTS = &since
+		}
+	} else if scope.active() {
+		progress.Active = true
+	}
+	if !progress.Active {
+		return progress, nil
+	}
+
+	positions, err := s.posRepo.GetAllOpenPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get open positions: %w", err)
+	}
+
+	for _, pos := range positions {
+		if accountID != "" && pos.AccountID != accountID {
+			continue
+		}
+		since, ok := scope.since(pos.AccountID)
+		if !ok || pos.Qty <= 0 {
+			continue
+		}
+		if progress.StartedTS == nil || since.Before(*progress.StartedTS) {
+			started := since
+			progress.StartedTS = &started
+		}
+
+		attempts, err := s.loadFlattenIntents(ctx, pos.PositionID, since)
+		if err != nil {
+			return nil, err
+		}
+
+		item := exit.FlattenPositionProgress{
+			PositionID: pos.PositionID,
+			AccountID:  pos.AccountID,
+			Symbol:     pos.Symbol,
+			Qty:        pos.Qty,
+			Attempts:   len(attempts),
+			Stuck:      flattenFailures(attempts) >= maxFlattenAttempts,
+		}
+		if len(attempts) > 0 {
+			latest := attempts[len(attempts)-1]
+			item.IntentID = &latest.IntentID
+			item.IntentStatus = latest.Status
+			item.OrderType = latest.OrderType
+		}
+
+		progress.Positions = append(progress.Positions, item)
+		progress.RemainingPositions++
+		progress.RemainingQty += pos.Qty
+	}
+
+	progress.Complete = progress.RemainingPositions == 0
+	return progress, nil
+}
+
+// referenceLimitPrice returns 매수호가 (없으면 현재가) for limit-type exit orders
+func (s *Service) referenceLimitPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	bestPrice, err := s.priceSync.GetBestPrice(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	ref := bestPrice.BestPrice
+	if bestPrice.BidPrice != nil && *bestPrice.BidPrice > 0 {
+		ref = *bestPrice.BidPrice
+	}
+	return decimal.NewFromInt(ref), nil
+}
@@ -0,0 +1,208 @@
+package exit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+)
+
+// fakeFlattenIntentRepo in-memory order_intents (생성순)
+type fakeFlattenIntentRepo struct {
+	exit.OrderIntentRepository
+	intents []*exit.OrderIntent
+}
+
+func (f *fakeFlattenIntentRepo) GetActiveIntentsByPosition(ctx context.Context, positionID uuid.UUID) ([]*exit.OrderIntent, error) {
+	var active []*exit.OrderIntent
+	for _, intent := range f.intents {
+		switch intent.Status {
+		case exit.IntentStatusNew, exit.IntentStatusPendingApproval, exit.IntentStatusAck:
+			active = append(active, intent)
+		}
+	}
+	return active, nil
+}
+
+func (f *fakeFlattenIntentRepo) LoadIntentsForPosition(ctx context.Context, positionID uuid.UUID, intentTypes []string, statuses []string, since time.Time) ([]*exit.OrderIntent, error) {
+	var intents []*exit.OrderIntent
+	for _, intent := range f.intents {
+		if intent.IntentType == exit.IntentTypeExitFull && !intent.CreatedTS.Before(since) {
+			intents = append(intents, intent)
+		}
+	}
+	return intents, nil
+}
+
+func (f *fakeFlattenIntentRepo) UpdateIntentStatus(ctx context.Context, intentID uuid.UUID, status string) error {
+	for _, intent := range f.intents {
+		if intent.IntentID == intentID {
+			intent.Status = status
+		}
+	}
+	return nil
+}
+
+func (f *fakeFlattenIntentRepo) CreateIntent(ctx context.Context, intent *exit.OrderIntent) error {
+	intent.CreatedTS = time.Now()
+	f.intents = append(f.intents, intent)
+	return nil
+}
+
+// fakeFlattenPositionRepo 매도 가능 수량 + 상태 전환 기록
+type fakeFlattenPositionRepo struct {
+	exit.PositionRepository
+	available int64
+	statuses  []string
+}
+
+func (f *fakeFlattenPositionRepo) GetAvailableQty(ctx context.Context, positionID uuid.UUID) (int64, error) {
+	return f.available, nil
+}
+
+func (f *fakeFlattenPositionRepo) UpdateStatus(ctx context.Context, positionID uuid.UUID, status string, expectedVersion int) error {
+	f.statuses = append(f.statuses, status)
+	return nil
+}
+
+// TestFlattenPosition tests the flatten intent state machine (대기 / 세션 주문유형 교체 / 잔량 재청산 / 실패 한도)
+func TestFlattenPosition(t *testing.T) {
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+	stale := time.Now().Add(-2 * flattenRetryInterval)
+
+	attempt := func(status, orderType string, created time.Time) *exit.OrderIntent {
+		return &exit.OrderIntent{
+			IntentID:   uuid.New(),
+			IntentType: exit.IntentTypeExitFull,
+			OrderType:  orderType,
+			ReasonCode: exit.ReasonEmergencyFlatten,
+			Status:     status,
+			CreatedTS:  created,
+		}
+	}
+	failures := func(n int) []*exit.OrderIntent {
+		var attempts []*exit.OrderIntent
+		for i := 0; i < n; i++ {
+			attempts = append(attempts, attempt(execution.IntentStatusFailed, execution.OrderTypeMarket, stale))
+		}
+		return attempts
+	}
+
+	tests := []struct {
+		name        string
+		prior       []*exit.OrderIntent
+		available   int64
+		wantAttempt int  // 0 = 새 intent 없음
+		wantCancel  bool // 최근 flatten intent 취소 여부
+	}{
+		{"First attempt", nil, 50, 1, false},
+		{"NEW same order type waits", []*exit.OrderIntent{attempt(exit.IntentStatusNew, execution.OrderTypeMarket, stale)}, 50, 0, false},
+		{"NEW from previous session swapped", []*exit.OrderIntent{attempt(exit.IntentStatusNew, execution.OrderTypeAfterHoursClose, stale)}, 50, 2, true},
+		{"ACK waits for execution", []*exit.OrderIntent{attempt(exit.IntentStatusAck, execution.OrderTypeMarket, stale)}, 50, 0, false},
+		{"Submitted within retry interval", []*exit.OrderIntent{attempt(execution.IntentStatusSubmitted, execution.OrderTypeMarket, time.Now())}, 50, 0, false},
+		{"Submitted with qty locked", []*exit.OrderIntent{attempt(execution.IntentStatusSubmitted, execution.OrderTypeMarket, stale)}, 0, 0, false},
+		{"Submitted with remaining qty retried", []*exit.OrderIntent{attempt(execution.IntentStatusSubmitted, execution.OrderTypeMarket, stale)}, 30, 2, false},
+		{"Failed below limit retried", failures(maxFlattenAttempts - 1), 50, maxFlattenAttempts, false},
+		{"Failed at limit stops", failures(maxFlattenAttempts), 50, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intents := &fakeFlattenIntentRepo{intents: tt.prior}
+			positions := &fakeFlattenPositionRepo{available: tt.available}
+			svc := &Service{intentRepo: intents, posRepo: positions}
+			pos := &exit.Position{PositionID: uuid.New(), AccountID: "11111111-01", Symbol: "005930", Qty: 50, Status: exit.StatusOpen}
+
+			if err := svc.flattenPosition(ctx, pos, since, execution.OrderTypeMarket); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			created := intents.intents[len(tt.prior):]
+			if tt.wantAttempt == 0 {
+				if len(created) != 0 {
+					t.Fatalf("Expected no new flatten intent, got %+v", created[0])
+				}
+			} else {
+				if len(created) != 1 {
+					t.Fatalf("Expected one new flatten intent, got %d", len(created))
+				}
+				got := created[0]
+				if got.Status != exit.IntentStatusNew || got.DecidedBy != exit.DecidedByKillSwitch || got.DecidedTS == nil {
+					t.Errorf("Expected auto-approved NEW intent with decided_ts, got %s (decided_by=%s, decided_ts=%v)", got.Status, got.DecidedBy, got.DecidedTS)
+				}
+				if got.OrderType != execution.OrderTypeMarket || got.Qty != pos.Qty {
+					t.Errorf("Expected MKT full qty %d, got %s %d", pos.Qty, got.OrderType, got.Qty)
+				}
+				if want := fmt.Sprintf("emergency flatten attempt %d", tt.wantAttempt); got.ReasonDetail != want {
+					t.Errorf("Expected %q, got %q", want, got.ReasonDetail)
+				}
+				if len(positions.statuses) != 1 || positions.statuses[0] != exit.StatusClosing {
+					t.Errorf("Expected position moved to CLOSING, got %v", positions.statuses)
+				}
+			}
+
+			if len(tt.prior) > 0 {
+				latest := tt.prior[len(tt.prior)-1]
+				if cancelled := latest.Status == exit.IntentStatusCancelled; cancelled != tt.wantCancel {
+					t.Errorf("Expected latest flatten intent cancelled=%v, got status %s", tt.wantCancel, latest.Status)
+				}
+			}
+		})
+	}
+
+	t.Run("Pending intents superseded", func(t *testing.T) {
+		pending := &exit.OrderIntent{IntentID: uuid.New(), IntentType: exit.IntentTypeExitPartial, ReasonCode: exit.ReasonTP1, Status: exit.IntentStatusPendingApproval}
+		acked := &exit.OrderIntent{IntentID: uuid.New(), IntentType: exit.IntentTypeExitPartial, ReasonCode: exit.ReasonTP2, Status: exit.IntentStatusAck}
+		intents := &fakeFlattenIntentRepo{intents: []*exit.OrderIntent{pending, acked}}
+		svc := &Service{intentRepo: intents, posRepo: &fakeFlattenPositionRepo{available: 50}}
+		pos := &exit.Position{PositionID: uuid.New(), Symbol: "005930", Qty: 50, Status: exit.StatusClosing}
+
+		if err := svc.flattenPosition(ctx, pos, since, execution.OrderTypeMarket); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if pending.Status != exit.IntentStatusCancelled {
+			t.Errorf("Expected pending approval intent cancelled, got %s", pending.Status)
+		}
+		if acked.Status != exit.IntentStatusAck {
+			t.Errorf("Expected ACK intent left to execution, got %s", acked.Status)
+		}
+		if len(intents.intents) != 3 {
+			t.Errorf("Expected flatten intent created, got %d intents", len(intents.intents))
+		}
+	})
+}
+
+// TestFlattenScope tests flatten start time resolution across global/account controls
+func TestFlattenScope(t *testing.T) {
+	early := time.Date(2026, 3, 10, 9, 1, 0, 0, time.UTC)
+	late := early.Add(5 * time.Minute)
+
+	scope := flattenScope{
+		global: &exit.ExitControl{Mode: exit.ControlModePauseAll, UpdatedTS: early},
+		accounts: map[string]*exit.ExitControl{
+			"11111111-01": {Mode: exit.ControlModeEmergencyFlatten, UpdatedTS: late},
+		},
+	}
+	if !scope.active() {
+		t.Fatalf("Expected flatten active for account control")
+	}
+	if since, ok := scope.since("11111111-01"); !ok || !since.Equal(late) {
+		t.Errorf("Expected account flatten since %s, got %s (ok=%v)", late, since, ok)
+	}
+	if _, ok := scope.since("22222222-01"); ok {
+		t.Errorf("Expected no flatten for other account")
+	}
+
+	scope.global = &exit.ExitControl{Mode: exit.ControlModeEmergencyFlatten, UpdatedTS: early}
+	if since, ok := scope.since("11111111-01"); !ok || !since.Equal(early) {
+		t.Errorf("Expected earliest flatten start %s, got %s", early, since)
+	}
+	if _, ok := scope.since("22222222-01"); !ok {
+		t.Errorf("Expected global flatten to cover all accounts")
+	}
+}
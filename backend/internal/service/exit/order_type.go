@@ -0,0 +1,71 @@
+package exit
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// applyRuleOrderType applies per-rule order type override to a trigger
+// - 지정가 계열(COND_LMT, IOC_LMT, ...)은 가격이 없으면 매수호가(없으면 현재가)로 설정
+// - 시장가 계열은 LimitPrice 제거
+func applyRuleOrderType(trigger *exit.ExitTrigger, profile *exit.ExitProfile, bestPrice *price.BestPrice) *exit.ExitTrigger {
+	if override := profile.Config.RuleOrderType(trigger.ReasonCode); override != "" {
+		trigger.OrderType = override
+	}
+
+	if !execution.OrderTypeNeedsPrice(trigger.OrderType) {
+		trigger.LimitPrice = nil
+		return trigger
+	}
+
+	if trigger.LimitPrice == nil && bestPrice != nil {
+		ref := bestPrice.BestPrice
+		if bestPrice.BidPrice != nil && *bestPrice.BidPrice > 0 {
+			ref = *bestPrice.BidPrice
+		}
+		limit := decimal.NewFromInt(ref)
+		trigger.LimitPrice = &limit
+	}
+	return trigger
+}
+
+// customRuleOrderType returns order type of a custom rule (기본 MKT)
+func customRuleOrderType(rule exit.CustomExitRule) string {
+	if rule.OrderType != "" {
+		return rule.OrderType
+	}
+	return exit.OrderTypeMKT
+}
+
+// validateProfileOrderTypes checks all order type overrides are supported
+func validateProfileOrderTypes(cfg exit.ExitProfileConfig) error {
+	for rule, orderType := range cfg.OrderTypeOverrides() {
+		if !execution.IsValidOrderType(orderType) {
+			return fmt.Errorf("%w: %s (rule %s)", execution.ErrInvalidOrderType, orderType, rule)
+		}
+	}
+	return nil
+}
+
+// validateTrailingConfig checks trailing mode and its required parameters
+func validateTrailingConfig(cfg exit.TrailingConfig) error {
+	switch cfg.Mode {
+	case "", exit.TrailModePct:
+		return nil
+	case exit.TrailModeATR:
+		if cfg.ATRK <= 0 {
+			return fmt.Errorf("%w: trailing mode %s requires atr_k > 0", exit.ErrInvalidProfile, cfg.Mode)
+		}
+		return nil
+	case exit.TrailModeTighter, exit.TrailModeLooser:
+		if cfg.ATRK <= 0 || cfg.PctTrail <= 0 {
+			return fmt.Errorf("%w: trailing mode %s requires atr_k and pct_trail > 0", exit.ErrInvalidProfile, cfg.Mode)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown trailing mode %s", exit.ErrInvalidProfile, cfg.Mode)
+}
@@ -0,0 +1,329 @@
+package exit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// Replayer replays a simulated position through the real Exit trigger evaluation and FSM (backtest)
+// - 트리거 평가: evaluateTriggers (ATR 스케일링, 우선순위, breach tick 확인 포함)
+// - 상태 전이: FSMHandler (TP1 → Stop Floor, TP3 → Trailing, HWM 갱신)
+// - 멱등: 라이브와 동일한 action_key ({phase}:{reason}) 기준 1회 발동
+type Replayer struct {
+	svc     *Service
+	fsm     *FSMHandler
+	pos     *exit.Position
+	profile *exit.ExitProfile
+	fired   map[string]bool
+	now     time.Time
+}
+
+// NewReplayer creates a replayer for one historical entry
+// atrPct: 진입 시점 ATR 비율 (nil = 스케일링 없음), atrWon: ATR 원 단위 (nil = ATR trailing 불가 → PCT)
+func NewReplayer(profile *exit.ExitProfile, symbol string, qty int64, avgPrice decimal.Decimal, entryTS time.Time, atrPct, atrWon *decimal.Decimal) *Replayer {
+	pos := &exit.Position{
+		PositionID:  uuid.New(),
+		Symbol:      symbol,
+		Qty:         qty,
+		OriginalQty: qty,
+		AvgPrice:    avgPrice,
+		EntryTS:     entryTS,
+		Status:      exit.StatusOpen,
+		Version:     1,
+	}
+
+	states := &replayStateRepo{states: map[uuid.UUID]*exit.PositionState{
+		pos.PositionID: {
+			PositionID:   pos.PositionID,
+			Phase:        exit.PhaseOpen,
+			ATR:          atrPct,
+			ATRWon:       atrWon,
+			LastAvgPrice: &avgPrice,
+		},
+	}}
+	positions := &replayPositionRepo{pos: pos}
+
+	r := &Replayer{
+		pos:     pos,
+		profile: profile,
+		fired:   make(map[string]bool),
+		now:     entryTS,
+	}
+	r.svc = &Service{
+		posRepo:        positions,
+		stateRepo:      states,
+		intentRepo:     replayIntentRepo{},
+		defaultProfile: profile,
+		now:            func() time.Time { return r.now },
+	}
+	r.fsm = NewFSMHandler(states, positions)
+
+	return r
+}
+
+// Qty returns remaining simulated qty
+func (r *Replayer) Qty() int64 {
+	return r.pos.Qty
+}
+
+// Phase returns current FSM phase
+func (r *Replayer) Phase(ctx context.Context) string {
+	state, err := r.svc.stateRepo.GetState(ctx, r.pos.PositionID)
+	if err != nil {
+		return ""
+	}
+	return state.Phase
+}
+
+// Evaluate evaluates exit triggers at simulated time ts with the exit-side (bid) price
+// Returns nil if no trigger hit or the trigger already fired in this phase
+func (r *Replayer) Evaluate(ctx context.Context, ts time.Time, bid decimal.Decimal) (*exit.ExitTrigger, error) {
+	if r.pos.Qty <= 0 {
+		return nil, nil
+	}
+	r.now = ts
+
+	// HWM 갱신 (TRAILING_ACTIVE에서만, 라이브 evaluatePosition과 동일)
+	if err := r.fsm.UpdateHWM(ctx, r.pos.PositionID, bid); err != nil {
+		return nil, fmt.Errorf("update hwm: %w", err)
+	}
+
+	state, err := r.svc.stateRepo.GetState(ctx, r.pos.PositionID)
+	if err != nil {
+		return nil, fmt.Errorf("get state: %w", err)
+	}
+
+	snapshot := PositionSnapshot{
+		PositionID:  r.pos.PositionID,
+		Symbol:      r.pos.Symbol,
+		Qty:         r.pos.Qty,
+		OriginalQty: r.pos.OriginalQty,
+		AvgPrice:    r.pos.AvgPrice,
+		EntryTS:     r.pos.EntryTS,
+		Version:     r.pos.Version,
+		Phase:       state.Phase,
+	}
+
+	bidInt := bid.IntPart()
+	bestPrice := &price.BestPrice{
+		Symbol:    r.pos.Symbol,
+		BestPrice: bidInt,
+		BidPrice:  &bidInt,
+		BestTS:    ts,
+	}
+
+	trigger := r.svc.evaluateTriggers(ctx, snapshot, state, bestPrice, r.profile, exit.ControlModeRunning)
+	if trigger == nil {
+		return nil, nil
+	}
+
+	// action_key 멱등 (라이브: {position_id}:{phase}:{reason} unique)
+	actionKey := state.Phase + ":" + trigger.ReasonCode
+	if r.fired[actionKey] {
+		return nil, nil
+	}
+	r.fired[actionKey] = true
+
+	if trigger.Qty > r.pos.Qty {
+		trigger.Qty = r.pos.Qty
+	}
+	return trigger, nil
+}
+
+// ApplyFill applies a simulated fill of trigger (잔량 차감 + FSM 전이)
+func (r *Replayer) ApplyFill(ctx context.Context, trigger *exit.ExitTrigger, fillPrice decimal.Decimal) error {
+	r.pos.Qty -= trigger.Qty
+	if r.pos.Qty < 0 {
+		r.pos.Qty = 0
+	}
+
+	var err error
+	switch trigger.ReasonCode {
+	case exit.ReasonTP1:
+		err = r.fsm.HandleTP1Filled(ctx, r.pos.PositionID, r.profile)
+	case exit.ReasonTP2:
+		err = r.fsm.HandleTP2Filled(ctx, r.pos.PositionID)
+	case exit.ReasonTP3:
+		err = r.fsm.HandleTP3Filled(ctx, r.pos.PositionID, fillPrice)
+	}
+	if err != nil {
+		return err
+	}
+
+	if r.pos.Qty == 0 {
+		r.pos.Status = exit.StatusClosed
+		return r.fsm.HandleExitFilled(ctx, r.pos.PositionID)
+	}
+	return nil
+}
+
+// =============================================================================
+// In-memory repositories (replay 전용)
+// =============================================================================
+
+// replayStateRepo in-memory PositionStateRepository
+type replayStateRepo struct {
+	states map[uuid.UUID]*exit.PositionState
+}
+
+func (r *replayStateRepo) get(positionID uuid.UUID) (*exit.PositionState, error) {
+	state, ok := r.states[positionID]
+	if !ok {
+		return nil, exit.ErrPositionNotFound
+	}
+	return state, nil
+}
+
+func (r *replayStateRepo) GetState(ctx context.Context, positionID uuid.UUID) (*exit.PositionState, error) {
+	state, err := r.get(positionID)
+	if err != nil {
+		return nil, err
+	}
+	copied := *state
+	return &copied, nil
+}
+
+func (r *replayStateRepo) UpsertState(ctx context.Context, state *exit.PositionState) error {
+	copied := *state
+	r.states[state.PositionID] = &copied
+	return nil
+}
+
+func (r *replayStateRepo) UpdatePhase(ctx context.Context, positionID uuid.UUID, phase string) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.Phase = phase
+	return nil
+}
+
+func (r *replayStateRepo) UpdateHWM(ctx context.Context, positionID uuid.UUID, hwmPrice decimal.Decimal) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.HWMPrice = &hwmPrice
+	return nil
+}
+
+func (r *replayStateRepo) UpdateStopFloor(ctx context.Context, positionID uuid.UUID, stopFloorPrice decimal.Decimal) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.StopFloorPrice = &stopFloorPrice
+	return nil
+}
+
+func (r *replayStateRepo) UpdateATR(ctx context.Context, positionID uuid.UUID, atrPct, atrWon decimal.Decimal) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.ATR = &atrPct
+	state.ATRWon = &atrWon
+	return nil
+}
+
+func (r *replayStateRepo) UpdateTrailingStop(ctx context.Context, positionID uuid.UUID, stopPrice decimal.Decimal) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.TrailingStopPrice = &stopPrice
+	return nil
+}
+
+func (r *replayStateRepo) IncrementStopFloorBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.StopFloorBreachTicks++
+	return nil
+}
+
+func (r *replayStateRepo) ResetStopFloorBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.StopFloorBreachTicks = 0
+	return nil
+}
+
+func (r *replayStateRepo) IncrementTrailingBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.TrailingBreachTicks++
+	return nil
+}
+
+func (r *replayStateRepo) ResetTrailingBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.TrailingBreachTicks = 0
+	return nil
+}
+
+func (r *replayStateRepo) UpdateLastAvgPrice(ctx context.Context, positionID uuid.UUID, newAvgPrice decimal.Decimal) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.LastAvgPrice = &newAvgPrice
+	return nil
+}
+
+func (r *replayStateRepo) ResetStateToOpen(ctx context.Context, positionID uuid.UUID, newAvgPrice decimal.Decimal) error {
+	state, err := r.get(positionID)
+	if err != nil {
+		return err
+	}
+	state.Phase = exit.PhaseOpen
+	state.HWMPrice = nil
+	state.StopFloorPrice = nil
+	state.TrailingStopPrice = nil
+	state.StopFloorBreachTicks = 0
+	state.TrailingBreachTicks = 0
+	state.LastAvgPrice = &newAvgPrice
+	return nil
+}
+
+// replayPositionRepo in-memory PositionRepository (FSMHandler/evaluator가 사용하는 조회만 지원)
+type replayPositionRepo struct {
+	exit.PositionRepository
+	pos *exit.Position
+}
+
+func (r *replayPositionRepo) GetPosition(ctx context.Context, positionID uuid.UUID) (*exit.Position, error) {
+	if positionID != r.pos.PositionID {
+		return nil, exit.ErrPositionNotFound
+	}
+	copied := *r.pos
+	return &copied, nil
+}
+
+func (r *replayPositionRepo) GetAvailableQty(ctx context.Context, positionID uuid.UUID) (int64, error) {
+	return r.pos.Qty, nil
+}
+
+// replayIntentRepo 시뮬레이션 체결은 즉시 완료 → 활성 intent 없음
+type replayIntentRepo struct {
+	exit.OrderIntentRepository
+}
+
+func (replayIntentRepo) GetActiveIntentsByPosition(ctx context.Context, positionID uuid.UUID) ([]*exit.OrderIntent, error) {
+	return nil, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	"github.com/wonny/aegis/v14/internal/service/pricesync"
 )
@@ -24,14 +26,31 @@ type Service struct {
 
 	// Dependencies
 	priceSync     *pricesync.Service
+	dailyPrices   DailyPriceReader // daily ATR job + custom rule 일봉 변수 (optional)
 
 	// Default profile (loaded from config)
 	defaultProfile *exit.ExitProfile
 
+	// Clock (nil = time.Now, backtest replay에서 시뮬레이션 시각 주입)
+	now func() time.Time
+
 	// State
 	mu        sync.RWMutex
 	isRunning bool
 
+	// EMERGENCY_FLATTEN progress (평가 루프 전용, 변경 시 로그)
+	flattenActive    bool
+	flattenRemaining int
+
+	// Custom rule 일봉 변수 캐시 (종목 → 거래일 1회)
+	ruleStatsMu sync.Mutex
+	ruleStats   map[string]*ruleDailyStats
+	ruleWarned  map[string]string // 변수 없음 경고 (종목 → 거래일, 1일 1회)
+
+	// Custom rule expression 파싱 캐시 (rule ID → 파싱 결과, 식 변경 시 재파싱)
+	ruleExprMu sync.Mutex
+	ruleExprs  map[string]*cachedRuleExpr
+
 	// Context
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -63,6 +82,14 @@ func NewService(
 	}
 }
 
+// clock returns current time (simulated time in backtest replay)
+func (s *Service) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
 // Start starts the Exit evaluation loop
 func (s *Service) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -157,6 +184,7 @@ func (s *Service) CreateManualIntent(ctx context.Context, positionID uuid.UUID,
 	}
 
 	// Create intent
+	now := time.Now()
 	intent := &exit.OrderIntent{
 		IntentID:   uuid.New(),
 		PositionID: positionID,
@@ -167,6 +195,21 @@ func (s *Service) CreateManualIntent(ctx context.Context, positionID uuid.UUID,
 		ReasonCode: exit.ReasonManual,
 		ActionKey:  actionKey,
 		Status:     exit.IntentStatusNew,
+		AccountID:  pos.AccountID,
+
+		ApprovalMode:   exit.ApprovalModeAuto, // 사용자 직접 생성 → 승인 생략
+		ApprovalSource: "manual",
+		DecidedBy:      exit.DecidedByDefaultUser,
+		DecidedTS:      &now,
+	}
+
+	// 지정가 계열: 매수호가(없으면 현재가)로 가격 설정
+	if execution.OrderTypeNeedsPrice(orderType) {
+		limit, err := s.referenceLimitPrice(ctx, pos.Symbol)
+		if err != nil {
+			return fmt.Errorf("get best price for %s order: %w", orderType, err)
+		}
+		intent.LimitPrice = &limit
 	}
 
 	return s.intentRepo.CreateIntent(ctx, intent)
@@ -182,11 +225,37 @@ func (s *Service) UpdateControl(ctx context.Context, mode string, reason *string
 	return s.controlRepo.UpdateControl(ctx, mode, reason, updatedBy)
 }
 
+// GetAccountControl retrieves the exit control mode of an account (미설정 → RUNNING)
+func (s *Service) GetAccountControl(ctx context.Context, accountID string) (*exit.ExitControl, error) {
+	controls, err := s.controlRepo.GetAccountControls(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ctrl, ok := controls[accountID]; ok {
+		return ctrl, nil
+	}
+	return &exit.ExitControl{AccountID: accountID, Mode: exit.ControlModeRunning}, nil
+}
+
+// UpdateAccountControl updates the exit control mode of an account
+func (s *Service) UpdateAccountControl(ctx context.Context, accountID, mode string, reason *string, updatedBy string) error {
+	return s.controlRepo.UpdateAccountControl(ctx, accountID, mode, reason, updatedBy)
+}
+
 // GetPositionState retrieves the FSM state for a position
 func (s *Service) GetPositionState(ctx context.Context, positionID uuid.UUID) (*exit.PositionState, error) {
 	return s.stateRepo.GetState(ctx, positionID)
 }
 
+// GetPositionWithProfile retrieves a position and its currently assigned exit profile (what-if 시뮬레이션용)
+func (s *Service) GetPositionWithProfile(ctx context.Context, positionID uuid.UUID) (*exit.Position, *exit.ExitProfile, error) {
+	pos, err := s.posRepo.GetPosition(ctx, positionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pos, s.resolveExitProfile(ctx, pos), nil
+}
+
 // GetActiveProfiles retrieves all active exit profiles
 func (s *Service) GetActiveProfiles(ctx context.Context) ([]*exit.ExitProfile, error) {
 	return s.profileRepo.GetActiveProfiles(ctx)
@@ -199,6 +268,18 @@ func (s *Service) GetAllProfiles(ctx context.Context) ([]*exit.ExitProfile, erro
 
 // CreateOrUpdateProfile creates or updates an exit profile
 func (s *Service) CreateOrUpdateProfile(ctx context.Context, profile *exit.ExitProfile) error {
+	if err := validateProfileOrderTypes(profile.Config); err != nil {
+		return err
+	}
+	if err := validateTrailingConfig(profile.Config.Trailing); err != nil {
+		return err
+	}
+	if err := validateApprovalPolicy(profile.Config.Approval); err != nil {
+		return err
+	}
+	if err := validateCustomRules(profile.Config.CustomRules); err != nil {
+		return err
+	}
 	return s.profileRepo.CreateOrUpdateProfile(ctx, profile)
 }
 
@@ -209,6 +290,9 @@ func (s *Service) GetSymbolOverride(ctx context.Context, symbol string) (*exit.S
 
 // SetSymbolOverride sets or updates symbol override
 func (s *Service) SetSymbolOverride(ctx context.Context, override *exit.SymbolExitOverride) error {
+	if err := validateApprovalPolicy(override.Approval); err != nil {
+		return err
+	}
 	return s.symbolOverrideRepo.SetOverride(ctx, override)
 }
 
@@ -2,12 +2,13 @@ package exit
 
 import (
 	"context"
-	"time"
+	"errors"
 
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
 // scaleTriggerPct scales trigger percentage based on ATR
@@ -90,6 +91,8 @@ func calculateATRFactor(currentATR *decimal.Decimal, atrConfig exit.ATRConfig) f
 // Control Mode Filtering:
 // - PAUSE_PROFIT: Only SL/STOP_FLOOR triggers (block TP/TRAIL)
 // - PAUSE_ALL: No triggers (except HardStop if configured)
+//
+// 규칙별 주문유형 override (ExitProfileConfig.*.OrderType)는 applyRuleOrderType에서 적용
 func (s *Service) evaluateTriggers(
 	ctx context.Context,
 	snapshot PositionSnapshot,
@@ -97,6 +100,22 @@ func (s *Service) evaluateTriggers(
 	bestPrice *price.BestPrice,
 	profile *exit.ExitProfile,
 	controlMode string,
+) *exit.ExitTrigger {
+	trigger := s.evaluateRules(ctx, snapshot, state, bestPrice, profile, controlMode)
+	if trigger == nil {
+		return nil
+	}
+	return applyRuleOrderType(trigger, profile, bestPrice)
+}
+
+// evaluateRules evaluates exit rules in priority order (evaluateTriggers 참고)
+func (s *Service) evaluateRules(
+	ctx context.Context,
+	snapshot PositionSnapshot,
+	state *exit.PositionState,
+	bestPrice *price.BestPrice,
+	profile *exit.ExitProfile,
+	controlMode string,
 ) *exit.ExitTrigger {
 	// Calculate ATR factor for dynamic scaling
 	atrFactor := calculateATRFactor(state.ATR, profile.Config.ATR)
@@ -163,7 +182,7 @@ func (s *Service) evaluateTriggers(
 
 	// Priority 3.5: Custom Rules (user-defined exit conditions)
 	// Evaluated after SL (stop losses) but before TP (take profits)
-	if trigger := s.evaluateCustomRules(ctx, snapshot, pnlPct, profile); trigger != nil {
+	if trigger := s.evaluateCustomRules(ctx, snapshot, state, bestPrice, currentPrice, pnlPct, profile); trigger != nil {
 		return trigger
 	}
 
@@ -174,18 +193,27 @@ func (s *Service) evaluateTriggers(
 	}
 
 	// Priority 4: TP1 (5% 도달 시 10% 매도)
-	if trigger := s.evaluateTP1(snapshot, pnlPct, currentPrice, profile, atrFactor); trigger != nil {
-		return trigger
+	// 이미 완료된 TP 단계는 재평가하지 않음
+	// - action_key에 phase 포함 → TP1_DONE에서 TP1이 다시 잡히면 새 intent로 중복 매도
+	// - 하위 TP가 먼저 반환되어 상위 TP(TP2/TP3)를 가리지 않도록
+	if phaseRank(state.Phase) < phaseRank(exit.PhaseTP1Done) {
+		if trigger := s.evaluateTP1(snapshot, pnlPct, currentPrice, profile, atrFactor); trigger != nil {
+			return trigger
+		}
 	}
 
 	// Priority 5: TP2 (10% 도달 시 20% 매도)
-	if trigger := s.evaluateTP2(snapshot, pnlPct, currentPrice, profile, atrFactor); trigger != nil {
-		return trigger
+	if phaseRank(state.Phase) < phaseRank(exit.PhaseTP2Done) {
+		if trigger := s.evaluateTP2(snapshot, pnlPct, currentPrice, profile, atrFactor); trigger != nil {
+			return trigger
+		}
 	}
 
 	// Priority 6: TP3 (15% 도달 시 30% 매도)
-	if trigger := s.evaluateTP3(snapshot, pnlPct, currentPrice, profile, atrFactor); trigger != nil {
-		return trigger
+	if phaseRank(state.Phase) < phaseRank(exit.PhaseTP3Done) {
+		if trigger := s.evaluateTP3(snapshot, pnlPct, currentPrice, profile, atrFactor); trigger != nil {
+			return trigger
+		}
 	}
 
 	// Priority 7: TRAIL (Phase 1: TP2_DONE or TRAILING_ACTIVE phase)
@@ -206,6 +234,24 @@ func (s *Service) evaluateTriggers(
 	return nil
 }
 
+// phaseRank returns FSM progress order (OPEN < TP1_DONE < TP2_DONE < TP3_DONE < TRAILING_ACTIVE)
+func phaseRank(phase string) int {
+	switch phase {
+	case exit.PhaseTP1Done:
+		return 1
+	case exit.PhaseTP2Done:
+		return 2
+	case exit.PhaseTP3Done:
+		return 3
+	case exit.PhaseTrailingActive:
+		return 4
+	case exit.PhaseExited:
+		return 5
+	default:
+		return 0
+	}
+}
+
 // evaluateSL2 evaluates SL2 (full stop loss) trigger with ATR scaling
 func (s *Service) evaluateSL2(snapshot PositionSnapshot, pnlPct decimal.Decimal, profile *exit.ExitProfile, atrFactor float64) *exit.ExitTrigger {
 	// Calculate ATR-scaled threshold
@@ -498,6 +544,52 @@ func (s *Service) evaluateTP3(snapshot PositionSnapshot, pnlPct, currentPrice de
 	return nil
 }
 
+// calculateTrailingStop returns trailing stop price by TrailingConfig.Mode
+// - PCT: HWM × (1 - PctTrail)
+// - ATR: HWM - ATRK × ATR (chandelier, ATR 원 단위, 미계산 → PCT)
+// - TIGHTER / LOOSER: PCT와 ATR 중 높은 / 낮은 stop (한쪽만 가능하면 그 값)
+// 계산 불가 (PctTrail, ATRK 모두 0) → false
+func calculateTrailingStop(hwm decimal.Decimal, atrWon *decimal.Decimal, cfg exit.TrailingConfig) (decimal.Decimal, bool) {
+	one := decimal.NewFromInt(1)
+
+	var pctStop, atrStop *decimal.Decimal
+	if cfg.PctTrail > 0 {
+		v := hwm.Mul(one.Sub(decimal.NewFromFloat(cfg.PctTrail)))
+		pctStop = &v
+	}
+	if cfg.ATRK > 0 && atrWon != nil && atrWon.IsPositive() {
+		v := hwm.Sub(decimal.NewFromFloat(cfg.ATRK).Mul(*atrWon))
+		atrStop = &v
+	}
+
+	var stop *decimal.Decimal
+	switch cfg.Mode {
+	case exit.TrailModeATR:
+		stop = atrStop
+		if stop == nil {
+			stop = pctStop
+		}
+	case exit.TrailModeTighter, exit.TrailModeLooser:
+		switch {
+		case pctStop == nil:
+			stop = atrStop
+		case atrStop == nil:
+			stop = pctStop
+		case (cfg.Mode == exit.TrailModeTighter) == atrStop.GreaterThan(*pctStop):
+			stop = atrStop
+		default:
+			stop = pctStop
+		}
+	default:
+		stop = pctStop
+	}
+
+	if stop == nil {
+		return decimal.Zero, false
+	}
+	return *stop, true
+}
+
 // evaluateTrailing evaluates trailing stop trigger
 // Phase 1: Phase별 분기 + 2틱 연속 확인 (confirm_ticks=2)
 // - TP2_DONE: 잔량 50% 부분 트레일 (단발, fire_once)
@@ -509,9 +601,19 @@ func (s *Service) evaluateTrailing(ctx context.Context, snapshot PositionSnapsho
 		return nil
 	}
 
-	// Calculate trailing stop price
-	trailingPct := decimal.NewFromFloat(profile.Config.Trailing.PctTrail) // e.g., 0.03 (3%)
-	trailingStopPrice := state.HWMPrice.Mul(decimal.NewFromInt(1).Sub(trailingPct))
+	// Calculate trailing stop price (PCT / ATR chandelier)
+	trailingStopPrice, ok := calculateTrailingStop(*state.HWMPrice, state.ATRWon, profile.Config.Trailing)
+	if !ok {
+		log.Warn().Str("symbol", snapshot.Symbol).Str("mode", profile.Config.Trailing.Mode).Msg("Trailing stop not configured (pct_trail/atr_k), skipping")
+		return nil
+	}
+
+	// UI stop line용 저장 (변경 시에만)
+	if state.TrailingStopPrice == nil || !state.TrailingStopPrice.Equal(trailingStopPrice) {
+		if err := s.stateRepo.UpdateTrailingStop(ctx, snapshot.PositionID, trailingStopPrice); err != nil {
+			log.Warn().Err(err).Str("symbol", snapshot.Symbol).Msg("Failed to persist trailing stop price (non-fatal)")
+		}
+	}
 
 	if currentPrice.LessThanOrEqual(trailingStopPrice) {
 		// Phase 1: Increment breach counter
@@ -623,8 +725,8 @@ func (s *Service) evaluateTimeStop(snapshot PositionSnapshot, state *exit.Positi
 		return nil
 	}
 
-	// Calculate holding days
-	holdingDays := int(time.Since(snapshot.EntryTS).Hours() / 24)
+	// Calculate holding days (거래일 기준: 주말/휴장일 제외)
+	holdingDays := calendar.TradingDaysBetween(snapshot.EntryTS, s.clock())
 
 	// Condition 1: Max hold days exceeded
 	if holdingDays >= profile.Config.TimeStop.MaxHoldDays {
@@ -716,6 +818,9 @@ func (s *Service) evaluateHardStop(
 func (s *Service) evaluateCustomRules(
 	ctx context.Context,
 	snapshot PositionSnapshot,
+	state *exit.PositionState,
+	bestPrice *price.BestPrice,
+	currentPrice decimal.Decimal,
 	pnlPct decimal.Decimal,
 	profile *exit.ExitProfile,
 ) *exit.ExitTrigger {
@@ -724,6 +829,16 @@ func (s *Service) evaluateCustomRules(
 		return nil
 	}
 
+	// Expression 변수 (최초 expression 규칙 평가 시 1회 생성)
+	var env *exit.RuleEnv
+	ruleEnv := func() exit.RuleEnv {
+		if env == nil {
+			e := s.buildRuleEnv(ctx, snapshot, state, bestPrice, currentPrice, pnlPct)
+			env = &e
+		}
+		return *env
+	}
+
 	// Sort by priority (ascending)
 	rules := sortCustomRulesByPriority(profile.Config.CustomRules)
 
@@ -761,15 +876,20 @@ func (s *Service) evaluateCustomRules(
 			continue
 		}
 
-		// Evaluate condition
-		triggered := false
-		pnlPctFloat, _ := pnlPct.Float64()
-
-		switch rule.Condition {
-		case "profit_above":
-			triggered = pnlPctFloat >= rule.Threshold
-		case "profit_below":
-			triggered = pnlPctFloat <= rule.Threshold
+		// Evaluate condition (변수 없음/오류 → 미발동)
+		triggered, err := s.evaluateCustomRuleCondition(rule, pnlPct, ruleEnv)
+		if err != nil {
+			if errors.Is(err, exit.ErrRuleVarUnavailable) {
+				s.warnRuleVarUnavailable(snapshot.Symbol, rule, err)
+				continue
+			}
+			log.Debug().
+				Err(err).
+				Str("symbol", snapshot.Symbol).
+				Str("rule_id", rule.ID).
+				Str("expression", rule.Expression).
+				Msg("Custom rule not evaluable, skipping")
+			continue
 		}
 
 		if triggered {
@@ -787,6 +907,7 @@ func (s *Service) evaluateCustomRules(
 				Str("rule_id", rule.ID).
 				Str("condition", rule.Condition).
 				Float64("threshold", rule.Threshold).
+				Str("expression", rule.Expression).
 				Float64("exit_percent", rule.ExitPercent).
 				Str("pnl_pct", pnlPct.StringFixed(2)).
 				Int64("qty", qty).
@@ -797,7 +918,7 @@ func (s *Service) evaluateCustomRules(
 				ReasonCode:   exit.ReasonCustom,
 				ReasonDetail: rule.Description, // 맞춤규칙 상세 사유 (예: "+4%/10% 익절")
 				Qty:          qty,
-				OrderType:    exit.OrderTypeMKT,
+				OrderType:    customRuleOrderType(rule),
 			}
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
 	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
 // TestEvaluateSL2 tests SL2 trigger evaluation
@@ -17,12 +18,13 @@ func TestEvaluateSL2(t *testing.T) {
 
 	// Setup test data
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour), // 1 day ago
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour), // 1 day ago
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -69,12 +71,13 @@ func TestEvaluateSL1(t *testing.T) {
 	svc := &Service{}
 
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour), // 1 day ago
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour), // 1 day ago
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -139,17 +142,59 @@ func TestEvaluateSL1(t *testing.T) {
 	})
 }
 
+// priceAtPnL returns the price matching pnlPct (%) from snapshot avg price
+func priceAtPnL(snapshot PositionSnapshot, pnlPct decimal.Decimal) decimal.Decimal {
+	return snapshot.AvgPrice.Mul(decimal.NewFromInt(1).Add(pnlPct.Div(decimal.NewFromInt(100))))
+}
+
+// fakeStateRepo in-memory PositionStateRepository (breach tick 카운터 / trailing stop만 지원)
+type fakeStateRepo struct {
+	exit.PositionStateRepository
+	state *exit.PositionState
+}
+
+func (r *fakeStateRepo) GetState(ctx context.Context, positionID uuid.UUID) (*exit.PositionState, error) {
+	s := *r.state
+	return &s, nil
+}
+
+func (r *fakeStateRepo) IncrementStopFloorBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	r.state.StopFloorBreachTicks++
+	return nil
+}
+
+func (r *fakeStateRepo) ResetStopFloorBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	r.state.StopFloorBreachTicks = 0
+	return nil
+}
+
+func (r *fakeStateRepo) IncrementTrailingBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	r.state.TrailingBreachTicks++
+	return nil
+}
+
+func (r *fakeStateRepo) ResetTrailingBreachTicks(ctx context.Context, positionID uuid.UUID) error {
+	r.state.TrailingBreachTicks = 0
+	return nil
+}
+
+func (r *fakeStateRepo) UpdateTrailingStop(ctx context.Context, positionID uuid.UUID, stopPrice decimal.Decimal) error {
+	r.state.TrailingStopPrice = &stopPrice
+	return nil
+}
+
 // TestEvaluateTP1 tests TP1 trigger evaluation
 func TestEvaluateTP1(t *testing.T) {
 	svc := &Service{}
 
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour), // 1 day ago
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour), // 1 day ago
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -164,7 +209,7 @@ func TestEvaluateTP1(t *testing.T) {
 	t.Run("TP1 hit", func(t *testing.T) {
 		pnlPct := decimal.NewFromFloat(7.5) // +7.5%
 
-		trigger := svc.evaluateTP1(snapshot, pnlPct, profile, 1.0)
+		trigger := svc.evaluateTP1(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.0)
 
 		if trigger == nil {
 			t.Fatal("Expected TP1 trigger, got nil")
@@ -183,7 +228,7 @@ func TestEvaluateTP1(t *testing.T) {
 	t.Run("TP1 not hit", func(t *testing.T) {
 		pnlPct := decimal.NewFromFloat(5.0) // +5% (below TP1)
 
-		trigger := svc.evaluateTP1(snapshot, pnlPct, profile, 1.0)
+		trigger := svc.evaluateTP1(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.0)
 
 		if trigger != nil {
 			t.Errorf("Expected no trigger, got %+v", trigger)
@@ -196,12 +241,13 @@ func TestEvaluateTP2(t *testing.T) {
 	svc := &Service{}
 
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour), // 1 day ago
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour), // 1 day ago
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -216,7 +262,7 @@ func TestEvaluateTP2(t *testing.T) {
 	t.Run("TP2 hit", func(t *testing.T) {
 		pnlPct := decimal.NewFromFloat(10.5) // +10.5%
 
-		trigger := svc.evaluateTP2(snapshot, pnlPct, profile, 1.0)
+		trigger := svc.evaluateTP2(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.0)
 
 		if trigger == nil {
 			t.Fatal("Expected TP2 trigger, got nil")
@@ -235,7 +281,7 @@ func TestEvaluateTP2(t *testing.T) {
 	t.Run("TP2 not hit", func(t *testing.T) {
 		pnlPct := decimal.NewFromFloat(8.0) // +8% (below TP2)
 
-		trigger := svc.evaluateTP2(snapshot, pnlPct, profile, 1.0)
+		trigger := svc.evaluateTP2(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.0)
 
 		if trigger != nil {
 			t.Errorf("Expected no trigger, got %+v", trigger)
@@ -248,12 +294,13 @@ func TestEvaluateTP3(t *testing.T) {
 	svc := &Service{}
 
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour), // 1 day ago
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour), // 1 day ago
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -268,7 +315,7 @@ func TestEvaluateTP3(t *testing.T) {
 	t.Run("TP3 hit", func(t *testing.T) {
 		pnlPct := decimal.NewFromFloat(17.0) // +17%
 
-		trigger := svc.evaluateTP3(snapshot, pnlPct, profile, 1.0)
+		trigger := svc.evaluateTP3(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.0)
 
 		if trigger == nil {
 			t.Fatal("Expected TP3 trigger, got nil")
@@ -287,7 +334,7 @@ func TestEvaluateTP3(t *testing.T) {
 	t.Run("TP3 not hit", func(t *testing.T) {
 		pnlPct := decimal.NewFromFloat(12.0) // +12% (below TP3)
 
-		trigger := svc.evaluateTP3(snapshot, pnlPct, profile, 1.0)
+		trigger := svc.evaluateTP3(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.0)
 
 		if trigger != nil {
 			t.Errorf("Expected no trigger, got %+v", trigger)
@@ -298,7 +345,8 @@ func TestEvaluateTP3(t *testing.T) {
 // TestEvaluateStopFloor tests Stop Floor trigger evaluation
 func TestEvaluateStopFloor(t *testing.T) {
 	ctx := context.Background()
-	svc := &Service{}
+	repo := &fakeStateRepo{}
+	svc := &Service{stateRepo: repo}
 
 	snapshot := PositionSnapshot{
 		PositionID: uuid.New(),
@@ -320,6 +368,7 @@ func TestEvaluateStopFloor(t *testing.T) {
 
 		currentPrice := decimal.NewFromInt(70300) // Below Stop Floor
 
+		repo.state = state
 		trigger := svc.evaluateStopFloor(ctx, snapshot, currentPrice, state)
 
 		if trigger != nil {
@@ -327,19 +376,20 @@ func TestEvaluateStopFloor(t *testing.T) {
 		}
 	})
 
-	t.Run("Stop Floor - second breach but not confirmed (tick=1)", func(t *testing.T) {
+	t.Run("Stop Floor - second breach confirms (prior tick=1)", func(t *testing.T) {
 		state := &exit.PositionState{
 			Phase:                exit.PhaseTP1Done,
 			StopFloorPrice:       &stopFloorPrice,
-			StopFloorBreachTicks: 1, // Second breach, not yet >= 2
+			StopFloorBreachTicks: 1, // 이번 tick에서 2로 증가 → 확정
 		}
 
 		currentPrice := decimal.NewFromInt(70300) // Below Stop Floor
 
+		repo.state = state
 		trigger := svc.evaluateStopFloor(ctx, snapshot, currentPrice, state)
 
-		if trigger != nil {
-			t.Errorf("Expected no trigger when StopFloorBreachTicks=1, got %+v", trigger)
+		if trigger == nil {
+			t.Fatal("Expected trigger on second consecutive breach, got nil")
 		}
 	})
 
@@ -352,6 +402,7 @@ func TestEvaluateStopFloor(t *testing.T) {
 
 		currentPrice := decimal.NewFromInt(70300) // Below Stop Floor
 
+		repo.state = state
 		trigger := svc.evaluateStopFloor(ctx, snapshot, currentPrice, state)
 
 		if trigger == nil {
@@ -376,6 +427,7 @@ func TestEvaluateStopFloor(t *testing.T) {
 
 		currentPrice := decimal.NewFromInt(71000) // Above Stop Floor
 
+		repo.state = state
 		trigger := svc.evaluateStopFloor(ctx, snapshot, currentPrice, state)
 
 		if trigger != nil {
@@ -391,6 +443,7 @@ func TestEvaluateStopFloor(t *testing.T) {
 
 		currentPrice := decimal.NewFromInt(70000)
 
+		repo.state = state
 		trigger := svc.evaluateStopFloor(ctx, snapshot, currentPrice, state)
 
 		if trigger != nil {
@@ -402,7 +455,8 @@ func TestEvaluateStopFloor(t *testing.T) {
 // TestEvaluateTrailing tests Trailing Stop trigger evaluation
 func TestEvaluateTrailing(t *testing.T) {
 	ctx := context.Background()
-	svc := &Service{}
+	repo := &fakeStateRepo{}
+	svc := &Service{stateRepo: repo}
 
 	snapshot := PositionSnapshot{
 		PositionID: uuid.New(),
@@ -433,6 +487,7 @@ func TestEvaluateTrailing(t *testing.T) {
 		// Trailing stop price = 85000 * 0.96 = 81600
 		currentPrice := decimal.NewFromInt(81500) // Below trailing stop
 
+		repo.state = state
 		trigger := svc.evaluateTrailing(ctx, snapshot, currentPrice, state, profile)
 
 		if trigger != nil {
@@ -440,20 +495,21 @@ func TestEvaluateTrailing(t *testing.T) {
 		}
 	})
 
-	t.Run("Trailing stop - second breach but not confirmed (tick=1)", func(t *testing.T) {
+	t.Run("Trailing stop - second breach confirms (prior tick=1)", func(t *testing.T) {
 		state := &exit.PositionState{
 			Phase:               exit.PhaseTrailingActive,
 			HWMPrice:            &hwmPrice,
-			TrailingBreachTicks: 1, // Second breach, not yet >= 2
+			TrailingBreachTicks: 1, // 이번 tick에서 2로 증가 → 확정
 		}
 
 		// Trailing stop price = 85000 * 0.96 = 81600
 		currentPrice := decimal.NewFromInt(81500) // Below trailing stop
 
+		repo.state = state
 		trigger := svc.evaluateTrailing(ctx, snapshot, currentPrice, state, profile)
 
-		if trigger != nil {
-			t.Errorf("Expected no trigger when TrailingBreachTicks=1, got %+v", trigger)
+		if trigger == nil {
+			t.Fatal("Expected trigger on second consecutive breach, got nil")
 		}
 	})
 
@@ -467,6 +523,7 @@ func TestEvaluateTrailing(t *testing.T) {
 		// Trailing stop price = 85000 * 0.96 = 81600
 		currentPrice := decimal.NewFromInt(81500) // Below trailing stop
 
+		repo.state = state
 		trigger := svc.evaluateTrailing(ctx, snapshot, currentPrice, state, profile)
 
 		if trigger == nil {
@@ -475,8 +532,8 @@ func TestEvaluateTrailing(t *testing.T) {
 		if trigger.ReasonCode != exit.ReasonTrail {
 			t.Errorf("Expected ReasonTrail, got %s", trigger.ReasonCode)
 		}
-		if trigger.Qty != 50 {
-			t.Errorf("Expected full remaining qty 50, got %d", trigger.Qty)
+		if trigger.Qty != 25 {
+			t.Errorf("Expected 50%% of remaining qty (25), got %d", trigger.Qty)
 		}
 		if trigger.OrderType != exit.OrderTypeMKT {
 			t.Errorf("Expected MKT order, got %s", trigger.OrderType)
@@ -491,6 +548,7 @@ func TestEvaluateTrailing(t *testing.T) {
 
 		currentPrice := decimal.NewFromInt(82000) // Above trailing stop
 
+		repo.state = state
 		trigger := svc.evaluateTrailing(ctx, snapshot, currentPrice, state, profile)
 
 		if trigger != nil {
@@ -506,6 +564,7 @@ func TestEvaluateTrailing(t *testing.T) {
 
 		currentPrice := decimal.NewFromInt(82000)
 
+		repo.state = state
 		trigger := svc.evaluateTrailing(ctx, snapshot, currentPrice, state, profile)
 
 		if trigger != nil {
@@ -514,18 +573,71 @@ func TestEvaluateTrailing(t *testing.T) {
 	})
 }
 
+// TestCalculateTrailingStop tests PCT / ATR chandelier trailing stop modes
+func TestCalculateTrailingStop(t *testing.T) {
+	hwm := decimal.NewFromInt(100000)
+	atrWon := decimal.NewFromInt(2000) // ATR 2,000원 (일봉 ATR14)
+
+	// PCT 4% → 96,000 / ATR 2.5 × 2,000 → 95,000
+	tests := []struct {
+		name   string
+		cfg    exit.TrailingConfig
+		atr    *decimal.Decimal
+		want   int64
+		wantOK bool
+	}{
+		{"PCT (default)", exit.TrailingConfig{PctTrail: 0.04, ATRK: 2.5}, &atrWon, 96000, true},
+		{"ATR chandelier", exit.TrailingConfig{PctTrail: 0.04, ATRK: 2.5, Mode: exit.TrailModeATR}, &atrWon, 95000, true},
+		{"ATR missing falls back to PCT", exit.TrailingConfig{PctTrail: 0.04, ATRK: 2.5, Mode: exit.TrailModeATR}, nil, 96000, true},
+		{"Tighter", exit.TrailingConfig{PctTrail: 0.04, ATRK: 2.5, Mode: exit.TrailModeTighter}, &atrWon, 96000, true},
+		{"Looser", exit.TrailingConfig{PctTrail: 0.04, ATRK: 2.5, Mode: exit.TrailModeLooser}, &atrWon, 95000, true},
+		{"Looser without PCT", exit.TrailingConfig{ATRK: 2.5, Mode: exit.TrailModeLooser}, &atrWon, 95000, true},
+		{"Not configured", exit.TrailingConfig{Mode: exit.TrailModeATR}, &atrWon, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := calculateTrailingStop(hwm, tt.atr, tt.cfg)
+			if ok != tt.wantOK || (ok && !got.Equal(decimal.NewFromInt(tt.want))) {
+				t.Errorf("Expected %d (ok=%v), got %s (ok=%v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+
+	t.Run("Persists stop price for UI", func(t *testing.T) {
+		repo := &fakeStateRepo{state: &exit.PositionState{
+			Phase:    exit.PhaseTrailingActive,
+			HWMPrice: &hwm,
+			ATRWon:   &atrWon,
+		}}
+		svc := &Service{stateRepo: repo}
+		profile := &exit.ExitProfile{Config: exit.ExitProfileConfig{
+			Trailing: exit.TrailingConfig{ATRK: 2.5, Mode: exit.TrailModeATR},
+		}}
+		snapshot := PositionSnapshot{PositionID: uuid.New(), Symbol: "005930", Qty: 10}
+
+		if trigger := svc.evaluateTrailing(context.Background(), snapshot, decimal.NewFromInt(97000), repo.state, profile); trigger != nil {
+			t.Errorf("Expected no trigger above stop, got %+v", trigger)
+		}
+		if repo.state.TrailingStopPrice == nil || !repo.state.TrailingStopPrice.Equal(decimal.NewFromInt(95000)) {
+			t.Errorf("Expected persisted stop 95000, got %v", repo.state.TrailingStopPrice)
+		}
+	})
+}
+
 // TestTriggerPriority tests trigger priority order
 func TestTriggerPriority(t *testing.T) {
 	ctx := context.Background()
 	svc := &Service{}
 
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour), // 1 day ago
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour), // 1 day ago
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -545,7 +657,7 @@ func TestTriggerPriority(t *testing.T) {
 	}
 
 	t.Run("SL2 has highest priority (both SL1 and SL2 triggered)", func(t *testing.T) {
-		trigger := svc.evaluateTriggers(ctx, snapshot, state,bestPrice, profile, exit.ControlModeRunning)
+		trigger := svc.evaluateTriggers(ctx, snapshot, state, bestPrice, profile, exit.ControlModeRunning)
 
 		if trigger == nil {
 			t.Fatal("Expected trigger, got nil")
@@ -560,7 +672,7 @@ func TestTriggerPriority(t *testing.T) {
 			BestPrice: 75000, // +7.1% (TP1 would trigger)
 		}
 
-		trigger := svc.evaluateTriggers(ctx, snapshot, state,profitBestPrice, profile, exit.ControlModePauseProfit)
+		trigger := svc.evaluateTriggers(ctx, snapshot, state, profitBestPrice, profile, exit.ControlModePauseProfit)
 
 		if trigger != nil {
 			t.Errorf("Expected no trigger (TP blocked by PAUSE_PROFIT), got %+v", trigger)
@@ -572,7 +684,7 @@ func TestTriggerPriority(t *testing.T) {
 			BestPrice: 67500, // -3.6% (SL1 triggers)
 		}
 
-		trigger := svc.evaluateTriggers(ctx, snapshot, state,lossBestPrice, profile, exit.ControlModePauseProfit)
+		trigger := svc.evaluateTriggers(ctx, snapshot, state, lossBestPrice, profile, exit.ControlModePauseProfit)
 
 		if trigger == nil {
 			t.Fatal("Expected SL1 trigger, got nil")
@@ -582,8 +694,31 @@ func TestTriggerPriority(t *testing.T) {
 		}
 	})
 
+	t.Run("Completed TP stage is not re-fired", func(t *testing.T) {
+		// action_key는 phase 포함 ({position_id}:{phase}:{reason}) → TP1_DONE에서 TP1이 다시 잡히면 새 intent로 재매도
+		tpProfile := &exit.ExitProfile{
+			Config: exit.ExitProfileConfig{
+				TP1: exit.TriggerConfig{BasePct: 0.07, QtyPct: 0.25},
+				TP2: exit.TriggerConfig{BasePct: 0.10, QtyPct: 0.25},
+			},
+		}
+		tp1Done := &exit.PositionState{Phase: exit.PhaseTP1Done}
+
+		// +8.6%: TP1 구간이지만 TP1 완료 → 트리거 없음
+		trigger := svc.evaluateTriggers(ctx, snapshot, tp1Done, &price.BestPrice{BestPrice: 76000}, tpProfile, exit.ControlModeRunning)
+		if trigger != nil {
+			t.Errorf("Expected no trigger (TP1 already done), got %s", trigger.ReasonCode)
+		}
+
+		// +11.4%: TP1이 TP2를 가리지 않음
+		trigger = svc.evaluateTriggers(ctx, snapshot, tp1Done, &price.BestPrice{BestPrice: 78000}, tpProfile, exit.ControlModeRunning)
+		if trigger == nil || trigger.ReasonCode != exit.ReasonTP2 {
+			t.Fatalf("Expected TP2 trigger, got %+v", trigger)
+		}
+	})
+
 	t.Run("PAUSE_ALL blocks all triggers", func(t *testing.T) {
-		trigger := svc.evaluateTriggers(ctx, snapshot, state,bestPrice, profile, exit.ControlModePauseAll)
+		trigger := svc.evaluateTriggers(ctx, snapshot, state, bestPrice, profile, exit.ControlModePauseAll)
 
 		if trigger != nil {
 			t.Errorf("Expected no trigger (PAUSE_ALL), got %+v", trigger)
@@ -596,12 +731,13 @@ func TestATRScaling(t *testing.T) {
 	svc := &Service{}
 
 	snapshot := PositionSnapshot{
-		PositionID: uuid.New(),
-		Symbol:     "005930",
-		Qty:        100,
-		AvgPrice:   decimal.NewFromInt(70000),
-		EntryTS:    time.Now().Add(-24 * time.Hour),
-		Version:    1,
+		PositionID:  uuid.New(),
+		Symbol:      "005930",
+		Qty:         100,
+		OriginalQty: 100,
+		AvgPrice:    decimal.NewFromInt(70000),
+		EntryTS:     time.Now().Add(-24 * time.Hour),
+		Version:     1,
 	}
 
 	profile := &exit.ExitProfile{
@@ -683,7 +819,7 @@ func TestATRScaling(t *testing.T) {
 		// TP1 threshold = +7% * 1.4 = +9.8%
 		pnlPct := decimal.NewFromFloat(8.0) // +8% (no trigger, wider target)
 
-		trigger := svc.evaluateTP1(snapshot, pnlPct, profile, 1.4)
+		trigger := svc.evaluateTP1(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.4)
 
 		if trigger != nil {
 			t.Errorf("Expected no trigger (wider target due to high volatility), got %+v", trigger)
@@ -691,7 +827,7 @@ func TestATRScaling(t *testing.T) {
 
 		// But +10% should trigger
 		pnlPct = decimal.NewFromFloat(10.0)
-		trigger = svc.evaluateTP1(snapshot, pnlPct, profile, 1.4)
+		trigger = svc.evaluateTP1(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 1.4)
 
 		if trigger == nil {
 			t.Fatal("Expected TP1 trigger at +10%, got nil")
@@ -704,7 +840,7 @@ func TestATRScaling(t *testing.T) {
 		// But clamped to MaxPct = +10%
 		pnlPct := decimal.NewFromFloat(10.1) // +10.1% (trigger at max bound)
 
-		trigger := svc.evaluateTP1(snapshot, pnlPct, profile, 2.0)
+		trigger := svc.evaluateTP1(snapshot, pnlPct, priceAtPnL(snapshot, pnlPct), profile, 2.0)
 
 		if trigger == nil {
 			t.Fatal("Expected TP1 trigger (clamped to MaxPct), got nil")
@@ -720,12 +856,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("Max hold days exceeded", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-31 * 24 * time.Hour), // 31 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 31), // 31 trading days ago
+			Version:     1,
 		}
 
 		profile := &exit.ExitProfile{
@@ -758,12 +895,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("Max hold days not exceeded", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-20 * 24 * time.Hour), // 20 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 20), // 20 trading days ago
+			Version:     1,
 		}
 
 		profile := &exit.ExitProfile{
@@ -787,12 +925,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("No momentum with HWM", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-11 * 24 * time.Hour), // 11 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 11), // 11 trading days ago
+			Version:     1,
 		}
 
 		hwmPrice := decimal.NewFromInt(70280) // Only +0.4% max profit
@@ -824,12 +963,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("No momentum without HWM (current price)", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-11 * 24 * time.Hour), // 11 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 11), // 11 trading days ago
+			Version:     1,
 		}
 
 		lowCurrentPrice := decimal.NewFromInt(70280) // Only +0.4% current profit
@@ -861,12 +1001,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("No momentum not triggered (sufficient profit)", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-11 * 24 * time.Hour), // 11 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 11), // 11 trading days ago
+			Version:     1,
 		}
 
 		hwmPrice := decimal.NewFromInt(71000) // +1.43% max profit (above threshold)
@@ -895,12 +1036,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("No momentum not triggered (insufficient days)", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-8 * 24 * time.Hour), // Only 8 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 8), // Only 8 trading days ago
+			Version:     1,
 		}
 
 		hwmPrice := decimal.NewFromInt(70280) // Only +0.4% max profit
@@ -929,12 +1071,13 @@ func TestEvaluateTimeStop(t *testing.T) {
 
 	t.Run("MaxHoldDays disabled (0)", func(t *testing.T) {
 		snapshot := PositionSnapshot{
-			PositionID: uuid.New(),
-			Symbol:     "005930",
-			Qty:        100,
-			AvgPrice:   decimal.NewFromInt(70000),
-			EntryTS:    time.Now().Add(-100 * 24 * time.Hour), // 100 days ago
-			Version:    1,
+			PositionID:  uuid.New(),
+			Symbol:      "005930",
+			Qty:         100,
+			OriginalQty: 100,
+			AvgPrice:    decimal.NewFromInt(70000),
+			EntryTS:     calendar.TradingDaysAgo(time.Now(), 100), // 100 trading days ago
+			Version:     1,
 		}
 
 		profile := &exit.ExitProfile{
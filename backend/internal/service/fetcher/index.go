@@ -0,0 +1,109 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// indexBackfillDays 지수 일봉 최초 수집 기간 (data.index_prices 비어 있을 때)
+const indexBackfillDays = 400
+
+// SetIndexCollector sets the index daily price source and repository (optional)
+// Start 이전에 호출해야 지수 수집기가 함께 실행됨
+func (s *Service) SetIndexCollector(source fetcher.IndexSource, repo fetcher.IndexPriceRepository) {
+	s.indexSource = source
+	s.indexRepo = repo
+}
+
+// indexInterval returns index collection interval
+func (s *Service) indexInterval() time.Duration {
+	if s.config.IndexInterval > 0 {
+		return s.config.IndexInterval
+	}
+	return 1 * time.Hour
+}
+
+// collectIndexPrices 지수 일봉 수집 (KOSPI/KOSDAQ/KOSPI200 → data.index_prices)
+// 마지막 저장일부터 다시 수집 (장중 수집된 당일 봉 갱신), 최초에는 indexBackfillDays 백필
+func (s *Service) collectIndexPrices(ctx context.Context) error {
+	startTime := time.Now()
+	log.Info().Msg("Collecting index prices")
+
+	collected := 0
+	failed := 0
+
+	for _, code := range price.IndexSymbols() {
+		from := startTime.AddDate(0, 0, -indexBackfillDays)
+		latest, err := s.indexRepo.GetLatest(ctx, code)
+		if err != nil && !errors.Is(err, fetcher.ErrIndexPriceNotFound) {
+			log.Warn().Err(err).Str("index", code).Msg("Failed to get latest index price")
+			failed++
+			continue
+		}
+		if latest != nil {
+			from = latest.TradeDate
+		}
+
+		prices, err := s.indexSource.FetchDailyIndexPrices(ctx, code, from, startTime)
+		if err != nil {
+			log.Warn().Err(err).Str("index", code).Msg("Failed to fetch index prices")
+			failed++
+			continue
+		}
+
+		count, err := s.indexRepo.UpsertBatch(ctx, prices)
+		if err != nil {
+			log.Warn().Err(err).Str("index", code).Msg("Failed to save index prices")
+			failed++
+			continue
+		}
+		collected += count
+	}
+
+	// fetch_logs 기록
+	finishedAt := time.Now()
+	durationMs := int(finishedAt.Sub(startTime).Milliseconds())
+	status := "success"
+	if failed > 0 && collected == 0 {
+		status = "failed"
+	}
+
+	fetchLog := &fetcher.FetchLog{
+		JobType:         "collector",
+		Source:          "kis",
+		TargetTable:     "index_price",
+		RecordsFetched:  collected,
+		RecordsInserted: collected,
+		Status:          status,
+		StartedAt:       startTime,
+		FinishedAt:      &finishedAt,
+		DurationMs:      &durationMs,
+	}
+	if _, err := s.fetchLogRepo.Create(ctx, fetchLog); err != nil {
+		log.Warn().Err(err).Msg("Failed to save fetch log")
+	}
+
+	log.Info().
+		Int("collected", collected).
+		Int("failed", failed).
+		Msg("Index price collection completed")
+
+	if status == "failed" {
+		return fmt.Errorf("index price collection failed for all %d indices", failed)
+	}
+	return nil
+}
+
+// GetIndexPriceRange 기간별 지수 일봉 조회 (trade_date 오름차순)
+func (s *Service) GetIndexPriceRange(ctx context.Context, indexCode string, from, to time.Time) ([]*fetcher.IndexPrice, error) {
+	if s.indexRepo == nil {
+		return nil, fmt.Errorf("index repository not configured")
+	}
+	return s.indexRepo.GetRange(ctx, indexCode, from, to)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog/log"
 	"github.com/wonny/aegis/v14/internal/domain/fetcher"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
 // CollectorType 수집기 타입
@@ -21,8 +22,19 @@ const (
 	CollectorMarketCap  CollectorType = "marketcap"
 	CollectorDisclosure CollectorType = "disclosure"
 	CollectorRanking    CollectorType = "ranking"
+	CollectorIndex      CollectorType = "index"
 )
 
+// tradingDayOnly 시세 기반 수집기 (휴장일에는 신규 데이터 없음 → 스케줄 수집 생략)
+func tradingDayOnly(collectorType CollectorType) bool {
+	switch collectorType {
+	case CollectorPrice, CollectorFlow, CollectorMarketCap, CollectorIndex:
+		return true
+	default:
+		return false
+	}
+}
+
 // Config 서비스 설정
 type Config struct {
 	// 수집 간격
@@ -31,6 +43,7 @@ type Config struct {
 	FundamentalInterval time.Duration
 	MarketCapInterval   time.Duration
 	DisclosureInterval  time.Duration
+	IndexInterval       time.Duration // 지수 일봉 (0 = 1시간)
 
 	// 배치 크기
 	BatchSize int
@@ -51,6 +64,7 @@ func DefaultConfig() *Config {
 		FundamentalInterval: 24 * time.Hour,
 		MarketCapInterval:   6 * time.Hour,
 		DisclosureInterval:  30 * time.Minute,
+		IndexInterval:       1 * time.Hour,
 		BatchSize:           100,
 		MaxRetries:          3,
 		RetryBackoff:        5 * time.Second,
@@ -84,6 +98,10 @@ type Service struct {
 	fetchLogRepo    fetcher.FetchLogRepository
 	rankingRepo     fetcher.RankingRepository
 
+	// Index collector (optional, KIS 업종기간별시세)
+	indexSource fetcher.IndexSource
+	indexRepo   fetcher.IndexPriceRepository
+
 	// State
 	running bool
 	mu      sync.RWMutex
@@ -150,6 +168,11 @@ func (s *Service) Start() error {
 	go s.runCollector(CollectorDisclosure, s.config.DisclosureInterval, s.collectDisclosures)
 	go s.runCollector(CollectorRanking, 10*time.Minute, s.collectRankings)
 
+	if s.indexSource != nil && s.indexRepo != nil {
+		s.wg.Add(1)
+		go s.runCollector(CollectorIndex, s.indexInterval(), s.collectIndexPrices)
+	}
+
 	log.Info().Msg("Fetcher service started")
 	return nil
 }
@@ -190,6 +213,10 @@ func (s *Service) runCollector(collectorType CollectorType, interval time.Durati
 	for {
 		select {
 		case <-ticker.C:
+			if tradingDayOnly(collectorType) && !calendar.IsTradingDay(time.Now()) {
+				logger.Debug().Msg("Non-trading day, skipping collection")
+				continue
+			}
 			if err := collectFunc(s.ctx); err != nil {
 				logger.Error().Err(err).Msg("Collection failed")
 			}
@@ -590,6 +617,11 @@ func (s *Service) CollectNow(ctx context.Context, collectorType CollectorType) e
 		return s.collectDisclosures(ctx)
 	case CollectorRanking:
 		return s.collectRankings(ctx)
+	case CollectorIndex:
+		if s.indexSource == nil || s.indexRepo == nil {
+			return fmt.Errorf("index collector not configured")
+		}
+		return s.collectIndexPrices(ctx)
 	default:
 		return fmt.Errorf("unknown collector type: %s", collectorType)
 	}
@@ -875,6 +907,14 @@ func (s *Service) GetSchedules() []ScheduleInfo {
 			IntervalSec:   int64(s.config.DisclosureInterval / time.Second),
 		},
 	}
+	if s.indexSource != nil && s.indexRepo != nil {
+		schedules = append(schedules, ScheduleInfo{
+			CollectorType: CollectorIndex,
+			DisplayName:   "지수 일봉",
+			Interval:      formatDuration(s.indexInterval()),
+			IntervalSec:   int64(s.indexInterval() / time.Second),
+		})
+	}
 	return schedules
 }
 
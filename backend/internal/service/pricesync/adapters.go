@@ -5,6 +5,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/price"
 )
 
 // ==============================================================================
@@ -161,9 +162,10 @@ func NewSystemAdapter(pool *pgxpool.Pool) *SystemAdapter {
 // These are hardcoded for now, but could be stored in a DB table
 func (a *SystemAdapter) GetSystemSymbols(ctx context.Context) ([]string, error) {
 	// System-critical symbols:
+	// - Market indices (KOSPI/KOSDAQ/KOSPI200, KIS 업종 시세)
 	// - Major indices ETFs
 	// - Benchmark symbols for market sentiment
-	return []string{
+	return append(price.IndexSymbols(),
 		// KOSPI 200 ETF
 		"069500", // KODEX 200
 		"102110", // TIGER 200
@@ -180,7 +182,7 @@ func (a *SystemAdapter) GetSystemSymbols(ctx context.Context) ([]string, error)
 		"000660", // SK Hynix
 		"035420", // NAVER
 		"035720", // Kakao
-	}, nil
+	), nil
 }
 
 // ==============================================================================
@@ -23,6 +23,9 @@ type Broker struct {
 	// All-symbol subscribers (monitoring/debugging)
 	allSubs map[*Subscription]bool
 
+	// Order book (호가) subscribers
+	bookSubs map[*BookSubscription]bool
+
 	// Configuration
 	channelSize int // buffer size for subscription channels
 
@@ -40,6 +43,12 @@ type Subscription struct {
 	Symbol string           // Specific symbol or "*" for all
 }
 
+// BookSubscription represents an order book subscription
+type BookSubscription struct {
+	C       chan price.OrderBook // Channel to receive order book updates
+	symbols map[string]bool      // empty = all symbols
+}
+
 // PriceUpdate represents a price update event
 type PriceUpdate struct {
 	Symbol      string       `json:"symbol"`
@@ -75,6 +84,7 @@ func NewBroker(config BrokerConfig) *Broker {
 	return &Broker{
 		subscribers: make(map[string]map[*Subscription]bool),
 		allSubs:     make(map[*Subscription]bool),
+		bookSubs:    make(map[*BookSubscription]bool),
 		channelSize: config.ChannelSize,
 	}
 }
@@ -208,6 +218,45 @@ func (b *Broker) Unsubscribe(sub *Subscription) {
 		Msg("Broker: unsubscribed")
 }
 
+// SubscribeBooks creates an order book subscription for symbols (empty = all symbols)
+func (b *Broker) SubscribeBooks(symbols []string) *BookSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &BookSubscription{
+		C:       make(chan price.OrderBook, b.channelSize*max(len(symbols), 1)),
+		symbols: make(map[string]bool, len(symbols)),
+	}
+	for _, symbol := range symbols {
+		sub.symbols[symbol] = true
+	}
+
+	b.bookSubs[sub] = true
+
+	log.Debug().
+		Int("symbol_count", len(symbols)).
+		Int("book_subs", len(b.bookSubs)).
+		Msg("Broker: new order book subscription")
+
+	return sub
+}
+
+// UnsubscribeBooks removes an order book subscription
+func (b *Broker) UnsubscribeBooks(sub *BookSubscription) {
+	if sub == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.bookSubs[sub] {
+		return // Already closed
+	}
+	delete(b.bookSubs, sub)
+	close(sub.C)
+}
+
 // ==============================================================================
 // Publish Methods
 // ==============================================================================
@@ -232,6 +281,24 @@ func (b *Broker) Publish(update PriceUpdate) {
 	}
 }
 
+// PublishBook publishes an order book update to book subscribers (non-blocking)
+func (b *Broker) PublishBook(book price.OrderBook) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.bookSubs {
+		if len(sub.symbols) > 0 && !sub.symbols[book.Symbol] {
+			continue
+		}
+		select {
+		case sub.C <- book:
+			b.delivered++
+		default:
+			b.dropped++
+		}
+	}
+}
+
 // PublishFromTick publishes a price update from a tick
 func (b *Broker) PublishFromTick(tick price.Tick) {
 	update := PriceUpdate{
@@ -355,8 +422,13 @@ func (b *Broker) Close() {
 		close(sub.C)
 	}
 
+	for sub := range b.bookSubs {
+		close(sub.C)
+	}
+
 	b.subscribers = make(map[string]map[*Subscription]bool)
 	b.allSubs = make(map[*Subscription]bool)
+	b.bookSubs = make(map[*BookSubscription]bool)
 	b.activeSyms = 0
 	b.activeSubs = 0
 
@@ -226,6 +226,28 @@ func (c *PriceCache) Update(tick price.Tick) {
 	cached.IsStale = false
 }
 
+// UpdateQuote updates bid/ask of an existing cached price from the order book
+// 체결가가 없는 종목은 건드리지 않음 (BestPrice는 체결 틱 기준)
+func (c *PriceCache) UpdateQuote(book *price.OrderBook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.prices[book.Symbol]
+	if !ok {
+		return
+	}
+
+	if ask := book.BestAsk(); ask != nil {
+		p := ask.Price
+		cached.AskPrice = &p
+	}
+	if bid := book.BestBid(); bid != nil {
+		p := bid.Price
+		cached.BidPrice = &p
+	}
+	cached.UpdatedAt = time.Now()
+}
+
 // UpdateFromBestPrice updates cache from BestPrice (used in LoadFromDB)
 func (c *PriceCache) UpdateFromBestPrice(bp *price.BestPrice) {
 	c.updateFromBestPrice(bp)
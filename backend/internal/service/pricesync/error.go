@@ -8,4 +8,5 @@ var (
 	ErrTierMaxSizeExceeded = errors.New("tier max size exceeded")
 	ErrSymbolNotInTier     = errors.New("symbol not in tier")
 	ErrPriceSyncNotRunning = errors.New("price sync not running")
+	ErrKISNotConfigured    = errors.New("kis client not configured")
 )
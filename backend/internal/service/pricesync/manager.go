@@ -64,6 +64,11 @@ func NewManagerV2(serviceV2 *ServiceV2, kisClient *kis.Client, priorityManager *
 	}
 }
 
+// hasWS returns true if KIS WebSocket is available (nil kisClient = REST/Naver only)
+func (m *Manager) hasWS() bool {
+	return m.kisClient != nil && m.kisClient.WS != nil
+}
+
 // SetPriorityManager sets or updates the priority manager
 func (m *Manager) SetPriorityManager(pm *PriorityManager) {
 	m.mu.Lock()
@@ -133,7 +138,7 @@ func (m *Manager) Stop() {
 	}
 
 	// Stop WebSocket
-	if m.kisClient != nil && m.kisClient.WS != nil {
+	if m.hasWS() {
 		m.kisClient.WS.Stop()
 	}
 
@@ -157,11 +162,21 @@ func (m *Manager) Stop() {
 
 // startWebSocket initializes and starts WebSocket client
 func (m *Manager) startWebSocket() error {
+	// KIS 미사용 (paper 모드 등): REST/Naver만 사용
+	if !m.hasWS() {
+		return ErrKISNotConfigured
+	}
+
 	log.Info().Msg("Starting KIS WebSocket...")
 
 	// Set tick handler based on service version
 	if m.useV2 && m.serviceV2 != nil {
 		m.kisClient.WS.SetTickHandler(func(tick price.Tick) {
+			// 최신 호가로 bid/ask 보강 (호가 구독 종목만)
+			if tick.BidPrice == nil || tick.AskPrice == nil {
+				m.serviceV2.OrderBooks().ApplyTo(&tick)
+			}
+
 			// Process tick through ServiceV2 (with DB protection)
 			if err := m.serviceV2.ProcessTick(m.ctx, tick); err != nil {
 				log.Error().Err(err).Str("symbol", tick.Symbol).Msg("Failed to process WS tick (v2)")
@@ -169,6 +184,11 @@ func (m *Manager) startWebSocket() error {
 				log.Debug().Str("symbol", tick.Symbol).Int64("price", tick.LastPrice).Msg("Processed WS tick (v2)")
 			}
 		})
+		m.kisClient.WS.SetOrderBookHandler(func(book price.OrderBook) {
+			if err := m.serviceV2.ProcessOrderBook(m.ctx, book); err != nil {
+				log.Error().Err(err).Str("symbol", book.Symbol).Msg("Failed to process WS order book")
+			}
+		})
 	} else {
 		m.kisClient.WS.SetTickHandler(func(tick price.Tick) {
 			// Process tick through legacy service
@@ -202,7 +222,11 @@ func (m *Manager) startRESTPoller() error {
 	} else {
 		processor = m.service
 	}
-	m.restPoller = NewRESTPoller(m.kisClient.REST, m.naverClient, processor)
+	var restClient *kis.RESTClient
+	if m.kisClient != nil {
+		restClient = m.kisClient.REST
+	}
+	m.restPoller = NewRESTPoller(restClient, m.naverClient, processor)
 
 	// Start poller
 	if err := m.restPoller.Start(m.ctx); err != nil {
@@ -224,7 +248,7 @@ func (m *Manager) SubscribeSymbol(symbol string, tier Tier) error {
 	}
 
 	// Try WebSocket first (if available and space left)
-	if m.kisClient.WS != nil && m.kisClient.WS.CanSubscribe() {
+	if m.hasWS() && m.kisClient.WS.CanSubscribe() {
 		if err := m.kisClient.WS.Subscribe(symbol); err != nil {
 			log.Warn().Err(err).Str("symbol", symbol).Msg("WS subscription failed, using REST")
 			// Fallback to REST
@@ -253,7 +277,7 @@ func (m *Manager) UnsubscribeSymbol(symbol string) error {
 	}
 
 	// Try to unsubscribe from WebSocket
-	if m.kisClient.WS != nil {
+	if m.hasWS() {
 		if err := m.kisClient.WS.Unsubscribe(symbol); err == nil {
 			log.Info().Str("symbol", symbol).Msg("Unsubscribed from WebSocket")
 			return nil
@@ -286,7 +310,7 @@ func (m *Manager) GetWSSubscriptionCount() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if m.kisClient == nil || m.kisClient.WS == nil {
+	if !m.hasWS() {
 		return 0
 	}
 
@@ -379,8 +403,9 @@ func (m *Manager) RefreshSubscriptions(ctx context.Context) error {
 		return err
 	}
 
-	// 2. Get WS symbols (top 40)
+	// 2. Get WS symbols (40 slots = 체결가 + 호가)
 	wsSymbols := m.priorityManager.GetWSSymbols()
+	bookSymbols := m.priorityManager.GetBookSymbols()
 
 	// 3. Get REST tier symbols
 	tier0Symbols := m.priorityManager.GetTier0Symbols()
@@ -388,7 +413,7 @@ func (m *Manager) RefreshSubscriptions(ctx context.Context) error {
 	tier2Symbols := m.priorityManager.GetTier2Symbols()
 
 	// 4. Update WS subscriptions
-	if m.kisClient.WS != nil {
+	if m.hasWS() {
 		// Get current subscriptions
 		currentWS := m.kisClient.WS.GetSubscriptions()
 
@@ -398,17 +423,34 @@ func (m *Manager) RefreshSubscriptions(ctx context.Context) error {
 		// Find symbols to unsubscribe (in current but not in new list)
 		toUnsubscribe := difference(currentWS, wsSymbols)
 
+		// Order book diff
+		currentBooks := m.kisClient.WS.GetOrderBookSubscriptions()
+		booksToSubscribe := difference(bookSymbols, currentBooks)
+		booksToUnsubscribe := difference(currentBooks, bookSymbols)
+
+		// Unsubscribe removed symbols first (슬롯 확보 후 신규 구독)
+		for _, symbol := range toUnsubscribe {
+			if err := m.kisClient.WS.Unsubscribe(symbol); err != nil {
+				log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to unsubscribe WS")
+			}
+		}
+		for _, symbol := range booksToUnsubscribe {
+			if err := m.kisClient.WS.UnsubscribeOrderBook(symbol); err != nil {
+				log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to unsubscribe WS order book")
+			} else if m.serviceV2 != nil {
+				m.serviceV2.OrderBooks().Remove(symbol)
+			}
+		}
+
 		// Subscribe new symbols
 		for _, symbol := range toSubscribe {
 			if err := m.kisClient.WS.Subscribe(symbol); err != nil {
 				log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to subscribe WS")
 			}
 		}
-
-		// Unsubscribe removed symbols
-		for _, symbol := range toUnsubscribe {
-			if err := m.kisClient.WS.Unsubscribe(symbol); err != nil {
-				log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to unsubscribe WS")
+		for _, symbol := range booksToSubscribe {
+			if err := m.kisClient.WS.SubscribeOrderBook(symbol); err != nil {
+				log.Warn().Err(err).Str("symbol", symbol).Msg("Failed to subscribe WS order book")
 			}
 		}
 
@@ -416,6 +458,9 @@ func (m *Manager) RefreshSubscriptions(ctx context.Context) error {
 			Int("ws_total", len(wsSymbols)).
 			Int("subscribed", len(toSubscribe)).
 			Int("unsubscribed", len(toUnsubscribe)).
+			Int("book_total", len(bookSymbols)).
+			Int("book_subscribed", len(booksToSubscribe)).
+			Int("book_unsubscribed", len(booksToUnsubscribe)).
 			Msg("WS subscriptions updated")
 	}
 
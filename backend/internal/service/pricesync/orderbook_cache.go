@@ -0,0 +1,103 @@
+package pricesync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// ==============================================================================
+// OrderBookCache - 실시간 호가 (H0STASP0) in-memory 캐시
+// ==============================================================================
+
+// DefaultOrderBookMaxAge 체결 틱에 호가를 덧붙일 때 허용하는 최대 호가 경과 시간
+const DefaultOrderBookMaxAge = 5 * time.Second
+
+// OrderBookCache holds the latest order book per symbol (PriceCache와 나란히 유지)
+type OrderBookCache struct {
+	mu    sync.RWMutex
+	books map[string]*price.OrderBook // symbol → latest book
+
+	maxAge time.Duration
+}
+
+// NewOrderBookCache creates a new order book cache
+func NewOrderBookCache() *OrderBookCache {
+	return &OrderBookCache{
+		books:  make(map[string]*price.OrderBook),
+		maxAge: DefaultOrderBookMaxAge,
+	}
+}
+
+// Update replaces the cached book for the symbol
+func (c *OrderBookCache) Update(book price.OrderBook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.books[book.Symbol] = copyOrderBook(&book)
+}
+
+// Get returns cached order book for a symbol (nil if not found)
+func (c *OrderBookCache) Get(symbol string) *price.OrderBook {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	book, ok := c.books[symbol]
+	if !ok {
+		return nil
+	}
+	return copyOrderBook(book)
+}
+
+// GetMultiple returns cached order books for multiple symbols (missing symbols omitted)
+func (c *OrderBookCache) GetMultiple(symbols []string) map[string]*price.OrderBook {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make(map[string]*price.OrderBook, len(symbols))
+	for _, symbol := range symbols {
+		if book, ok := c.books[symbol]; ok {
+			result[symbol] = copyOrderBook(book)
+		}
+	}
+	return result
+}
+
+// ApplyTo fills tick bid/ask from the cached book if it is fresh enough
+// Returns true if the tick was enriched
+func (c *OrderBookCache) ApplyTo(tick *price.Tick) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	book, ok := c.books[tick.Symbol]
+	if !ok || time.Since(book.TS) > c.maxAge {
+		return false
+	}
+	book.ApplyTo(tick)
+	return true
+}
+
+// Remove removes a symbol from the cache (호가 구독 해제 시)
+func (c *OrderBookCache) Remove(symbol string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.books, symbol)
+}
+
+// Count returns number of cached books
+func (c *OrderBookCache) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.books)
+}
+
+// copyOrderBook returns a deep copy (levels slices 포함)
+func copyOrderBook(book *price.OrderBook) *price.OrderBook {
+	cp := *book
+	cp.Asks = append([]price.OrderBookLevel(nil), book.Asks...)
+	cp.Bids = append([]price.OrderBookLevel(nil), book.Bids...)
+	return &cp
+}
@@ -29,6 +29,7 @@ type TierConfig struct {
 // DefaultTierConfigs returns default tier configurations
 // Note: Rate limiting is 50ms per KIS API call
 // Tier0: 40 symbols × 50ms = 2s minimum, interval 3s (safe margin)
+//        보유종목은 MaxSize 초과해도 전부 폴링 (초과 시 주기만 늘어남, SetTierSymbols 참고)
 // Tier1: 100 symbols × 50ms = 5s minimum, interval 10s (safe margin)
 // Tier2: 200 symbols × 50ms = 10s minimum, interval 30s (batch of 200, then next batch)
 func DefaultTierConfigs() map[Tier]TierConfig {
@@ -138,7 +139,7 @@ func (p *RESTPoller) fetchTierPrices(tier Tier) {
 		Msg("REST Poller fetching prices...")
 
 	// Try KIS first
-	ticks, err := p.kisClient.GetCurrentPrices(p.ctx, symbols)
+	ticks, err := p.fetchKIS(symbols)
 
 	// Trigger Naver fallback if:
 	// 1. KIS returned an error, OR
@@ -213,6 +214,14 @@ func (p *RESTPoller) fetchTierPrices(tier Tier) {
 		Msg("✅ REST Tier prices processed")
 }
 
+// fetchKIS fetches prices from KIS (nil client = Naver only)
+func (p *RESTPoller) fetchKIS(symbols []string) ([]*price.Tick, error) {
+	if p.kisClient == nil {
+		return nil, ErrKISNotConfigured
+	}
+	return p.kisClient.GetCurrentPrices(p.ctx, symbols)
+}
+
 // FetchSymbolPrice immediately fetches price for a single symbol
 func (p *RESTPoller) FetchSymbolPrice(symbol string) error {
 	if symbol == "" {
@@ -222,7 +231,7 @@ func (p *RESTPoller) FetchSymbolPrice(symbol string) error {
 	symbols := []string{symbol}
 
 	// Try KIS first
-	ticks, err := p.kisClient.GetCurrentPrices(p.ctx, symbols)
+	ticks, err := p.fetchKIS(symbols)
 	if err != nil {
 		log.Warn().
 			Err(err).
@@ -274,7 +283,14 @@ func (p *RESTPoller) SetTierSymbols(tier Tier, symbols []string) error {
 	}
 
 	if len(symbols) > config.MaxSize {
-		return ErrTierMaxSizeExceeded
+		// Tier0 = 보유종목 전체 (WS 백업) → 누락 불가, 주기 지연 감수
+		if tier != Tier0 {
+			return ErrTierMaxSizeExceeded
+		}
+		log.Warn().
+			Int("symbol_count", len(symbols)).
+			Int("max_size", config.MaxSize).
+			Msg("Tier0 exceeds max size, polling cycle will be slower than interval")
 	}
 
 	p.tiers[tier] = symbols
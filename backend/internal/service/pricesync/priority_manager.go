@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/price"
 )
 
 // ==============================================================================
@@ -24,8 +25,19 @@ type PriorityManager struct {
 	systemRepo    SystemRepository
 	rankingRepo   RankingRepository // 랭킹 종목 (optional)
 	signalsRepo   SignalsRepository // 매수 시그널 종목 (optional)
+
+	// WS slot budget (KIS 세션당 40개, 체결가 + 호가 합산)
+	wsSlots   int
+	bookSlots int // 호가(H0STASP0) 구독에 할당할 슬롯 수
 }
 
+const (
+	// DefaultWSSlots KIS WebSocket 세션당 최대 구독 수
+	DefaultWSSlots = 40
+	// DefaultBookSlots 호가 구독에 기본 할당하는 슬롯 수
+	DefaultBookSlots = 10
+)
+
 // SymbolPriority represents priority metadata for a symbol
 type SymbolPriority struct {
 	Symbol      string
@@ -101,6 +113,19 @@ func WithSignalsRepo(repo SignalsRepository) PriorityManagerOption {
 	}
 }
 
+// WithBookSlots sets how many of the 40 WS slots are reserved for order book subscriptions
+func WithBookSlots(n int) PriorityManagerOption {
+	return func(pm *PriorityManager) {
+		if n < 0 {
+			n = 0
+		}
+		if n > pm.wsSlots {
+			n = pm.wsSlots
+		}
+		pm.bookSlots = n
+	}
+}
+
 // NewPriorityManager creates a new PriorityManager
 func NewPriorityManager(
 	positionRepo PositionRepository,
@@ -115,6 +140,8 @@ func NewPriorityManager(
 		orderRepo:     orderRepo,
 		watchlistRepo: watchlistRepo,
 		systemRepo:    systemRepo,
+		wsSlots:       DefaultWSSlots,
+		bookSlots:     DefaultBookSlots,
 	}
 
 	for _, opt := range opts {
@@ -277,16 +304,27 @@ func (pm *PriorityManager) Refresh(ctx context.Context) error {
 	return nil
 }
 
-// GetWSSymbols returns Portfolio (Holding) symbols for WS subscription
+// GetWSSymbols returns market index + Portfolio (Holding) symbols for WS trade (체결가) subscription
 // Only portfolio positions use WS - watchlist/ranking use REST only
+// 호가 구독이 사용하는 슬롯을 제외한 나머지 (40 - 호가 슬롯) 내에서 지수 → 보유 (점수 순)
 func (pm *PriorityManager) GetWSSymbols() []string {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
-	// Only return Holding symbols (max 40)
-	wsSymbols := make([]string, 0, 40)
-	for _, p := range pm.priorities {
-		if p.IsHolding && len(wsSymbols) < 40 {
+	limit := pm.wsSlots - len(pm.bookSymbols())
+	sorted := pm.getSortedPriorities()
+
+	wsSymbols := make([]string, 0, limit)
+	for _, p := range sorted {
+		if len(wsSymbols) < limit && p.IsSystem && price.IsIndexSymbol(p.Symbol) {
+			wsSymbols = append(wsSymbols, p.Symbol)
+		}
+	}
+	for _, p := range sorted {
+		if len(wsSymbols) >= limit {
+			break
+		}
+		if p.IsHolding && !price.IsIndexSymbol(p.Symbol) {
 			wsSymbols = append(wsSymbols, p.Symbol)
 		}
 	}
@@ -294,11 +332,43 @@ func (pm *PriorityManager) GetWSSymbols() []string {
 	return wsSymbols
 }
 
-// GetTier0Symbols returns Portfolio symbols for REST Tier0 (WS backup, 3s interval)
-// Same as WS symbols - provides backup in case WS disconnects
+// GetBookSymbols returns symbols for WS order book (호가) subscription
+// 점수 순 (청산 중 포지션/활성 주문 종목 우선), 최대 bookSlots개
+func (pm *PriorityManager) GetBookSymbols() []string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	return pm.bookSymbols()
+}
+
+// bookSymbols selects order book symbols (must be called with lock held)
+func (pm *PriorityManager) bookSymbols() []string {
+	symbols := make([]string, 0, pm.bookSlots)
+	for _, p := range pm.getSortedPriorities() {
+		if len(symbols) >= pm.bookSlots {
+			break
+		}
+		if p.IsClosing || p.IsOrder || p.IsHolding {
+			symbols = append(symbols, p.Symbol)
+		}
+	}
+	return symbols
+}
+
+// GetTier0Symbols returns all Portfolio symbols for REST Tier0 (WS backup, 3s interval)
+// WS 슬롯 한도와 무관하게 보유 종목 전체 포함 (WS에서 밀린 보유 종목도 SL/TP 평가 가능하도록)
+// 지수는 Tier2 (System)에서 폴링
 func (pm *PriorityManager) GetTier0Symbols() []string {
-	// Tier0 = same as WS symbols (Portfolio backup)
-	return pm.GetWSSymbols()
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	tier0 := make([]string, 0, len(pm.priorities))
+	for _, p := range pm.getSortedPriorities() {
+		if p.IsHolding && !price.IsIndexSymbol(p.Symbol) {
+			tier0 = append(tier0, p.Symbol)
+		}
+	}
+	return tier0
 }
 
 // GetTier1Symbols returns Watchlist + Orders + Signals for REST Tier1 (15s interval)
@@ -443,7 +513,10 @@ func (pm *PriorityManager) getSortedPriorities() []*SymbolPriority {
 
 	// Sort by score (descending)
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Score > sorted[j].Score
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score > sorted[j].Score
+		}
+		return sorted[i].Symbol < sorted[j].Symbol
 	})
 
 	return sorted
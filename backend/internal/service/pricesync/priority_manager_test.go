@@ -0,0 +1,92 @@
+package pricesync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+type fakePositions struct{ open, closing []PositionSummary }
+
+func (f *fakePositions) GetOpenPositions(ctx context.Context) ([]PositionSummary, error) {
+	return f.open, nil
+}
+
+func (f *fakePositions) GetClosingPositions(ctx context.Context) ([]PositionSummary, error) {
+	return f.closing, nil
+}
+
+type fakeOrders []string
+
+func (f fakeOrders) GetActiveOrderSymbols(ctx context.Context) ([]string, error) { return f, nil }
+
+type fakeSymbols []string
+
+func (f fakeSymbols) GetWatchlistSymbols(ctx context.Context) ([]string, error) { return f, nil }
+func (f fakeSymbols) GetSystemSymbols(ctx context.Context) ([]string, error)    { return f, nil }
+
+// TestPriorityManagerSlotBudget tests that trade + book subscriptions share the 40-slot budget
+func TestPriorityManagerSlotBudget(t *testing.T) {
+	positions := &fakePositions{}
+	for i := 0; i < 45; i++ {
+		positions.open = append(positions.open, PositionSummary{Symbol: fmt.Sprintf("%06d", i), Status: "OPEN"})
+	}
+	positions.closing = []PositionSummary{{Symbol: "000044", Status: "CLOSING"}}
+
+	pm := NewPriorityManager(positions, fakeOrders{"000043"}, fakeSymbols{}, fakeSymbols{}, WithBookSlots(5))
+	if err := pm.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	books := pm.GetBookSymbols()
+	trades := pm.GetWSSymbols()
+
+	if len(books) != 5 {
+		t.Fatalf("Expected 5 book symbols, got %d", len(books))
+	}
+	if len(trades)+len(books) != DefaultWSSlots {
+		t.Errorf("Expected %d total slots, got %d trade + %d book", DefaultWSSlots, len(trades), len(books))
+	}
+	top := map[string]bool{books[0]: true, books[1]: true}
+	if !top["000044"] || !top["000043"] {
+		t.Errorf("Expected closing and order symbols first, got %v", books[:2])
+	}
+}
+
+// TestPriorityManagerTier0AllHoldings tests that REST Tier0 covers holdings beyond the WS slot cap
+func TestPriorityManagerTier0AllHoldings(t *testing.T) {
+	positions := &fakePositions{}
+	for i := 0; i < 50; i++ {
+		positions.open = append(positions.open, PositionSummary{Symbol: fmt.Sprintf("%06d", i), Status: "OPEN"})
+	}
+
+	pm := NewPriorityManager(positions, fakeOrders{}, fakeSymbols{}, fakeSymbols(price.IndexSymbols()))
+	if err := pm.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	if ws := pm.GetWSSymbols(); len(ws) > DefaultWSSlots-DefaultBookSlots {
+		t.Errorf("Expected WS capped at %d, got %d", DefaultWSSlots-DefaultBookSlots, len(ws))
+	}
+
+	tier0 := pm.GetTier0Symbols()
+	if len(tier0) != 50 {
+		t.Fatalf("Expected all 50 holdings in Tier0, got %d", len(tier0))
+	}
+	for _, symbol := range tier0 {
+		if price.IsIndexSymbol(symbol) {
+			t.Errorf("Expected no index symbol in Tier0, got %s", symbol)
+		}
+	}
+
+	// REST Tier0은 MaxSize(40) 초과 보유종목도 수용
+	poller := NewRESTPoller(nil, nil, nil)
+	if err := poller.SetTierSymbols(Tier0, tier0); err != nil {
+		t.Fatalf("Expected Tier0 to accept all holdings, got %v", err)
+	}
+	if err := poller.SetTierSymbols(Tier1, make([]string, 101)); err != ErrTierMaxSizeExceeded {
+		t.Errorf("Expected Tier1 cap enforced, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/pkg/calendar"
 )
 
 // Service handles price synchronization logic
@@ -35,7 +36,7 @@ func (s *Service) ProcessTick(ctx context.Context, tick price.Tick) error {
 
 	// 2. Calculate freshness for this source
 	now := time.Now()
-	isTrading := IsMarketOpen(now) // TODO: implement market hours check
+	isTrading := IsMarketOpen(now)
 
 	threshold := price.GetThreshold(tick.Source, isTrading)
 	staleness := price.CalculateStaleness(tick.TS, now)
@@ -161,37 +162,19 @@ func (s *Service) GetFreshness(ctx context.Context, symbol string) ([]price.Fres
 	return s.repo.GetFreshnessBySymbol(ctx, symbol)
 }
 
-// IsMarketOpen checks if Korean stock market is currently open
-// KRX hours:
-//   - 08:30-09:00: 동시호가 (Pre-market, 시간외 단일가)
-//   - 09:00-15:30: 정규장 (Regular trading)
-//   - 15:30-16:00: 시간외 단일가 (After-hours)
+// IsMarketOpen checks if Korean stock market is currently open (freshness 기준)
+// KRX calendar 기준 (휴장일/연초 개장일/수능 지연 반영):
+//   - 동시호가 (Pre-open auction)
+//   - 정규장 + 장마감 동시호가 (Regular trading)
+//   - 장후 시간외 종가 (After-hours close)
+//
+// 시간외 단일가(16:00~18:00)는 10분 단위 체결이라 장중 freshness 기준에서 제외
 func IsMarketOpen(t time.Time) bool {
-	loc, _ := time.LoadLocation("Asia/Seoul")
-	seoulTime := t.In(loc)
-
-	// Check if weekend
-	weekday := seoulTime.Weekday()
-	if weekday == time.Saturday || weekday == time.Sunday {
-		return false
-	}
-
-	// Check time
-	hour := seoulTime.Hour()
-	minute := seoulTime.Minute()
-
-	// Market opens at 08:30 (동시호가 시작)
-	if hour < 8 {
+	switch calendar.CurrentPhase(t) {
+	case calendar.PhasePreOpenAuction, calendar.PhaseRegular,
+		calendar.PhaseClosingAuction, calendar.PhaseAfterHoursClose:
+		return true
+	default:
 		return false
 	}
-	if hour == 8 && minute < 30 {
-		return false
-	}
-
-	// Market closes at 16:00 (시간외 종료)
-	if hour >= 16 {
-		return false
-	}
-
-	return true
 }
@@ -16,9 +16,11 @@ import (
 // - Cache: In-memory price cache (조회 시 DB 안 감)
 // - Coalescer: DB 쓰기 debounce (1초, 가격 변화 없으면 스킵)
 // - Broker: Pub/Sub for real-time updates (UI에 푸시)
+// - OrderBookCache: 실시간 호가 캐시 (DB 저장 안 함)
 type ServiceV2 struct {
 	repo      price.PriceRepository
 	cache     *PriceCache
+	books     *OrderBookCache
 	coalescer *Coalescer
 	broker    *Broker
 }
@@ -46,6 +48,7 @@ func NewServiceV2(repo price.PriceRepository, config ServiceV2Config) *ServiceV2
 	return &ServiceV2{
 		repo:      repo,
 		cache:     cache,
+		books:     NewOrderBookCache(),
 		coalescer: coalescer,
 		broker:    broker,
 	}
@@ -109,6 +112,20 @@ func (s *ServiceV2) ProcessTick(ctx context.Context, tick price.Tick) error {
 	return nil
 }
 
+// ProcessOrderBook processes a real-time order book update
+// Flow:
+// 1. Update order book cache
+// 2. Update bid/ask of cached price (최우선 호가)
+// 3. Publish to book subscribers
+//
+// 호가는 DB에 저장하지 않음 (in-memory only)
+func (s *ServiceV2) ProcessOrderBook(ctx context.Context, book price.OrderBook) error {
+	s.books.Update(book)
+	s.cache.UpdateQuote(&book)
+	s.broker.PublishBook(book)
+	return nil
+}
+
 // ==============================================================================
 // Query Methods (Cache-first)
 // ==============================================================================
@@ -128,6 +145,11 @@ func (s *ServiceV2) GetAllCachedPrices() map[string]*CachedPrice {
 	return s.cache.GetAll()
 }
 
+// GetOrderBook returns latest order book for a symbol (nil if not subscribed)
+func (s *ServiceV2) GetOrderBook(symbol string) *price.OrderBook {
+	return s.books.Get(symbol)
+}
+
 // GetFreshness returns freshness data for a symbol (DB direct)
 func (s *ServiceV2) GetFreshness(ctx context.Context, symbol string) ([]price.Freshness, error) {
 	return s.repo.GetFreshnessBySymbol(ctx, symbol)
@@ -157,6 +179,16 @@ func (s *ServiceV2) Unsubscribe(sub *Subscription) {
 	s.broker.Unsubscribe(sub)
 }
 
+// SubscribeBooks creates an order book subscription (empty symbols = all)
+func (s *ServiceV2) SubscribeBooks(symbols []string) *BookSubscription {
+	return s.broker.SubscribeBooks(symbols)
+}
+
+// UnsubscribeBooks removes an order book subscription
+func (s *ServiceV2) UnsubscribeBooks(sub *BookSubscription) {
+	s.broker.UnsubscribeBooks(sub)
+}
+
 // ==============================================================================
 // Component Access
 // ==============================================================================
@@ -166,6 +198,11 @@ func (s *ServiceV2) Cache() *PriceCache {
 	return s.cache
 }
 
+// OrderBooks returns the order book cache
+func (s *ServiceV2) OrderBooks() *OrderBookCache {
+	return s.books
+}
+
 // Broker returns the price broker
 func (s *ServiceV2) Broker() *Broker {
 	return s.broker
@@ -0,0 +1,120 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+// bandCacheTTL 가격제한폭 캐시 유효기간 (상/하한가는 당일 고정, VI 상태 반영 주기)
+const bandCacheTTL = 10 * time.Second
+
+// BestPriceReader reads best price (현재가/전일대비 → 기준가 추정용)
+type BestPriceReader interface {
+	GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error)
+}
+
+// Guard rounds and validates outgoing limit prices
+// KRX 호가단위 + 가격제한폭(±30%) + VI 상태 기준
+type Guard struct {
+	bands   price.BandReader      // KIS 현재가 시세 (nil → 현재가 기반 추정)
+	prices  BestPriceReader       // 추정용 (optional)
+	symbols risk.SymbolInfoReader // 시장 구분 (ETF/ETN 호가단위, optional)
+
+	mu    sync.Mutex
+	cache map[string]*price.Band
+}
+
+// NewGuard creates a new price guard
+func NewGuard(bands price.BandReader, prices BestPriceReader) *Guard {
+	return &Guard{
+		bands:  bands,
+		prices: prices,
+		cache:  make(map[string]*price.Band),
+	}
+}
+
+// SetSymbolInfoReader sets market lookup for market-specific tick size (optional)
+func (g *Guard) SetSymbolInfoReader(reader risk.SymbolInfoReader) {
+	g.symbols = reader
+}
+
+// NormalizeLimitPrice rounds limit price onto tick grid and checks today's band
+// 가격제한폭 조회 실패 시 호가단위 보정만 수행 (KIS가 최종 검증)
+func (g *Guard) NormalizeLimitPrice(ctx context.Context, symbol, side, orderType string, limit *decimal.Decimal) (*decimal.Decimal, error) {
+	band, err := g.Band(ctx, symbol)
+	if err != nil {
+		log.Warn().Err(err).Str("symbol", symbol).Msg("Price band unavailable, tick rounding only")
+		band = nil
+	}
+	return execution.NormalizeLimitPrice(side, orderType, limit, band)
+}
+
+// Band returns today's price band (cached)
+func (g *Guard) Band(ctx context.Context, symbol string) (*price.Band, error) {
+	now := time.Now()
+
+	g.mu.Lock()
+	cached, ok := g.cache[symbol]
+	g.mu.Unlock()
+	if ok && now.Sub(cached.UpdatedTS) < bandCacheTTL {
+		return cached, nil
+	}
+
+	band, err := g.loadBand(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	band.Market = g.market(ctx, symbol)
+	band.UpdatedTS = now
+
+	g.mu.Lock()
+	g.cache[symbol] = band
+	g.mu.Unlock()
+	return band, nil
+}
+
+// loadBand loads band from KIS, falling back to best price (기준가 = 현재가 - 전일대비)
+func (g *Guard) loadBand(ctx context.Context, symbol string) (*price.Band, error) {
+	if g.bands != nil {
+		band, err := g.bands.GetPriceBand(ctx, symbol)
+		if err == nil {
+			return band, nil
+		}
+		log.Debug().Err(err).Str("symbol", symbol).Msg("KIS price band failed, deriving from best price")
+	}
+
+	if g.prices == nil {
+		return nil, fmt.Errorf("no price band source for %s", symbol)
+	}
+	bp, err := g.prices.GetBestPrice(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("get best price: %w", err)
+	}
+	if bp.ChangePrice == nil || bp.BestPrice-*bp.ChangePrice <= 0 {
+		return nil, fmt.Errorf("no base price for %s", symbol)
+	}
+
+	band := price.NewBand(symbol, g.market(ctx, symbol), bp.BestPrice-*bp.ChangePrice)
+	band.Derived = true
+	return band, nil
+}
+
+// market returns market of a symbol ("" if unknown)
+func (g *Guard) market(ctx context.Context, symbol string) string {
+	if g.symbols == nil {
+		return ""
+	}
+	infos, err := g.symbols.LoadSymbolInfo(ctx, []string{symbol})
+	if err != nil {
+		return ""
+	}
+	return infos[symbol].Market
+}
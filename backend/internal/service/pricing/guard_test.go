@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+type fakeBands struct {
+	band *price.Band
+	err  error
+}
+
+func (f *fakeBands) GetPriceBand(ctx context.Context, symbol string) (*price.Band, error) {
+	return f.band, f.err
+}
+
+type fakePrices struct {
+	bp *price.BestPrice
+}
+
+func (f *fakePrices) GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error) {
+	return f.bp, nil
+}
+
+// TestGuardDerivesBandFromBestPrice tests fallback band from current price and change
+func TestGuardDerivesBandFromBestPrice(t *testing.T) {
+	change := int64(2_000)
+	g := NewGuard(&fakeBands{err: errors.New("KIS unavailable")}, &fakePrices{bp: &price.BestPrice{
+		Symbol:      "005930",
+		BestPrice:   12_000,
+		BestTS:      time.Now(),
+		ChangePrice: &change,
+	}})
+
+	band, err := g.Band(context.Background(), "005930")
+	if err != nil {
+		t.Fatalf("Band failed: %v", err)
+	}
+	if !band.Derived || band.BasePrice != 10_000 || band.Upper != 13_000 || band.Lower != 7_000 {
+		t.Fatalf("Expected derived band 10000 (13000/7000), got %+v", band)
+	}
+
+	limit := decimal.NewFromInt(13_500)
+	if _, err := g.NormalizeLimitPrice(context.Background(), "005930", execution.SideSell, execution.OrderTypeLimit, &limit); !errors.Is(err, execution.ErrPriceOutOfBand) {
+		t.Errorf("Expected ErrPriceOutOfBand, got %v", err)
+	}
+}
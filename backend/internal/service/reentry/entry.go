@@ -0,0 +1,98 @@
+package reentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/reentry"
+)
+
+// createEntryIntent sizes and writes an ENTRY intent for a READY candidate
+func (s *Service) createEntryIntent(ctx context.Context, candidate *reentry.ReentryCandidate, profile *reentry.ReentryProfile) error {
+	if candidate.ReadyReasonCode == nil || candidate.ReadyPrice == nil {
+		return fmt.Errorf("ready candidate has no trigger info")
+	}
+
+	// 1. Position sizing (FIXED/PERCENT/KELLY, capped by SizingMax)
+	qty, err := s.calculateEntryQty(ctx, profile.Config, *candidate.ReadyPrice)
+	if err != nil {
+		return fmt.Errorf("calculate entry qty: %w", err)
+	}
+
+	if qty <= 0 {
+		log.Debug().
+			Str("candidate_id", candidate.CandidateID.String()).
+			Str("symbol", candidate.Symbol).
+			Str("sizing_mode", profile.Config.SizingMode).
+			Msg("Entry qty is zero, skipping entry")
+		return nil
+	}
+
+	// 2. Build intent (action_key: {candidate_id}:ENTRY:{n})
+	intent := &reentry.EntryIntent{
+		IntentID:    uuid.New(),
+		PositionID:  uuid.New(),
+		CandidateID: candidate.CandidateID,
+		Symbol:      candidate.Symbol,
+		Qty:         qty,
+		OrderType:   exit.OrderTypeMKT,
+		ReasonCode:  *candidate.ReadyReasonCode,
+		ActionKey:   fmt.Sprintf("%s:%s:%d", candidate.CandidateID, execution.IntentTypeEntry, candidate.ReentryCount+1),
+	}
+
+	// 3. Write intent (idempotent)
+	if err := s.intentWriter.CreateEntryIntent(ctx, intent); err != nil {
+		if errors.Is(err, reentry.ErrEntryIntentExists) {
+			log.Debug().
+				Str("candidate_id", candidate.CandidateID.String()).
+				Str("action_key", intent.ActionKey).
+				Msg("ENTRY intent already exists (idempotent)")
+			return nil
+		}
+		return fmt.Errorf("create entry intent: %w", err)
+	}
+
+	log.Info().
+		Str("candidate_id", candidate.CandidateID.String()).
+		Str("intent_id", intent.IntentID.String()).
+		Str("symbol", candidate.Symbol).
+		Str("reason", intent.ReasonCode).
+		Int64("qty", qty).
+		Str("ready_price", candidate.ReadyPrice.String()).
+		Msg("ENTRY intent created")
+
+	return nil
+}
+
+// OnEntryFilled implements execution.EntryFillHandler
+// ENTRY 체결 → 포지션 생성 완료 → Candidate READY → ENTERED (reentry_count++)
+func (s *Service) OnEntryFilled(ctx context.Context, intent *exit.OrderIntent, position *exit.Position) error {
+	candidate, err := s.candidateRepo.GetCandidateByEntryIntent(ctx, intent.IntentID)
+	if err != nil {
+		if errors.Is(err, reentry.ErrCandidateNotFound) {
+			// Not a reentry intent (other ENTRY source)
+			return nil
+		}
+		return fmt.Errorf("get candidate by entry intent: %w", err)
+	}
+
+	if err := s.candidateRepo.MarkEntered(ctx, candidate.CandidateID); err != nil {
+		return fmt.Errorf("update state to ENTERED: %w", err)
+	}
+
+	log.Info().
+		Str("candidate_id", candidate.CandidateID.String()).
+		Str("symbol", candidate.Symbol).
+		Str("position_id", position.PositionID.String()).
+		Int64("qty", position.Qty).
+		Str("avg_price", position.AvgPrice.String()).
+		Int("reentry_count", candidate.ReentryCount+1).
+		Msg("Candidate → ENTERED")
+
+	return nil
+}
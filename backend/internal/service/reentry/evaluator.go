@@ -93,8 +93,10 @@ func (s *Service) handleCooldownState(ctx context.Context, candidate *reentry.Re
 
 // handleWatchState handles candidate in WATCH state
 func (s *Service) handleWatchState(ctx context.Context, candidate *reentry.ReentryCandidate, now time.Time, controlMode string) error {
+	profile := s.resolveProfile(ctx, candidate)
+
 	// Check if max watch time exceeded
-	maxWatchDuration := time.Duration(s.defaultProfile.Config.MaxWatchHours) * time.Hour
+	maxWatchDuration := time.Duration(profile.Config.MaxWatchHours) * time.Hour
 	if now.Sub(candidate.CooldownUntil) > maxWatchDuration {
 		// Expired
 		if err := s.candidateRepo.UpdateCandidateState(ctx, candidate.CandidateID, reentry.StateExpired); err != nil {
@@ -109,12 +111,69 @@ func (s *Service) handleWatchState(ctx context.Context, candidate *reentry.Reent
 		return nil
 	}
 
-	// TODO: Check reentry triggers (Rebound/Breakout/Chase)
-	// For now, just log
-	log.Debug().
+	// Check reentry limit
+	if candidate.MaxReentries > 0 && candidate.ReentryCount >= candidate.MaxReentries {
+		if err := s.candidateRepo.UpdateCandidateState(ctx, candidate.CandidateID, reentry.StateBlocked); err != nil {
+			return fmt.Errorf("update state to BLOCKED: %w", err)
+		}
+
+		log.Info().
+			Str("candidate_id", candidate.CandidateID.String()).
+			Str("symbol", candidate.Symbol).
+			Int("reentry_count", candidate.ReentryCount).
+			Int("max_reentries", candidate.MaxReentries).
+			Msg("Candidate → BLOCKED (max reentries exceeded)")
+
+		return nil
+	}
+
+	// Check control gate (PAUSE_ENTRY: 추적만, READY 전이 차단)
+	if controlMode == reentry.ControlModePauseEntry || controlMode == reentry.ControlModePauseAll {
+		log.Debug().
+			Str("candidate_id", candidate.CandidateID.String()).
+			Str("control_mode", controlMode).
+			Msg("Trigger evaluation paused by control gate")
+		return nil
+	}
+
+	if s.priceReader == nil {
+		log.Debug().Str("symbol", candidate.Symbol).Msg("No price reader configured, skipping trigger evaluation")
+		return nil
+	}
+
+	// Get current price (Fail-Closed on stale price)
+	bestPrice, err := s.priceReader.GetBestPrice(ctx, candidate.Symbol)
+	if err != nil {
+		return fmt.Errorf("get best price: %w", err)
+	}
+
+	if bestPrice.IsStale || time.Since(bestPrice.BestTS) > freshnessThreshold {
+		log.Debug().
+			Str("symbol", candidate.Symbol).
+			Float64("age_seconds", time.Since(bestPrice.BestTS).Seconds()).
+			Msg("Price is stale, skipping trigger evaluation (Fail-Closed)")
+		return nil
+	}
+
+	// Evaluate triggers (Rebound/Breakout/Chase)
+	trigger := evaluateReentryTriggers(candidate, profile.Config, bestPrice)
+	if trigger == nil {
+		return nil
+	}
+
+	// Transition to READY
+	if err := s.candidateRepo.MarkReady(ctx, candidate.CandidateID, trigger.ReasonCode, trigger.CurrentPrice); err != nil {
+		return fmt.Errorf("update state to READY: %w", err)
+	}
+
+	log.Info().
 		Str("candidate_id", candidate.CandidateID.String()).
 		Str("symbol", candidate.Symbol).
-		Msg("Candidate in WATCH state (trigger evaluation not implemented)")
+		Str("reason", trigger.ReasonCode).
+		Str("exit_price", candidate.ExitPrice.String()).
+		Str("target_price", trigger.TargetPrice.String()).
+		Str("current_price", trigger.CurrentPrice.String()).
+		Msg("Candidate → READY")
 
 	return nil
 }
@@ -130,12 +189,31 @@ func (s *Service) handleReadyState(ctx context.Context, candidate *reentry.Reent
 		return nil
 	}
 
-	// TODO: Create ENTRY intent
-	// For now, just log
-	log.Info().
-		Str("candidate_id", candidate.CandidateID.String()).
-		Str("symbol", candidate.Symbol).
-		Msg("Candidate READY for entry (intent creation not implemented)")
+	// Check if max watch time exceeded (READY 상태에서 진입 못하고 방치 방지)
+	profile := s.resolveProfile(ctx, candidate)
+	maxWatchDuration := time.Duration(profile.Config.MaxWatchHours) * time.Hour
+	if now.Sub(candidate.CooldownUntil) > maxWatchDuration {
+		if err := s.candidateRepo.UpdateCandidateState(ctx, candidate.CandidateID, reentry.StateExpired); err != nil {
+			return fmt.Errorf("update state to EXPIRED: %w", err)
+		}
 
-	return nil
+		log.Info().
+			Str("candidate_id", candidate.CandidateID.String()).
+			Str("symbol", candidate.Symbol).
+			Msg("Candidate → EXPIRED (not entered within max watch time)")
+
+		return nil
+	}
+
+	// ENTRY intent already created → wait for fill (OnEntryFilled → ENTERED)
+	if candidate.EntryIntentID != nil {
+		return nil
+	}
+
+	if s.intentWriter == nil {
+		log.Debug().Str("symbol", candidate.Symbol).Msg("No intent writer configured, skipping entry")
+		return nil
+	}
+
+	return s.createEntryIntent(ctx, candidate, profile)
 }
@@ -8,12 +8,14 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
 	"github.com/wonny/aegis/v14/internal/domain/reentry"
 )
 
 const (
 	evaluationInterval = 5 * time.Second  // Evaluation loop 주기 (5초)
 	exitEventCheckInterval = 3 * time.Second // ExitEvent polling 주기 (3초)
+	freshnessThreshold = 25 * time.Second // 가격 신선도 임계값 (Exit Engine과 동일)
 )
 
 // Service is the Reentry Engine service
@@ -28,26 +30,32 @@ type Service struct {
 
 	// External dependencies (read-only)
 	exitEventRepo execution.ExitEventRepository
+	priceReader   PriceReader  // For trigger evaluation (live best price)
 	intentWriter  IntentWriter // For creating ENTRY intents
 
+	// Optional dependencies
+	portfolioReader PortfolioValueReader // For PERCENT/KELLY sizing
+
 	// Config
 	defaultProfile *reentry.ReentryProfile
 }
 
+// PriceReader is an interface for reading live best prices (PriceSync)
+type PriceReader interface {
+	// GetBestPrice returns the current best price for a symbol
+	GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error)
+}
+
 // IntentWriter is an interface for creating order intents
 type IntentWriter interface {
 	// CreateEntryIntent creates a new ENTRY intent
-	CreateEntryIntent(ctx context.Context, intent *EntryIntent) error
+	CreateEntryIntent(ctx context.Context, intent *reentry.EntryIntent) error
 }
 
-// EntryIntent represents an ENTRY order intent (to be created)
-type EntryIntent struct {
-	CandidateID  uuid.UUID
-	Symbol       string
-	Qty          int64
-	OrderType    string // MKT, LMT
-	LimitPrice   *decimal.Decimal
-	ReasonCode   string // REENTRY_REBOUND, REENTRY_BREAKOUT, REENTRY_CHASE
+// PortfolioValueReader is an interface for reading portfolio value (for PERCENT/KELLY sizing)
+type PortfolioValueReader interface {
+	// GetPortfolioValue returns the current total portfolio value (KRW)
+	GetPortfolioValue(ctx context.Context) (decimal.Decimal, error)
 }
 
 // NewService creates a new Reentry service
@@ -57,6 +65,7 @@ func NewService(
 	controlRepo reentry.ControlRepository,
 	profileRepo reentry.ProfileRepository,
 	exitEventRepo execution.ExitEventRepository,
+	priceReader PriceReader,
 	intentWriter IntentWriter,
 ) *Service {
 	return &Service{
@@ -65,11 +74,17 @@ func NewService(
 		controlRepo:   controlRepo,
 		profileRepo:   profileRepo,
 		exitEventRepo: exitEventRepo,
+		priceReader:   priceReader,
 		intentWriter:  intentWriter,
 		defaultProfile: nil, // Will be loaded on Start()
 	}
 }
 
+// SetPortfolioValueReader sets the optional portfolio value reader (PERCENT/KELLY sizing)
+func (s *Service) SetPortfolioValueReader(reader PortfolioValueReader) {
+	s.portfolioReader = reader
+}
+
 // Start starts the Reentry Engine
 func (s *Service) Start() error {
 	log.Info().Msg("Starting Reentry Engine")
@@ -0,0 +1,148 @@
+package reentry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/reentry"
+)
+
+const (
+	kellyLookback   = 90 * 24 * time.Hour // Kelly 통계 기간 (최근 90일 ExitEvent)
+	kellyMinSamples = 10                  // Kelly 계산 최소 표본 (미만 → PERCENT fallback)
+	kellyFraction   = 0.5                 // Half-Kelly (변동성 완화)
+)
+
+// calculateEntryQty calculates entry quantity per profile sizing mode
+// - FIXED: SizingMax 주
+// - PERCENT: 포트폴리오 × SizingPercent / 가격
+// - KELLY: 포트폴리오 × Half-Kelly / 가격 (표본 부족 시 PERCENT)
+// All modes are capped by SizingMax (0 = no cap)
+func (s *Service) calculateEntryQty(ctx context.Context, config reentry.ReentryProfileConfig, price decimal.Decimal) (int64, error) {
+	if !price.IsPositive() {
+		return 0, fmt.Errorf("invalid entry price: %s", price)
+	}
+
+	var qty int64
+
+	switch config.SizingMode {
+	case reentry.SizingModeFixed:
+		qty = config.SizingMax
+
+	case reentry.SizingModePercent:
+		portfolioValue, err := s.getPortfolioValue(ctx)
+		if err != nil {
+			return 0, err
+		}
+		qty = sizeByPercent(portfolioValue, config.SizingPercent, price)
+
+	case reentry.SizingModeKelly:
+		portfolioValue, err := s.getPortfolioValue(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		pct := config.SizingPercent
+		events, err := s.exitEventRepo.LoadExitEventsSince(ctx, time.Now().Add(-kellyLookback))
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to load exit events for Kelly sizing, fallback to PERCENT")
+		} else if kelly, ok := kellyPercent(events); ok {
+			pct = kelly
+		} else {
+			log.Debug().Int("samples", len(events)).Msg("Not enough samples for Kelly sizing, fallback to PERCENT")
+		}
+		qty = sizeByPercent(portfolioValue, pct, price)
+
+	default:
+		return 0, fmt.Errorf("unknown sizing mode: %s", config.SizingMode)
+	}
+
+	return capQty(qty, config.SizingMax), nil
+}
+
+// getPortfolioValue reads portfolio value (PERCENT/KELLY 필수)
+func (s *Service) getPortfolioValue(ctx context.Context) (decimal.Decimal, error) {
+	if s.portfolioReader == nil {
+		return decimal.Zero, fmt.Errorf("portfolio value reader not configured")
+	}
+
+	value, err := s.portfolioReader.GetPortfolioValue(ctx)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("get portfolio value: %w", err)
+	}
+
+	return value, nil
+}
+
+// sizeByPercent returns floor(portfolioValue × pct / price)
+func sizeByPercent(portfolioValue decimal.Decimal, pct float64, price decimal.Decimal) int64 {
+	if pct <= 0 || !portfolioValue.IsPositive() || !price.IsPositive() {
+		return 0
+	}
+
+	budget := portfolioValue.Mul(decimal.NewFromFloat(pct))
+	return budget.Div(price).Floor().IntPart()
+}
+
+// kellyPercent calculates Half-Kelly fraction from realized exit results
+// f* = W - (1 - W) / R (W: 승률, R: 평균이익 / 평균손실)
+// Returns ok=false when samples are insufficient; negative edge → 0
+func kellyPercent(events []*execution.ExitEvent) (float64, bool) {
+	var wins, losses int
+	var sumWin, sumLoss float64
+
+	for _, event := range events {
+		switch {
+		case event.RealizedPnlPct > 0:
+			wins++
+			sumWin += event.RealizedPnlPct
+		case event.RealizedPnlPct < 0:
+			losses++
+			sumLoss += -event.RealizedPnlPct
+		}
+	}
+
+	if wins+losses < kellyMinSamples {
+		return 0, false
+	}
+
+	// 이익 없음 → edge 없음 (진입 안 함)
+	if wins == 0 {
+		return 0, true
+	}
+
+	// 손실 없음 → R 정의 불가, 표본 부족으로 취급 (PERCENT fallback)
+	if losses == 0 {
+		return 0, false
+	}
+
+	winRate := float64(wins) / float64(wins+losses)
+	payoff := (sumWin / float64(wins)) / (sumLoss / float64(losses))
+
+	f := winRate - (1-winRate)/payoff
+	if f <= 0 {
+		return 0, true
+	}
+
+	f *= kellyFraction
+	if f > 1 {
+		f = 1
+	}
+
+	return f, true
+}
+
+// capQty caps qty by max (max <= 0 → no cap)
+func capQty(qty, max int64) int64 {
+	if qty < 0 {
+		return 0
+	}
+	if max > 0 && qty > max {
+		return max
+	}
+	return qty
+}
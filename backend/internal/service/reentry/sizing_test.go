@@ -0,0 +1,62 @@
+package reentry
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+)
+
+func testExitEvents(pnlPcts ...float64) []*execution.ExitEvent {
+	events := make([]*execution.ExitEvent, 0, len(pnlPcts))
+	for _, pct := range pnlPcts {
+		events = append(events, &execution.ExitEvent{RealizedPnlPct: pct})
+	}
+	return events
+}
+
+// TestSizeByPercent tests PERCENT sizing and SizingMax cap
+func TestSizeByPercent(t *testing.T) {
+	// 10,000,000 × 2% / 70,000 = 2.85 → 2
+	qty := sizeByPercent(decimal.NewFromInt(10_000_000), 0.02, decimal.NewFromInt(70000))
+	if qty != 2 {
+		t.Errorf("Expected 2, got %d", qty)
+	}
+
+	if got := capQty(150, 100); got != 100 {
+		t.Errorf("Expected cap 100, got %d", got)
+	}
+	if got := capQty(150, 0); got != 150 {
+		t.Errorf("Expected no cap, got %d", got)
+	}
+}
+
+// TestKellyPercent tests Half-Kelly fraction from exit results
+func TestKellyPercent(t *testing.T) {
+	t.Run("Insufficient samples", func(t *testing.T) {
+		if _, ok := kellyPercent(testExitEvents(5, -3, 4)); ok {
+			t.Error("Expected ok=false with 3 samples")
+		}
+	})
+
+	t.Run("Positive edge", func(t *testing.T) {
+		// W=0.6, R=6/3=2 → f*=0.6-0.4/2=0.4 → Half-Kelly 0.2
+		events := testExitEvents(6, 6, 6, 6, 6, 6, -3, -3, -3, -3)
+		f, ok := kellyPercent(events)
+		if !ok {
+			t.Fatal("Expected ok=true")
+		}
+		if f < 0.199 || f > 0.201 {
+			t.Errorf("Expected 0.2, got %f", f)
+		}
+	})
+
+	t.Run("Negative edge", func(t *testing.T) {
+		// W=0.3, R=1 → f*<0 → 0
+		events := testExitEvents(2, 2, 2, -2, -2, -2, -2, -2, -2, -2)
+		f, ok := kellyPercent(events)
+		if !ok || f != 0 {
+			t.Errorf("Expected (0, true), got (%f, %v)", f, ok)
+		}
+	})
+}
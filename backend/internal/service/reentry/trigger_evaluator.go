@@ -0,0 +1,138 @@
+package reentry
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/reentry"
+)
+
+// ReentryTrigger represents a triggered reentry condition (WATCH → READY)
+type ReentryTrigger struct {
+	ReasonCode   string          // REENTRY_REBOUND | REENTRY_BREAKOUT | REENTRY_CHASE
+	CurrentPrice decimal.Decimal // 평가 시점 가격 (매수 기준: Ask 우선)
+	TargetPrice  decimal.Decimal // 트리거 기준가 (ExitPrice × (1 + pct))
+}
+
+// evaluateReentryTriggers evaluates reentry triggers for a candidate
+// Returns the first trigger that is hit, or nil if none
+//
+// Strategy by exit reason:
+// - SL1/SL2 → Rebound (청산가 대비 반등)
+// - TP1/TP2/TP3/TRAIL → Breakout (청산가 돌파) → Chase (모멘텀 추격)
+//
+// All triggers compare the current price against ExitPrice and require
+// the day's cumulative volume to reach the trigger's MinVolume.
+func evaluateReentryTriggers(
+	candidate *reentry.ReentryCandidate,
+	config reentry.ReentryProfileConfig,
+	bestPrice *price.BestPrice,
+) *ReentryTrigger {
+	if candidate.ExitPrice.IsZero() || candidate.ExitPrice.IsNegative() {
+		return nil
+	}
+
+	currentPrice := entryReferencePrice(bestPrice)
+
+	switch candidate.ExitReasonCode {
+	case execution.ExitReasonSL1, execution.ExitReasonSL2:
+		rebound := config.TriggerRebound
+		if !rebound.Enabled {
+			return nil
+		}
+		return checkPriceTrigger(reentry.ReasonReentryRebound, candidate.ExitPrice, currentPrice,
+			rebound.BouncePercent, rebound.MinVolume, bestPrice.Volume)
+
+	case execution.ExitReasonTP1, execution.ExitReasonTP2, execution.ExitReasonTP3, execution.ExitReasonTrail:
+		if breakout := config.TriggerBreakout; breakout.Enabled {
+			if trigger := checkPriceTrigger(reentry.ReasonReentryBreakout, candidate.ExitPrice, currentPrice,
+				breakout.BreakPercent, breakout.MinVolume, bestPrice.Volume); trigger != nil {
+				return trigger
+			}
+		}
+
+		if chase := config.TriggerChase; chase.Enabled {
+			return checkPriceTrigger(reentry.ReasonReentryChase, candidate.ExitPrice, currentPrice,
+				chase.ChasePercent, chase.MinVolume, bestPrice.Volume)
+		}
+
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// checkPriceTrigger checks currentPrice >= exitPrice × (1 + pct) and volume >= minVolume
+func checkPriceTrigger(
+	reasonCode string,
+	exitPrice decimal.Decimal,
+	currentPrice decimal.Decimal,
+	pct float64,
+	minVolume int64,
+	volume *int64,
+) *ReentryTrigger {
+	targetPrice := exitPrice.Mul(decimal.NewFromInt(1).Add(decimal.NewFromFloat(pct)))
+
+	if currentPrice.LessThan(targetPrice) {
+		return nil
+	}
+
+	if !hasMinVolume(volume, minVolume) {
+		log.Debug().
+			Str("reason", reasonCode).
+			Int64("min_volume", minVolume).
+			Msg("Reentry price condition met but volume below minimum")
+		return nil
+	}
+
+	return &ReentryTrigger{
+		ReasonCode:   reasonCode,
+		CurrentPrice: currentPrice,
+		TargetPrice:  targetPrice,
+	}
+}
+
+// hasMinVolume checks cumulative volume against the configured minimum
+// Volume unknown → fail-closed when a minimum is configured
+func hasMinVolume(volume *int64, minVolume int64) bool {
+	if minVolume <= 0 {
+		return true
+	}
+	if volume == nil {
+		return false
+	}
+	return *volume >= minVolume
+}
+
+// entryReferencePrice returns the price used for entry decisions
+// Use AskPrice for entry (conservative), fallback to BestPrice
+func entryReferencePrice(bestPrice *price.BestPrice) decimal.Decimal {
+	if bestPrice.AskPrice != nil && *bestPrice.AskPrice > 0 {
+		return decimal.NewFromInt(*bestPrice.AskPrice)
+	}
+	return decimal.NewFromInt(bestPrice.BestPrice)
+}
+
+// resolveProfile resolves the reentry profile for a candidate
+// Candidate profile > Default profile
+func (s *Service) resolveProfile(ctx context.Context, candidate *reentry.ReentryCandidate) *reentry.ReentryProfile {
+	if candidate.ReentryProfileID == nil || *candidate.ReentryProfileID == s.defaultProfile.ProfileID {
+		return s.defaultProfile
+	}
+
+	profile, err := s.profileRepo.GetProfile(ctx, *candidate.ReentryProfileID)
+	if err != nil || profile == nil || !profile.IsActive {
+		log.Warn().
+			Err(err).
+			Str("profile_id", *candidate.ReentryProfileID).
+			Str("symbol", candidate.Symbol).
+			Msg("Failed to load candidate reentry profile, fallback to default")
+		return s.defaultProfile
+	}
+
+	return profile
+}
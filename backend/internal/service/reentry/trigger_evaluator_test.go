@@ -0,0 +1,164 @@
+package reentry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/reentry"
+)
+
+func testReentryConfig() reentry.ReentryProfileConfig {
+	return reentry.ReentryProfileConfig{
+		TriggerRebound: reentry.ReboundConfig{
+			Enabled:       true,
+			BouncePercent: 0.02, // 2%
+			MinVolume:     10000,
+		},
+		TriggerBreakout: reentry.BreakoutConfig{
+			Enabled:      true,
+			BreakPercent: 0.03, // 3%
+			MinVolume:    10000,
+		},
+		TriggerChase: reentry.ChaseConfig{
+			Enabled:      true,
+			ChasePercent: 0.05, // 5%
+			MinVolume:    10000,
+		},
+	}
+}
+
+func testCandidate(exitReason string) *reentry.ReentryCandidate {
+	return &reentry.ReentryCandidate{
+		CandidateID:    uuid.New(),
+		Symbol:         "005930",
+		ExitReasonCode: exitReason,
+		ExitTS:         time.Now().Add(-1 * time.Hour),
+		ExitPrice:      decimal.NewFromInt(70000),
+		State:          reentry.StateWatch,
+	}
+}
+
+func testBestPrice(last int64, volume int64) *price.BestPrice {
+	return &price.BestPrice{
+		Symbol:    "005930",
+		BestPrice: last,
+		BestTS:    time.Now(),
+		Volume:    &volume,
+	}
+}
+
+// TestEvaluateReentryRebound tests Rebound trigger after SL exits
+func TestEvaluateReentryRebound(t *testing.T) {
+	config := testReentryConfig()
+	candidate := testCandidate(execution.ExitReasonSL1)
+
+	t.Run("Rebound hit", func(t *testing.T) {
+		// 70000 × 1.02 = 71400
+		trigger := evaluateReentryTriggers(candidate, config, testBestPrice(71400, 20000))
+
+		if trigger == nil {
+			t.Fatal("Expected Rebound trigger, got nil")
+		}
+		if trigger.ReasonCode != reentry.ReasonReentryRebound {
+			t.Errorf("Expected REENTRY_REBOUND, got %s", trigger.ReasonCode)
+		}
+		if !trigger.TargetPrice.Equal(decimal.NewFromInt(71400)) {
+			t.Errorf("Expected target 71400, got %s", trigger.TargetPrice)
+		}
+	})
+
+	t.Run("Rebound not hit", func(t *testing.T) {
+		trigger := evaluateReentryTriggers(candidate, config, testBestPrice(71300, 20000))
+
+		if trigger != nil {
+			t.Errorf("Expected no trigger, got %+v", trigger)
+		}
+	})
+
+	t.Run("Rebound blocked by volume", func(t *testing.T) {
+		trigger := evaluateReentryTriggers(candidate, config, testBestPrice(72000, 5000))
+
+		if trigger != nil {
+			t.Errorf("Expected no trigger (volume below min), got %+v", trigger)
+		}
+	})
+
+	t.Run("Rebound disabled", func(t *testing.T) {
+		disabled := testReentryConfig()
+		disabled.TriggerRebound.Enabled = false
+
+		trigger := evaluateReentryTriggers(candidate, disabled, testBestPrice(72000, 20000))
+
+		if trigger != nil {
+			t.Errorf("Expected no trigger (rebound disabled), got %+v", trigger)
+		}
+	})
+
+	t.Run("Ask price preferred", func(t *testing.T) {
+		bp := testBestPrice(71400, 20000)
+		ask := int64(71300)
+		bp.AskPrice = &ask
+
+		trigger := evaluateReentryTriggers(candidate, config, bp)
+
+		if trigger != nil {
+			t.Errorf("Expected no trigger (ask below target), got %+v", trigger)
+		}
+	})
+}
+
+// TestEvaluateReentryBreakoutChase tests Breakout/Chase triggers after TP/TRAIL exits
+func TestEvaluateReentryBreakoutChase(t *testing.T) {
+	config := testReentryConfig()
+	candidate := testCandidate(execution.ExitReasonTP2)
+
+	t.Run("Breakout hit", func(t *testing.T) {
+		// 70000 × 1.03 = 72100
+		trigger := evaluateReentryTriggers(candidate, config, testBestPrice(72100, 20000))
+
+		if trigger == nil {
+			t.Fatal("Expected Breakout trigger, got nil")
+		}
+		if trigger.ReasonCode != reentry.ReasonReentryBreakout {
+			t.Errorf("Expected REENTRY_BREAKOUT, got %s", trigger.ReasonCode)
+		}
+	})
+
+	t.Run("Chase when breakout disabled", func(t *testing.T) {
+		chaseOnly := testReentryConfig()
+		chaseOnly.TriggerBreakout.Enabled = false
+
+		// 70000 × 1.05 = 73500
+		trigger := evaluateReentryTriggers(candidate, chaseOnly, testBestPrice(73500, 20000))
+
+		if trigger == nil {
+			t.Fatal("Expected Chase trigger, got nil")
+		}
+		if trigger.ReasonCode != reentry.ReasonReentryChase {
+			t.Errorf("Expected REENTRY_CHASE, got %s", trigger.ReasonCode)
+		}
+	})
+
+	t.Run("SL exit does not use breakout", func(t *testing.T) {
+		noRebound := testReentryConfig()
+		noRebound.TriggerRebound.Enabled = false
+
+		trigger := evaluateReentryTriggers(testCandidate(execution.ExitReasonSL2), noRebound, testBestPrice(80000, 20000))
+
+		if trigger != nil {
+			t.Errorf("Expected no trigger, got %+v", trigger)
+		}
+	})
+
+	t.Run("Ineligible exit reason", func(t *testing.T) {
+		trigger := evaluateReentryTriggers(testCandidate(execution.ExitReasonManual), config, testBestPrice(80000, 20000))
+
+		if trigger != nil {
+			t.Errorf("Expected no trigger, got %+v", trigger)
+		}
+	})
+}
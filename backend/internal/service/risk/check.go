@@ -0,0 +1,271 @@
+package risk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+// quote 기준가 (PriceSync best price)
+type quote struct {
+	Price     decimal.Decimal // 현재가 (0 = 없음)
+	PrevClose decimal.Decimal // 전일 종가 (0 = 없음)
+}
+
+// portfolioState 검증 시점 포트폴리오 상태 (매수 검증용)
+type portfolioState struct {
+	HoldingsValue decimal.Decimal            // 보유 평가금액 합계
+	Cash          decimal.Decimal            // 예수금
+	CashKnown     bool                       // 예수금 Reader 존재 여부
+	Positions     map[string]decimal.Decimal // symbol → 평가금액 (qty > 0)
+	Sectors       map[string]string          // symbol → sector
+	DailyPnL      decimal.Decimal            // 당일 실현 + 평가 변동
+	DailyPnLPct   float64                    // 당일 손익률 (%)
+}
+
+// evaluate runs all checks in order and stops at the first failure (Fail-Closed)
+// 매도(청산)는 긴급 정지/손실 한도/가격 밴드와 무관하게 허용 (지정가 호가단위/가격제한폭은 pricing.Guard가 정규화)
+func evaluate(req risk.RiskCheckRequest, limits risk.RiskLimits, stop risk.EmergencyStop, state *portfolioState, q quote) risk.RiskCheckResult {
+	isBuy := req.Side == execution.SideBuy
+
+	refPrice := q.Price
+	if execution.OrderTypeNeedsPrice(req.OrderType) && req.LimitPrice != nil {
+		refPrice = *req.LimitPrice
+	}
+	orderValue := refPrice.Mul(decimal.NewFromInt(req.Qty))
+
+	result := risk.RiskCheckResult{
+		RefPrice:   q.Price,
+		OrderValue: orderValue,
+		CheckedAt:  time.Now(),
+	}
+
+	steps := []func() risk.RiskCheck{
+		func() risk.RiskCheck { return checkEmergencyStop(isBuy, stop) },
+	}
+	if isBuy {
+		steps = append(steps,
+			func() risk.RiskCheck { return checkPriceBand(req, isBuy, limits, q) },
+			func() risk.RiskCheck { return checkOrderValue(orderValue, limits) },
+			func() risk.RiskCheck { return checkTotalPositions(req.Symbol, state, limits) },
+			func() risk.RiskCheck { return checkSingleWeight(req.Symbol, orderValue, state, limits) },
+			func() risk.RiskCheck { return checkSectorWeight(req.Symbol, orderValue, state, limits) },
+			func() risk.RiskCheck { return checkDailyLoss(state, limits) },
+		)
+	}
+
+	for _, step := range steps {
+		check := step()
+		result.Checks = append(result.Checks, check)
+		if !check.Passed {
+			result.FailedCheck = &check
+			result.Message = fmt.Sprintf("Risk check failed: %s - %s", check.CheckType, check.Message)
+			return result
+		}
+	}
+
+	result.Approved = true
+	result.Message = "All risk checks passed"
+	return result
+}
+
+// checkEmergencyStop 긴급 정지 시 신규 매수 차단
+func checkEmergencyStop(isBuy bool, stop risk.EmergencyStop) risk.RiskCheck {
+	check := risk.RiskCheck{CheckType: risk.CheckEmergencyStop, Passed: true, Message: "Emergency stop inactive"}
+	if !stop.Enabled {
+		return check
+	}
+
+	if !isBuy {
+		check.Message = "Emergency stop active - sell allowed"
+		return check
+	}
+
+	check.Passed = false
+	check.CurrentValue = 1
+	check.Message = "Emergency stop activated - new buy orders blocked"
+	if stop.Reason != nil && *stop.Reason != "" {
+		check.Message += " (" + *stop.Reason + ")"
+	}
+	return check
+}
+
+// checkPriceBand 지정가 sanity (현재가 괴리 + KRX 가격제한폭), 매수 전용
+func checkPriceBand(req risk.RiskCheckRequest, isBuy bool, limits risk.RiskLimits, q quote) risk.RiskCheck {
+	check := risk.RiskCheck{CheckType: risk.CheckPriceBand, Passed: true, LimitValue: limits.MaxPriceDeviationPct}
+	isLimit := execution.OrderTypeNeedsPrice(req.OrderType)
+
+	if isLimit && (req.LimitPrice == nil || !req.LimitPrice.IsPositive()) {
+		check.Passed = false
+		check.Message = "Limit order without a positive limit price"
+		return check
+	}
+
+	if !q.Price.IsPositive() {
+		// 기준가 없음: 시장가 매도(청산)만 허용
+		if !isLimit && !isBuy {
+			check.Message = "Reference price unavailable - market sell allowed"
+			return check
+		}
+		check.Passed = false
+		check.Message = "Reference price unavailable"
+		return check
+	}
+
+	if !isLimit {
+		check.Message = "Market order - price band check skipped"
+		return check
+	}
+
+	deviation := req.LimitPrice.Sub(q.Price).Abs().Div(q.Price).Mul(decimal.NewFromInt(100))
+	check.CurrentValue = deviation.InexactFloat64()
+	if check.CurrentValue > limits.MaxPriceDeviationPct {
+		check.Passed = false
+		check.Message = fmt.Sprintf("Limit price %s deviates %.2f%% from %s (max %.2f%%)",
+			req.LimitPrice.String(), check.CurrentValue, q.Price.String(), limits.MaxPriceDeviationPct)
+		return check
+	}
+
+	if q.PrevClose.IsPositive() {
+		band := req.LimitPrice.Sub(q.PrevClose).Abs().Div(q.PrevClose).Mul(decimal.NewFromInt(100)).InexactFloat64()
+		if limitPct := price.PriceLimitRate * 100; band > limitPct {
+			check.Passed = false
+			check.CurrentValue = band
+			check.LimitValue = limitPct
+			check.Message = fmt.Sprintf("Limit price %s outside KRX price limit (±%.0f%% of %s)",
+				req.LimitPrice.String(), limitPct, q.PrevClose.String())
+			return check
+		}
+	}
+
+	check.Message = fmt.Sprintf("Price deviation: %.2f%%/%.2f%%", check.CurrentValue, limits.MaxPriceDeviationPct)
+	return check
+}
+
+// checkOrderValue 1회 주문 금액 한도
+func checkOrderValue(orderValue decimal.Decimal, limits risk.RiskLimits) risk.RiskCheck {
+	check := risk.RiskCheck{
+		CheckType:    risk.CheckOrderValue,
+		Passed:       orderValue.LessThanOrEqual(limits.MaxOrderValue),
+		CurrentValue: orderValue.InexactFloat64(),
+		LimitValue:   limits.MaxOrderValue.InexactFloat64(),
+	}
+
+	check.Message = fmt.Sprintf("Order value: %s/%s", orderValue.StringFixed(0), limits.MaxOrderValue.StringFixed(0))
+	if !check.Passed {
+		check.Message = fmt.Sprintf("Exceeds max order value: %s > %s", orderValue.StringFixed(0), limits.MaxOrderValue.StringFixed(0))
+	}
+	return check
+}
+
+// checkTotalPositions 최대 보유 종목 수 (신규 종목 매수 시 +1)
+func checkTotalPositions(symbol string, state *portfolioState, limits risk.RiskLimits) risk.RiskCheck {
+	future := len(state.Positions)
+	if _, held := state.Positions[symbol]; !held {
+		future++
+	}
+
+	check := risk.RiskCheck{
+		CheckType:    risk.CheckTotalPositions,
+		Passed:       future <= limits.MaxTotalPositions,
+		CurrentValue: float64(future),
+		LimitValue:   float64(limits.MaxTotalPositions),
+	}
+
+	check.Message = fmt.Sprintf("Position count: %d/%d", future, limits.MaxTotalPositions)
+	if !check.Passed {
+		check.Message = fmt.Sprintf("Exceeds max positions: %d > %d", future, limits.MaxTotalPositions)
+	}
+	return check
+}
+
+// checkSingleWeight 종목당 최대 비중 (주문 후)
+func checkSingleWeight(symbol string, orderValue decimal.Decimal, state *portfolioState, limits risk.RiskLimits) risk.RiskCheck {
+	check := risk.RiskCheck{CheckType: risk.CheckSingleWeight, Passed: true, LimitValue: limits.MaxSingleWeight}
+
+	base, ok := weightBase(orderValue, state)
+	if !ok {
+		check.Message = "Portfolio value unavailable - weight check skipped"
+		return check
+	}
+
+	future := state.Positions[symbol].Add(orderValue)
+	check.CurrentValue = future.Div(base).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	check.Passed = check.CurrentValue <= limits.MaxSingleWeight
+
+	check.Message = fmt.Sprintf("Position weight: %.2f%%/%.2f%%", check.CurrentValue, limits.MaxSingleWeight)
+	if !check.Passed {
+		check.Message = fmt.Sprintf("Exceeds max single weight: %.2f%% > %.2f%%", check.CurrentValue, limits.MaxSingleWeight)
+	}
+	return check
+}
+
+// checkSectorWeight 섹터당 최대 비중 (주문 후)
+func checkSectorWeight(symbol string, orderValue decimal.Decimal, state *portfolioState, limits risk.RiskLimits) risk.RiskCheck {
+	check := risk.RiskCheck{CheckType: risk.CheckSectorWeight, Passed: true, LimitValue: limits.MaxSectorWeight}
+
+	base, ok := weightBase(orderValue, state)
+	if !ok {
+		check.Message = "Portfolio value unavailable - weight check skipped"
+		return check
+	}
+
+	sector := sectorOf(symbol, state)
+	future := orderValue
+	for sym, value := range state.Positions {
+		if sectorOf(sym, state) == sector {
+			future = future.Add(value)
+		}
+	}
+
+	check.CurrentValue = future.Div(base).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	check.Passed = check.CurrentValue <= limits.MaxSectorWeight
+
+	check.Message = fmt.Sprintf("Sector %s weight: %.2f%%/%.2f%%", sector, check.CurrentValue, limits.MaxSectorWeight)
+	if !check.Passed {
+		check.Message = fmt.Sprintf("Exceeds max sector weight (%s): %.2f%% > %.2f%%", sector, check.CurrentValue, limits.MaxSectorWeight)
+	}
+	return check
+}
+
+// checkDailyLoss 일간 손실 한도 도달 시 신규 매수 차단
+func checkDailyLoss(state *portfolioState, limits risk.RiskLimits) risk.RiskCheck {
+	check := risk.RiskCheck{
+		CheckType:    risk.CheckDailyLoss,
+		Passed:       state.DailyPnLPct >= limits.MaxDailyLossPct,
+		CurrentValue: state.DailyPnLPct,
+		LimitValue:   limits.MaxDailyLossPct,
+	}
+
+	check.Message = fmt.Sprintf("Daily P&L: %.2f%%/%.2f%%", state.DailyPnLPct, limits.MaxDailyLossPct)
+	if !check.Passed {
+		check.Message = fmt.Sprintf("Daily loss limit exceeded: %.2f%% < %.2f%%", state.DailyPnLPct, limits.MaxDailyLossPct)
+	}
+	return check
+}
+
+// weightBase 비중 계산 기준 자산
+// 예수금을 모르면 최소한 주문 금액만큼의 현금은 있다고 보고 더함
+// 보유/현금 모두 없으면 비중 계산 불가 (ORDER_VALUE 한도로만 제한)
+func weightBase(orderValue decimal.Decimal, state *portfolioState) (decimal.Decimal, bool) {
+	base := state.HoldingsValue.Add(state.Cash)
+	if !base.IsPositive() {
+		return decimal.Zero, false
+	}
+	if !state.CashKnown {
+		base = base.Add(orderValue)
+	}
+	return base, true
+}
+
+// sectorOf 종목 섹터 (미확인 → 기타)
+func sectorOf(symbol string, state *portfolioState) string {
+	if sector, ok := state.Sectors[symbol]; ok && sector != "" {
+		return sector
+	}
+	return "기타"
+}
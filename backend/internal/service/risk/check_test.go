@@ -0,0 +1,146 @@
+package risk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+// TestEvaluate tests check ordering, sell exemptions and each limit
+func TestEvaluate(t *testing.T) {
+	limits := risk.DefaultRiskLimits()
+	running := risk.EmergencyStop{}
+	q := quote{Price: decimal.NewFromInt(10_000), PrevClose: decimal.NewFromInt(10_000)}
+
+	// 보유 10,000,000 + 현금 10,000,000 (AAA 1,000,000 반도체 / BBB 5,000,000 반도체 / CCC 4,000,000 금융)
+	newState := func() *portfolioState {
+		return &portfolioState{
+			HoldingsValue: decimal.NewFromInt(10_000_000),
+			CashKnown:     true,
+			Cash:          decimal.NewFromInt(10_000_000),
+			Positions: map[string]decimal.Decimal{
+				"AAA": decimal.NewFromInt(1_000_000),
+				"BBB": decimal.NewFromInt(5_000_000),
+				"CCC": decimal.NewFromInt(4_000_000),
+			},
+			Sectors: map[string]string{"AAA": "반도체", "BBB": "반도체", "NEW": "반도체", "CCC": "금융"},
+		}
+	}
+
+	buy := func(symbol string, qty int64) risk.RiskCheckRequest {
+		return risk.RiskCheckRequest{Symbol: symbol, Side: execution.SideBuy, OrderType: execution.OrderTypeMarket, Qty: qty}
+	}
+
+	tests := []struct {
+		name   string
+		req    risk.RiskCheckRequest
+		stop   risk.EmergencyStop
+		state  func() *portfolioState
+		q      quote
+		failed risk.RiskCheckType // "" = approved
+	}{
+		{"Buy within limits", buy("AAA", 100), running, newState, q, ""},
+		{"Emergency stop blocks buy", buy("AAA", 1), risk.EmergencyStop{Enabled: true}, newState, q, risk.CheckEmergencyStop},
+		{"Emergency stop allows sell", risk.RiskCheckRequest{Symbol: "AAA", Side: execution.SideSell, OrderType: execution.OrderTypeMarket, Qty: 100}, risk.EmergencyStop{Enabled: true}, nil, q, ""},
+		{"Market sell without price allowed", risk.RiskCheckRequest{Symbol: "AAA", Side: execution.SideSell, OrderType: execution.OrderTypeMarket, Qty: 100}, running, nil, quote{}, ""},
+		{"Buy without price blocked", buy("AAA", 1), running, newState, quote{}, risk.CheckPriceBand},
+		{"Order value", buy("CCC", 2_001), running, newState, q, risk.CheckOrderValue},
+		{"Single weight", buy("AAA", 300), running, newState, q, risk.CheckSingleWeight}, // 4,000,000 / 20,000,000 = 20%
+		{"Sector weight", buy("NEW", 290), running, newState, q, risk.CheckSectorWeight}, // 8,900,000 / 20,000,000 = 44.5%
+		{"Daily loss", buy("AAA", 1), running, func() *portfolioState {
+			s := newState()
+			s.DailyPnLPct = -3.5
+			return s
+		}, q, risk.CheckDailyLoss},
+		{"Total positions", buy("NEW", 1), running, func() *portfolioState {
+			s := newState()
+			for i := 0; i < limits.MaxTotalPositions; i++ {
+				s.Positions[string(rune('a'+i))] = decimal.Zero
+			}
+			return s
+		}, q, risk.CheckTotalPositions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state *portfolioState
+			if tt.state != nil {
+				state = tt.state()
+			}
+
+			result := evaluate(tt.req, limits, tt.stop, state, tt.q)
+
+			if tt.failed == "" {
+				if !result.Approved {
+					t.Errorf("Expected approved, got %s", result.Message)
+				}
+				return
+			}
+			if result.Approved || result.FailedCheck == nil {
+				t.Fatalf("Expected %s failure, got approved", tt.failed)
+			}
+			if result.FailedCheck.CheckType != tt.failed {
+				t.Errorf("Expected %s failure, got %s (%s)", tt.failed, result.FailedCheck.CheckType, result.FailedCheck.Message)
+			}
+		})
+	}
+
+	t.Run("Limit price band", func(t *testing.T) {
+		req := buy("AAA", 10)
+		req.OrderType = execution.OrderTypeLimit
+
+		far := decimal.NewFromInt(10_600) // +6% > 5%
+		req.LimitPrice = &far
+		if result := evaluate(req, limits, running, newState(), q); result.Approved || result.FailedCheck.CheckType != risk.CheckPriceBand {
+			t.Errorf("Expected PRICE_BAND failure for +6%% limit, got %s", result.Message)
+		}
+
+		near := decimal.NewFromInt(10_200)
+		req.LimitPrice = &near
+		if result := evaluate(req, limits, running, newState(), q); !result.Approved {
+			t.Errorf("Expected +2%% limit approved, got %s", result.Message)
+		}
+
+		// 현재가는 상한가 근처지만 지정가가 가격제한폭 밖
+		upper := quote{Price: decimal.NewFromInt(12_900), PrevClose: decimal.NewFromInt(10_000)}
+		outside := decimal.NewFromInt(13_100)
+		req.LimitPrice = &outside
+		if result := evaluate(req, limits, running, newState(), upper); result.Approved || result.FailedCheck.LimitValue != price.PriceLimitRate*100 {
+			t.Errorf("Expected KRX price limit failure, got %s", result.Message)
+		}
+	})
+
+	t.Run("Exit at lower limit passes", func(t *testing.T) {
+		// 하한가 (전일 10,000 → 7,000) 도달 상태의 SL2 청산
+		lowerLimit := quote{Price: decimal.NewFromInt(7_000), PrevClose: decimal.NewFromInt(10_000)}
+		sl2 := risk.RiskCheckRequest{
+			Source:     risk.SourceIntent,
+			Symbol:     "AAA",
+			Side:       execution.SideSell,
+			IntentType: "EXIT_FULL",
+			OrderType:  execution.OrderTypeMarket,
+			Qty:        100,
+		}
+		if result := evaluate(sl2, limits, running, nil, lowerLimit); !result.Approved {
+			t.Errorf("Expected SL2 market exit approved at lower limit, got %s", result.Message)
+		}
+
+		// 현재가 괴리 (5% 초과) 지정가도 매도는 차단하지 않음 (호가단위/가격제한폭은 pricing.Guard 담당)
+		within := decimal.NewFromInt(7_500)
+		sl2.OrderType = execution.OrderTypeLimit
+		sl2.LimitPrice = &within
+		if result := evaluate(sl2, limits, running, nil, lowerLimit); !result.Approved {
+			t.Errorf("Expected sell limit approved, got %s", result.Message)
+		}
+	})
+
+	t.Run("Weights skipped without portfolio value", func(t *testing.T) {
+		empty := &portfolioState{Positions: map[string]decimal.Decimal{}, Sectors: map[string]string{}}
+		if result := evaluate(buy("AAA", 100), limits, running, empty, q); !result.Approved {
+			t.Errorf("Expected approved on empty portfolio, got %s", result.Message)
+		}
+	})
+}
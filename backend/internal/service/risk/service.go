@@ -0,0 +1,318 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/risk"
+)
+
+// 한국 시간대 (당일 손익 기준)
+var kst = time.FixedZone("KST", 9*60*60)
+
+// Service is the pre-trade Risk gate
+// 모든 주문 제출 전 검증 (Fail-Closed: 검증 불가 시 제출하지 않음)
+type Service struct {
+	// Repositories
+	repo         risk.RiskRepository
+	symbolReader risk.SymbolInfoReader
+
+	// Readers
+	holdingReader   HoldingReader
+	priceReader     PriceReader
+	exitEventReader ExitEventReader
+	cashReader      CashReader // optional (nil → 예수금 미확인)
+
+	// Config
+	accountID   string
+	profileName string // 계좌별 한도 프로필 ("" → 활성 프로필)
+}
+
+// HoldingReader 보유 종목 Reader (Execution)
+type HoldingReader interface {
+	LoadHoldings(ctx context.Context, accountID string) ([]*execution.Holding, error)
+}
+
+// PriceReader 현재가 Reader (PriceSync)
+type PriceReader interface {
+	GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error)
+}
+
+// ExitEventReader 청산 이벤트 Reader (당일 실현손익)
+type ExitEventReader interface {
+	LoadExitEventsSince(ctx context.Context, since time.Time) ([]*execution.ExitEvent, error)
+}
+
+// CashReader 예수금 Reader
+type CashReader interface {
+	GetCash(ctx context.Context) (decimal.Decimal, error)
+}
+
+// NewService creates a new Risk service
+func NewService(
+	repo risk.RiskRepository,
+	symbolReader risk.SymbolInfoReader,
+	holdingReader HoldingReader,
+	priceReader PriceReader,
+	exitEventReader ExitEventReader,
+	accountID string,
+) *Service {
+	return &Service{
+		repo:            repo,
+		symbolReader:    symbolReader,
+		holdingReader:   holdingReader,
+		priceReader:     priceReader,
+		exitEventReader: exitEventReader,
+		accountID:       accountID,
+	}
+}
+
+// SetLimitsProfile sets the risk limits profile of this account ("" → 활성 프로필)
+func (s *Service) SetLimitsProfile(profileName string) {
+	s.profileName = profileName
+}
+
+// loadLimits loads limits of the configured profile
+func (s *Service) loadLimits(ctx context.Context) (*risk.RiskLimits, error) {
+	if s.profileName != "" {
+		return s.repo.GetLimitsByProfile(ctx, s.profileName)
+	}
+	return s.repo.GetActiveLimits(ctx)
+}
+
+// SetCashReader sets the optional cash reader (예수금 → 비중 계산 정확도 향상)
+func (s *Service) SetCashReader(reader CashReader) {
+	s.cashReader = reader
+}
+
+// =============================================================================
+// Pre-trade Check
+// =============================================================================
+
+// CheckOrder validates an order before submission
+// error 반환 = 검증 불가 (호출자는 주문을 제출하지 않아야 함)
+// Approved=false = 한도 위반 (control.risk_blocks 기록)
+func (s *Service) CheckOrder(ctx context.Context, req risk.RiskCheckRequest) (*risk.RiskCheckResult, error) {
+	stop, err := s.repo.GetEmergencyStop(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load emergency stop: %w", err)
+	}
+
+	limits, err := s.loadLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load risk limits: %w", err)
+	}
+
+	q := s.loadQuote(ctx, req.Symbol)
+
+	var state *portfolioState
+	if req.Side == execution.SideBuy {
+		state, err = s.loadState(ctx, req.Symbol)
+		if err != nil {
+			return nil, fmt.Errorf("load portfolio state: %w", err)
+		}
+	}
+
+	result := evaluate(req, *limits, *stop, state, q)
+
+	if !result.Approved {
+		log.Warn().
+			Str("source", req.Source).
+			Str("symbol", req.Symbol).
+			Str("side", req.Side).
+			Int64("qty", req.Qty).
+			Str("check", string(result.FailedCheck.CheckType)).
+			Str("message", result.FailedCheck.Message).
+			Msg("🚫 Order blocked by risk gate")
+
+		s.recordBlock(ctx, req, &result)
+	}
+
+	return &result, nil
+}
+
+// loadQuote loads reference price (실패 시 0 → PRICE_BAND에서 처리)
+func (s *Service) loadQuote(ctx context.Context, symbol string) quote {
+	bp, err := s.priceReader.GetBestPrice(ctx, symbol)
+	if err != nil || bp == nil || bp.BestPrice <= 0 {
+		log.Debug().Err(err).Str("symbol", symbol).Msg("Reference price unavailable for risk check")
+		return quote{}
+	}
+
+	q := quote{Price: decimal.NewFromInt(bp.BestPrice)}
+	if bp.ChangePrice != nil {
+		q.PrevClose = decimal.NewFromInt(bp.BestPrice - *bp.ChangePrice)
+	}
+	return q
+}
+
+// loadState builds current portfolio state (holdings + cash + daily P&L)
+func (s *Service) loadState(ctx context.Context, symbol string) (*portfolioState, error) {
+	holdings, err := s.holdingReader.LoadHoldings(ctx, s.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("load holdings: %w", err)
+	}
+
+	state := &portfolioState{
+		Positions: make(map[string]decimal.Decimal),
+		Sectors:   make(map[string]string),
+	}
+
+	symbols := []string{symbol}
+	intraday := decimal.Zero
+	for _, h := range holdings {
+		if h.Qty <= 0 {
+			continue
+		}
+		qty := decimal.NewFromInt(h.Qty)
+		value := h.CurrentPrice.Mul(qty)
+
+		state.Positions[h.Symbol] = value
+		state.HoldingsValue = state.HoldingsValue.Add(value)
+		intraday = intraday.Add(decimal.NewFromInt(h.ChangePrice).Mul(qty))
+		symbols = append(symbols, h.Symbol)
+	}
+
+	if s.cashReader != nil {
+		cash, err := s.cashReader.GetCash(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("load cash: %w", err)
+		}
+		state.Cash = cash
+		state.CashKnown = true
+	}
+
+	infos, err := s.symbolReader.LoadSymbolInfo(ctx, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("load symbol info: %w", err)
+	}
+	for sym, info := range infos {
+		state.Sectors[sym] = info.Sector
+	}
+
+	// 당일 손익 = 당일 실현손익 + 보유 종목 전일대비 평가 변동
+	now := time.Now().In(kst)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, kst)
+
+	events, err := s.exitEventReader.LoadExitEventsSince(ctx, dayStart)
+	if err != nil {
+		return nil, fmt.Errorf("load exit events: %w", err)
+	}
+
+	realized := decimal.Zero
+	for _, e := range events {
+		if e.AccountID != s.accountID {
+			continue
+		}
+		realized = realized.Add(e.RealizedPnl)
+	}
+
+	state.DailyPnL = realized.Add(intraday)
+	startValue := state.HoldingsValue.Add(state.Cash).Sub(state.DailyPnL)
+	if startValue.IsPositive() {
+		state.DailyPnLPct = state.DailyPnL.Div(startValue).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	}
+
+	return state, nil
+}
+
+// recordBlock saves block record (실패해도 차단은 유지)
+func (s *Service) recordBlock(ctx context.Context, req risk.RiskCheckRequest, result *risk.RiskCheckResult) {
+	failed := result.FailedCheck
+	block := &risk.RiskBlock{
+		BlockID:      uuid.New(),
+		Source:       req.Source,
+		IntentID:     req.IntentID,
+		AccountID:    req.AccountID,
+		Symbol:       req.Symbol,
+		Side:         req.Side,
+		IntentType:   req.IntentType,
+		Qty:          req.Qty,
+		OrderValue:   result.OrderValue,
+		CheckType:    failed.CheckType,
+		Message:      failed.Message,
+		CurrentValue: failed.CurrentValue,
+		LimitValue:   failed.LimitValue,
+		BlockedAt:    result.CheckedAt,
+	}
+
+	if err := s.repo.SaveBlock(ctx, block); err != nil {
+		log.Error().Err(err).Str("symbol", req.Symbol).Msg("Failed to save risk block")
+	}
+}
+
+// =============================================================================
+// Limits / Emergency Stop / Blocks
+// =============================================================================
+
+// GetLimits returns active risk limits
+func (s *Service) GetLimits(ctx context.Context) (*risk.RiskLimits, error) {
+	return s.repo.GetActiveLimits(ctx)
+}
+
+// UpdateLimits validates and saves risk limits (운영자)
+func (s *Service) UpdateLimits(ctx context.Context, limits *risk.RiskLimits) error {
+	if limits.ProfileName == "" {
+		limits.ProfileName = risk.ProfileDefault
+	}
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+
+	limits.IsActive = true
+	limits.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateLimits(ctx, limits); err != nil {
+		return fmt.Errorf("update limits: %w", err)
+	}
+
+	log.Info().
+		Str("profile", limits.ProfileName).
+		Int("max_positions", limits.MaxTotalPositions).
+		Float64("max_single_weight", limits.MaxSingleWeight).
+		Float64("max_sector_weight", limits.MaxSectorWeight).
+		Float64("max_daily_loss_pct", limits.MaxDailyLossPct).
+		Str("max_order_value", limits.MaxOrderValue.String()).
+		Str("updated_by", limits.UpdatedBy).
+		Msg("Risk limits updated")
+
+	return nil
+}
+
+// GetEmergencyStop returns emergency stop state
+func (s *Service) GetEmergencyStop(ctx context.Context) (*risk.EmergencyStop, error) {
+	return s.repo.GetEmergencyStop(ctx)
+}
+
+// SetEmergencyStop enables/disables emergency stop (수동)
+func (s *Service) SetEmergencyStop(ctx context.Context, enabled bool, reason *string, updatedBy string) error {
+	stop := &risk.EmergencyStop{
+		Enabled:   enabled,
+		Reason:    reason,
+		UpdatedBy: updatedBy,
+		UpdatedTS: time.Now(),
+	}
+
+	if err := s.repo.SetEmergencyStop(ctx, stop); err != nil {
+		return fmt.Errorf("set emergency stop: %w", err)
+	}
+
+	if enabled {
+		log.Warn().Str("updated_by", updatedBy).Msg("🚨 Emergency stop ENABLED - new buy orders blocked")
+	} else {
+		log.Info().Str("updated_by", updatedBy).Msg("Emergency stop disabled")
+	}
+
+	return nil
+}
+
+// ListBlocks returns recent risk blocks
+func (s *Service) ListBlocks(ctx context.Context, since time.Time, limit int) ([]*risk.RiskBlock, error) {
+	return s.repo.ListBlocks(ctx, since, limit)
+}
@@ -0,0 +1,167 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+)
+
+// maxConstraintPasses 제약 적용/재분배 최대 반복 횟수
+const maxConstraintPasses = 20
+
+// Allocator 목표 비중 계산 (선정 → 할당 → 제약)
+type Allocator struct {
+	criteria *portfolio.PortfolioCriteria
+}
+
+// NewAllocator 새 Allocator 생성
+func NewAllocator(criteria *portfolio.PortfolioCriteria) *Allocator {
+	return &Allocator{
+		criteria: criteria,
+	}
+}
+
+// SelectCandidates Ranking 선정 종목 중 편입 후보 선택
+// Selected && TotalScore >= MinScore, 점수 내림차순 상위 MaxHoldings
+func (a *Allocator) SelectCandidates(stocks []ranking.RankedStock) []ranking.RankedStock {
+	candidates := make([]ranking.RankedStock, 0, len(stocks))
+
+	for _, stock := range stocks {
+		if !stock.Selected {
+			continue
+		}
+		if stock.TotalScore < a.criteria.MinScore {
+			continue
+		}
+		candidates = append(candidates, stock)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].TotalScore > candidates[j].TotalScore
+	})
+
+	if a.criteria.MaxHoldings > 0 && len(candidates) > a.criteria.MaxHoldings {
+		candidates = candidates[:a.criteria.MaxHoldings]
+	}
+
+	return candidates
+}
+
+// Allocate 후보별 목표 비중 계산 (총 비중 = 100 - CashBuffer)
+func (a *Allocator) Allocate(candidates []ranking.RankedStock) []portfolio.TargetPosition {
+	targets := make([]portfolio.TargetPosition, 0, len(candidates))
+	if len(candidates) == 0 {
+		return targets
+	}
+
+	budget := a.budget()
+
+	totalScore := 0.0
+	for _, stock := range candidates {
+		totalScore += stock.TotalScore
+	}
+
+	for _, stock := range candidates {
+		weight := budget / float64(len(candidates))
+		if a.criteria.AllocationMethod == portfolio.AllocationMethodScoreWeighted && totalScore > 0 {
+			weight = budget * stock.TotalScore / totalScore
+		}
+
+		targets = append(targets, portfolio.TargetPosition{
+			Symbol:       stock.Symbol,
+			Name:         stock.Name,
+			Market:       stock.Market,
+			Sector:       stock.Sector,
+			Rank:         stock.Rank,
+			TotalScore:   stock.TotalScore,
+			AlphaScore:   stock.AlphaScore,
+			RiskScore:    stock.RiskScore,
+			TargetWeight: weight,
+		})
+	}
+
+	return a.applyConstraints(targets, budget)
+}
+
+// applyConstraints 단일 종목/섹터 한도 적용 후 초과분을 미제약 종목에 재분배
+// 한도에 걸린 종목은 고정(capped), 재분배할 곳이 없으면 남은 비중은 현금
+func (a *Allocator) applyConstraints(targets []portfolio.TargetPosition, budget float64) []portfolio.TargetPosition {
+	for pass := 0; pass < maxConstraintPasses; pass++ {
+		// 1. 단일 종목 한도
+		if a.criteria.MaxSingleWeight > 0 {
+			for i := range targets {
+				if targets[i].TargetWeight > a.criteria.MaxSingleWeight {
+					targets[i].TargetWeight = a.criteria.MaxSingleWeight
+					targets[i].Capped = true
+					targets[i].CappedReason = fmt.Sprintf("Single position limit (%.1f%%)", a.criteria.MaxSingleWeight)
+				}
+			}
+		}
+
+		// 2. 섹터 한도 (섹터 정보 없으면 제약 없음)
+		if a.criteria.MaxSectorWeight > 0 {
+			sectorWeights := make(map[string]float64)
+			for _, t := range targets {
+				if t.Sector != "" {
+					sectorWeights[t.Sector] += t.TargetWeight
+				}
+			}
+
+			for sector, total := range sectorWeights {
+				if total <= a.criteria.MaxSectorWeight+1e-9 {
+					continue
+				}
+				ratio := a.criteria.MaxSectorWeight / total
+				for i := range targets {
+					if targets[i].Sector == sector {
+						targets[i].TargetWeight *= ratio
+						targets[i].Capped = true
+						targets[i].CappedReason = fmt.Sprintf("Sector limit (%.1f%%)", a.criteria.MaxSectorWeight)
+					}
+				}
+			}
+		}
+
+		// 3. 초과분 재분배 (미제약 종목에 비례 배분)
+		excess := budget - sumWeights(targets)
+		uncapped := 0.0
+		for _, t := range targets {
+			if !t.Capped {
+				uncapped += t.TargetWeight
+			}
+		}
+
+		if excess < 1e-9 || uncapped <= 0 {
+			break
+		}
+
+		factor := (uncapped + excess) / uncapped
+		for i := range targets {
+			if !targets[i].Capped {
+				targets[i].TargetWeight *= factor
+			}
+		}
+	}
+
+	return targets
+}
+
+// budget 총 목표 비중 (%)
+func (a *Allocator) budget() float64 {
+	budget := 100.0 - a.criteria.CashBuffer
+	if budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// sumWeights 목표 비중 합계
+func sumWeights(targets []portfolio.TargetPosition) float64 {
+	total := 0.0
+	for _, t := range targets {
+		total += t.TargetWeight
+	}
+	return total
+}
@@ -0,0 +1,104 @@
+package portfolio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+)
+
+// TestAllocate tests weight allocation with single/sector limits
+func TestAllocate(t *testing.T) {
+	t.Run("Equal weight within budget", func(t *testing.T) {
+		criteria := portfolio.DefaultPortfolioCriteria()
+		criteria.MaxSingleWeight = 15.0
+		criteria.CashBuffer = 2.0
+
+		candidates := make([]ranking.RankedStock, 0, 10)
+		for i := 0; i < 10; i++ {
+			candidates = append(candidates, ranking.RankedStock{Symbol: string(rune('A' + i)), TotalScore: 70})
+		}
+
+		targets := NewAllocator(criteria).Allocate(candidates)
+		for _, tgt := range targets {
+			if math.Abs(tgt.TargetWeight-9.8) > 1e-9 {
+				t.Errorf("%s: expected 9.8%%, got %f", tgt.Symbol, tgt.TargetWeight)
+			}
+		}
+	})
+
+	t.Run("Sector cap redistributes to other sectors", func(t *testing.T) {
+		criteria := portfolio.DefaultPortfolioCriteria()
+		criteria.MaxSingleWeight = 30.0
+		criteria.MaxSectorWeight = 40.0
+		criteria.CashBuffer = 0
+
+		candidates := []ranking.RankedStock{
+			{Symbol: "A", Sector: "반도체", TotalScore: 80},
+			{Symbol: "B", Sector: "반도체", TotalScore: 80},
+			{Symbol: "C", Sector: "반도체", TotalScore: 80},
+			{Symbol: "D", Sector: "금융", TotalScore: 80},
+			{Symbol: "E", Sector: "화학", TotalScore: 80},
+		}
+
+		targets := NewAllocator(criteria).Allocate(candidates)
+
+		// 반도체 60% → 40% cap, 나머지 20%를 D/E에 재분배 (각 30%)
+		weights := make(map[string]float64)
+		for _, tgt := range targets {
+			weights[tgt.Symbol] = tgt.TargetWeight
+		}
+		if sector := weights["A"] + weights["B"] + weights["C"]; math.Abs(sector-40.0) > 1e-6 {
+			t.Errorf("Expected sector weight 40, got %f", sector)
+		}
+		if math.Abs(weights["D"]-30.0) > 1e-6 || math.Abs(weights["E"]-30.0) > 1e-6 {
+			t.Errorf("Expected D/E 30%%, got %f / %f", weights["D"], weights["E"])
+		}
+		if math.Abs(sumWeights(targets)-100.0) > 1e-6 {
+			t.Errorf("Expected total 100, got %f", sumWeights(targets))
+		}
+	})
+
+	t.Run("All capped leaves residual as cash", func(t *testing.T) {
+		criteria := portfolio.DefaultPortfolioCriteria()
+		criteria.MaxSingleWeight = 15.0
+		criteria.CashBuffer = 0
+
+		candidates := []ranking.RankedStock{
+			{Symbol: "A", TotalScore: 80},
+			{Symbol: "B", TotalScore: 70},
+		}
+
+		targets := NewAllocator(criteria).Allocate(candidates)
+		if math.Abs(sumWeights(targets)-30.0) > 1e-9 {
+			t.Errorf("Expected total 30, got %f", sumWeights(targets))
+		}
+	})
+}
+
+// TestSizeTarget tests tick normalization and lot sizing
+func TestSizeTarget(t *testing.T) {
+	tests := []struct {
+		price    int64
+		expected int64
+	}{
+		{1_999, 1_999},
+		{4_997, 4_995},
+		{72_350, 72_300},
+		{487_700, 487_500},
+		{1_234_567, 1_234_000},
+	}
+
+	for _, tt := range tests {
+		got := normalizeToTick(decimal.NewFromInt(tt.price))
+		if got.IntPart() != tt.expected {
+			t.Errorf("normalizeToTick(%d) = %s, expected %d", tt.price, got, tt.expected)
+		}
+	}
+
+	if qty := sizeTarget(decimal.NewFromInt(1_000_000), decimal.NewFromInt(72_300)); qty != 13 {
+		t.Errorf("Expected 13 shares, got %d", qty)
+	}
+}
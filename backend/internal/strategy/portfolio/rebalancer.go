@@ -0,0 +1,365 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/exit"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+)
+
+// Rebalancer 목표 포트폴리오 vs 보유 → 승인 대기 주문 의도
+type Rebalancer struct {
+	// Repositories
+	planRepo portfolio.RebalancePlanRepository
+
+	// External readers
+	targetReader   TargetReader
+	holdingReader  HoldingReader
+	positionReader PositionReader
+	cashReader     CashReader // optional (nil → 스냅샷 현금)
+
+	// Config
+	accountID string
+	criteria  *portfolio.RebalanceCriteria
+}
+
+// TargetReader 목표 포트폴리오 Reader
+type TargetReader interface {
+	// 최신 포트폴리오 스냅샷 조회
+	GetLatestSnapshot(ctx context.Context) (*portfolio.PortfolioSnapshot, error)
+}
+
+// PositionReader 포지션 Reader (매도 intent position_id 연결)
+type PositionReader interface {
+	// 종목별 포지션 조회
+	GetPositionBySymbol(ctx context.Context, accountID, symbol, status string) (*exit.Position, error)
+}
+
+// NewRebalancer 새 Rebalancer 생성
+func NewRebalancer(
+	planRepo portfolio.RebalancePlanRepository,
+	targetReader TargetReader,
+	holdingReader HoldingReader,
+	positionReader PositionReader,
+	accountID string,
+) *Rebalancer {
+	return &Rebalancer{
+		planRepo:       planRepo,
+		targetReader:   targetReader,
+		holdingReader:  holdingReader,
+		positionReader: positionReader,
+		accountID:      accountID,
+		criteria:       portfolio.DefaultRebalanceCriteria(),
+	}
+}
+
+// SetCashReader sets the cash reader (optional)
+func (r *Rebalancer) SetCashReader(reader CashReader) {
+	r.cashReader = reader
+}
+
+// PlanRebalance 최신 목표 포트폴리오로 리밸런싱 계획 생성
+// dryRun=false → 계획 저장 + PENDING_APPROVAL intent 생성 (승인 전까지 실행 안 됨)
+func (r *Rebalancer) PlanRebalance(ctx context.Context, dryRun bool) (*portfolio.RebalancePlan, error) {
+	// 1. Load target portfolio
+	snapshot, err := r.targetReader.GetLatestSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load portfolio snapshot: %w", err)
+	}
+
+	// 2. Load live holdings
+	holdings, err := r.holdingReader.LoadHoldings(ctx, r.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("load holdings: %w", err)
+	}
+
+	held := make(map[string]*execution.Holding, len(holdings))
+	for _, h := range holdings {
+		if h.Qty > 0 {
+			held[h.Symbol] = h
+		}
+	}
+
+	// 3. Load pending orders/intents (중복 주문 방지)
+	pending, err := r.planRepo.LoadPendingQty(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load pending qty: %w", err)
+	}
+
+	// 4. Cash (조회 실패 시 Fail-Closed: 스냅샷 현금으로 sizing하지 않고 중단)
+	cash := snapshot.Cash
+	if r.cashReader != nil {
+		c, err := r.cashReader.GetCash(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get cash: %w", err)
+		}
+		cash = c
+	}
+
+	// 5. Build plan
+	plan := buildPlan(snapshot, held, pending, cash, r.criteria)
+	plan.PlanID = uuid.New()
+	plan.AccountID = r.accountID
+	plan.CreatedAt = time.Now()
+	plan.DryRun = dryRun
+
+	if len(plan.Orders) == 0 {
+		log.Info().
+			Str("portfolio_snapshot_id", snapshot.SnapshotID).
+			Int("skipped", len(plan.Skipped)).
+			Msg("Nothing to rebalance")
+		return plan, portfolio.ErrNothingToRebalance
+	}
+
+	// 6. Link positions (보유 포지션 → 기존 position_id, 신규 → 새 ID)
+	for i := range plan.Orders {
+		o := &plan.Orders[i]
+		o.PositionID = uuid.New()
+		if pos, err := r.positionReader.GetPositionBySymbol(ctx, r.accountID, o.Symbol, exit.StatusOpen); err == nil && pos != nil {
+			o.PositionID = pos.PositionID
+		}
+		if !dryRun {
+			o.IntentID = uuid.New()
+		}
+	}
+
+	if dryRun {
+		return plan, nil
+	}
+
+	// 7. Save plan + intents
+	if err := r.planRepo.CreatePlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("create plan: %w", err)
+	}
+
+	log.Info().
+		Str("plan_id", plan.PlanID.String()).
+		Str("portfolio_snapshot_id", plan.PortfolioSnapshotID).
+		Int("sells", plan.Summary.SellCount).
+		Int("buys", plan.Summary.BuyCount).
+		Int("skipped", plan.Summary.SkippedCount).
+		Str("cash_after", plan.Summary.CashAfter.String()).
+		Msg("📋 Rebalance plan created (pending approval)")
+
+	return plan, nil
+}
+
+// GetPlan 계획 조회
+func (r *Rebalancer) GetPlan(ctx context.Context, planID uuid.UUID) (*portfolio.RebalancePlan, error) {
+	return r.planRepo.GetPlan(ctx, planID)
+}
+
+// GetLatestPlan 최신 계획 조회
+func (r *Rebalancer) GetLatestPlan(ctx context.Context) (*portfolio.RebalancePlan, error) {
+	return r.planRepo.GetLatestPlan(ctx)
+}
+
+// ApprovePlan 계획 일괄 승인 (intent → NEW, 매수는 계획의 매도가 모두 체결된 후 제출)
+func (r *Rebalancer) ApprovePlan(ctx context.Context, planID uuid.UUID) error {
+	if err := r.planRepo.ApprovePlan(ctx, planID); err != nil {
+		return err
+	}
+	log.Info().Str("plan_id", planID.String()).Msg("✅ Rebalance plan approved")
+	return nil
+}
+
+// RejectPlan 계획 일괄 거부 (intent → CANCELLED)
+func (r *Rebalancer) RejectPlan(ctx context.Context, planID uuid.UUID) error {
+	if err := r.planRepo.RejectPlan(ctx, planID); err != nil {
+		return err
+	}
+	log.Info().Str("plan_id", planID.String()).Msg("Rebalance plan rejected")
+	return nil
+}
+
+// buildPlan 목표 vs (보유 + 대기) 차이로 매도/매수 주문 산출
+// - 괴리 < DriftThreshold 또는 주문금액 < MinOrderValue → 스킵 (전량 편출은 예외)
+// - 매도 먼저 (순수입으로 현금 확보), 매수는 괴리 큰 순서로 현금 한도 내
+func buildPlan(
+	snapshot *portfolio.PortfolioSnapshot,
+	held map[string]*execution.Holding,
+	pending map[string]int64,
+	cash decimal.Decimal,
+	criteria *portfolio.RebalanceCriteria,
+) *portfolio.RebalancePlan {
+	plan := &portfolio.RebalancePlan{
+		PortfolioSnapshotID: snapshot.SnapshotID,
+		Status:              portfolio.PlanStatusPendingApproval,
+		Orders:              make([]portfolio.RebalanceOrder, 0),
+		Skipped:             make([]portfolio.RebalanceOrder, 0),
+	}
+
+	var sells, buys []portfolio.RebalanceOrder
+
+	for _, t := range snapshot.Targets {
+		var currentQty int64
+		var h *execution.Holding
+		if h = held[t.Symbol]; h != nil {
+			currentQty = h.Qty
+		}
+		pendingQty := pending[t.Symbol]
+
+		delta := t.TargetQty - (currentQty + pendingQty)
+		if delta == 0 {
+			continue
+		}
+
+		estPrice := t.RefPrice
+		market := t.Market
+		if h != nil {
+			if !estPrice.IsPositive() {
+				estPrice = h.CurrentPrice
+			}
+			if market == "" {
+				market, _ = h.Raw["market"].(string)
+			}
+		}
+
+		order := portfolio.RebalanceOrder{
+			Symbol:     t.Symbol,
+			Name:       t.Name,
+			Market:     market,
+			TargetQty:  t.TargetQty,
+			CurrentQty: currentQty,
+			PendingQty: pendingQty,
+			EstPrice:   estPrice,
+		}
+
+		if !estPrice.IsPositive() {
+			order.Reason = "No price"
+			plan.Skipped = append(plan.Skipped, order)
+			continue
+		}
+
+		if delta < 0 {
+			// 매도 가능 수량 = 보유 - 대기 중 매도
+			sellable := currentQty
+			if pendingQty < 0 {
+				sellable += pendingQty
+			}
+			order.Side = execution.SideSell
+			order.Qty = min(-delta, sellable)
+		} else {
+			order.Side = execution.SideBuy
+			order.Qty = delta
+		}
+
+		if order.Qty <= 0 {
+			order.Reason = "Already pending"
+			plan.Skipped = append(plan.Skipped, order)
+			continue
+		}
+
+		order.EstAmount = estPrice.Mul(decimal.NewFromInt(order.Qty))
+		if snapshot.TotalValue.IsPositive() {
+			order.DriftPct = order.EstAmount.Div(snapshot.TotalValue).Mul(decimal.NewFromInt(100)).InexactFloat64()
+		}
+
+		fullExit := t.TargetQty == 0 && order.Side == execution.SideSell
+		if !fullExit {
+			if order.DriftPct < criteria.DriftThreshold {
+				order.Reason = fmt.Sprintf("Drift below threshold: %.2f%% < %.2f%%", order.DriftPct, criteria.DriftThreshold)
+				plan.Skipped = append(plan.Skipped, order)
+				continue
+			}
+			if order.EstAmount.LessThan(criteria.MinOrderValue) {
+				order.Reason = fmt.Sprintf("Below min order value: %s < %s", order.EstAmount, criteria.MinOrderValue)
+				plan.Skipped = append(plan.Skipped, order)
+				continue
+			}
+		}
+
+		if order.Side == execution.SideSell {
+			if fullExit {
+				order.Reason = "Exit (not in target)"
+			} else {
+				order.Reason = "Trim to target"
+			}
+			sells = append(sells, order)
+		} else {
+			if currentQty == 0 {
+				order.Reason = "New position"
+			} else {
+				order.Reason = "Add to target"
+			}
+			buys = append(buys, order)
+		}
+	}
+
+	sortByDrift(sells)
+	sortByDrift(buys)
+
+	summary := portfolio.RebalanceSummary{
+		SellAmount: decimal.Zero,
+		BuyAmount:  decimal.Zero,
+		TotalFee:   decimal.Zero,
+		TotalTax:   decimal.Zero,
+		CashBefore: cash,
+	}
+	available := cash
+
+	// 1. 매도 (순수입 = 금액 - 수수료 - 세금)
+	for _, o := range sells {
+		o.EstFee, o.EstTax = execution.EstimateTradeCost(o.Side, o.Market, o.EstAmount)
+		available = available.Add(o.EstAmount).Sub(o.EstFee).Sub(o.EstTax)
+
+		summary.SellCount++
+		summary.SellAmount = summary.SellAmount.Add(o.EstAmount)
+		summary.TotalFee = summary.TotalFee.Add(o.EstFee)
+		summary.TotalTax = summary.TotalTax.Add(o.EstTax)
+
+		o.Seq = len(plan.Orders) + 1
+		plan.Orders = append(plan.Orders, o)
+	}
+
+	// 2. 매수 (현금 한도 내, 부족 시 수량 축소)
+	for _, o := range buys {
+		o.EstFee, o.EstTax = execution.EstimateTradeCost(o.Side, o.Market, o.EstAmount)
+		cost := o.EstAmount.Add(o.EstFee)
+
+		if cost.GreaterThan(available) {
+			unitFee, _ := execution.EstimateTradeCost(o.Side, o.Market, o.EstPrice)
+			o.Qty = available.Div(o.EstPrice.Add(unitFee)).IntPart() / krxLotSize * krxLotSize
+			if o.Qty <= 0 || o.EstPrice.Mul(decimal.NewFromInt(o.Qty)).LessThan(criteria.MinOrderValue) {
+				o.Qty = 0
+				o.Reason = "Insufficient cash"
+				plan.Skipped = append(plan.Skipped, o)
+				continue
+			}
+			o.Reason = "Reduced for cash"
+			o.EstAmount = o.EstPrice.Mul(decimal.NewFromInt(o.Qty))
+			o.EstFee, o.EstTax = execution.EstimateTradeCost(o.Side, o.Market, o.EstAmount)
+			cost = o.EstAmount.Add(o.EstFee)
+		}
+
+		available = available.Sub(cost)
+
+		summary.BuyCount++
+		summary.BuyAmount = summary.BuyAmount.Add(o.EstAmount)
+		summary.TotalFee = summary.TotalFee.Add(o.EstFee)
+
+		o.Seq = len(plan.Orders) + 1
+		plan.Orders = append(plan.Orders, o)
+	}
+
+	summary.SkippedCount = len(plan.Skipped)
+	summary.CashAfter = available
+	plan.Summary = summary
+
+	return plan
+}
+
+// sortByDrift 괴리 큰 순서 (내림차순)
+func sortByDrift(orders []portfolio.RebalanceOrder) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].DriftPct > orders[j].DriftPct
+	})
+}
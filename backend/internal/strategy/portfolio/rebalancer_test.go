@@ -0,0 +1,136 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+)
+
+// TestBuildPlan tests drift threshold, sell-before-buy sequencing and cash limits
+func TestBuildPlan(t *testing.T) {
+	price := decimal.NewFromInt(10_000)
+
+	snapshot := &portfolio.PortfolioSnapshot{
+		SnapshotID: "20261016-test",
+		TotalValue: decimal.NewFromInt(10_000_000),
+		Targets: []portfolio.TargetPosition{
+			{Symbol: "NEW", Market: "KOSPI", TargetQty: 300, RefPrice: price},  // 매수 300 (30%)
+			{Symbol: "TRIM", Market: "KOSPI", TargetQty: 100, RefPrice: price}, // 매도 100 (10%)
+			{Symbol: "HOLD", Market: "KOSDAQ", TargetQty: 205, RefPrice: price},
+			{Symbol: "OUT", TargetQty: 0, RefPrice: price}, // 전량 편출 (0.5% → 예외)
+		},
+	}
+
+	held := map[string]*execution.Holding{
+		"TRIM": {Symbol: "TRIM", Qty: 200, CurrentPrice: price},
+		"HOLD": {Symbol: "HOLD", Qty: 200, CurrentPrice: price}, // 괴리 0.05% → 스킵
+		"OUT":  {Symbol: "OUT", Qty: 50, CurrentPrice: price, Raw: map[string]any{"market": "KOSDAQ"}},
+	}
+
+	criteria := portfolio.DefaultRebalanceCriteria()
+
+	t.Run("Sells first, drift threshold", func(t *testing.T) {
+		plan := buildPlan(snapshot, held, map[string]int64{}, decimal.NewFromInt(5_000_000), criteria)
+
+		if len(plan.Orders) != 3 {
+			t.Fatalf("Expected 3 orders, got %d", len(plan.Orders))
+		}
+		if plan.Orders[0].Side != execution.SideSell || plan.Orders[1].Side != execution.SideSell || plan.Orders[2].Side != execution.SideBuy {
+			t.Errorf("Expected SELL, SELL, BUY order, got %s, %s, %s", plan.Orders[0].Side, plan.Orders[1].Side, plan.Orders[2].Side)
+		}
+		if plan.Summary.SkippedCount != 1 || plan.Skipped[0].Symbol != "HOLD" {
+			t.Errorf("Expected HOLD skipped by drift threshold, got %+v", plan.Skipped)
+		}
+
+		// TRIM: 1,000,000 KOSPI 매도 → 수수료 150 + 세금 2,000 (2026 세율, 0.215%)
+		trim := plan.Orders[0]
+		if trim.Symbol != "TRIM" || !trim.EstFee.Add(trim.EstTax).Equal(decimal.NewFromInt(2_150)) {
+			t.Errorf("Expected TRIM sell cost 2150, got %s + %s", trim.EstFee, trim.EstTax)
+		}
+
+		// OUT: 500,000 KOSDAQ 매도 → 0.215%
+		out := plan.Orders[1]
+		if out.Symbol != "OUT" || !out.EstFee.Add(out.EstTax).Equal(decimal.NewFromInt(1_075)) {
+			t.Errorf("Expected OUT sell cost 1075, got %s + %s", out.EstFee, out.EstTax)
+		}
+	})
+
+	t.Run("Buy reduced to available cash", func(t *testing.T) {
+		plan := buildPlan(snapshot, held, map[string]int64{}, decimal.Zero, criteria)
+
+		// 매도 순수입 = 1,500,000 - 3,225 = 1,496,775 → 1주당 10,001.5 → 149주
+		buy := plan.Orders[len(plan.Orders)-1]
+		if buy.Symbol != "NEW" || buy.Qty != 149 {
+			t.Errorf("Expected NEW reduced to 149, got %s %d", buy.Symbol, buy.Qty)
+		}
+		if !strings.HasPrefix(buy.Reason, "Reduced for cash") {
+			t.Errorf("Expected reduced reason, got %q", buy.Reason)
+		}
+		if plan.Summary.CashAfter.IsNegative() {
+			t.Errorf("Expected non-negative cash after, got %s", plan.Summary.CashAfter)
+		}
+	})
+
+	t.Run("Pending orders are netted", func(t *testing.T) {
+		pending := map[string]int64{"NEW": 300, "TRIM": -100}
+		plan := buildPlan(snapshot, held, pending, decimal.NewFromInt(5_000_000), criteria)
+
+		for _, o := range plan.Orders {
+			if o.Symbol == "NEW" || o.Symbol == "TRIM" {
+				t.Errorf("Expected %s netted by pending orders, got %s %d", o.Symbol, o.Side, o.Qty)
+			}
+		}
+	})
+}
+
+// fakeTargetReader 고정 목표 포트폴리오
+type fakeTargetReader struct {
+	snapshot *portfolio.PortfolioSnapshot
+}
+
+func (f fakeTargetReader) GetLatestSnapshot(ctx context.Context) (*portfolio.PortfolioSnapshot, error) {
+	return f.snapshot, nil
+}
+
+// fakeHoldingReader 보유 없음
+type fakeHoldingReader struct{}
+
+func (fakeHoldingReader) LoadHoldings(ctx context.Context, accountID string) ([]*execution.Holding, error) {
+	return nil, nil
+}
+
+// fakePlanRepo 미체결 없음
+type fakePlanRepo struct {
+	portfolio.RebalancePlanRepository
+}
+
+func (fakePlanRepo) LoadPendingQty(ctx context.Context) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
+// failingCashReader 예수금 조회 실패
+type failingCashReader struct{}
+
+func (failingCashReader) GetCash(ctx context.Context) (decimal.Decimal, error) {
+	return decimal.Zero, errors.New("broker balance unavailable")
+}
+
+// TestPlanRebalanceFailsClosedOnCashError tests that a cash lookup failure aborts the cycle
+func TestPlanRebalanceFailsClosedOnCashError(t *testing.T) {
+	snapshot := &portfolio.PortfolioSnapshot{Cash: decimal.NewFromInt(10_000_000)}
+	r := NewRebalancer(fakePlanRepo{}, fakeTargetReader{snapshot: snapshot}, fakeHoldingReader{}, nil, "11111111-01")
+	r.SetCashReader(failingCashReader{})
+
+	plan, err := r.PlanRebalance(context.Background(), true)
+	if err == nil {
+		t.Fatalf("Expected error on cash lookup failure, got plan %+v", plan)
+	}
+	if plan != nil {
+		t.Errorf("Expected no plan built from stale snapshot cash")
+	}
+}
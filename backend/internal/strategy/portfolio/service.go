@@ -0,0 +1,368 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/wonny/aegis/v14/internal/domain/execution"
+	"github.com/wonny/aegis/v14/internal/domain/portfolio"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+)
+
+// Service Portfolio 서비스 (Ranking → 목표 비중/수량)
+type Service struct {
+	ctx context.Context
+
+	// Repositories
+	portfolioRepo portfolio.PortfolioRepository
+
+	// External readers
+	rankingReader RankingReader
+	holdingReader HoldingReader
+	priceReader   PriceReader
+	cashReader    CashReader // optional (nil → 현금 0)
+
+	// Config
+	accountID string
+	criteria  *portfolio.PortfolioCriteria
+
+	// Cache
+	latestSnapshot *portfolio.PortfolioSnapshot
+
+	// Components
+	allocator *Allocator
+}
+
+// RankingReader Ranking 스냅샷 Reader
+type RankingReader interface {
+	// 최신 Ranking 스냅샷 조회
+	GetLatestSnapshot(ctx context.Context) (*ranking.RankingSnapshot, error)
+}
+
+// HoldingReader 보유 종목 Reader
+type HoldingReader interface {
+	// 계좌 보유 종목 조회
+	LoadHoldings(ctx context.Context, accountID string) ([]*execution.Holding, error)
+}
+
+// PriceReader 현재가 Reader (PriceSync)
+type PriceReader interface {
+	// 종목 최적 현재가 조회
+	GetBestPrice(ctx context.Context, symbol string) (*price.BestPrice, error)
+}
+
+// CashReader 예수금 Reader
+type CashReader interface {
+	// 주문 가능 현금 조회 (원)
+	GetCash(ctx context.Context) (decimal.Decimal, error)
+}
+
+// NewService 새 서비스 생성
+func NewService(
+	ctx context.Context,
+	portfolioRepo portfolio.PortfolioRepository,
+	rankingReader RankingReader,
+	holdingReader HoldingReader,
+	priceReader PriceReader,
+	accountID string,
+) *Service {
+	criteria := portfolio.DefaultPortfolioCriteria()
+
+	return &Service{
+		ctx:           ctx,
+		portfolioRepo: portfolioRepo,
+		rankingReader: rankingReader,
+		holdingReader: holdingReader,
+		priceReader:   priceReader,
+		accountID:     accountID,
+		criteria:      criteria,
+		allocator:     NewAllocator(criteria),
+	}
+}
+
+// SetCashReader sets the cash reader (optional)
+func (s *Service) SetCashReader(reader CashReader) {
+	s.cashReader = reader
+}
+
+// Start 서비스 시작
+func (s *Service) Start() error {
+	log.Info().Msg("Starting Portfolio service")
+
+	snapshot, err := s.portfolioRepo.GetLatestSnapshot(s.ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("No existing portfolio snapshot")
+	} else {
+		s.latestSnapshot = snapshot
+		log.Info().
+			Str("snapshot_id", snapshot.SnapshotID).
+			Int("targets", len(snapshot.Targets)).
+			Msg("Loaded latest portfolio snapshot")
+	}
+
+	return nil
+}
+
+// Stop 서비스 정지
+func (s *Service) Stop() error {
+	log.Info().Msg("Stopping Portfolio service")
+	return nil
+}
+
+// GeneratePortfolio 최신 Ranking + 현재 보유/현금으로 목표 포트폴리오 생성
+func (s *Service) GeneratePortfolio(ctx context.Context) (*portfolio.PortfolioSnapshot, error) {
+	log.Info().Msg("Generating portfolio from latest ranking")
+
+	// 1. Load latest ranking snapshot
+	rankingSnapshot, err := s.rankingReader.GetLatestSnapshot(ctx)
+	if err != nil {
+		if errors.Is(err, ranking.ErrSnapshotNotFound) {
+			return nil, portfolio.ErrRankingNotReady
+		}
+		return nil, fmt.Errorf("load ranking snapshot: %w", err)
+	}
+
+	// 2. Load current holdings
+	holdings, err := s.holdingReader.LoadHoldings(ctx, s.accountID)
+	if err != nil {
+		return nil, fmt.Errorf("load holdings: %w", err)
+	}
+
+	held := make(map[string]*execution.Holding, len(holdings))
+	for _, h := range holdings {
+		if h.Qty > 0 {
+			held[h.Symbol] = h
+		}
+	}
+
+	// 3. Value holdings + cash
+	cash, err := s.getCash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]decimal.Decimal)
+	holdingsValue := decimal.Zero
+	for symbol, h := range held {
+		p := s.getPrice(ctx, symbol, h.CurrentPrice)
+		prices[symbol] = p
+		holdingsValue = holdingsValue.Add(p.Mul(decimal.NewFromInt(h.Qty)))
+	}
+
+	totalValue := holdingsValue.Add(cash)
+	if !totalValue.IsPositive() {
+		return nil, portfolio.ErrNoCapital
+	}
+
+	// 4. Select candidates (가격 없는 종목 제외)
+	selected := s.allocator.SelectCandidates(rankingSnapshot.Rankings)
+	candidates := make([]ranking.RankedStock, 0, len(selected))
+	for _, stock := range selected {
+		fallback := decimal.Zero
+		if h, ok := held[stock.Symbol]; ok {
+			fallback = h.CurrentPrice
+		}
+		p := s.getPrice(ctx, stock.Symbol, fallback)
+		if !p.IsPositive() {
+			log.Warn().Str("symbol", stock.Symbol).Msg("No price for candidate, skipping")
+			continue
+		}
+		prices[stock.Symbol] = p
+		candidates = append(candidates, stock)
+	}
+
+	if len(candidates) == 0 {
+		return nil, portfolio.ErrNoCandidates
+	}
+	if len(candidates) < s.criteria.MinHoldings {
+		log.Warn().
+			Int("candidates", len(candidates)).
+			Int("min_holdings", s.criteria.MinHoldings).
+			Msg("Portfolio below minimum holdings")
+	}
+
+	// 5. Allocate target weights (제약 적용)
+	targets := s.allocator.Allocate(candidates)
+
+	// 6. Size targets (호가단위/매매단위)
+	for i := range targets {
+		t := &targets[i]
+		t.TargetValue = totalValue.Mul(decimal.NewFromFloat(t.TargetWeight / 100.0)).Floor()
+		t.RefPrice = normalizeToTick(prices[t.Symbol])
+		t.TargetQty = sizeTarget(t.TargetValue, t.RefPrice)
+
+		if h, ok := held[t.Symbol]; ok {
+			t.CurrentQty = h.Qty
+			t.CurrentWeight = currentWeight(h.Qty, prices[t.Symbol], totalValue)
+			delete(held, t.Symbol)
+		}
+		t.DeltaQty = t.TargetQty - t.CurrentQty
+	}
+
+	// 7. 편출 종목 (보유 중이지만 목표에 없음 → 목표 0)
+	for symbol, h := range held {
+		targets = append(targets, portfolio.TargetPosition{
+			Symbol:        symbol,
+			RefPrice:      normalizeToTick(prices[symbol]),
+			TargetValue:   decimal.Zero,
+			CurrentQty:    h.Qty,
+			CurrentWeight: currentWeight(h.Qty, prices[symbol], totalValue),
+			DeltaQty:      -h.Qty,
+		})
+	}
+
+	// 8. Create snapshot
+	snapshot := &portfolio.PortfolioSnapshot{
+		SnapshotID:        generateSnapshotID(),
+		RankingSnapshotID: rankingSnapshot.SnapshotID,
+		AccountID:         s.accountID,
+		GeneratedAt:       time.Now(),
+		TotalValue:        totalValue,
+		HoldingsValue:     holdingsValue,
+		Cash:              cash,
+		Investable:        totalValue.Mul(decimal.NewFromFloat(s.allocator.budget() / 100.0)).Floor(),
+		Targets:           targets,
+		TotalWeight:       sumWeights(targets),
+		Stats:             calculateStats(targets),
+	}
+
+	// 9. Save snapshot
+	if err := s.portfolioRepo.SaveSnapshot(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("save snapshot: %w", err)
+	}
+
+	// 10. Update cache
+	s.latestSnapshot = snapshot
+
+	log.Info().
+		Str("snapshot_id", snapshot.SnapshotID).
+		Str("ranking_snapshot_id", snapshot.RankingSnapshotID).
+		Int("holdings", snapshot.Stats.TotalHoldings).
+		Int("buys", snapshot.Stats.BuyCount).
+		Int("sells", snapshot.Stats.SellCount).
+		Str("total_value", totalValue.String()).
+		Msg("Portfolio generated successfully")
+
+	return snapshot, nil
+}
+
+// GetLatestSnapshot 최신 스냅샷 조회
+func (s *Service) GetLatestSnapshot(ctx context.Context) (*portfolio.PortfolioSnapshot, error) {
+	if s.latestSnapshot != nil {
+		return s.latestSnapshot, nil
+	}
+
+	snapshot, err := s.portfolioRepo.GetLatestSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.latestSnapshot = snapshot
+	return snapshot, nil
+}
+
+// GetSnapshotByID 특정 스냅샷 조회
+func (s *Service) GetSnapshotByID(ctx context.Context, snapshotID string) (*portfolio.PortfolioSnapshot, error) {
+	return s.portfolioRepo.GetSnapshotByID(ctx, snapshotID)
+}
+
+// ListSnapshots 스냅샷 목록 조회
+func (s *Service) ListSnapshots(ctx context.Context, from, to time.Time) ([]*portfolio.PortfolioSnapshot, error) {
+	return s.portfolioRepo.ListSnapshots(ctx, from, to)
+}
+
+// getCash 현금 조회 (reader 없으면 0, 조회 실패 시 error → 잘못된 자산 기준으로 sizing하지 않음)
+func (s *Service) getCash(ctx context.Context) (decimal.Decimal, error) {
+	if s.cashReader == nil {
+		return decimal.Zero, nil
+	}
+
+	cash, err := s.cashReader.GetCash(ctx)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("get cash: %w", err)
+	}
+
+	return cash, nil
+}
+
+// getPrice 현재가 조회 (PriceSync 실패 시 fallback)
+func (s *Service) getPrice(ctx context.Context, symbol string, fallback decimal.Decimal) decimal.Decimal {
+	if s.priceReader != nil {
+		bp, err := s.priceReader.GetBestPrice(ctx, symbol)
+		if err == nil && bp != nil && bp.BestPrice > 0 {
+			return decimal.NewFromInt(bp.BestPrice)
+		}
+	}
+	return fallback
+}
+
+// currentWeight 현재 비중 (%)
+func currentWeight(qty int64, p, totalValue decimal.Decimal) float64 {
+	if !totalValue.IsPositive() {
+		return 0
+	}
+	return p.Mul(decimal.NewFromInt(qty)).Div(totalValue).Mul(decimal.NewFromInt(100)).InexactFloat64()
+}
+
+// calculateStats 통계 계산 (목표 비중 > 0 종목 기준)
+func calculateStats(targets []portfolio.TargetPosition) portfolio.PortfolioStats {
+	stats := portfolio.PortfolioStats{
+		SectorWeights: make(map[string]float64),
+		MarketCount:   make(map[string]int),
+	}
+
+	totalWeight := 0.0
+	totalScore := 0.0
+	totalRiskScore := 0.0
+
+	for _, t := range targets {
+		if t.DeltaQty > 0 {
+			stats.BuyCount++
+		} else if t.DeltaQty < 0 {
+			stats.SellCount++
+		}
+
+		if t.TargetWeight <= 0 {
+			continue
+		}
+
+		if stats.TotalHoldings == 0 || t.TargetWeight > stats.MaxWeight {
+			stats.MaxWeight = t.TargetWeight
+		}
+		if stats.TotalHoldings == 0 || t.TargetWeight < stats.MinWeight {
+			stats.MinWeight = t.TargetWeight
+		}
+		stats.TotalHoldings++
+
+		totalWeight += t.TargetWeight
+		totalScore += t.TotalScore
+		totalRiskScore += t.RiskScore
+
+		if t.Sector != "" {
+			stats.SectorWeights[t.Sector] += t.TargetWeight
+		}
+		stats.MarketCount[t.Market]++
+	}
+
+	if stats.TotalHoldings > 0 {
+		n := float64(stats.TotalHoldings)
+		stats.AvgWeight = totalWeight / n
+		stats.AvgTotalScore = totalScore / n
+		stats.AvgRiskScore = totalRiskScore / n
+	}
+
+	return stats
+}
+
+// generateSnapshotID 스냅샷 ID 생성
+func generateSnapshotID() string {
+	now := time.Now()
+	return fmt.Sprintf("%s-%s", now.Format("20060102"), uuid.New().String()[:8])
+}
@@ -0,0 +1,27 @@
+package portfolio
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/wonny/aegis/v14/internal/domain/price"
+)
+
+// krxLotSize KRX 매매단위 (주식 1주)
+const krxLotSize int64 = 1
+
+// normalizeToTick 가격을 호가단위로 내림
+func normalizeToTick(v decimal.Decimal) decimal.Decimal {
+	p := v.IntPart()
+	if p <= 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(price.RoundToTick("", p, false))
+}
+
+// sizeTarget 목표 금액 → 수량 (매매단위 내림, 목표 초과 매수 방지)
+func sizeTarget(targetValue, refPrice decimal.Decimal) int64 {
+	if !refPrice.IsPositive() || !targetValue.IsPositive() {
+		return 0
+	}
+	qty := targetValue.Div(refPrice).IntPart()
+	return qty / krxLotSize * krxLotSize
+}
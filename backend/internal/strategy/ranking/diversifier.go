@@ -0,0 +1,77 @@
+package ranking
+
+import (
+	"fmt"
+
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+)
+
+// Diversifier 다양성 제약 (섹터/시장 집중 방지)
+type Diversifier struct {
+	criteria *ranking.RankingCriteria
+}
+
+// NewDiversifier 새 Diversifier 생성
+func NewDiversifier(criteria *ranking.RankingCriteria) *Diversifier {
+	return &Diversifier{
+		criteria: criteria,
+	}
+}
+
+// ApplyDiversityConstraints 다양성 제약 적용
+// 점수 내림차순으로 순회하며:
+// - 시장당 MaxPerMarket, 섹터당 MaxPerSector 초과 → 탈락 (Reason 기록)
+// - 같은 섹터 N번째 종목 → SectorPenalty × (N-1) 감점, 감점 후 MinTotalScore 미달 → 탈락
+// 한도는 최종 통과한 종목만 차지 (이전 단계/이번 단계에서 탈락한 종목은 카운트하지 않음)
+func (d *Diversifier) ApplyDiversityConstraints(stocks []ranking.RankedStock) []ranking.RankedStock {
+	sortByTotalScore(stocks)
+
+	sectorCount := make(map[string]int)
+	marketCount := make(map[string]int)
+
+	for i := range stocks {
+		stock := &stocks[i]
+
+		if stock.Reason != "" {
+			continue
+		}
+
+		// 시장 한도
+		if d.criteria.MaxPerMarket > 0 && marketCount[stock.Market] >= d.criteria.MaxPerMarket {
+			stock.Selected = false
+			stock.Reason = fmt.Sprintf("Market limit exceeded: %s (max %d)", stock.Market, d.criteria.MaxPerMarket)
+			continue
+		}
+
+		// 섹터 한도 (섹터 정보 없으면 제약 없음)
+		if stock.Sector != "" {
+			if d.criteria.MaxPerSector > 0 && sectorCount[stock.Sector] >= d.criteria.MaxPerSector {
+				stock.Selected = false
+				stock.Reason = fmt.Sprintf("Sector limit exceeded: %s (max %d)", stock.Sector, d.criteria.MaxPerSector)
+				continue
+			}
+
+			// 섹터 중복 페널티
+			if n := sectorCount[stock.Sector]; n > 0 && d.criteria.SectorPenalty > 0 {
+				penalty := d.criteria.SectorPenalty * float64(n)
+				stock.Adjustment -= penalty
+				stock.TotalScore -= penalty
+				stock.Breakdown.SectorPenalty = penalty
+
+				if stock.TotalScore < d.criteria.MinTotalScore {
+					stock.Selected = false
+					stock.Reason = fmt.Sprintf("Score too low after adjustment: %.2f < %.2f", stock.TotalScore, d.criteria.MinTotalScore)
+					continue
+				}
+			}
+		}
+
+		// 통과 → 한도 차지
+		if stock.Sector != "" {
+			sectorCount[stock.Sector]++
+		}
+		marketCount[stock.Market]++
+	}
+
+	return stocks
+}
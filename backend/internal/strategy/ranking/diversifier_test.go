@@ -0,0 +1,129 @@
+package ranking
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+	"github.com/wonny/aegis/v14/internal/domain/signals"
+)
+
+// TestScorerBlend tests Alpha/Risk weighted total score
+func TestScorerBlend(t *testing.T) {
+	scorer := NewScorer(nil, ranking.DefaultRankingCriteria())
+	sig := signals.Signal{Symbol: "005930", Market: "KOSPI", Strength: 80, Conviction: 70}
+
+	t.Run("Missing risk data", func(t *testing.T) {
+		// Risk 50 → 80×0.7 + 50×0.3 = 71
+		ranked := scorer.Score(sig, nil)
+		if math.Abs(ranked.TotalScore-71.0) > 1e-9 {
+			t.Errorf("Expected 71, got %f", ranked.TotalScore)
+		}
+	})
+
+	t.Run("Low risk large cap", func(t *testing.T) {
+		risk := &ranking.RiskData{
+			Symbol:         "005930",
+			Sector:         "반도체",
+			Volatility60D:  20.0,                // → 30
+			AvgDailyValue:  20_000_000_000,      // → 0
+			MarketCap:      400_000_000_000_000, // → 0
+			FreeFloatRatio: 80.0,                // → 20
+		}
+		ranked := scorer.Score(sig, risk)
+
+		// Concentration = 0×0.7 + 20×0.3 = 6
+		// Risk = 30×0.5 + 0×0.3 + 6×0.2 = 16.2
+		if math.Abs(ranked.RiskScore-16.2) > 1e-9 {
+			t.Errorf("Expected risk 16.2, got %f", ranked.RiskScore)
+		}
+		if ranked.Sector != "반도체" {
+			t.Errorf("Expected sector from risk data, got %q", ranked.Sector)
+		}
+		if ranked.Reason != "" {
+			t.Errorf("Expected no rejection, got %q", ranked.Reason)
+		}
+	})
+
+	t.Run("Volatility above max", func(t *testing.T) {
+		risk := &ranking.RiskData{Symbol: "005930", Volatility60D: 75.0, AvgDailyValue: 20_000_000_000}
+		ranked := scorer.Score(sig, risk)
+		if !strings.HasPrefix(ranked.Reason, "Volatility too high") {
+			t.Errorf("Expected volatility rejection, got %q", ranked.Reason)
+		}
+	})
+}
+
+// TestDiversifierConstraints tests sector/market limits and sector penalty
+func TestDiversifierConstraints(t *testing.T) {
+	criteria := ranking.DefaultRankingCriteria()
+	criteria.MaxPerSector = 2
+	criteria.MaxPerMarket = 3
+	criteria.SectorPenalty = 5.0
+
+	stocks := []ranking.RankedStock{
+		{Symbol: "A", Market: "KOSPI", Sector: "IT", TotalScore: 90},
+		{Symbol: "B", Market: "KOSPI", Sector: "IT", TotalScore: 85},
+		{Symbol: "C", Market: "KOSPI", Sector: "IT", TotalScore: 80},
+		{Symbol: "D", Market: "KOSPI", Sector: "금융", TotalScore: 75},
+		{Symbol: "E", Market: "KOSPI", Sector: "화학", TotalScore: 70},
+		{Symbol: "F", Market: "KOSDAQ", Sector: "IT", TotalScore: 95, Reason: "Score too low"},
+	}
+
+	result := NewDiversifier(criteria).ApplyDiversityConstraints(stocks)
+
+	bySymbol := make(map[string]ranking.RankedStock)
+	for _, s := range result {
+		bySymbol[s.Symbol] = s
+	}
+
+	if got := bySymbol["B"]; got.Breakdown.SectorPenalty != 5.0 || got.TotalScore != 80 {
+		t.Errorf("Expected B penalty 5 → 80, got penalty %f score %f", got.Breakdown.SectorPenalty, got.TotalScore)
+	}
+	if got := bySymbol["C"]; !strings.HasPrefix(got.Reason, "Sector limit exceeded") {
+		t.Errorf("Expected C dropped by sector limit, got %q", got.Reason)
+	}
+	if got := bySymbol["E"]; !strings.HasPrefix(got.Reason, "Market limit exceeded") {
+		t.Errorf("Expected E dropped by market limit, got %q", got.Reason)
+	}
+	if got := bySymbol["F"]; got.Reason != "Score too low" {
+		t.Errorf("Expected F reason untouched, got %q", got.Reason)
+	}
+}
+
+// TestDiversifierRejectedDoesNotConsumeLimits tests that dropped stocks leave sector/market slots to later ones
+func TestDiversifierRejectedDoesNotConsumeLimits(t *testing.T) {
+	criteria := ranking.DefaultRankingCriteria()
+	criteria.MaxPerSector = 2
+	criteria.MaxPerMarket = 2
+	criteria.SectorPenalty = 10.0
+	criteria.MinTotalScore = 60.0
+
+	stocks := []ranking.RankedStock{
+		{Symbol: "A", Market: "KOSPI", Sector: "IT", TotalScore: 90},
+		{Symbol: "B", Market: "KOSPI", Sector: "IT", TotalScore: 66}, // 66-10=56 < 60 → 탈락
+		{Symbol: "C", Market: "KOSDAQ", Sector: "금융", TotalScore: 80},
+		{Symbol: "D", Market: "KOSDAQ", Sector: "바이오", TotalScore: 78},
+		{Symbol: "E", Market: "KOSDAQ", Sector: "화학", TotalScore: 76}, // KOSDAQ 한도 → 탈락
+		{Symbol: "F", Market: "KOSPI", Sector: "화학", TotalScore: 64},  // E와 같은 섹터, 유효
+	}
+
+	result := NewDiversifier(criteria).ApplyDiversityConstraints(stocks)
+
+	bySymbol := make(map[string]ranking.RankedStock)
+	for _, s := range result {
+		bySymbol[s.Symbol] = s
+	}
+
+	if got := bySymbol["B"]; !strings.HasPrefix(got.Reason, "Score too low after adjustment") {
+		t.Errorf("Expected B dropped after sector penalty, got %q", got.Reason)
+	}
+	if got := bySymbol["E"]; !strings.HasPrefix(got.Reason, "Market limit exceeded") {
+		t.Errorf("Expected E dropped by market limit, got %q", got.Reason)
+	}
+	// B가 KOSPI 슬롯을, E가 화학 섹터를 차지하지 않아야 F 통과 (페널티 없음)
+	if got := bySymbol["F"]; got.Reason != "" || got.Breakdown.SectorPenalty != 0 || got.TotalScore != 64 {
+		t.Errorf("Expected F accepted without penalty, got reason %q penalty %f score %f", got.Reason, got.Breakdown.SectorPenalty, got.TotalScore)
+	}
+}
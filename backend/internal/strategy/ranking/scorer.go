@@ -0,0 +1,162 @@
+package ranking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/wonny/aegis/v14/internal/domain/ranking"
+	"github.com/wonny/aegis/v14/internal/domain/signals"
+)
+
+// 리스크 구성 가중치 (합계 1.0)
+const (
+	volatilityRiskWeight    = 0.5
+	liquidityRiskWeight     = 0.3
+	concentrationRiskWeight = 0.2
+
+	defaultRiskScore = 50.0 // 리스크 데이터 없음
+)
+
+// Scorer 신호 점수화 (Alpha + Risk 조정)
+type Scorer struct {
+	riskRepo ranking.RiskDataRepository
+	criteria *ranking.RankingCriteria
+}
+
+// NewScorer 새 Scorer 생성
+func NewScorer(riskRepo ranking.RiskDataRepository, criteria *ranking.RankingCriteria) *Scorer {
+	return &Scorer{
+		riskRepo: riskRepo,
+		criteria: criteria,
+	}
+}
+
+// ScoreSignal 신호를 리스크 데이터와 결합하여 점수화
+func (s *Scorer) ScoreSignal(ctx context.Context, sig signals.Signal) (*ranking.RankedStock, error) {
+	risk, err := s.riskRepo.GetRiskData(ctx, sig.Symbol)
+	if err != nil {
+		if !errors.Is(err, ranking.ErrRiskDataMissing) {
+			return nil, fmt.Errorf("get risk data: %w", err)
+		}
+		log.Debug().Str("symbol", sig.Symbol).Msg("Risk data missing, using default risk score")
+		risk = nil
+	}
+
+	return s.Score(sig, risk), nil
+}
+
+// Score 점수 계산 (risk == nil이면 기본 리스크 적용)
+// Total = Alpha × AlphaWeight + (100 - Risk) × RiskWeight
+// 예: Alpha 80, Risk 30 → 80×0.7 + 70×0.3 = 77
+func (s *Scorer) Score(sig signals.Signal, risk *ranking.RiskData) *ranking.RankedStock {
+	alphaScore := float64(sig.Strength)
+
+	breakdown := ranking.RankingBreakdown{
+		SignalStrength:    alphaScore,
+		SignalConviction:  float64(sig.Conviction),
+		VolatilityRisk:    defaultRiskScore,
+		LiquidityRisk:     defaultRiskScore,
+		ConcentrationRisk: defaultRiskScore,
+	}
+
+	sector := ""
+	reason := ""
+
+	if risk != nil {
+		sector = risk.Sector
+		breakdown.VolatilityRisk = volatilityRisk(risk.Volatility60D)
+		breakdown.LiquidityRisk = liquidityRisk(risk.AvgDailyValue)
+		breakdown.ConcentrationRisk = concentrationRisk(risk.MarketCap, risk.FreeFloatRatio)
+
+		// 리스크 한도 (하드 필터)
+		if s.criteria.MaxVolatility > 0 && risk.Volatility60D > s.criteria.MaxVolatility {
+			breakdown.VolatilityRisk = 100.0
+			reason = fmt.Sprintf("Volatility too high: %.1f%% > %.1f%%", risk.Volatility60D, s.criteria.MaxVolatility)
+		} else if s.criteria.MinLiquidity > 0 && risk.AvgDailyValue < s.criteria.MinLiquidity {
+			breakdown.LiquidityRisk = 100.0
+			reason = fmt.Sprintf("Liquidity too low: %.0f < %.0f", risk.AvgDailyValue, s.criteria.MinLiquidity)
+		}
+	}
+
+	riskScore := breakdown.VolatilityRisk*volatilityRiskWeight +
+		breakdown.LiquidityRisk*liquidityRiskWeight +
+		breakdown.ConcentrationRisk*concentrationRiskWeight
+
+	totalScore := alphaScore*s.criteria.AlphaWeight + (100.0-riskScore)*s.criteria.RiskWeight
+
+	return &ranking.RankedStock{
+		RankID:     uuid.New(),
+		SignalID:   sig.SignalID,
+		Symbol:     sig.Symbol,
+		Name:       sig.Name,
+		Market:     sig.Market,
+		Sector:     sector,
+		TotalScore: totalScore,
+		AlphaScore: alphaScore,
+		RiskScore:  riskScore,
+		Breakdown:  breakdown,
+		Selected:   false, // 선정은 selectTopN에서 결정
+		Reason:     reason,
+	}
+}
+
+// volatilityRisk 변동성 리스크 (0-100, 높을수록 위험)
+// 0-20%: 0→30, 20-40%: 30→60, 40-60%: 60→100, 60%+: 100
+func volatilityRisk(volatility float64) float64 {
+	switch {
+	case volatility > 60.0:
+		return 100.0
+	case volatility > 40.0:
+		return 60.0 + (volatility-40.0)*(40.0/20.0)
+	case volatility > 20.0:
+		return 30.0 + (volatility-20.0)*(30.0/20.0)
+	case volatility > 0:
+		return volatility * (30.0 / 20.0)
+	default:
+		return 0.0
+	}
+}
+
+// liquidityRisk 유동성 리스크 (일평균 거래대금 기준)
+// 100억+: 0, 50-100억: 20, 10-50억: 50, 10억 미만: 100
+func liquidityRisk(avgDailyValue float64) float64 {
+	switch {
+	case avgDailyValue >= 10_000_000_000:
+		return 0.0
+	case avgDailyValue >= 5_000_000_000:
+		return 20.0
+	case avgDailyValue >= 1_000_000_000:
+		return 50.0
+	default:
+		return 100.0
+	}
+}
+
+// concentrationRisk 집중도 리스크 (시가총액 70% + 유동주식비율 30%)
+// 시총 10조+: 0, 1조+: 20, 3000억+: 50, 그 미만: 80
+// 유동주식비율(%) 낮을수록 위험, 데이터 없음(0) → 50
+func concentrationRisk(marketCap, freeFloatRatio float64) float64 {
+	capRisk := 80.0
+	switch {
+	case marketCap >= 10_000_000_000_000:
+		capRisk = 0.0
+	case marketCap >= 1_000_000_000_000:
+		capRisk = 20.0
+	case marketCap >= 300_000_000_000:
+		capRisk = 50.0
+	}
+
+	floatRisk := defaultRiskScore
+	if freeFloatRatio > 0 {
+		floatRisk = 100.0 - freeFloatRatio
+		if floatRisk < 0 {
+			floatRisk = 0
+		}
+	}
+
+	return capRisk*0.7 + floatRisk*0.3
+}
@@ -2,6 +2,7 @@ package ranking
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -28,6 +29,7 @@ type Service struct {
 
 	// Cache
 	latestSnapshot *ranking.RankingSnapshot
+	jobSignalID    string // 순위 job이 마지막으로 처리한 Signal 스냅샷 (생성 불가 스냅샷 재시도 방지)
 
 	// Components
 	scorer      *Scorer
@@ -88,6 +90,57 @@ func (s *Service) Stop() error {
 	return nil
 }
 
+// StartJob 순위 생성 job 시작 (ranking.snapshots producer)
+// interval마다 최신 Signal 스냅샷을 확인해 아직 순위가 없는 스냅샷이면 생성 → Portfolio가 최신 순위 사용
+func (s *Service) StartJob(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.refreshRankings(ctx); err != nil {
+				log.Error().Err(err).Msg("Ranking job failed")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// refreshRankings generates rankings when a newer signal snapshot exists
+func (s *Service) refreshRankings(ctx context.Context) error {
+	signalSnapshot, err := s.signalReader.GetLatestSnapshot(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("No signal snapshot for ranking job")
+		return nil
+	}
+
+	if signalSnapshot.SnapshotID == s.jobSignalID {
+		return nil
+	}
+	latest, err := s.GetLatestSnapshot(ctx)
+	if err == nil && latest.SignalID == signalSnapshot.SnapshotID {
+		s.jobSignalID = signalSnapshot.SnapshotID
+		return nil // 이미 생성됨
+	}
+
+	_, err = s.GenerateRankings(ctx)
+	if errors.Is(err, ranking.ErrSignalsNotReady) || errors.Is(err, ranking.ErrNoValidStocks) {
+		log.Warn().Err(err).Str("signal_id", signalSnapshot.SnapshotID).Msg("Ranking job skipped")
+		s.jobSignalID = signalSnapshot.SnapshotID
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.jobSignalID = signalSnapshot.SnapshotID
+	return nil
+}
+
 // GenerateRankings Signals에서 순위 생성
 func (s *Service) GenerateRankings(ctx context.Context) (*ranking.RankingSnapshot, error) {
 	log.Info().Msg("Generating rankings from latest signals")
@@ -256,6 +309,12 @@ func (s *Service) selectTopN(stocks []ranking.RankedStock) []ranking.RankedStock
 
 	count := 0
 	for i := range stocks {
+		// Already rejected by an earlier stage (score/risk/diversity)
+		if stocks[i].Reason != "" {
+			stocks[i].Selected = false
+			continue
+		}
+
 		if count >= s.criteria.MaxSelections {
 			stocks[i].Selected = false
 			stocks[i].Reason = fmt.Sprintf("Exceeds max selections: %d", s.criteria.MaxSelections)
@@ -264,6 +323,9 @@ func (s *Service) selectTopN(stocks []ranking.RankedStock) []ranking.RankedStock
 			stocks[i].Reason = "Selected"
 			selected = append(selected, stocks[i])
 			count++
+		} else {
+			stocks[i].Selected = false
+			stocks[i].Reason = fmt.Sprintf("Score too low after adjustment: %.2f < %.2f", stocks[i].TotalScore, s.criteria.MinTotalScore)
 		}
 	}
 
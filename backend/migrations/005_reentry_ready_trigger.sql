@@ -0,0 +1,8 @@
+-- Migration: Record which reentry trigger moved a candidate WATCH → READY
+-- Purpose: READY handler needs the trigger (REENTRY_REBOUND/BREAKOUT/CHASE) and price to build the ENTRY intent
+-- Date: 2026-10-16
+
+ALTER TABLE trade.reentry_candidates
+ADD COLUMN IF NOT EXISTS ready_reason_code TEXT,
+ADD COLUMN IF NOT EXISTS ready_price NUMERIC(20,4),
+ADD COLUMN IF NOT EXISTS ready_ts TIMESTAMP;
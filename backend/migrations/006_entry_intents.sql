@@ -0,0 +1,14 @@
+-- Migration: ENTRY intents for reentry candidates
+-- Purpose: READY candidates create ENTRY (BUY) intents in trade.order_intents; track the intent on the candidate
+-- Date: 2026-10-16
+
+-- ENTRY intent_type (action_key: {candidate_id}:ENTRY:{n}, existing UNIQUE constraint gives idempotency)
+COMMENT ON COLUMN trade.order_intents.intent_type IS 'EXIT_PARTIAL | EXIT_FULL | ENTRY';
+
+-- Candidate → ENTRY intent link (READY → ENTERED on fill)
+ALTER TABLE trade.reentry_candidates
+ADD COLUMN IF NOT EXISTS entry_intent_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_reentry_candidates_entry_intent
+ON trade.reentry_candidates(entry_intent_id)
+WHERE entry_intent_id IS NOT NULL;
@@ -0,0 +1,57 @@
+-- Migration: Ranking / Portfolio snapshots
+-- Purpose: Persist ranking snapshots and the target portfolio built from them (target weights/qty per symbol)
+-- Date: 2026-10-16
+
+CREATE SCHEMA IF NOT EXISTS ranking;
+CREATE SCHEMA IF NOT EXISTS portfolio;
+
+-- ================================================
+-- 1. ranking.snapshots (Ranking 결과)
+-- SSOT: Ranking 서비스만 쓰기 가능
+-- ================================================
+CREATE TABLE IF NOT EXISTS ranking.snapshots (
+    snapshot_id     VARCHAR(32) PRIMARY KEY,          -- YYYYMMDD-xxxxxxxx
+    signal_id       VARCHAR(64) NOT NULL,             -- signals.snapshots.snapshot_id
+    generated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    total_count     INT NOT NULL DEFAULT 0,
+    selected_count  INT NOT NULL DEFAULT 0,
+    rankings        JSONB NOT NULL,                   -- RankedStock[]
+    stats           JSONB NOT NULL                    -- RankingStats
+);
+
+CREATE INDEX IF NOT EXISTS idx_ranking_snapshots_generated_at
+ON ranking.snapshots(generated_at DESC);
+
+COMMENT ON TABLE ranking.snapshots IS 'Ranking 스냅샷 - Signals BUY → 리스크 조정/다양성 제약 후 순위';
+
+-- ================================================
+-- 2. portfolio.snapshots (목표 포트폴리오)
+-- SSOT: Portfolio 서비스만 쓰기 가능
+-- ================================================
+CREATE TABLE IF NOT EXISTS portfolio.snapshots (
+    snapshot_id         VARCHAR(32) PRIMARY KEY,      -- YYYYMMDD-xxxxxxxx
+    ranking_snapshot_id VARCHAR(32) NOT NULL,         -- ranking.snapshots.snapshot_id
+    account_id          TEXT NOT NULL,
+    generated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- 평가 기준 자산 (원)
+    total_value         NUMERIC(20,2) NOT NULL,       -- 보유 평가액 + 현금
+    holdings_value      NUMERIC(20,2) NOT NULL,
+    cash                NUMERIC(20,2) NOT NULL,
+    investable          NUMERIC(20,2) NOT NULL,       -- 현금 버퍼 제외
+
+    -- 목표 구성
+    targets             JSONB NOT NULL,               -- TargetPosition[]
+    total_weight        NUMERIC(7,4) NOT NULL,        -- 총 목표 비중 (%)
+    stats               JSONB NOT NULL,               -- PortfolioStats
+
+    CONSTRAINT chk_portfolio_total_weight CHECK (total_weight >= 0 AND total_weight <= 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_portfolio_snapshots_generated_at
+ON portfolio.snapshots(generated_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_portfolio_snapshots_ranking
+ON portfolio.snapshots(ranking_snapshot_id);
+
+COMMENT ON TABLE portfolio.snapshots IS '목표 포트폴리오 - Ranking 선정 종목의 목표 비중/수량 (호가단위/매매단위/단일종목·섹터 한도 적용)';
@@ -0,0 +1,35 @@
+-- Migration: Rebalance plans
+-- Purpose: Target portfolio vs holdings → reviewable BUY/SELL intents (PENDING_APPROVAL), approved/rejected as a plan
+-- Date: 2026-10-16
+
+-- REBALANCE_* intent_type (action_key: {plan_id}:REBALANCE:{symbol})
+COMMENT ON COLUMN trade.order_intents.intent_type IS 'EXIT_PARTIAL | EXIT_FULL | ENTRY | REBALANCE_BUY | REBALANCE_SELL';
+
+-- ================================================
+-- portfolio.rebalance_plans
+-- SSOT: Portfolio 서비스만 쓰기 가능
+-- ================================================
+CREATE TABLE IF NOT EXISTS portfolio.rebalance_plans (
+    plan_id                 UUID PRIMARY KEY,
+    portfolio_snapshot_id   VARCHAR(32) NOT NULL,     -- portfolio.snapshots.snapshot_id
+    account_id              TEXT NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    status                  VARCHAR(20) NOT NULL DEFAULT 'PENDING_APPROVAL',
+
+    orders                  JSONB NOT NULL,           -- RebalanceOrder[] (seq: 매도 → 매수, intent_id 포함)
+    skipped                 JSONB NOT NULL,           -- RebalanceOrder[] (reason)
+    summary                 JSONB NOT NULL,           -- RebalanceSummary (예상 수수료/세금/현금)
+
+    CONSTRAINT chk_rebalance_plan_status CHECK (status IN ('PENDING_APPROVAL', 'APPROVED', 'REJECTED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_rebalance_plans_created_at
+ON portfolio.rebalance_plans(created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_rebalance_plans_pending
+ON portfolio.rebalance_plans(created_at DESC)
+WHERE status = 'PENDING_APPROVAL';
+
+COMMENT ON TABLE portfolio.rebalance_plans IS '리밸런싱 계획 - 목표 vs 보유 차이 주문 (일괄 승인/거부)';
@@ -0,0 +1,103 @@
+-- Migration: Pre-trade risk gate
+-- Purpose: Risk limits, emergency stop and block log checked before every order submission (Fail-Closed)
+-- Date: 2026-10-16
+
+CREATE SCHEMA IF NOT EXISTS control;
+
+-- ================================================
+-- control.risk_limits
+-- 활성 프로필 1개 (is_active) - 없으면 모든 주문 보류 (Fail-Closed)
+-- ================================================
+CREATE TABLE IF NOT EXISTS control.risk_limits (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_name            VARCHAR(50) NOT NULL UNIQUE,
+
+    -- 포지션 한도
+    max_total_positions     INTEGER NOT NULL DEFAULT 15,
+    max_single_weight       NUMERIC(5,2) NOT NULL DEFAULT 15.00,
+
+    -- 집중도 한도
+    max_sector_weight       NUMERIC(5,2) NOT NULL DEFAULT 40.00,
+
+    -- 손실 한도
+    max_daily_loss_pct      NUMERIC(5,2) NOT NULL DEFAULT -3.00,
+
+    -- 주문 한도
+    max_order_value         NUMERIC(20,0) NOT NULL DEFAULT 20000000,
+    max_price_deviation_pct NUMERIC(5,2) NOT NULL DEFAULT 5.00,
+
+    is_active               BOOLEAN NOT NULL DEFAULT true,
+    updated_by              TEXT NOT NULL DEFAULT 'system',
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_max_total_positions CHECK (max_total_positions > 0),
+    CONSTRAINT chk_single_weight CHECK (max_single_weight > 0 AND max_single_weight <= 100),
+    CONSTRAINT chk_sector_weight CHECK (max_sector_weight > 0 AND max_sector_weight <= 100),
+    CONSTRAINT chk_daily_loss CHECK (max_daily_loss_pct < 0),
+    CONSTRAINT chk_max_order_value CHECK (max_order_value > 0),
+    CONSTRAINT chk_price_deviation CHECK (max_price_deviation_pct > 0 AND max_price_deviation_pct <= 30)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_limits_single_active
+ON control.risk_limits ((1))
+WHERE is_active;
+
+INSERT INTO control.risk_limits (profile_name)
+VALUES ('DEFAULT')
+ON CONFLICT (profile_name) DO NOTHING;
+
+COMMENT ON TABLE control.risk_limits IS '리스크 한도 설정 (pre-trade risk gate)';
+COMMENT ON COLUMN control.risk_limits.max_single_weight IS '종목당 최대 비중 (%)';
+COMMENT ON COLUMN control.risk_limits.max_daily_loss_pct IS '일간 최대 손실률 (음수) - 도달 시 신규 매수 차단';
+COMMENT ON COLUMN control.risk_limits.max_order_value IS '1회 매수 주문 최대 금액 (원)';
+COMMENT ON COLUMN control.risk_limits.max_price_deviation_pct IS '지정가 vs 현재가 최대 괴리 (%)';
+
+-- ================================================
+-- control.emergency_stop (Singleton)
+-- 활성화 시 신규 매수 차단, 청산은 허용
+-- ================================================
+CREATE TABLE IF NOT EXISTS control.emergency_stop (
+    id          INTEGER PRIMARY KEY DEFAULT 1,
+    enabled     BOOLEAN NOT NULL DEFAULT false,
+    reason      TEXT,
+    updated_by  TEXT NOT NULL,
+    updated_ts  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT emergency_stop_singleton CHECK (id = 1)
+);
+
+INSERT INTO control.emergency_stop (id, enabled, updated_by)
+VALUES (1, false, 'system')
+ON CONFLICT (id) DO NOTHING;
+
+COMMENT ON TABLE control.emergency_stop IS '긴급 정지 상태 (Singleton)';
+
+-- ================================================
+-- control.risk_blocks
+-- 차단된 주문 기록 (intent: status=REJECTED, 사유는 check_type)
+-- ================================================
+CREATE TABLE IF NOT EXISTS control.risk_blocks (
+    block_id        UUID PRIMARY KEY,
+    source          VARCHAR(10) NOT NULL,       -- INTENT | MANUAL
+    intent_id       UUID,                       -- trade.order_intents.intent_id (INTENT only)
+    account_id      TEXT NOT NULL,
+    symbol          VARCHAR(20) NOT NULL,
+    side            VARCHAR(4) NOT NULL,        -- BUY | SELL
+    intent_type     VARCHAR(20) NOT NULL DEFAULT '',
+    qty             BIGINT NOT NULL,
+    order_value     NUMERIC(20,2) NOT NULL,
+    check_type      VARCHAR(20) NOT NULL,       -- EMERGENCY_STOP | PRICE_BAND | ORDER_VALUE | TOTAL_POSITIONS | SINGLE_WEIGHT | SECTOR_WEIGHT | DAILY_LOSS
+    message         TEXT NOT NULL,
+    current_value   DOUBLE PRECISION NOT NULL,
+    limit_value     DOUBLE PRECISION NOT NULL,
+    blocked_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_blocks_blocked_at
+ON control.risk_blocks(blocked_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_risk_blocks_intent
+ON control.risk_blocks(intent_id)
+WHERE intent_id IS NOT NULL;
+
+COMMENT ON TABLE control.risk_blocks IS '리스크 게이트 차단 기록';
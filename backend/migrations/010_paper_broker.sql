@@ -0,0 +1,85 @@
+-- Migration: Paper broker ledger
+-- Purpose: In-process simulated broker (BROKER_MODE=paper) orders, fills, holdings and cash
+-- Date: 2026-10-16
+
+CREATE SCHEMA IF NOT EXISTS paper;
+
+-- ================================================
+-- paper.accounts
+-- 계좌별 현금 (최초 주문 시 PAPER_INITIAL_CASH로 생성)
+-- ================================================
+CREATE TABLE IF NOT EXISTS paper.accounts (
+    account_id      TEXT PRIMARY KEY,
+    cash            NUMERIC(20,0) NOT NULL,
+    initial_cash    NUMERIC(20,0) NOT NULL,
+    created_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE paper.accounts IS 'Paper broker 계좌 (현금 잔고)';
+
+-- ================================================
+-- paper.orders
+-- ================================================
+CREATE TABLE IF NOT EXISTS paper.orders (
+    order_id        TEXT PRIMARY KEY,
+    account_id      TEXT NOT NULL REFERENCES paper.accounts(account_id),
+    symbol          TEXT NOT NULL,
+    side            TEXT NOT NULL,
+    order_type      TEXT NOT NULL,
+    qty             BIGINT NOT NULL,
+    limit_price     NUMERIC(20,0),
+    filled_qty      BIGINT NOT NULL DEFAULT 0,
+    status          TEXT NOT NULL,
+    reject_reason   TEXT,
+    submitted_ts    TIMESTAMPTZ NOT NULL,
+    eligible_ts     TIMESTAMPTZ NOT NULL,
+    updated_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_paper_order_side CHECK (side IN ('BUY', 'SELL')),
+    CONSTRAINT chk_paper_order_type CHECK (order_type IN ('MKT', 'LMT')),
+    CONSTRAINT chk_paper_order_status CHECK (status IN ('PENDING', 'PARTIAL', 'FILLED', 'CANCELLED', 'REJECTED')),
+    CONSTRAINT chk_paper_filled_qty CHECK (filled_qty >= 0 AND filled_qty <= qty)
+);
+
+CREATE INDEX IF NOT EXISTS idx_paper_orders_open
+ON paper.orders (account_id)
+WHERE status IN ('PENDING', 'PARTIAL');
+
+COMMENT ON COLUMN paper.orders.eligible_ts IS '체결 가능 시각 (submitted_ts + 시뮬레이션 지연)';
+
+-- ================================================
+-- paper.fills
+-- ================================================
+CREATE TABLE IF NOT EXISTS paper.fills (
+    exec_id         TEXT PRIMARY KEY,
+    order_id        TEXT NOT NULL REFERENCES paper.orders(order_id),
+    account_id      TEXT NOT NULL,
+    symbol          TEXT NOT NULL,
+    side            TEXT NOT NULL,
+    qty             BIGINT NOT NULL,
+    price           NUMERIC(20,0) NOT NULL,
+    fee             NUMERIC(20,0) NOT NULL DEFAULT 0,
+    tax             NUMERIC(20,0) NOT NULL DEFAULT 0,
+    seq             INTEGER NOT NULL DEFAULT 0,
+    ts              TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_paper_fills_account_ts
+ON paper.fills (account_id, ts);
+
+-- ================================================
+-- paper.holdings
+-- ================================================
+CREATE TABLE IF NOT EXISTS paper.holdings (
+    account_id      TEXT NOT NULL REFERENCES paper.accounts(account_id),
+    symbol          TEXT NOT NULL,
+    qty             BIGINT NOT NULL,
+    avg_price       NUMERIC(20,4) NOT NULL,
+    updated_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (account_id, symbol),
+    CONSTRAINT chk_paper_holding_qty CHECK (qty >= 0)
+);
+
+COMMENT ON TABLE paper.holdings IS 'Paper broker 보유 (평균단가 = 매수금액 / 수량, 수수료 제외)';
@@ -0,0 +1,22 @@
+-- Migration: Shared KIS token store
+-- Purpose: Access token / WebSocket approval key shared by all processes (EGW00133 1분당 1회 발급 제한 회피)
+-- Date: 2026-10-16
+
+CREATE SCHEMA IF NOT EXISTS control;
+
+-- ================================================
+-- control.kis_tokens
+-- token_key = {kind}:{sha256(app_key) 앞 16자리} (app key 원문 미저장)
+-- 발급은 pg_advisory_lock(hashtextextended(token_key, 0))으로 직렬화
+-- ================================================
+CREATE TABLE IF NOT EXISTS control.kis_tokens (
+    token_key   TEXT PRIMARY KEY,
+    kind        VARCHAR(20) NOT NULL CHECK (kind IN ('ACCESS_TOKEN', 'APPROVAL_KEY')),
+    token       TEXT NOT NULL DEFAULT '',
+    expires_at  TIMESTAMPTZ NOT NULL,
+    hold_until  TIMESTAMPTZ,
+    updated_ts  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE control.kis_tokens IS 'KIS 토큰 공유 저장소 (KIS_TOKEN_STORE=postgres)';
+COMMENT ON COLUMN control.kis_tokens.hold_until IS 'EGW00133 발급 제한 해제 시각 (이 시각 전에는 재발급 시도 금지)';
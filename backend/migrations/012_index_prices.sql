@@ -0,0 +1,24 @@
+-- Migration: Market index daily prices
+-- Purpose: 시장 지수 (KOSPI/KOSDAQ/KOSPI200) 일봉 저장 - audit.benchmark_data 원천
+-- Date: 2026-10-16
+
+-- ================================================
+-- data.index_prices (지수 일봉)
+-- SSOT: Fetcher만 쓰기 가능
+-- ================================================
+CREATE TABLE IF NOT EXISTS data.index_prices (
+    index_code      VARCHAR(20) NOT NULL,           -- KOSPI, KOSDAQ, KOSPI200
+    trade_date      DATE NOT NULL,
+    open_price      NUMERIC(12,2) NOT NULL,
+    high_price      NUMERIC(12,2) NOT NULL,
+    low_price       NUMERIC(12,2) NOT NULL,
+    close_price     NUMERIC(12,2) NOT NULL,
+    volume          BIGINT NOT NULL DEFAULT 0,
+    trading_value   NUMERIC(20,0),                  -- 누적 거래대금 (백만원)
+    created_at      TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (index_code, trade_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_index_prices_date ON data.index_prices(trade_date DESC);
+
+COMMENT ON TABLE data.index_prices IS '시장 지수 일봉 - Fetcher 소유, Audit 벤치마크 원천';
@@ -0,0 +1,31 @@
+-- Migration: Order amendment events
+-- Purpose: 주문 정정/자동 재호가/시장가 전환 이력 (trade.orders 하위 이벤트)
+-- Date: 2026-10-16
+
+-- ================================================
+-- trade.order_events
+-- 정정 시 KIS는 잔량을 새 주문번호로 이관 → 원주문 REPLACED, new_order_id로 새 orders row
+-- ================================================
+CREATE TABLE IF NOT EXISTS trade.order_events (
+    event_id        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id        TEXT NOT NULL REFERENCES trade.orders(order_id),
+    new_order_id    TEXT NOT NULL,
+    intent_id       UUID,
+    event_type      VARCHAR(20) NOT NULL CHECK (event_type IN ('MODIFY', 'REPRICE', 'CONVERT_MKT')),
+    attempt         INTEGER NOT NULL DEFAULT 0,
+    old_order_type  VARCHAR(10) NOT NULL,
+    new_order_type  VARCHAR(10) NOT NULL,
+    old_price       NUMERIC(20,4),
+    new_price       NUMERIC(20,4),
+    qty             BIGINT NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    raw             JSONB,
+    created_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_events_order_id ON trade.order_events(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_events_new_order_id ON trade.order_events(new_order_id);
+CREATE INDEX IF NOT EXISTS idx_order_events_intent_id ON trade.order_events(intent_id, created_ts);
+
+COMMENT ON TABLE trade.order_events IS '주문 정정 이력 (MODIFY: 수동, REPRICE: 매수호가 추격, CONVERT_MKT: 시장가 전환)';
+COMMENT ON COLUMN trade.order_events.attempt IS 'intent 기준 재호가 차수 (1부터, MODIFY는 0)';
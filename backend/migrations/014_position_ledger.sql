@@ -0,0 +1,59 @@
+-- Migration: Fill-driven position ledger
+-- Purpose: 체결(trade.fills / H0STCNI0) 기반 포지션 원장 + KIS 잔고 대사 불일치 기록
+-- Date: 2026-10-16
+
+-- ================================================
+-- trade.position_ledger
+-- 주문별 누적 체결 증분마다 1행 (qty/avg_cost는 적용 후 상태 → trade.positions와 동일 트랜잭션)
+-- ================================================
+CREATE TABLE IF NOT EXISTS trade.position_ledger (
+    entry_id        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    position_id     UUID NOT NULL,
+    account_id      TEXT NOT NULL,
+    symbol          TEXT NOT NULL,
+    order_id        TEXT NOT NULL DEFAULT '',
+    intent_id       UUID,
+    entry_type      VARCHAR(10) NOT NULL CHECK (entry_type IN ('BUY', 'SELL', 'OPENING')),
+    source          VARCHAR(10) NOT NULL CHECK (source IN ('FILL', 'NOTICE', 'HOLDINGS')),
+    qty             BIGINT NOT NULL CHECK (qty > 0),
+    price           NUMERIC(20,4) NOT NULL,
+    fee             NUMERIC(20,4) NOT NULL DEFAULT 0,
+    tax             NUMERIC(20,4) NOT NULL DEFAULT 0,
+    qty_after       BIGINT NOT NULL CHECK (qty_after >= 0),
+    avg_cost_after  NUMERIC(20,4) NOT NULL,
+    realized_pnl    NUMERIC(20,4) NOT NULL DEFAULT 0,
+    ts              TIMESTAMPTZ NOT NULL,
+    created_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_position_ledger_position ON trade.position_ledger(position_id, ts);
+CREATE INDEX IF NOT EXISTS idx_position_ledger_order ON trade.position_ledger(order_id) WHERE order_id <> '';
+CREATE INDEX IF NOT EXISTS idx_position_ledger_symbol ON trade.position_ledger(account_id, symbol, ts);
+
+COMMENT ON TABLE trade.position_ledger IS '체결 기반 포지션 원장 (BUY/SELL: 체결 증분, OPENING: 원장 밖 보유분 편입)';
+COMMENT ON COLUMN trade.position_ledger.avg_cost_after IS '수수료 포함 평균 취득단가 (적용 후)';
+COMMENT ON COLUMN trade.position_ledger.realized_pnl IS '매도 실현손익 (수수료/세금 차감)';
+
+-- ================================================
+-- trade.ledger_discrepancies
+-- holdings sync는 원장을 덮어쓰지 않고 불일치만 기록 (미해결 건은 종목/종류당 1행)
+-- ================================================
+CREATE TABLE IF NOT EXISTS trade.ledger_discrepancies (
+    discrepancy_id  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id      TEXT NOT NULL,
+    symbol          TEXT NOT NULL,
+    kind            VARCHAR(20) NOT NULL CHECK (kind IN ('QTY_MISMATCH', 'UNTRACKED_HOLDING', 'MISSING_HOLDING', 'UNTRACKED_SELL')),
+    ledger_qty      BIGINT NOT NULL DEFAULT 0,
+    broker_qty      BIGINT NOT NULL DEFAULT 0,
+    ledger_avg      NUMERIC(20,4) NOT NULL DEFAULT 0,
+    broker_avg      NUMERIC(20,4) NOT NULL DEFAULT 0,
+    detail          TEXT NOT NULL DEFAULT '',
+    detected_ts     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_ts     TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ledger_discrepancies_open
+    ON trade.ledger_discrepancies(account_id, symbol, kind)
+    WHERE resolved_ts IS NULL;
+
+COMMENT ON TABLE trade.ledger_discrepancies IS '포지션 원장 vs KIS 잔고 대사 불일치';
@@ -0,0 +1,11 @@
+-- Migration: Trade history fee/tax columns
+-- Purpose: lot 매칭 실현손익의 매도 수수료/거래세 기록 (KIS 거래내역 대사용)
+-- Date: 2026-10-17
+
+ALTER TABLE audit.trade_history
+    ADD COLUMN IF NOT EXISTS fee NUMERIC(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax NUMERIC(15,2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN audit.trade_history.fee IS '매도 위탁수수료 (lot 배분)';
+COMMENT ON COLUMN audit.trade_history.tax IS '증권거래세 + 농어촌특별세 (lot 배분)';
+COMMENT ON COLUMN audit.trade_history.realized_pnl IS '실현 손익 (원, 매수/매도 수수료 및 세금 차감)';
@@ -0,0 +1,73 @@
+-- Migration: Multiple trading accounts
+-- Purpose: 주문/intent/거래내역 계좌 태깅 + 계좌별 Exit 제어 (KIS_ACCOUNTS)
+-- Date: 2026-10-17
+
+-- ================================================
+-- trade.orders / trade.order_intents: 주문 계좌
+-- '' → 기본 계좌 (단일 계좌 시절 데이터)
+-- ================================================
+ALTER TABLE trade.orders
+    ADD COLUMN IF NOT EXISTS account_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_orders_account_status ON trade.orders(account_id, status);
+
+ALTER TABLE trade.order_intents
+    ADD COLUMN IF NOT EXISTS account_id TEXT NOT NULL DEFAULT '';
+
+-- 기존 intent는 포지션 계좌로 backfill
+UPDATE trade.order_intents i
+SET account_id = p.account_id
+FROM trade.positions p
+WHERE i.position_id = p.position_id
+  AND i.account_id = '';
+
+-- 기존 주문은 intent 계좌로 backfill
+UPDATE trade.orders o
+SET account_id = i.account_id
+FROM trade.order_intents i
+WHERE o.intent_id = i.intent_id
+  AND o.account_id = '';
+
+COMMENT ON COLUMN trade.orders.account_id IS '주문 계좌번호 ('''' → 기본 계좌)';
+COMMENT ON COLUMN trade.order_intents.account_id IS '주문 계좌번호 ('''' → 기본 계좌)';
+
+-- ================================================
+-- trade.account_exit_control
+-- 계좌별 Kill Switch (유효 모드 = 전역 exit_control과 비교해 더 엄격한 쪽)
+-- ================================================
+CREATE TABLE IF NOT EXISTS trade.account_exit_control (
+    account_id  TEXT PRIMARY KEY,
+    mode        TEXT NOT NULL DEFAULT 'RUNNING',
+    reason      TEXT,
+    updated_by  TEXT NOT NULL,
+    updated_ts  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- ================================================
+-- audit.trade_history: 거래 계좌 (계좌별/통합 성과 리포트)
+-- ================================================
+ALTER TABLE audit.trade_history
+    ADD COLUMN IF NOT EXISTS account_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_trade_history_account ON audit.trade_history(account_id, exit_date);
+
+COMMENT ON COLUMN audit.trade_history.account_id IS '거래 계좌번호 ('''' → 계좌 미상)';
+
+-- 뷰에 fee/tax/account_id 추가 (기존 컬럼 순서 유지)
+CREATE OR REPLACE VIEW audit.trades AS
+SELECT
+    trade_id::TEXT as id,
+    stock_code as symbol,
+    CASE WHEN exit_date IS NOT NULL THEN 'SELL' ELSE 'BUY' END as side,
+    entry_qty::INT as quantity,
+    entry_price::BIGINT as price,
+    COALESCE(realized_pnl, 0) as pnl,
+    COALESCE(realized_pnl_pct, 0) as pnl_percent,
+    entry_date,
+    COALESCE(exit_date, entry_date) as exit_date,
+    COALESCE(holding_days, 0) as hold_days,
+    fee,
+    tax,
+    account_id
+FROM audit.trade_history
+WHERE exit_date IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Migration: Daily ATR for exit volatility scaling
+-- Purpose: 장전 ATR job 계산 시각 기록 (position state API의 ATR MISSING/STALE 판단)
+-- Date: 2026-10-17
+
+ALTER TABLE trade.position_state
+    ADD COLUMN IF NOT EXISTS atr_updated_ts TIMESTAMPTZ;
+
+COMMENT ON COLUMN trade.position_state.atr IS 'ATR% = Wilder ATR(N) / 전일 종가 (daily ATR job)';
+COMMENT ON COLUMN trade.position_state.atr_updated_ts IS 'ATR 계산 시각 (NULL → 미계산)';
+
+-- ATR job 기록 조회용 (rule_name = ATR)
+CREATE INDEX IF NOT EXISTS idx_exit_signals_rule_ts ON trade.exit_signals(rule_name, evaluated_ts DESC);
@@ -0,0 +1,8 @@
+-- Migration: ATR chandelier trailing stop
+-- Purpose: 계산된 trailing stop 가격 저장 (position state API → UI stop line)
+-- Date: 2026-10-17
+
+ALTER TABLE trade.position_state
+    ADD COLUMN IF NOT EXISTS trailing_stop_price NUMERIC(20,4);
+
+COMMENT ON COLUMN trade.position_state.trailing_stop_price IS 'Trailing stop (PCT: HWM×(1-pct_trail), ATR: HWM-atr_k×ATR), trailing phase에서 갱신';
@@ -0,0 +1,26 @@
+-- Migration: Exit intent approval policy
+-- Purpose: intent별 승인 정책(AUTO/MANUAL/TIMEOUT_*) 및 승인 주체 기록, 종목별 승인 정책 override
+-- Date: 2026-10-17
+
+ALTER TABLE trade.order_intents
+    ADD COLUMN IF NOT EXISTS approval_mode TEXT,
+    ADD COLUMN IF NOT EXISTS approval_source TEXT,
+    ADD COLUMN IF NOT EXISTS approval_deadline TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS decided_by TEXT,
+    ADD COLUMN IF NOT EXISTS decided_ts TIMESTAMP;
+
+COMMENT ON COLUMN trade.order_intents.approval_mode IS 'AUTO | MANUAL | TIMEOUT_APPROVE | TIMEOUT_CANCEL (생성 시 결정)';
+COMMENT ON COLUMN trade.order_intents.approval_source IS '결정한 정책 (예: profile:default/reasons.SL2, symbol:005930/default)';
+COMMENT ON COLUMN trade.order_intents.approval_deadline IS 'TIMEOUT_* 모드: 경과 시 자동 승인/취소';
+COMMENT ON COLUMN trade.order_intents.decided_by IS '승인/거부 주체 (policy, policy:timeout, EMERGENCY_FLATTEN, 사용자)';
+COMMENT ON COLUMN trade.order_intents.decided_ts IS '승인/거부 시각';
+
+-- 승인 대기 timeout 스캔용
+CREATE INDEX IF NOT EXISTS idx_order_intents_approval_deadline
+    ON trade.order_intents (approval_deadline)
+    WHERE status = 'PENDING_APPROVAL' AND approval_deadline IS NOT NULL;
+
+ALTER TABLE trade.symbol_exit_overrides
+    ADD COLUMN IF NOT EXISTS approval_policy JSONB;
+
+COMMENT ON COLUMN trade.symbol_exit_overrides.approval_policy IS '종목별 승인 정책 (프로필 정책보다 우선)';
@@ -0,0 +1,9 @@
+-- Migration: ATR in won for chandelier trailing stop
+-- Purpose: daily ATR job의 Wilder ATR(원) 저장 → ATR trailing stop = HWM - atr_k × ATR(원)
+-- Date: 2026-10-17
+
+ALTER TABLE trade.position_state
+    ADD COLUMN IF NOT EXISTS atr_won NUMERIC(20,4);
+
+COMMENT ON COLUMN trade.position_state.atr_won IS 'Wilder ATR(N) 원 단위 (daily ATR job, chandelier trailing stop)';
+COMMENT ON COLUMN trade.position_state.trailing_stop_price IS 'Trailing stop (PCT: HWM×(1-pct_trail), ATR: HWM-atr_k×atr_won), trailing phase에서 갱신';
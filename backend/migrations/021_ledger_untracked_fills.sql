@@ -0,0 +1,20 @@
+-- Migration: Ledger untracked fills
+-- Purpose: 원장 포지션 없이 체결된 매도(UNTRACKED_SELL) 증분 기록 → 주문별 반영 누적에 포함 (매 sync 재감지/재반영 방지)
+-- Date: 2026-10-17
+
+CREATE TABLE IF NOT EXISTS trade.ledger_untracked_fills (
+    fill_id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id      TEXT NOT NULL,
+    symbol          TEXT NOT NULL,
+    order_id        TEXT NOT NULL,
+    qty             BIGINT NOT NULL CHECK (qty > 0),
+    amount          NUMERIC(20,4) NOT NULL,
+    fee             NUMERIC(20,4) NOT NULL DEFAULT 0,
+    tax             NUMERIC(20,4) NOT NULL DEFAULT 0,
+    ts              TIMESTAMPTZ NOT NULL,
+    created_ts      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_untracked_fills_order ON trade.ledger_untracked_fills(order_id);
+
+COMMENT ON TABLE trade.ledger_untracked_fills IS '원장 포지션 없는 매도 체결 증분 (처리 완료 표시, 불일치는 ledger_discrepancies)';
@@ -4,13 +4,14 @@
  * Custom Exit Rules 관련 타입 정의
  */
 
-export type CustomRuleCondition = 'profit_above' | 'profit_below';
+export type CustomRuleCondition = 'profit_above' | 'profit_below' | 'expression';
 
 export interface CustomExitRule {
   id: string;
   enabled: boolean;
   condition: CustomRuleCondition;
   threshold: number;    // e.g., 7 for +7%
+  expression?: string;  // condition === 'expression' (e.g., "time >= 14:50 AND pnl_pct < 1 AND days_held >= 3")
   exitPercent: number;  // e.g., 20 for 20%
   priority: number;
   description?: string;